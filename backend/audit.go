@@ -0,0 +1,347 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Audit log
+//
+// Every execution and every change to flows, steps, variables, environments
+// and tokens is recorded in the audit_events table along with who asked for
+// it. The table is append-only: nothing in the API changes it and triggers
+// reject updates and deletes. Events are also written to the server log.
+
+// Audit event actions
+const (
+	AuditExecution              = "execution"        // A command was run, or could not be started
+	AuditExecutionDenied        = "execution.denied" // An execution was rejected by policy
+	AuditShellOpened            = "shell.opened"     // A free interactive shell was started
+	AuditFlowCreated            = "flow.created"
+	AuditFlowUpdated            = "flow.updated"
+	AuditFlowDeleted            = "flow.deleted"
+	AuditFlowImported           = "flow.imported"
+	AuditStepCreated            = "step.created"
+	AuditStepUpdated            = "step.updated"
+	AuditStepDeleted            = "step.deleted"
+	AuditVariableUpdated        = "variable.updated"
+	AuditVariableDeleted        = "variable.deleted"
+	AuditGlobalVariablesUpdated = "global_variables.updated"
+	AuditEnvironmentCreated     = "environment.created"
+	AuditEnvironmentUpdated     = "environment.updated"
+	AuditEnvironmentDeleted     = "environment.deleted"
+	AuditTokenCreated           = "token.created"
+	AuditTokenUpdated           = "token.updated"
+	AuditTokenDeleted           = "token.deleted"
+)
+
+// AuditActorAnonymous is the actor of requests made while authentication is disabled
+const AuditActorAnonymous = "anonymous"
+
+// Limits on the number of events GET /api/audit returns
+const (
+	defaultAuditLimit = 100
+	maxAuditLimit     = 1000
+)
+
+// AuditEvent records a security-relevant action
+type AuditEvent struct {
+	ID              int           `json:"id"`
+	Time            time.Time     `json:"time"`
+	Action          string        `json:"action"`             // One of the Audit* actions
+	Actor           string        `json:"actor"`              // Name of the token that made the request
+	TokenID         int           `json:"token_id,omitempty"` // Zero when authentication is disabled
+	RemoteAddr      string        `json:"remote_addr,omitempty"`
+	Target          string        `json:"target"` // What the action applied to, e.g. "step:12" or "command:3"
+	FlowID          int           `json:"flow_id,omitempty"`
+	StepID          int           `json:"step_id,omitempty"`
+	RunID           int           `json:"run_id,omitempty"`
+	Command         string        `json:"command,omitempty"`          // As written, before variable substitution
+	ResolvedCommand string        `json:"resolved_command,omitempty"` // As run, with secret values masked
+	ExitCode        *int          `json:"exit_code,omitempty"`        // Unset if the command did not run to completion
+	Duration        time.Duration `json:"duration,omitempty"`
+	Status          string        `json:"status,omitempty"` // Command status of executions
+	Detail          string        `json:"detail,omitempty"`
+}
+
+// createAuditTables creates the append-only audit log
+func createAuditTables() error {
+	queries := []string{
+		`CREATE TABLE IF NOT EXISTS audit_events (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			created_at DATETIME NOT NULL,
+			action TEXT NOT NULL,
+			actor TEXT NOT NULL,
+			token_id INTEGER,
+			remote_addr TEXT,
+			target TEXT,
+			flow_id INTEGER,
+			step_id INTEGER,
+			run_id INTEGER,
+			command TEXT,
+			resolved_command TEXT,
+			exit_code INTEGER,
+			duration INTEGER,
+			status TEXT,
+			detail TEXT
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_audit_events_created_at ON audit_events (created_at)`,
+		`CREATE INDEX IF NOT EXISTS idx_audit_events_actor ON audit_events (actor)`,
+		`CREATE TRIGGER IF NOT EXISTS audit_events_no_update BEFORE UPDATE ON audit_events
+		BEGIN
+			SELECT RAISE(ABORT, 'audit_events is append-only');
+		END`,
+		`CREATE TRIGGER IF NOT EXISTS audit_events_no_delete BEFORE DELETE ON audit_events
+		BEGIN
+			SELECT RAISE(ABORT, 'audit_events is append-only');
+		END`,
+	}
+
+	for _, query := range queries {
+		if _, err := db.Exec(query); err != nil {
+			return fmt.Errorf("failed to execute query %s: %v", query, err)
+		}
+	}
+	return nil
+}
+
+// requestAudit returns an audit event for an action taken by the request's caller
+func requestAudit(c echo.Context, action, target string) AuditEvent {
+	event := AuditEvent{
+		Action:     action,
+		Actor:      AuditActorAnonymous,
+		RemoteAddr: c.Request().RemoteAddr,
+		Target:     target,
+	}
+	if token := currentToken(c); token != nil {
+		event.Actor = token.Name
+		event.TokenID = token.ID
+	}
+	return event
+}
+
+// stepAudit returns an audit event for the request's caller running a step
+func stepAudit(c echo.Context, stepID, flowID int) AuditEvent {
+	event := requestAudit(c, AuditExecution, stepKey(stepID))
+	event.FlowID = flowID
+	event.StepID = stepID
+	return event
+}
+
+// auditChange records a change made by the request's caller to something
+// belonging to flowID and stepID, which are zero if it doesn't
+func auditChange(c echo.Context, action, target string, flowID, stepID int, detail string) {
+	event := requestAudit(c, action, target)
+	event.FlowID = flowID
+	event.StepID = stepID
+	event.Detail = detail
+	recordAudit(event)
+}
+
+// flowKey identifies a flow as an audit target
+func flowKey(flowID int) string {
+	return fmt.Sprintf("flow:%d", flowID)
+}
+
+// recordAudit logs an audit event and appends it to the audit log. Secret
+// values are masked in the resolved command.
+func recordAudit(event AuditEvent) {
+	event.Time = time.Now().UTC()
+	event.ResolvedCommand = secrets.mask(event.ResolvedCommand)
+	if event.Actor == "" {
+		event.Actor = AuditActorAnonymous
+	}
+
+	log.Printf("AUDIT action=%s actor=%s target=%s: %s", event.Action, event.Actor, event.Target, event.Detail)
+
+	var exitCode, duration interface{}
+	if event.ExitCode != nil {
+		exitCode = *event.ExitCode
+	}
+	if event.Action == AuditExecution {
+		duration = int64(event.Duration)
+	}
+	_, err := db.Exec(`INSERT INTO audit_events (created_at, action, actor, token_id, remote_addr, target, flow_id, step_id, run_id,
+		command, resolved_command, exit_code, duration, status, detail) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		event.Time, event.Action, event.Actor, nullableID(event.TokenID), event.RemoteAddr, event.Target,
+		nullableID(event.FlowID), nullableID(event.StepID), nullableID(event.RunID),
+		event.Command, event.ResolvedCommand, exitCode, duration, event.Status, event.Detail)
+	if err != nil {
+		slog.Error("Failed to record audit event", "action", event.Action, "target", event.Target, "error", err)
+	}
+}
+
+// nullableID stores a zero ID as NULL
+func nullableID(id int) interface{} {
+	if id == 0 {
+		return nil
+	}
+	return id
+}
+
+// auditExecution records the outcome of running command, which resolved to
+// resolved. err is why the command could not be started, if it wasn't; a
+// *PolicyError records the execution as denied.
+func auditExecution(event AuditEvent, command, resolved string, result CommandResult, err error) {
+	event.Action = AuditExecution
+	event.Command = command
+	event.ResolvedCommand = resolved
+	event.Duration = result.Duration
+	event.Status = result.Status
+	if policyErr, ok := err.(*PolicyError); ok {
+		event.Action = AuditExecutionDenied
+		event.Detail = policyErr.Message
+	} else if err != nil {
+		event.Detail = err.Error()
+	} else if result.Status != CommandStatusStarted {
+		exitCode := result.ExitCode
+		event.ExitCode = &exitCode
+	}
+	recordAudit(event)
+}
+
+// auditPolicyError records event as a denied execution if err is a *PolicyError
+func auditPolicyError(event AuditEvent, err error) {
+	if policyErr, ok := err.(*PolicyError); ok {
+		event.Action = AuditExecutionDenied
+		event.Detail = policyErr.Message
+		recordAudit(event)
+	}
+}
+
+// AuditQuery selects audit events; zero fields don't filter
+type AuditQuery struct {
+	Since  time.Time
+	Until  time.Time
+	Actor  string
+	Action string
+	FlowID int
+	Limit  int
+}
+
+// getAuditEvents returns the events matching a query, most recent first
+func getAuditEvents(query AuditQuery) ([]AuditEvent, error) {
+	var conditions []string
+	var args []interface{}
+	if !query.Since.IsZero() {
+		conditions = append(conditions, "created_at >= ?")
+		args = append(args, query.Since.UTC())
+	}
+	if !query.Until.IsZero() {
+		conditions = append(conditions, "created_at < ?")
+		args = append(args, query.Until.UTC())
+	}
+	if query.Actor != "" {
+		conditions = append(conditions, "actor = ?")
+		args = append(args, query.Actor)
+	}
+	if query.Action != "" {
+		conditions = append(conditions, "action = ?")
+		args = append(args, query.Action)
+	}
+	if query.FlowID != 0 {
+		conditions = append(conditions, "flow_id = ?")
+		args = append(args, query.FlowID)
+	}
+
+	sqlQuery := `SELECT id, created_at, action, actor, token_id, remote_addr, target, flow_id, step_id, run_id,
+		command, resolved_command, exit_code, duration, status, detail FROM audit_events`
+	if len(conditions) > 0 {
+		sqlQuery += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	sqlQuery += " ORDER BY id DESC LIMIT ?"
+	args = append(args, query.Limit)
+
+	rows, err := db.Query(sqlQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query audit events: %v", err)
+	}
+	defer rows.Close()
+
+	events := []AuditEvent{}
+	for rows.Next() {
+		var event AuditEvent
+		var tokenID, flowID, stepID, runID, exitCode, duration sql.NullInt64
+		var remoteAddr, target, command, resolvedCommand, status, detail sql.NullString
+		if err := rows.Scan(&event.ID, &event.Time, &event.Action, &event.Actor, &tokenID, &remoteAddr, &target,
+			&flowID, &stepID, &runID, &command, &resolvedCommand, &exitCode, &duration, &status, &detail); err != nil {
+			return nil, err
+		}
+		event.TokenID = int(tokenID.Int64)
+		event.RemoteAddr = remoteAddr.String
+		event.Target = target.String
+		event.FlowID = int(flowID.Int64)
+		event.StepID = int(stepID.Int64)
+		event.RunID = int(runID.Int64)
+		event.Command = command.String
+		event.ResolvedCommand = resolvedCommand.String
+		if exitCode.Valid {
+			code := int(exitCode.Int64)
+			event.ExitCode = &code
+		}
+		event.Duration = time.Duration(duration.Int64)
+		event.Status = status.String
+		event.Detail = detail.String
+		events = append(events, event)
+	}
+	return events, rows.Err()
+}
+
+// handleGetAuditEvents lists audit events, most recent first. Query
+// parameters filter them: since and until (RFC 3339 times or dates), actor, action,
+// flow_id, and limit (default 100, at most 1000).
+func handleGetAuditEvents(c echo.Context) error {
+	query := AuditQuery{
+		Actor:  c.QueryParam("actor"),
+		Action: c.QueryParam("action"),
+		Limit:  defaultAuditLimit,
+	}
+
+	var err error
+	if query.Since, err = parseTimeParam(c, "since"); err == nil {
+		query.Until, err = parseTimeParam(c, "until")
+	}
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	if value := c.QueryParam("flow_id"); value != "" {
+		if _, err := fmt.Sscanf(value, "%d", &query.FlowID); err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid flow ID",
+			})
+		}
+	}
+
+	if value := c.QueryParam("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit <= 0 {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid limit",
+			})
+		}
+		if limit > maxAuditLimit {
+			limit = maxAuditLimit
+		}
+		query.Limit = limit
+	}
+
+	events, err := getAuditEvents(query)
+	if err != nil {
+		slog.Error("Error getting audit events", "error", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get audit events",
+		})
+	}
+	return c.JSON(http.StatusOK, events)
+}
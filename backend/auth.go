@@ -0,0 +1,580 @@
+package main
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"html"
+	"log"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// API authentication
+//
+// Every /api route requires an API token, sent as "Authorization: Bearer
+// <token>", in the session cookie set by the /login page, or, for WebSocket
+// upgrades only (browsers can't set headers on those), as the token query
+// parameter. Tokens are stored as SHA-256 hashes, so they are shown only once,
+// when created. On first start an admin token is generated and written to
+// security.auth.admin_token_file; it is only accepted from localhost. What a
+// token may do depends on its role, see rbac.go.
+
+const (
+	tokenPrefix       = "dtk_"
+	adminTokenName    = "admin"
+	sessionCookieName = "devtool_token"
+
+	// How stale a token's last_used_at may get, so that not every request writes to the database
+	tokenUseResolution = time.Minute
+)
+
+// AuthConfig controls API authentication
+type AuthConfig struct {
+	Enabled        bool   `yaml:"enabled"`          // Require a token on every /api route
+	AdminTokenFile string `yaml:"admin_token_file"` // Defaults to <data.base_dir>/admin.token
+}
+
+// APIToken describes an API token; the token itself is never stored
+type APIToken struct {
+	ID         int          `json:"id"`
+	Name       string       `json:"name"`
+	Prefix     string       `json:"prefix"` // Start of the token, to tell tokens apart
+	Role       string       `json:"role"`   // Empty if the token can only use the flows it has grants for
+	Grants     []TokenGrant `json:"grants,omitempty"`
+	LocalOnly  bool         `json:"local_only"`
+	CreatedAt  time.Time    `json:"created_at"`
+	ExpiresAt  *time.Time   `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time   `json:"last_used_at,omitempty"`
+}
+
+// CreateTokenRequest is the request body for creating an API token
+type CreateTokenRequest struct {
+	Name      string       `json:"name"`
+	Role      string       `json:"role"`
+	Grants    []TokenGrant `json:"grants,omitempty"`
+	ExpiresIn string       `json:"expires_in,omitempty"` // A duration like "720h"; empty means the token doesn't expire
+	LocalOnly bool         `json:"local_only,omitempty"` // Only accept the token from localhost
+}
+
+// UpdateTokenRequest changes the role and grants of an API token
+type UpdateTokenRequest struct {
+	Role   string       `json:"role"`
+	Grants []TokenGrant `json:"grants"`
+}
+
+// CreateTokenResponse returns a new token, the only time it is shown
+type CreateTokenResponse struct {
+	APIToken
+	Token string `json:"token"`
+}
+
+// createAuthTables creates the table holding API tokens
+func createAuthTables() error {
+	queries := []string{
+		`CREATE TABLE IF NOT EXISTS api_tokens (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			name TEXT NOT NULL,
+			token_hash TEXT NOT NULL UNIQUE,
+			prefix TEXT NOT NULL,
+			role TEXT DEFAULT '',
+			local_only BOOLEAN DEFAULT FALSE,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			expires_at DATETIME,
+			last_used_at DATETIME
+		)`,
+	}
+
+	for _, query := range queries {
+		if _, err := db.Exec(query); err != nil {
+			return fmt.Errorf("failed to execute query %s: %v", query, err)
+		}
+	}
+	return nil
+}
+
+// initAuth generates the admin token if there is none yet, or if reset is set
+func initAuth(cfg AuthConfig, baseDir string, reset bool) error {
+	if !cfg.Enabled {
+		slog.Warn("API authentication is disabled (security.auth.enabled); anyone who can reach the server can run commands")
+		return nil
+	}
+
+	var count int
+	if err := db.QueryRow("SELECT COUNT(*) FROM api_tokens WHERE name = ? AND local_only = TRUE", adminTokenName).Scan(&count); err != nil {
+		return fmt.Errorf("failed to look up admin token: %v", err)
+	}
+	if count > 0 && !reset {
+		// Tokens created before roles existed have none
+		_, err := db.Exec("UPDATE api_tokens SET role = ? WHERE name = ? AND local_only = TRUE", RoleAdmin, adminTokenName)
+		return err
+	}
+	if _, err := db.Exec("DELETE FROM api_tokens WHERE name = ? AND local_only = TRUE", adminTokenName); err != nil {
+		return fmt.Errorf("failed to delete admin token: %v", err)
+	}
+
+	token, err := generateToken()
+	if err != nil {
+		return err
+	}
+	if _, err := insertToken(adminTokenName, token, RoleAdmin, nil, true, nil); err != nil {
+		return err
+	}
+
+	tokenFile := cfg.AdminTokenFile
+	if tokenFile == "" {
+		tokenFile = filepath.Join(baseDir, "admin.token")
+	}
+	if err := os.MkdirAll(filepath.Dir(tokenFile), 0700); err != nil {
+		return fmt.Errorf("failed to create admin token directory: %v", err)
+	}
+	if err := os.WriteFile(tokenFile, []byte(token+"\n"), 0600); err != nil {
+		return fmt.Errorf("failed to write admin token file: %v", err)
+	}
+
+	// The token itself stays out of the log, which may be written to disk with looser permissions
+	log.Printf("Generated admin API token (accepted from localhost only), saved to %s", tokenFile)
+	return nil
+}
+
+// generateToken returns a new random API token
+func generateToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate token: %v", err)
+	}
+	return tokenPrefix + base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// hashToken returns the stored form of a token
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// insertToken stores a new token with its grants
+func insertToken(name, token, role string, grants []TokenGrant, localOnly bool, expiresAt *time.Time) (*APIToken, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	prefix := token[:len(tokenPrefix)+6]
+	result, err := tx.Exec(
+		"INSERT INTO api_tokens (name, token_hash, prefix, role, local_only, expires_at) VALUES (?, ?, ?, ?, ?, ?)",
+		name, hashToken(token), prefix, role, localOnly, expiresAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert token: %v", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token ID: %v", err)
+	}
+	if err := saveGrants(tx, id, grants); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %v", err)
+	}
+	return getToken("id = ?", id)
+}
+
+// updateToken replaces the role and grants of a token
+func updateToken(id int, req UpdateTokenRequest) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec("UPDATE api_tokens SET role = ? WHERE id = ?", req.Role, id)
+	if err != nil {
+		return fmt.Errorf("failed to update token: %v", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return sql.ErrNoRows
+	}
+	if err := saveGrants(tx, int64(id), req.Grants); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %v", err)
+	}
+	return nil
+}
+
+// getToken returns the token matching a WHERE clause
+func getToken(where string, args ...interface{}) (*APIToken, error) {
+	var token APIToken
+	var expiresAt, lastUsedAt sql.NullTime
+	err := db.QueryRow(
+		"SELECT id, name, prefix, role, local_only, created_at, expires_at, last_used_at FROM api_tokens WHERE "+where,
+		args...,
+	).Scan(&token.ID, &token.Name, &token.Prefix, &token.Role, &token.LocalOnly, &token.CreatedAt, &expiresAt, &lastUsedAt)
+	if err != nil {
+		return nil, err
+	}
+	if token.Grants, err = getTokenGrants(token.ID); err != nil {
+		return nil, fmt.Errorf("failed to get grants of token %d: %v", token.ID, err)
+	}
+	if expiresAt.Valid {
+		token.ExpiresAt = &expiresAt.Time
+	}
+	if lastUsedAt.Valid {
+		token.LastUsedAt = &lastUsedAt.Time
+	}
+	return &token, nil
+}
+
+// getAllTokens returns every API token
+func getAllTokens() ([]APIToken, error) {
+	rows, err := db.Query("SELECT id FROM api_tokens ORDER BY id")
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tokens: %v", err)
+	}
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+
+	tokens := []APIToken{}
+	for _, id := range ids {
+		token, err := getToken("id = ?", id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get token %d: %v", id, err)
+		}
+		tokens = append(tokens, *token)
+	}
+	return tokens, nil
+}
+
+// authenticate returns the token presented by a client at remoteAddr
+func authenticate(presented, remoteAddr string) (*APIToken, error) {
+	if !strings.HasPrefix(presented, tokenPrefix) {
+		return nil, fmt.Errorf("invalid token")
+	}
+	token, err := getToken("token_hash = ?", hashToken(presented))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("invalid token")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up token: %v", err)
+	}
+
+	if token.ExpiresAt != nil && time.Now().After(*token.ExpiresAt) {
+		return nil, fmt.Errorf("token expired")
+	}
+	if token.LocalOnly && !isLoopback(remoteAddr) {
+		return nil, fmt.Errorf("token %s is only accepted from localhost", token.Name)
+	}
+
+	now := time.Now().UTC()
+	if token.LastUsedAt == nil || now.Sub(*token.LastUsedAt) >= tokenUseResolution {
+		if _, err := db.Exec("UPDATE api_tokens SET last_used_at = ? WHERE id = ?", now, token.ID); err != nil {
+			slog.Error("Error updating last use of token", "token_id", token.ID, "error", err)
+		}
+	}
+	return token, nil
+}
+
+// isLoopback reports whether a request's remote address is on this machine.
+// Forwarding headers are ignored, as clients can set them.
+func isLoopback(remoteAddr string) bool {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// requestToken returns the token sent with a request and whether it came from the query string
+func requestToken(c echo.Context) (string, bool) {
+	if header := c.Request().Header.Get("Authorization"); header != "" {
+		if token, ok := strings.CutPrefix(header, "Bearer "); ok {
+			return strings.TrimSpace(token), false
+		}
+	}
+	if cookie, err := c.Cookie(sessionCookieName); err == nil && cookie.Value != "" {
+		return cookie.Value, false
+	}
+	if c.IsWebSocket() {
+		if token := c.QueryParam("token"); token != "" {
+			return token, true
+		}
+	}
+	return "", false
+}
+
+// requireToken rejects requests without a valid API token
+func requireToken(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if config != nil && !config.Security.Auth.Enabled {
+			return next(c)
+		}
+
+		presented, fromQuery := requestToken(c)
+		if presented == "" {
+			return c.JSON(http.StatusUnauthorized, map[string]string{
+				"error": "Authentication required: send an API token as \"Authorization: Bearer <token>\"",
+			})
+		}
+		if fromQuery {
+			// Keep the token out of the request log
+			scrubQueryToken(c.Request())
+		}
+
+		token, err := authenticate(presented, c.Request().RemoteAddr)
+		if err != nil {
+			slog.Warn("Rejected API request", "remote_addr", c.Request().RemoteAddr, "path", c.Path(), "error", err)
+			return c.JSON(http.StatusUnauthorized, map[string]string{
+				"error": fmt.Sprintf("Authentication failed: %v", err),
+			})
+		}
+
+		c.Set("token", token)
+		return next(c)
+	}
+}
+
+// scrubQueryToken removes the token query parameter from a request
+func scrubQueryToken(req *http.Request) {
+	query := req.URL.Query()
+	query.Del("token")
+	req.URL.RawQuery = query.Encode()
+	req.RequestURI = req.URL.RequestURI()
+}
+
+// currentToken returns the token a request was authenticated with, if any
+func currentToken(c echo.Context) *APIToken {
+	token, _ := c.Get("token").(*APIToken)
+	return token
+}
+
+// handleGetTokens lists the API tokens
+func handleGetTokens(c echo.Context) error {
+	tokens, err := getAllTokens()
+	if err != nil {
+		slog.Error("Error getting tokens", "error", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get tokens",
+		})
+	}
+	return c.JSON(http.StatusOK, tokens)
+}
+
+// handleCreateToken creates an API token and returns it
+func handleCreateToken(c echo.Context) error {
+	var req CreateTokenRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request payload",
+		})
+	}
+
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Token name is required",
+		})
+	}
+	if err := validateRole(req.Role, true); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+	if err := validateGrants(req.Grants); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+	var expiresAt *time.Time
+	if req.ExpiresIn != "" {
+		d, err := time.ParseDuration(req.ExpiresIn)
+		if err != nil || d <= 0 {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": fmt.Sprintf("Invalid expires_in %q: must be a positive duration like \"720h\"", req.ExpiresIn),
+			})
+		}
+		t := time.Now().UTC().Add(d)
+		expiresAt = &t
+	}
+
+	secret, err := generateToken()
+	if err != nil {
+		slog.Error("Error creating token", "error", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to create token",
+		})
+	}
+	token, err := insertToken(req.Name, secret, req.Role, req.Grants, req.LocalOnly, expiresAt)
+	if err != nil {
+		slog.Error("Error creating token", "error", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to create token",
+		})
+	}
+
+	auditChange(c, AuditTokenCreated, fmt.Sprintf("token:%d", token.ID), 0, 0, fmt.Sprintf("%s with role %q", token.Name, token.Role))
+	return c.JSON(http.StatusCreated, CreateTokenResponse{APIToken: *token, Token: secret})
+}
+
+// handleUpdateToken changes the role and grants of an API token
+func handleUpdateToken(c echo.Context) error {
+	id := 0
+	if _, err := fmt.Sscanf(c.Param("id"), "%d", &id); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid token ID",
+		})
+	}
+
+	var req UpdateTokenRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request payload",
+		})
+	}
+	if err := validateRole(req.Role, true); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+	if err := validateGrants(req.Grants); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	if err := updateToken(id, req); err != nil {
+		if err == sql.ErrNoRows {
+			return c.JSON(http.StatusNotFound, map[string]string{
+				"error": "Token not found",
+			})
+		}
+		slog.Error("Error updating token", "token_id", id, "error", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to update token",
+		})
+	}
+
+	token, err := getToken("id = ?", id)
+	if err != nil {
+		slog.Error("Error getting token", "token_id", id, "error", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get token",
+		})
+	}
+	auditChange(c, AuditTokenUpdated, fmt.Sprintf("token:%d", token.ID), 0, 0, fmt.Sprintf("%s: role %q, %d grants", token.Name, token.Role, len(token.Grants)))
+	return c.JSON(http.StatusOK, token)
+}
+
+// handleDeleteToken revokes an API token
+func handleDeleteToken(c echo.Context) error {
+	id := 0
+	if _, err := fmt.Sscanf(c.Param("id"), "%d", &id); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid token ID",
+		})
+	}
+
+	result, err := db.Exec("DELETE FROM api_tokens WHERE id = ?", id)
+	if err != nil {
+		slog.Error("Error deleting token", "token_id", id, "error", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to delete token",
+		})
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "Token not found",
+		})
+	}
+	if _, err := db.Exec("DELETE FROM token_grants WHERE token_id = ?", id); err != nil {
+		slog.Error("Error deleting grants of token", "token_id", id, "error", err)
+	}
+
+	auditChange(c, AuditTokenDeleted, fmt.Sprintf("token:%d", id), 0, 0, "")
+	return c.JSON(http.StatusOK, map[string]string{
+		"message": "Token deleted successfully",
+	})
+}
+
+// loginPage is the form the web interface uses to sign in with a token
+const loginPage = `<!DOCTYPE html>
+<html>
+<head>
+    <title>DevTool - Sign in</title>
+    <style>
+        body { font-family: Arial, sans-serif; max-width: 420px; margin: 80px auto; padding: 20px; }
+        input { width: 100%%; padding: 8px; margin: 10px 0; box-sizing: border-box; font-family: monospace; }
+        button { padding: 8px 16px; }
+        .error { color: #b00020; }
+    </style>
+</head>
+<body>
+    <h2>🔐 DevTool</h2>
+    <p>Enter an API token to use the web interface.</p>
+    %s
+    <form method="POST" action="/login">
+        <input type="password" name="token" placeholder="dtk_..." autofocus>
+        <button type="submit">Sign in</button>
+    </form>
+</body>
+</html>`
+
+// handleLoginPage shows the sign-in form
+func handleLoginPage(c echo.Context) error {
+	return c.HTML(http.StatusOK, fmt.Sprintf(loginPage, ""))
+}
+
+// handleLogin checks a token and stores it in the session cookie
+func handleLogin(c echo.Context) error {
+	token := strings.TrimSpace(c.FormValue("token"))
+	if _, err := authenticate(token, c.Request().RemoteAddr); err != nil {
+		message := fmt.Sprintf(`<p class="error">Sign in failed: %s</p>`, html.EscapeString(err.Error()))
+		return c.HTML(http.StatusUnauthorized, fmt.Sprintf(loginPage, message))
+	}
+
+	c.SetCookie(&http.Cookie{
+		Name:     sessionCookieName,
+		Value:    token,
+		Path:     "/",
+		HttpOnly: true,
+		SameSite: http.SameSiteStrictMode,
+		Secure:   c.Scheme() == "https",
+	})
+	return c.Redirect(http.StatusSeeOther, "/")
+}
+
+// handleLogout clears the session cookie
+func handleLogout(c echo.Context) error {
+	c.SetCookie(&http.Cookie{
+		Name:     sessionCookieName,
+		Value:    "",
+		Path:     "/",
+		MaxAge:   -1,
+		HttpOnly: true,
+		SameSite: http.SameSiteStrictMode,
+	})
+	return c.Redirect(http.StatusSeeOther, "/login")
+}
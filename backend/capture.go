@@ -0,0 +1,191 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// OutputCapture stores part of a step's stdout in a run-scoped variable that
+// later steps of the same run can reference as ${VARIABLE}. Without a regex or
+// JSON path the whole stdout (trimmed) is captured.
+type OutputCapture struct {
+	Variable string `yaml:"variable" json:"variable"`
+	Regex    string `yaml:"regex,omitempty" json:"regex,omitempty"`         // First capture group, or the whole match if there is none
+	JSONPath string `yaml:"json_path,omitempty" json:"json_path,omitempty"` // e.g. "data.items[0].id"; stdout must be JSON
+}
+
+var variableNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// encodeCaptures serializes captures for the steps.captures column
+func encodeCaptures(captures []OutputCapture) string {
+	if len(captures) == 0 {
+		return ""
+	}
+	data, err := json.Marshal(captures)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// decodeCaptures parses the steps.captures column
+func decodeCaptures(value string) []OutputCapture {
+	if value == "" {
+		return nil
+	}
+	var captures []OutputCapture
+	if err := json.Unmarshal([]byte(value), &captures); err != nil {
+		slog.Warn("Ignoring invalid captures value", "value", value, "error", err)
+		return nil
+	}
+	return captures
+}
+
+// validateCaptures checks the output captures of a step
+func validateCaptures(captures []OutputCapture) error {
+	for _, capture := range captures {
+		if !variableNamePattern.MatchString(capture.Variable) {
+			return fmt.Errorf("invalid capture variable name %q", capture.Variable)
+		}
+		if capture.Regex != "" && capture.JSONPath != "" {
+			return fmt.Errorf("capture %s: use either regex or json_path, not both", capture.Variable)
+		}
+		if capture.Regex != "" {
+			if _, err := regexp.Compile(capture.Regex); err != nil {
+				return fmt.Errorf("capture %s: invalid regex: %v", capture.Variable, err)
+			}
+		}
+		if capture.JSONPath != "" {
+			if _, err := parseJSONPath(capture.JSONPath); err != nil {
+				return fmt.Errorf("capture %s: %v", capture.Variable, err)
+			}
+		}
+	}
+	return nil
+}
+
+// applyCaptures extracts the captured variables from a step's stdout
+func applyCaptures(captures []OutputCapture, stdout string) (map[string]string, error) {
+	values := make(map[string]string, len(captures))
+	for _, capture := range captures {
+		value, err := capture.extract(stdout)
+		if err != nil {
+			return nil, fmt.Errorf("failed to capture %s: %v", capture.Variable, err)
+		}
+		values[capture.Variable] = value
+	}
+	return values, nil
+}
+
+func (c OutputCapture) extract(stdout string) (string, error) {
+	switch {
+	case c.Regex != "":
+		re, err := regexp.Compile(c.Regex)
+		if err != nil {
+			return "", fmt.Errorf("invalid regex: %v", err)
+		}
+		match := re.FindStringSubmatch(stdout)
+		if match == nil {
+			return "", fmt.Errorf("regex %q did not match", c.Regex)
+		}
+		if len(match) > 1 {
+			return match[1], nil
+		}
+		return match[0], nil
+
+	case c.JSONPath != "":
+		path, err := parseJSONPath(c.JSONPath)
+		if err != nil {
+			return "", err
+		}
+		var data interface{}
+		if err := json.Unmarshal([]byte(stdout), &data); err != nil {
+			return "", fmt.Errorf("stdout is not valid JSON: %v", err)
+		}
+		return path.lookup(data)
+	}
+
+	return strings.TrimSpace(stdout), nil
+}
+
+// jsonPath is a parsed path like "data.items[0].id". Each element is either an
+// object key (string) or an array index (int).
+type jsonPath []interface{}
+
+// parseJSONPath parses dot-separated keys with optional [index] suffixes. A
+// leading "$" or "." is allowed.
+func parseJSONPath(path string) (jsonPath, error) {
+	rest := strings.TrimPrefix(strings.TrimPrefix(path, "$"), ".")
+	if rest == "" {
+		return jsonPath{}, nil
+	}
+
+	var parsed jsonPath
+	for _, part := range strings.Split(rest, ".") {
+		key := part
+		if i := strings.IndexByte(part, '['); i >= 0 {
+			key = part[:i]
+		}
+		if key == "" && !strings.HasPrefix(part, "[") || strings.ContainsAny(key, "[]") {
+			return nil, fmt.Errorf("invalid json_path %q", path)
+		}
+		if key != "" {
+			parsed = append(parsed, key)
+		}
+
+		indexes := part[len(key):]
+		for indexes != "" {
+			end := strings.IndexByte(indexes, ']')
+			if indexes[0] != '[' || end < 0 {
+				return nil, fmt.Errorf("invalid json_path %q", path)
+			}
+			index, err := strconv.Atoi(indexes[1:end])
+			if err != nil || index < 0 {
+				return nil, fmt.Errorf("invalid index in json_path %q", path)
+			}
+			parsed = append(parsed, index)
+			indexes = indexes[end+1:]
+		}
+	}
+	return parsed, nil
+}
+
+// lookup resolves the path in decoded JSON. Strings are returned as is, other
+// values as JSON.
+func (p jsonPath) lookup(data interface{}) (string, error) {
+	current := data
+	for _, element := range p {
+		switch key := element.(type) {
+		case string:
+			object, ok := current.(map[string]interface{})
+			if !ok {
+				return "", fmt.Errorf("cannot look up %q in a non-object", key)
+			}
+			if current, ok = object[key]; !ok {
+				return "", fmt.Errorf("key %q not found", key)
+			}
+		case int:
+			array, ok := current.([]interface{})
+			if !ok {
+				return "", fmt.Errorf("cannot index a non-array with [%d]", key)
+			}
+			if key >= len(array) {
+				return "", fmt.Errorf("index [%d] out of range", key)
+			}
+			current = array[key]
+		}
+	}
+
+	if s, ok := current.(string); ok {
+		return s, nil
+	}
+	encoded, err := json.Marshal(current)
+	if err != nil {
+		return "", err
+	}
+	return string(encoded), nil
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// CommandEnvironment is the working directory and variables a command runs
+// with. Later variable sets take precedence: env files, then flow variables,
+// then the step's env.
+type CommandEnvironment struct {
+	WorkingDir    string            // Empty means commandWorkingDir()
+	FileVariables map[string]string // Loaded from env files, see dotenv.go
+	Variables     map[string]string // Flow (and run) variables
+	Env           map[string]string // The step's env
+}
+
+// dir returns the directory the command runs in
+func (e CommandEnvironment) dir() string {
+	if e.WorkingDir != "" {
+		return e.WorkingDir
+	}
+	return commandWorkingDir()
+}
+
+// layers returns the variable sets in increasing order of precedence
+func (e CommandEnvironment) layers() []map[string]string {
+	return []map[string]string{e.FileVariables, e.Variables, e.Env}
+}
+
+// lookup returns every variable the command can reference, resolving conflicts by precedence
+func (e CommandEnvironment) lookup() map[string]string {
+	if len(e.FileVariables) == 0 && len(e.Env) == 0 {
+		return e.Variables
+	}
+	merged := make(map[string]string)
+	for _, layer := range e.layers() {
+		for key, value := range layer {
+			merged[key] = value
+		}
+	}
+	return merged
+}
+
+// validateStepEnv checks the env map of a step
+func validateStepEnv(env map[string]string) error {
+	for key := range env {
+		if !variableNamePattern.MatchString(key) {
+			return fmt.Errorf("invalid env variable name %q", key)
+		}
+	}
+	return nil
+}
+
+// encodeStepEnv serializes a step's env for the steps.env column
+func encodeStepEnv(env map[string]string) string {
+	if len(env) == 0 {
+		return ""
+	}
+	data, err := json.Marshal(env)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// decodeStepEnv parses the steps.env column
+func decodeStepEnv(value string) map[string]string {
+	if value == "" {
+		return nil
+	}
+	var env map[string]string
+	if err := json.Unmarshal([]byte(value), &env); err != nil {
+		slog.Warn("Ignoring invalid env value", "value", value, "error", err)
+		return nil
+	}
+	return env
+}
+
+// prepareCommandEnvironment resolves a step's working directory, env files
+// and env for the given variables. The working directory and env values may
+// reference variables; relative directories and env files are resolved
+// against the default working directory and the step's working directory.
+// A working directory outside the allowed workspace is a *PolicyError.
+func prepareCommandEnvironment(variables map[string]string, workingDir string, envFiles []string, env map[string]string) (CommandEnvironment, error) {
+	cmdEnv := CommandEnvironment{Variables: variables}
+
+	if workingDir != "" {
+		dir, err := resolveWorkingDir(workingDir, variables)
+		if err != nil {
+			return cmdEnv, err
+		}
+		cmdEnv.WorkingDir = dir
+	}
+	if err := checkWorkingDir(cmdEnv.dir()); err != nil {
+		return cmdEnv, err
+	}
+
+	fileVariables, err := loadEnvFiles(envFiles, cmdEnv.dir(), variables)
+	if err != nil {
+		return cmdEnv, err
+	}
+	cmdEnv.FileVariables = fileVariables
+
+	if len(env) > 0 {
+		scope := cmdEnv.lookup()
+		cmdEnv.Env = make(map[string]string, len(env))
+		for key, value := range env {
+			expanded, err := interpolate(value, scope)
+			if err != nil {
+				return cmdEnv, fmt.Errorf("env %s: %v", key, err)
+			}
+			cmdEnv.Env[key] = expanded
+		}
+	}
+
+	return cmdEnv, nil
+}
+
+// resolveWorkingDir expands a step's working_dir and checks that it is a directory
+func resolveWorkingDir(workingDir string, variables map[string]string) (string, error) {
+	dir, err := interpolate(workingDir, variables)
+	if err != nil {
+		return "", fmt.Errorf("working_dir: %v", err)
+	}
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(commandWorkingDir(), dir)
+	}
+	dir = filepath.Clean(dir)
+
+	info, err := os.Stat(dir)
+	if os.IsNotExist(err) {
+		return "", fmt.Errorf("working directory %s does not exist", dir)
+	}
+	if err != nil {
+		return "", fmt.Errorf("working directory %s: %v", dir, err)
+	}
+	if !info.IsDir() {
+		return "", fmt.Errorf("working directory %s is not a directory", dir)
+	}
+	return dir, nil
+}
+
+// exportStatements returns bash statements exporting the command's variables
+// in increasing order of precedence, one per line
+func exportStatements(cmdEnv CommandEnvironment) string {
+	var out strings.Builder
+	for _, layer := range cmdEnv.layers() {
+		keys := make([]string, 0, len(layer))
+		for key := range layer {
+			if variableNamePattern.MatchString(key) {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			fmt.Fprintf(&out, "export %s=%s\n", key, shellQuote(layer[key]))
+		}
+	}
+	return out.String()
+}
+
+// shellQuote quotes a value for bash
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+// Steps refer to their dependencies by name. If no step in a flow declares
+// depends_on, the flow keeps its linear behaviour: every step implicitly
+// depends on the step before it (see stepDependencies for how conditional
+// steps fit in). Otherwise steps without depends_on have no dependencies and start
+// as soon as the run does.
+
+// encodeDependsOn serializes a dependency list for the steps.depends_on column
+func encodeDependsOn(dependsOn []string) string {
+	if len(dependsOn) == 0 {
+		return ""
+	}
+	data, err := json.Marshal(dependsOn)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// decodeDependsOn parses the steps.depends_on column
+func decodeDependsOn(value string) []string {
+	if value == "" {
+		return nil
+	}
+	var dependsOn []string
+	if err := json.Unmarshal([]byte(value), &dependsOn); err != nil {
+		slog.Warn("Ignoring invalid depends_on value", "value", value, "error", err)
+		return nil
+	}
+	return dependsOn
+}
+
+// stepGraph holds the ordering constraints of each step, by step index
+type stepGraph struct {
+	requires [][]int       // Steps that must succeed before the step runs
+	after    [][]int       // Steps that must finish before the step runs; a superset of requires
+	when     []*parsedWhen // Parsed step conditions; nil means always run
+}
+
+// stepDependencies resolves the dependencies and conditions of each step,
+// returning an error for unknown or ambiguous step names, invalid conditions
+// and cycles. Steps referenced by a condition's succeeded() or failed() must
+// finish first but don't have to succeed.
+//
+// In a linear flow each step waits for the previous one and requires the
+// closest earlier step without a condition to have succeeded, so conditional
+// steps don't block the flow when skipped and nothing runs past a failure.
+// Steps whose condition uses succeeded() or failed() are the exception: they
+// decide for themselves, which lets failure handlers run.
+func stepDependencies(steps []Step) (*stepGraph, error) {
+	graph := &stepGraph{
+		requires: make([][]int, len(steps)),
+		after:    make([][]int, len(steps)),
+		when:     make([]*parsedWhen, len(steps)),
+	}
+
+	declared, referenced := false, false
+	for i, step := range steps {
+		when, err := parseWhen(step.When)
+		if err != nil {
+			return nil, fmt.Errorf("step %q: %v", step.Name, err)
+		}
+		graph.when[i] = when
+		if len(step.DependsOn) > 0 {
+			declared = true
+		}
+		if when != nil && len(when.stepRefs) > 0 {
+			referenced = true
+		}
+	}
+
+	byName := make(map[string]int, len(steps))
+	for i, step := range steps {
+		if _, exists := byName[step.Name]; exists && (declared || referenced) {
+			return nil, fmt.Errorf("step name %q is used more than once; names must be unique when depends_on or step conditions are used", step.Name)
+		}
+		byName[step.Name] = i
+	}
+
+	lastUnconditional := -1
+	for i, step := range steps {
+		seen := make(map[int]bool)
+		addAfter := func(dep int) {
+			if !seen[dep] {
+				seen[dep] = true
+				graph.after[i] = append(graph.after[i], dep)
+			}
+		}
+
+		if !declared {
+			if i > 0 {
+				addAfter(i - 1)
+			}
+			when := graph.when[i]
+			if lastUnconditional >= 0 && (when == nil || len(when.stepRefs) == 0) {
+				graph.requires[i] = []int{lastUnconditional}
+			}
+			if when == nil {
+				lastUnconditional = i
+			}
+		}
+
+		for _, name := range step.DependsOn {
+			dep, ok := byName[name]
+			if !ok {
+				return nil, fmt.Errorf("step %q depends on unknown step %q", step.Name, name)
+			}
+			if dep == i {
+				return nil, fmt.Errorf("step %q depends on itself", step.Name)
+			}
+			if !seen[dep] {
+				graph.requires[i] = append(graph.requires[i], dep)
+			}
+			addAfter(dep)
+		}
+
+		if graph.when[i] != nil {
+			for _, name := range graph.when[i].stepRefs {
+				dep, ok := byName[name]
+				if !ok {
+					return nil, fmt.Errorf("step %q has a condition on unknown step %q", step.Name, name)
+				}
+				if dep == i {
+					return nil, fmt.Errorf("step %q has a condition on itself", step.Name)
+				}
+				addAfter(dep)
+			}
+		}
+	}
+
+	if cycle := findDependencyCycle(steps, graph.after); cycle != nil {
+		return nil, fmt.Errorf("dependency cycle: %s", strings.Join(cycle, " -> "))
+	}
+
+	return graph, nil
+}
+
+// findDependencyCycle returns the step names forming a cycle, or nil if there is none
+func findDependencyCycle(steps []Step, deps [][]int) []string {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make([]int, len(steps))
+	var stack []int
+
+	var visit func(i int) []string
+	visit = func(i int) []string {
+		state[i] = visiting
+		stack = append(stack, i)
+		for _, dep := range deps[i] {
+			switch state[dep] {
+			case visiting:
+				// Report the cycle from the first occurrence of dep on the stack
+				var cycle []string
+				for j := len(stack) - 1; j >= 0; j-- {
+					cycle = append([]string{steps[stack[j]].Name}, cycle...)
+					if stack[j] == dep {
+						break
+					}
+				}
+				return append(cycle, steps[dep].Name)
+			case unvisited:
+				if cycle := visit(dep); cycle != nil {
+					return cycle
+				}
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[i] = visited
+		return nil
+	}
+
+	for i := range steps {
+		if state[i] == unvisited {
+			if cycle := visit(i); cycle != nil {
+				return cycle
+			}
+		}
+	}
+	return nil
+}
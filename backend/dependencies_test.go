@@ -0,0 +1,127 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestStepDependencies(t *testing.T) {
+	tests := []struct {
+		name     string
+		steps    []Step
+		requires [][]int
+		after    [][]int
+	}{
+		{
+			name:     "linear",
+			steps:    []Step{{Name: "a"}, {Name: "b"}, {Name: "c"}},
+			requires: [][]int{nil, {0}, {1}},
+			after:    [][]int{nil, {0}, {1}},
+		},
+		{
+			name: "linear with conditions",
+			steps: []Step{
+				{Name: "build"},
+				{Name: "deploy", When: `${ENV} == "prod"`},
+				{Name: "notify", When: `${NOTIFY} == "y"`},
+				{Name: "cleanup"},
+			},
+			requires: [][]int{nil, {0}, {0}, {0}},
+			after:    [][]int{nil, {0}, {1}, {2}},
+		},
+		{
+			name: "linear with a failure handler",
+			steps: []Step{
+				{Name: "build"},
+				{Name: "report", When: `failed("build")`},
+				{Name: "publish"},
+			},
+			requires: [][]int{nil, nil, {0}},
+			after:    [][]int{nil, {0}, {1}},
+		},
+		{
+			name:     "linear with duplicate names",
+			steps:    []Step{{Name: "echo"}, {Name: "echo"}},
+			requires: [][]int{nil, {0}},
+			after:    [][]int{nil, {0}},
+		},
+		{
+			name: "declared",
+			steps: []Step{
+				{Name: "lint"},
+				{Name: "test"},
+				{Name: "build", DependsOn: []string{"lint", "test"}},
+				{Name: "report", When: `succeeded("build") || failed("test")`},
+			},
+			requires: [][]int{nil, nil, {0, 1}, nil},
+			after:    [][]int{nil, nil, {0, 1}, {2, 1}},
+		},
+		{
+			name: "declared with a condition on a dependency",
+			steps: []Step{
+				{Name: "build"},
+				{Name: "deploy", DependsOn: []string{"build"}, When: `succeeded("build")`},
+			},
+			requires: [][]int{nil, {0}},
+			after:    [][]int{nil, {0}},
+		},
+	}
+
+	for _, tt := range tests {
+		graph, err := stepDependencies(tt.steps)
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(graph.requires, tt.requires) {
+			t.Errorf("%s: requires = %v, want %v", tt.name, graph.requires, tt.requires)
+		}
+		if !reflect.DeepEqual(graph.after, tt.after) {
+			t.Errorf("%s: after = %v, want %v", tt.name, graph.after, tt.after)
+		}
+	}
+}
+
+func TestStepDependenciesErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		steps []Step
+		err   string
+	}{
+		{"unknown dependency", []Step{{Name: "a", DependsOn: []string{"b"}}}, `unknown step "b"`},
+		{"self dependency", []Step{{Name: "a", DependsOn: []string{"a"}}}, "depends on itself"},
+		{"unknown condition step", []Step{{Name: "a", When: `failed("b")`}}, `condition on unknown step "b"`},
+		{"self condition", []Step{{Name: "a", When: `succeeded("a")`}}, "condition on itself"},
+		{"invalid condition", []Step{{Name: "a", When: `${X} = "y"`}}, "invalid when condition"},
+		{
+			"duplicate names",
+			[]Step{{Name: "a"}, {Name: "a"}, {Name: "b", DependsOn: []string{"a"}}},
+			`step name "a" is used more than once`,
+		},
+		{
+			"cycle",
+			[]Step{
+				{Name: "a", DependsOn: []string{"c"}},
+				{Name: "b", DependsOn: []string{"a"}},
+				{Name: "c", DependsOn: []string{"b"}},
+			},
+			"dependency cycle: a -> c -> b -> a",
+		},
+		{
+			"cycle through a condition",
+			[]Step{
+				{Name: "a", DependsOn: []string{"b"}},
+				{Name: "b", When: `succeeded("a")`},
+			},
+			"dependency cycle",
+		},
+	}
+
+	for _, tt := range tests {
+		_, err := stepDependencies(tt.steps)
+		if err == nil || !strings.Contains(err.Error(), tt.err) {
+			t.Errorf("%s: got error %v, want one containing %q", tt.name, err, tt.err)
+		}
+	}
+}
@@ -0,0 +1,247 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Env files
+//
+// Flows and steps can list env_files in .env format. Their variables are added
+// to the command environment before the flow variables, so flow variables win
+// on conflicts; with several files, later files win. Files are parsed with the
+// usual dotenv rules:
+//
+//	# comments and blank lines are ignored
+//	export KEY=value       # the export prefix is optional; inline comments follow a space
+//	KEY="multi\nline $${LITERAL}"  # escapes and ${VAR} references are expanded
+//	KEY='${NOT_EXPANDED}'  # single quotes are literal
+//
+// References use the interpolation syntax of commands (see interpolate.go) and
+// resolve to variables set earlier in the env files, then flow variables, then
+// HOME and USER; nothing else is taken from the server's environment. Relative
+// paths are resolved against the command's working directory; paths may
+// reference variables too.
+
+// validateEnvFiles checks an env_files list
+func validateEnvFiles(envFiles []string) error {
+	for _, path := range envFiles {
+		if strings.TrimSpace(path) == "" {
+			return fmt.Errorf("env_files entries must not be empty")
+		}
+	}
+	return nil
+}
+
+// loadEnvFiles reads and merges the given env files
+func loadEnvFiles(envFiles []string, dir string, variables map[string]string) (map[string]string, error) {
+	if len(envFiles) == 0 {
+		return nil, nil
+	}
+
+	loaded := make(map[string]string)
+	for _, path := range envFiles {
+		resolved, err := interpolate(path, variables)
+		if err != nil {
+			return nil, fmt.Errorf("env file %s: %v", path, err)
+		}
+		if !filepath.IsAbs(resolved) {
+			resolved = filepath.Join(dir, resolved)
+		}
+
+		data, err := os.ReadFile(resolved)
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("env file %s not found", resolved)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read env file %s: %v", resolved, err)
+		}
+
+		scope := make(map[string]string, len(variables)+len(loaded))
+		for key, value := range variables {
+			scope[key] = value
+		}
+		for key, value := range loaded {
+			scope[key] = value
+		}
+		values, err := parseDotenv(string(data), scope)
+		if err != nil {
+			return nil, fmt.Errorf("env file %s: %v", resolved, err)
+		}
+		for key, value := range values {
+			loaded[key] = value
+		}
+	}
+	return loaded, nil
+}
+
+// parseDotenv parses the contents of an env file. References are resolved
+// against the variables defined earlier in the file, then scope.
+func parseDotenv(content string, scope map[string]string) (map[string]string, error) {
+	values := make(map[string]string)
+	lookup := make(map[string]string, len(scope))
+	for key, value := range scope {
+		lookup[key] = value
+	}
+
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+	for i := 0; i < len(lines); i++ {
+		lineNumber := i + 1
+		// Trailing whitespace is kept for quoted values that continue on the next line
+		line := strings.TrimLeft(lines[i], " \t")
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if rest := strings.TrimPrefix(line, "export"); rest != line && (rest == "" || rest[0] == ' ' || rest[0] == '\t') {
+			line = strings.TrimSpace(rest)
+		}
+
+		eq := strings.IndexByte(line, '=')
+		if eq < 0 {
+			return nil, fmt.Errorf("line %d: expected KEY=value", lineNumber)
+		}
+		key := strings.TrimSpace(line[:eq])
+		if !variableNamePattern.MatchString(key) {
+			return nil, fmt.Errorf("line %d: invalid variable name %q", lineNumber, key)
+		}
+		raw := strings.TrimLeft(line[eq+1:], " \t")
+
+		var value string
+		expand := true
+		if raw != "" && (raw[0] == '"' || raw[0] == '\'') {
+			quote := raw[0]
+			body := raw[1:]
+			for {
+				end := closingQuote(body, quote)
+				if end >= 0 {
+					if rest := strings.TrimSpace(body[end+1:]); rest != "" && !strings.HasPrefix(rest, "#") {
+						return nil, fmt.Errorf("line %d: unexpected %q after closing quote", lineNumber, rest)
+					}
+					body = body[:end]
+					break
+				}
+				// Quoted values may span lines
+				i++
+				if i >= len(lines) {
+					return nil, fmt.Errorf("line %d: unterminated quoted value", lineNumber)
+				}
+				body += "\n" + lines[i]
+			}
+
+			if quote == '\'' {
+				value, expand = body, false
+			} else {
+				value = unescapeDotenv(body)
+			}
+		} else {
+			for _, marker := range []string{" #", "\t#"} {
+				if idx := strings.Index(raw, marker); idx >= 0 {
+					raw = raw[:idx]
+				}
+			}
+			value = strings.TrimSpace(raw)
+		}
+
+		if expand {
+			expanded, err := interpolate(value, lookup)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %v", lineNumber, err)
+			}
+			value = expanded
+		}
+
+		values[key] = value
+		lookup[key] = value
+	}
+
+	return values, nil
+}
+
+// closingQuote returns the index of the quote ending a quoted value, skipping
+// backslash escapes in double-quoted values, or -1 if there is none
+func closingQuote(body string, quote byte) int {
+	for i := 0; i < len(body); i++ {
+		switch {
+		case body[i] == '\\' && quote == '"':
+			i++
+		case body[i] == quote:
+			return i
+		}
+	}
+	return -1
+}
+
+// unescapeDotenv resolves the escapes of a double-quoted value. An escaped \$
+// becomes $$ so that interpolation turns it into a literal $.
+func unescapeDotenv(body string) string {
+	var out strings.Builder
+	for i := 0; i < len(body); i++ {
+		if body[i] != '\\' || i+1 >= len(body) {
+			out.WriteByte(body[i])
+			continue
+		}
+		i++
+		switch body[i] {
+		case 'n':
+			out.WriteByte('\n')
+		case 'r':
+			out.WriteByte('\r')
+		case 't':
+			out.WriteByte('\t')
+		case '$':
+			out.WriteString("$$")
+		case '"', '\\':
+			out.WriteByte(body[i])
+		default:
+			out.WriteByte('\\')
+			out.WriteByte(body[i])
+		}
+	}
+	return out.String()
+}
+
+// getFlowEnvFiles returns the env files listed at the flow level
+func getFlowEnvFiles(flowID int) ([]string, error) {
+	var envFiles string
+	if err := db.QueryRow("SELECT env_files FROM flows WHERE id = ?", flowID).Scan(&envFiles); err != nil {
+		return nil, fmt.Errorf("failed to get env files of flow %d: %v", flowID, err)
+	}
+	return decodeEnvFiles(envFiles), nil
+}
+
+// stepEnvFiles returns the env files a step loads: the flow's, then its own
+func stepEnvFiles(flowEnvFiles, envFiles []string) []string {
+	if len(flowEnvFiles) == 0 {
+		return envFiles
+	}
+	return append(append([]string{}, flowEnvFiles...), envFiles...)
+}
+
+// encodeEnvFiles serializes an env_files list for the flows and steps tables
+func encodeEnvFiles(envFiles []string) string {
+	if len(envFiles) == 0 {
+		return ""
+	}
+	data, err := json.Marshal(envFiles)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// decodeEnvFiles parses an env_files column
+func decodeEnvFiles(value string) []string {
+	if value == "" {
+		return nil
+	}
+	var envFiles []string
+	if err := json.Unmarshal([]byte(value), &envFiles); err != nil {
+		slog.Warn("Ignoring invalid env_files value", "value", value, "error", err)
+		return nil
+	}
+	return envFiles
+}
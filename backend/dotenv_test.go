@@ -0,0 +1,140 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseDotenv(t *testing.T) {
+	scope := map[string]string{"HOST": "db.internal", "PORT": "5432"}
+
+	tests := []struct {
+		name    string
+		content string
+		want    map[string]string
+	}{
+		{"empty", "", map[string]string{}},
+		{"plain", "A=1\nB=two words\n", map[string]string{"A": "1", "B": "two words"}},
+		{"spaces around", "  A = 1  \n", map[string]string{"A": "1"}},
+		{"empty value", "A=\nB=\"\"\nC=''", map[string]string{"A": "", "B": "", "C": ""}},
+		{"equals in value", "URL=postgres://u:p@h/db?sslmode=disable", map[string]string{"URL": "postgres://u:p@h/db?sslmode=disable"}},
+		{"CRLF", "A=1\r\nB=2\r\n", map[string]string{"A": "1", "B": "2"}},
+		{"later wins", "A=1\nA=2", map[string]string{"A": "2"}},
+
+		// Comments
+		{"comment lines", "# setup\n\n  # indented\nA=1", map[string]string{"A": "1"}},
+		{"inline comment", "A=1 # one\nB=2\t# two", map[string]string{"A": "1", "B": "2"}},
+		{"hash in value", "A=a#b\nCOLOR=#fff", map[string]string{"A": "a#b", "COLOR": "#fff"}},
+		{"comment after quotes", `A="1 # not a comment" # comment`, map[string]string{"A": "1 # not a comment"}},
+		{"hash in single quotes", "A='#1' #c", map[string]string{"A": "#1"}},
+
+		// export prefix
+		{"export", "export A=1\nexport\tB=2", map[string]string{"A": "1", "B": "2"}},
+		{"export quoted", `export A="x y"`, map[string]string{"A": "x y"}},
+		{"named export", "export=1\nexporter=2", map[string]string{"export": "1", "exporter": "2"}},
+
+		// Quoting and escapes
+		{"double quotes", `A="  padded  "`, map[string]string{"A": "  padded  "}},
+		{"single quotes", `A='  padded  '`, map[string]string{"A": "  padded  "}},
+		{"escapes", `A="tab\there\nnew \"quoted\" back\\slash\r"`, map[string]string{"A": "tab\there\nnew \"quoted\" back\\slash\r"}},
+		{"unknown escape", `A="C:\dir\x"`, map[string]string{"A": `C:\dir\x`}},
+		{"escapes in single quotes", `A='no\nescape'`, map[string]string{"A": `no\nescape`}},
+		{"escapes unquoted", `A=no\nescape`, map[string]string{"A": `no\nescape`}},
+		{"other quote inside", `A="it's"` + "\n" + `B='say "hi"'`, map[string]string{"A": "it's", "B": `say "hi"`}},
+
+		// Multiline values
+		{"multiline double", "A=\"line 1\nline 2\n  line 3\"\nB=2", map[string]string{"A": "line 1\nline 2\n  line 3", "B": "2"}},
+		{"multiline trailing spaces", "A=\"x  \n  y\"  ", map[string]string{"A": "x  \n  y"}},
+		{"multiline single", "KEY='-----BEGIN KEY-----\nabc\n-----END KEY-----'", map[string]string{"KEY": "-----BEGIN KEY-----\nabc\n-----END KEY-----"}},
+		{"multiline with comment", "A=\"x\n# not a comment\ny\" # comment", map[string]string{"A": "x\n# not a comment\ny"}},
+		{"escaped quote across lines", "A=\"a\\\"\nb\"", map[string]string{"A": "a\"\nb"}},
+
+		// References
+		{"reference scope", "DSN=${HOST}:${PORT}", map[string]string{"DSN": "db.internal:5432"}},
+		{"reference earlier", "A=1\nB=${A}2\nA=3", map[string]string{"A": "3", "B": "12"}},
+		{"reference quoted", `A="${HOST}"`, map[string]string{"A": "db.internal"}},
+		{"reference default", "A=${MISSING:-fallback}", map[string]string{"A": "fallback"}},
+		{"reference shadowing scope", "PORT=6543\nDSN=${HOST}:${PORT}", map[string]string{"PORT": "6543", "DSN": "db.internal:6543"}},
+		{"reference in single quotes", "A='${HOST}'", map[string]string{"A": "${HOST}"}},
+		{"escaped dollar", `A="\${HOST}"` + "\nB=$${HOST}", map[string]string{"A": "${HOST}", "B": "${HOST}"}},
+	}
+
+	for _, tt := range tests {
+		got, err := parseDotenv(tt.content, scope)
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestParseDotenvErrors(t *testing.T) {
+	tests := []struct {
+		content string
+		err     string
+	}{
+		{"A=1\nJUST_A_KEY", "line 2: expected KEY=value"},
+		{"1A=x", `line 1: invalid variable name "1A"`},
+		{"MY-KEY=x", `line 1: invalid variable name "MY-KEY"`},
+		{"=x", `line 1: invalid variable name ""`},
+		{"A=1\nB=\"open\nstill open", "line 2: unterminated quoted value"},
+		{"A='open", "line 1: unterminated quoted value"},
+		{`A="x" y`, `line 1: unexpected "y" after closing quote`},
+		{"A=1\n\nB=${UNDEFINED}", "line 3: undefined variables: UNDEFINED"},
+		{"A=${B:?B is required}", "line 1: B: B is required"},
+	}
+
+	for _, tt := range tests {
+		_, err := parseDotenv(tt.content, nil)
+		if err == nil || err.Error() != tt.err {
+			t.Errorf("parseDotenv(%q): got error %v, want %q", tt.content, err, tt.err)
+		}
+	}
+}
+
+func TestLoadEnvFiles(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"base.env":         "NAME=base\nREGION=eu\nURL=https://${NAME}.example.com",
+		"prod.env":         "NAME=prod\nURL=https://${NAME}.${REGION}.example.com",
+		"config/extra.env": "FLOW=${FLOW_VAR}",
+	} {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	variables := map[string]string{"FLOW_VAR": "from flow", "ENV": "prod", "CONFIG": filepath.Join(dir, "config")}
+
+	// Later files win, and see the variables of earlier ones
+	got, err := loadEnvFiles([]string{"base.env", "${ENV}.env", "${CONFIG}/extra.env"}, dir, variables)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{"NAME": "prod", "REGION": "eu", "URL": "https://prod.eu.example.com", "FLOW": "from flow"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	if got, err := loadEnvFiles(nil, dir, variables); got != nil || err != nil {
+		t.Errorf("no env files: got %q, %v", got, err)
+	}
+
+	for envFile, wantErr := range map[string]string{
+		"missing.env":      "env file " + filepath.Join(dir, "missing.env") + " not found",
+		"${UNDEFINED}.env": "env file ${UNDEFINED}.env: undefined variables: UNDEFINED",
+	} {
+		if _, err := loadEnvFiles([]string{envFile}, dir, variables); err == nil || !strings.Contains(err.Error(), wantErr) {
+			t.Errorf("%s: got error %v, want %q", envFile, err, wantErr)
+		}
+	}
+}
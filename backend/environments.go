@@ -0,0 +1,520 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Variables are resolved in layers, each overriding the one before it:
+//
+//	global variables < environment variables < flow variables < run overrides
+//
+// Global variables apply to every flow. Environments (e.g. "local" or
+// "staging-sim") are named variable sets selected when a step or flow is run.
+
+var environmentNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+
+// VariableSet is a set of variables, some of which may be secret
+type VariableSet struct {
+	Variables map[string]string `json:"variables"`
+	Secrets   []string          `json:"secrets,omitempty"` // Names of secret variables, whose values are masked
+}
+
+// Environment is a named variable set that can be selected for a run
+type Environment struct {
+	ID          int               `json:"id"`
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	Variables   map[string]string `json:"variables"`
+	Secrets     []string          `json:"secrets,omitempty"`
+	CreatedAt   time.Time         `json:"created_at"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+}
+
+// EnvironmentRequest is the payload for creating or updating an environment
+type EnvironmentRequest struct {
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	Variables   map[string]string `json:"variables"`
+	Secrets     []string          `json:"secrets"`
+}
+
+// createEnvironmentTables creates the tables holding global and environment variables
+func createEnvironmentTables() error {
+	queries := []string{
+		`CREATE TABLE IF NOT EXISTS global_variables (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			key TEXT NOT NULL UNIQUE,
+			value TEXT,
+			secret BOOLEAN DEFAULT FALSE
+		)`,
+		`CREATE TABLE IF NOT EXISTS environments (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			name TEXT NOT NULL UNIQUE,
+			description TEXT,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE TABLE IF NOT EXISTS environment_variables (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			environment_id INTEGER NOT NULL,
+			key TEXT NOT NULL,
+			value TEXT,
+			secret BOOLEAN DEFAULT FALSE,
+			FOREIGN KEY (environment_id) REFERENCES environments (id) ON DELETE CASCADE,
+			UNIQUE(environment_id, key)
+		)`,
+	}
+
+	for _, query := range queries {
+		if _, err := db.Exec(query); err != nil {
+			return fmt.Errorf("failed to execute query %s: %v", query, err)
+		}
+	}
+	return nil
+}
+
+// validateVariableSet checks variable names and that every secret is a variable
+func validateVariableSet(variables map[string]string, secretKeys []string) error {
+	for key := range variables {
+		if !variableNamePattern.MatchString(key) {
+			return fmt.Errorf("invalid variable name %q", key)
+		}
+	}
+	for _, key := range secretKeys {
+		if _, ok := variables[key]; !ok {
+			return fmt.Errorf("secret %s is not a variable", key)
+		}
+	}
+	return nil
+}
+
+// queryVariableSet loads the variables selected by a query returning key,
+// value and secret columns, decrypting the secret ones
+func queryVariableSet(query string, args ...interface{}) (VariableSet, error) {
+	set := VariableSet{Variables: make(map[string]string)}
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return set, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var key string
+		var value sql.NullString
+		var secret bool
+		if err := rows.Scan(&key, &value, &secret); err != nil {
+			return set, err
+		}
+		plain := value.String
+		if secret {
+			if plain, err = secrets.decrypt(plain); err != nil {
+				return set, fmt.Errorf("failed to decrypt variable %s: %v", key, err)
+			}
+			set.Secrets = append(set.Secrets, key)
+		}
+		set.Variables[key] = plain
+	}
+	sort.Strings(set.Secrets)
+
+	return set, rows.Err()
+}
+
+// masked returns a copy of the set with secret values replaced by the mask
+func (s VariableSet) masked() VariableSet {
+	return VariableSet{Variables: maskSecretVariables(s.Variables, s.Secrets), Secrets: s.Secrets}
+}
+
+// getGlobalVariables returns the variables shared by every flow
+func getGlobalVariables() (VariableSet, error) {
+	return queryVariableSet("SELECT key, value, secret FROM global_variables")
+}
+
+// setGlobalVariables replaces the global variables. Secrets sent back as the
+// mask keep their current value.
+func setGlobalVariables(set VariableSet) error {
+	previous, err := getGlobalVariables()
+	if err != nil {
+		return fmt.Errorf("failed to get existing global variables: %v", err)
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("DELETE FROM global_variables"); err != nil {
+		return fmt.Errorf("failed to delete existing global variables: %v", err)
+	}
+	if err := insertVariableSet(tx, "INSERT INTO global_variables (key, value, secret) VALUES (?, ?, ?)", set, previous.Variables); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// insertVariableSet inserts each variable of a set with the given statement,
+// whose last three parameters are key, value and secret
+func insertVariableSet(tx *sql.Tx, statement string, set VariableSet, previous map[string]string, args ...interface{}) error {
+	secret := make(map[string]bool, len(set.Secrets))
+	for _, key := range set.Secrets {
+		secret[key] = true
+	}
+
+	for key, value := range set.Variables {
+		if secret[key] {
+			sealed, err := sealSecret(key, value, previous)
+			if err != nil {
+				return err
+			}
+			value = sealed
+		}
+		params := append(append([]interface{}{}, args...), key, value, secret[key])
+		if _, err := tx.Exec(statement, params...); err != nil {
+			return fmt.Errorf("failed to insert variable %s: %v", key, err)
+		}
+	}
+	return nil
+}
+
+// getEnvironment returns an environment by name with its variables
+func getEnvironment(name string) (*Environment, error) {
+	var env Environment
+	var description sql.NullString
+	err := db.QueryRow(
+		"SELECT id, name, description, created_at, updated_at FROM environments WHERE name = ?",
+		name,
+	).Scan(&env.ID, &env.Name, &description, &env.CreatedAt, &env.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	env.Description = description.String
+
+	set, err := queryVariableSet("SELECT key, value, secret FROM environment_variables WHERE environment_id = ?", env.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get variables of environment %s: %v", name, err)
+	}
+	env.Variables = set.Variables
+	env.Secrets = set.Secrets
+
+	return &env, nil
+}
+
+// getAllEnvironments returns every environment with its variables, secrets masked
+func getAllEnvironments() ([]Environment, error) {
+	rows, err := db.Query("SELECT name FROM environments ORDER BY name")
+	if err != nil {
+		return nil, fmt.Errorf("failed to query environments: %v", err)
+	}
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		names = append(names, name)
+	}
+	rows.Close()
+
+	environments := []Environment{}
+	for _, name := range names {
+		env, err := getEnvironment(name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get environment %s: %v", name, err)
+		}
+		environments = append(environments, env.masked())
+	}
+	return environments, nil
+}
+
+// masked returns a copy of the environment with secret values replaced by the mask
+func (e Environment) masked() Environment {
+	e.Variables = maskSecretVariables(e.Variables, e.Secrets)
+	return e
+}
+
+// saveEnvironment creates an environment, or replaces the environment named
+// existing. Secrets sent back as the mask keep their current value.
+func saveEnvironment(existing string, req EnvironmentRequest) (*Environment, error) {
+	var previous map[string]string
+	var envID int64
+	if existing != "" {
+		env, err := getEnvironment(existing)
+		if err != nil {
+			return nil, err
+		}
+		previous = env.Variables
+		envID = int64(env.ID)
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	if existing == "" {
+		result, err := tx.Exec(
+			"INSERT INTO environments (name, description) VALUES (?, ?)",
+			req.Name, req.Description,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to insert environment: %v", err)
+		}
+		if envID, err = result.LastInsertId(); err != nil {
+			return nil, fmt.Errorf("failed to get environment ID: %v", err)
+		}
+	} else {
+		_, err := tx.Exec(
+			"UPDATE environments SET name = ?, description = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?",
+			req.Name, req.Description, envID,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update environment: %v", err)
+		}
+		if _, err := tx.Exec("DELETE FROM environment_variables WHERE environment_id = ?", envID); err != nil {
+			return nil, fmt.Errorf("failed to delete existing environment variables: %v", err)
+		}
+	}
+
+	set := VariableSet{Variables: req.Variables, Secrets: req.Secrets}
+	if err := insertVariableSet(tx, "INSERT INTO environment_variables (environment_id, key, value, secret) VALUES (?, ?, ?, ?)", set, previous, envID); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %v", err)
+	}
+
+	return getEnvironment(req.Name)
+}
+
+// deleteEnvironment deletes an environment and its variables
+func deleteEnvironment(name string) error {
+	result, err := db.Exec("DELETE FROM environments WHERE name = ?", name)
+	if err != nil {
+		return fmt.Errorf("failed to delete environment: %v", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// resolveVariables returns the variables of a flow layered on top of the
+// global variables and, if one is selected, the environment's variables,
+// together with the names of the secret ones. An unknown environment is
+// reported as a *LaunchError.
+func resolveVariables(flowID int, environment string) (map[string]string, []string, error) {
+	global, err := getGlobalVariables()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get global variables: %v", err)
+	}
+	layers := []VariableSet{global}
+
+	if environment != "" {
+		env, err := getEnvironment(environment)
+		if err == sql.ErrNoRows {
+			return nil, nil, &LaunchError{Message: fmt.Sprintf("unknown environment %q", environment)}
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get environment %s: %v", environment, err)
+		}
+		layers = append(layers, VariableSet{Variables: env.Variables, Secrets: env.Secrets})
+	}
+
+	flowVariables, err := getFlowVariables(flowID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get flow variables: %v", err)
+	}
+	flowSecrets, err := getFlowSecrets(flowID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get flow secrets: %v", err)
+	}
+	layers = append(layers, VariableSet{Variables: flowVariables, Secrets: flowSecrets})
+
+	variables := make(map[string]string)
+	secret := make(map[string]bool)
+	for _, layer := range layers {
+		for key, value := range layer.Variables {
+			variables[key] = value
+			secret[key] = false
+		}
+		for _, key := range layer.Secrets {
+			secret[key] = true
+		}
+	}
+
+	var secretKeys []string
+	for key, isSecret := range secret {
+		if isSecret {
+			secretKeys = append(secretKeys, key)
+		}
+	}
+	sort.Strings(secretKeys)
+
+	return variables, secretKeys, nil
+}
+
+// handleGetGlobalVariables returns the global variables, secrets masked
+func handleGetGlobalVariables(c echo.Context) error {
+	set, err := getGlobalVariables()
+	if err != nil {
+		slog.Error("Error getting global variables", "error", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get global variables",
+		})
+	}
+	return c.JSON(http.StatusOK, set.masked())
+}
+
+// handleUpdateGlobalVariables replaces the global variables
+func handleUpdateGlobalVariables(c echo.Context) error {
+	var req VariableSet
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request payload",
+		})
+	}
+	if err := validateVariableSet(req.Variables, req.Secrets); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	if err := setGlobalVariables(req); err != nil {
+		slog.Error("Error updating global variables", "error", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to update global variables",
+		})
+	}
+	auditChange(c, AuditGlobalVariablesUpdated, "global-variables", 0, 0, fmt.Sprintf("%d variables", len(req.Variables)))
+
+	set, err := getGlobalVariables()
+	if err != nil {
+		slog.Error("Error getting global variables", "error", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get global variables",
+		})
+	}
+	return c.JSON(http.StatusOK, set.masked())
+}
+
+// handleGetEnvironments returns every environment
+func handleGetEnvironments(c echo.Context) error {
+	environments, err := getAllEnvironments()
+	if err != nil {
+		slog.Error("Error getting environments", "error", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get environments",
+		})
+	}
+	return c.JSON(http.StatusOK, environments)
+}
+
+// handleGetEnvironment returns a single environment
+func handleGetEnvironment(c echo.Context) error {
+	env, err := getEnvironment(c.Param("name"))
+	if err == sql.ErrNoRows {
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "Environment not found",
+		})
+	}
+	if err != nil {
+		slog.Error("Error getting environment", "error", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get environment",
+		})
+	}
+	return c.JSON(http.StatusOK, env.masked())
+}
+
+// handleCreateEnvironment creates an environment
+func handleCreateEnvironment(c echo.Context) error {
+	return saveEnvironmentRequest(c, "")
+}
+
+// handleUpdateEnvironment replaces the description and variables of an environment
+func handleUpdateEnvironment(c echo.Context) error {
+	return saveEnvironmentRequest(c, c.Param("name"))
+}
+
+// saveEnvironmentRequest validates and saves an environment request;
+// existing is empty when creating
+func saveEnvironmentRequest(c echo.Context, existing string) error {
+	var req EnvironmentRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request payload",
+		})
+	}
+	if req.Name == "" {
+		req.Name = existing
+	}
+	if !environmentNamePattern.MatchString(req.Name) {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": fmt.Sprintf("invalid environment name %q", req.Name),
+		})
+	}
+	if err := validateVariableSet(req.Variables, req.Secrets); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	env, err := saveEnvironment(existing, req)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return c.JSON(http.StatusNotFound, map[string]string{
+				"error": "Environment not found",
+			})
+		}
+		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
+			return c.JSON(http.StatusConflict, map[string]string{
+				"error": "Environment with this name already exists",
+			})
+		}
+		slog.Error("Error saving environment", "error", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to save environment",
+		})
+	}
+
+	status, action := http.StatusOK, AuditEnvironmentUpdated
+	if existing == "" {
+		status, action = http.StatusCreated, AuditEnvironmentCreated
+	}
+	auditChange(c, action, "environment:"+env.Name, 0, 0, "")
+	return c.JSON(status, env.masked())
+}
+
+// handleDeleteEnvironment deletes an environment
+func handleDeleteEnvironment(c echo.Context) error {
+	if err := deleteEnvironment(c.Param("name")); err != nil {
+		if err == sql.ErrNoRows {
+			return c.JSON(http.StatusNotFound, map[string]string{
+				"error": "Environment not found",
+			})
+		}
+		slog.Error("Error deleting environment", "error", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to delete environment",
+		})
+	}
+	auditChange(c, AuditEnvironmentDeleted, "environment:"+c.Param("name"), 0, 0, "")
+
+	return c.JSON(http.StatusOK, map[string]string{
+		"message": "Environment deleted successfully",
+	})
+}
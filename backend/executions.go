@@ -0,0 +1,165 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+
+	"github.com/labstack/echo/v4"
+)
+
+// executionRegistry tracks in-flight executions so they can be cancelled.
+// Entries are keyed by runKey or stepKey; a step may be executing more than
+// once at a time, so each key holds a set of cancel functions.
+type executionRegistry struct {
+	mu            sync.Mutex
+	nextID        int
+	nextCommandID int
+	entries       map[string]map[int]context.CancelFunc
+}
+
+// Global registry of in-flight executions
+var executions = newExecutionRegistry()
+
+func newExecutionRegistry() *executionRegistry {
+	return &executionRegistry{entries: make(map[string]map[int]context.CancelFunc)}
+}
+
+func runKey(runID int) string {
+	return fmt.Sprintf("run:%d", runID)
+}
+
+func stepKey(stepID int) string {
+	return fmt.Sprintf("step:%d", stepID)
+}
+
+// commandKey identifies an execution of /api/execute-command, numbered by newCommandID
+func commandKey(commandID int) string {
+	return fmt.Sprintf("command:%d", commandID)
+}
+
+// newCommandID returns the ID of a new /api/execute-command execution
+func (r *executionRegistry) newCommandID() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.nextCommandID++
+	return r.nextCommandID
+}
+
+// running reports whether an execution is registered under key
+func (r *executionRegistry) running(key string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.entries[key]) > 0
+}
+
+// start derives a cancellable context from parent and registers it under key.
+// The returned function must be called once the execution has finished.
+func (r *executionRegistry) start(parent context.Context, key string) (context.Context, func()) {
+	ctx, cancel := context.WithCancel(parent)
+
+	r.mu.Lock()
+	r.nextID++
+	id := r.nextID
+	if r.entries[key] == nil {
+		r.entries[key] = make(map[int]context.CancelFunc)
+	}
+	r.entries[key][id] = cancel
+	r.mu.Unlock()
+
+	return ctx, func() {
+		r.mu.Lock()
+		delete(r.entries[key], id)
+		if len(r.entries[key]) == 0 {
+			delete(r.entries, key)
+		}
+		r.mu.Unlock()
+		cancel()
+	}
+}
+
+// cancel cancels every execution registered under key and reports whether any were found
+func (r *executionRegistry) cancel(key string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entries := r.entries[key]
+	for _, cancel := range entries {
+		cancel()
+	}
+	return len(entries) > 0
+}
+
+// handleCancelRun cancels an in-progress flow run
+func handleCancelRun(c echo.Context) error {
+	runID := c.Param("id")
+	id := 0
+	if _, err := fmt.Sscanf(runID, "%d", &id); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid run ID",
+		})
+	}
+
+	if _, err := getRunByID(id); err != nil {
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "Run not found",
+		})
+	}
+
+	if !executions.cancel(runKey(id)) {
+		return c.JSON(http.StatusConflict, map[string]string{
+			"error": "Run is not in progress",
+		})
+	}
+
+	log.Printf("Run %d: cancellation requested", id)
+	return c.JSON(http.StatusAccepted, map[string]string{
+		"message": "Run cancellation requested",
+	})
+}
+
+// handleCancelStep cancels every in-flight direct execution of a step
+func handleCancelStep(c echo.Context) error {
+	stepID := c.Param("id")
+	id := 0
+	if _, err := fmt.Sscanf(stepID, "%d", &id); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid step ID",
+		})
+	}
+
+	if !executions.cancel(stepKey(id)) {
+		return c.JSON(http.StatusConflict, map[string]string{
+			"error": "Step is not executing",
+		})
+	}
+
+	log.Printf("Step %d: cancellation requested", id)
+	return c.JSON(http.StatusAccepted, map[string]string{
+		"message": "Step cancellation requested",
+	})
+}
+
+// handleCancelCommand cancels an in-flight /api/execute-command execution
+func handleCancelCommand(c echo.Context) error {
+	commandID := c.Param("id")
+	id := 0
+	if _, err := fmt.Sscanf(commandID, "%d", &id); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid command ID",
+		})
+	}
+
+	if !executions.cancel(commandKey(id)) {
+		return c.JSON(http.StatusConflict, map[string]string{
+			"error": "Command is not executing",
+		})
+	}
+
+	log.Printf("Command %d: cancellation requested", id)
+	return c.JSON(http.StatusAccepted, map[string]string{
+		"message": "Command cancellation requested",
+	})
+}
@@ -48,7 +48,7 @@ Error generating stack: `+i.message+`
 `+i.stack}}function ii(e){switch(typeof e){case"bigint":case"boolean":case"number":case"string":case"undefined":return e;case"object":return e;default:return""}}function ih(e){var t=e.type;return(e=e.nodeName)&&e.toLowerCase()==="input"&&(t==="checkbox"||t==="radio")}function Bv(e){var t=ih(e)?"checked":"value",i=Object.getOwnPropertyDescriptor(e.constructor.prototype,t),r=""+e[t];if(!e.hasOwnProperty(t)&&typeof i<"u"&&typeof i.get=="function"&&typeof i.set=="function"){var n=i.get,m=i.set;return Object.defineProperty(e,t,{configurable:!0,get:function(){return n.call(this)},set:function(L){r=""+L,m.call(this,L)}}),Object.defineProperty(e,t,{enumerable:i.enumerable}),{getValue:function(){return r},setValue:function(L){r=""+L},stopTracking:function(){e._valueTracker=null,delete e[t]}}}}function pa(e){e._valueTracker||(e._valueTracker=Bv(e))}function rh(e){if(!e)return!1;var t=e._valueTracker;if(!t)return!0;var i=t.getValue(),r="";return e&&(r=ih(e)?e.checked?"true":"false":e.value),e=r,e!==i?(t.setValue(e),!0):!1}function ba(e){if(e=e||(typeof document<"u"?document:void 0),typeof e>"u")return null;try{return e.activeElement||e.body}catch{return e.body}}var jv=/[\n"\\]/g;function ri(e){return e.replace(jv,function(t){return"\\"+t.charCodeAt(0).toString(16)+" "})}function Wl(e,t,i,r,n,m,L,H){e.name="",L!=null&&typeof L!="function"&&typeof L!="symbol"&&typeof L!="boolean"?e.type=L:e.removeAttribute("type"),t!=null?L==="number"?(t===0&&e.value===""||e.value!=t)&&(e.value=""+ii(t)):e.value!==""+ii(t)&&(e.value=""+ii(t)):L!=="submit"&&L!=="reset"||e.removeAttribute("value"),t!=null?Zl(e,L,ii(t)):i!=null?Zl(e,L,ii(i)):r!=null&&e.removeAttribute("value"),n==null&&m!=null&&(e.defaultChecked=!!m),n!=null&&(e.checked=n&&typeof n!="function"&&typeof n!="symbol"),H!=null&&typeof H!="function"&&typeof H!="symbol"&&typeof H!="boolean"?e.name=""+ii(H):e.removeAttribute("name")}function sh(e,t,i,r,n,m,L,H){if(m!=null&&typeof m!="function"&&typeof m!="symbol"&&typeof m!="boolean"&&(e.type=m),t!=null||i!=null){if(!(m!=="submit"&&m!=="reset"||t!=null))return;i=i!=null?""+ii(i):"",t=t!=null?""+ii(t):i,H||t===e.value||(e.value=t),e.defaultValue=t}r=r??n,r=typeof r!="function"&&typeof r!="symbol"&&!!r,e.checked=H?e.checked:!!r,e.defaultChecked=!!r,L!=null&&typeof L!="function"&&typeof L!="symbol"&&typeof L!="boolean"&&(e.name=L)}function Zl(e,t,i){t==="number"&&ba(e.ownerDocument)===e||e.defaultValue===""+i||(e.defaultValue=""+i)}function ss(e,t,i,r){if(e=e.options,t){t={};for(var n=0;n<i.length;n++)t["$"+i[n]]=!0;for(i=0;i<e.length;i++)n=t.hasOwnProperty("$"+e[i].value),e[i].selected!==n&&(e[i].selected=n),n&&r&&(e[i].defaultSelected=!0)}else{for(i=""+ii(i),t=null,n=0;n<e.length;n++){if(e[n].value===i){e[n].selected=!0,r&&(e[n].defaultSelected=!0);return}t!==null||e[n].disabled||(t=e[n])}t!==null&&(t.selected=!0)}}function nh(e,t,i){if(t!=null&&(t=""+ii(t),t!==e.value&&(e.value=t),i==null)){e.defaultValue!==t&&(e.defaultValue=t);return}e.defaultValue=i!=null?""+ii(i):""}function ah(e,t,i,r){if(t==null){if(r!=null){if(i!=null)throw Error(g(92));if(K(r)){if(1<r.length)throw Error(g(93));r=r[0]}i=r}i==null&&(i=""),t=i}i=ii(t),e.defaultValue=i,r=e.textContent,r===i&&r!==""&&r!==null&&(e.value=r)}function ns(e,t){if(t){var i=e.firstChild;if(i&&i===e.lastChild&&i.nodeType===3){i.nodeValue=t;return}}e.textContent=t}var Hv=new Set("animationIterationCount aspectRatio borderImageOutset borderImageSlice borderImageWidth boxFlex boxFlexGroup boxOrdinalGroup columnCount columns flex flexGrow flexPositive flexShrink flexNegative flexOrder gridArea gridRow gridRowEnd gridRowSpan gridRowStart gridColumn gridColumnEnd gridColumnSpan gridColumnStart fontWeight lineClamp lineHeight opacity order orphans scale tabSize widows zIndex zoom fillOpacity floodOpacity stopOpacity strokeDasharray strokeDashoffset strokeMiterlimit strokeOpacity strokeWidth MozAnimationIterationCount MozBoxFlex MozBoxFlexGroup MozLineClamp msAnimationIterationCount msFlex msZoom msFlexGrow msFlexNegative msFlexOrder msFlexPositive msFlexShrink msGridColumn msGridColumnSpan msGridRow msGridRowSpan WebkitAnimationIterationCount WebkitBoxFlex WebKitBoxFlexGroup WebkitBoxOrdinalGroup WebkitColumnCount WebkitColumns WebkitFlex WebkitFlexGrow WebkitFlexPositive WebkitFlexShrink WebkitLineClamp".split(" "));function lh(e,t,i){var r=t.indexOf("--")===0;i==null||typeof i=="boolean"||i===""?r?e.setProperty(t,""):t==="float"?e.cssFloat="":e[t]="":r?e.setProperty(t,i):typeof i!="number"||i===0||Hv.has(t)?t==="float"?e.cssFloat=i:e[t]=(""+i).trim():e[t]=i+"px"}function oh(e,t,i){if(t!=null&&typeof t!="object")throw Error(g(62));if(e=e.style,i!=null){for(var r in i)!i.hasOwnProperty(r)||t!=null&&t.hasOwnProperty(r)||(r.indexOf("--")===0?e.setProperty(r,""):r==="float"?e.cssFloat="":e[r]="");for(var n in t)r=t[n],t.hasOwnProperty(n)&&i[n]!==r&&lh(e,n,r)}else for(var m in t)t.hasOwnProperty(m)&&lh(e,m,t[m])}function Ql(e){if(e.indexOf("-")===-1)return!1;switch(e){case"annotation-xml":case"color-profile":case"font-face":case"font-face-src":case"font-face-uri":case"font-face-format":case"font-face-name":case"missing-glyph":return!1;default:return!0}}var zv=new Map([["acceptCharset","accept-charset"],["htmlFor","for"],["httpEquiv","http-equiv"],["crossOrigin","crossorigin"],["accentHeight","accent-height"],["alignmentBaseline","alignment-baseline"],["arabicForm","arabic-form"],["baselineShift","baseline-shift"],["capHeight","cap-height"],["clipPath","clip-path"],["clipRule","clip-rule"],["colorInterpolation","color-interpolation"],["colorInterpolationFilters","color-interpolation-filters"],["colorProfile","color-profile"],["colorRendering","color-rendering"],["dominantBaseline","dominant-baseline"],["enableBackground","enable-background"],["fillOpacity","fill-opacity"],["fillRule","fill-rule"],["floodColor","flood-color"],["floodOpacity","flood-opacity"],["fontFamily","font-family"],["fontSize","font-size"],["fontSizeAdjust","font-size-adjust"],["fontStretch","font-stretch"],["fontStyle","font-style"],["fontVariant","font-variant"],["fontWeight","font-weight"],["glyphName","glyph-name"],["glyphOrientationHorizontal","glyph-orientation-horizontal"],["glyphOrientationVertical","glyph-orientation-vertical"],["horizAdvX","horiz-adv-x"],["horizOriginX","horiz-origin-x"],["imageRendering","image-rendering"],["letterSpacing","letter-spacing"],["lightingColor","lighting-color"],["markerEnd","marker-end"],["markerMid","marker-mid"],["markerStart","marker-start"],["overlinePosition","overline-position"],["overlineThickness","overline-thickness"],["paintOrder","paint-order"],["panose-1","panose-1"],["pointerEvents","pointer-events"],["renderingIntent","rendering-intent"],["shapeRendering","shape-rendering"],["stopColor","stop-color"],["stopOpacity","stop-opacity"],["strikethroughPosition","strikethrough-position"],["strikethroughThickness","strikethrough-thickness"],["strokeDasharray","stroke-dasharray"],["strokeDashoffset","stroke-dashoffset"],["strokeLinecap","stroke-linecap"],["strokeLinejoin","stroke-linejoin"],["strokeMiterlimit","stroke-miterlimit"],["strokeOpacity","stroke-opacity"],["strokeWidth","stroke-width"],["textAnchor","text-anchor"],["textDecoration","text-decoration"],["textRendering","text-rendering"],["transformOrigin","transform-origin"],["underlinePosition","underline-position"],["underlineThickness","underline-thickness"],["unicodeBidi","unicode-bidi"],["unicodeRange","unicode-range"],["unitsPerEm","units-per-em"],["vAlphabetic","v-alphabetic"],["vHanging","v-hanging"],["vIdeographic","v-ideographic"],["vMathematical","v-mathematical"],["vectorEffect","vector-effect"],["vertAdvY","vert-adv-y"],["vertOriginX","vert-origin-x"],["vertOriginY","vert-origin-y"],["wordSpacing","word-spacing"],["writingMode","writing-mode"],["xmlnsXlink","xmlns:xlink"],["xHeight","x-height"]]),Uv=/^[\u0000-\u001F ]*j[\r\n\t]*a[\r\n\t]*v[\r\n\t]*a[\r\n\t]*s[\r\n\t]*c[\r\n\t]*r[\r\n\t]*i[\r\n\t]*p[\r\n\t]*t[\r\n\t]*:/i;function ya(e){return Uv.test(""+e)?"javascript:throw new Error('React has blocked a javascript: URL as a security precaution.')":e}var Jl=null;function eo(e){return e=e.target||e.srcElement||window,e.correspondingUseElement&&(e=e.correspondingUseElement),e.nodeType===3?e.parentNode:e}var as=null,ls=null;function ch(e){var t=es(e);if(t&&(e=t.stateNode)){var i=e[Ye]||null;e:switch(e=t.stateNode,t.type){case"input":if(Wl(e,i.value,i.defaultValue,i.defaultValue,i.checked,i.defaultChecked,i.type,i.name),t=i.name,i.type==="radio"&&t!=null){for(i=e;i.parentNode;)i=i.parentNode;for(i=i.querySelectorAll('input[name="'+ri(""+t)+'"][type="radio"]'),t=0;t<i.length;t++){var r=i[t];if(r!==e&&r.form===e.form){var n=r[Ye]||null;if(!n)throw Error(g(90));Wl(r,n.value,n.defaultValue,n.defaultValue,n.checked,n.defaultChecked,n.type,n.name)}}for(t=0;t<i.length;t++)r=i[t],r.form===e.form&&rh(r)}break e;case"textarea":nh(e,i.value,i.defaultValue);break e;case"select":t=i.value,t!=null&&ss(e,!!i.multiple,t,!1)}}}var to=!1;function uh(e,t,i){if(to)return e(t,i);to=!0;try{var r=e(t);return r}finally{if(to=!1,(as!==null||ls!==null)&&(nl(),as&&(t=as,e=ls,ls=as=null,ch(t),e)))for(t=0;t<e.length;t++)ch(e[t])}}function Ws(e,t){var i=e.stateNode;if(i===null)return null;var r=i[Ye]||null;if(r===null)return null;i=r[t];e:switch(t){case"onClick":case"onClickCapture":case"onDoubleClick":case"onDoubleClickCapture":case"onMouseDown":case"onMouseDownCapture":case"onMouseMove":case"onMouseMoveCapture":case"onMouseUp":case"onMouseUpCapture":case"onMouseEnter":(r=!r.disabled)||(e=e.type,r=!(e==="button"||e==="input"||e==="select"||e==="textarea")),e=!r;break e;default:e=!1}if(e)return null;if(i&&typeof i!="function")throw Error(g(231,t,typeof i));return i}var zi=!(typeof window>"u"||typeof window.document>"u"||typeof window.document.createElement>"u"),io=!1;if(zi)try{var Zs={};Object.defineProperty(Zs,"passive",{get:function(){io=!0}}),window.addEventListener("test",Zs,Zs),window.removeEventListener("test",Zs,Zs)}catch{io=!1}var sr=null,ro=null,Sa=null;function hh(){if(Sa)return Sa;var e,t=ro,i=t.length,r,n="value"in sr?sr.value:sr.textContent,m=n.length;for(e=0;e<i&&t[e]===n[e];e++);var L=i-e;for(r=1;r<=L&&t[i-r]===n[m-r];r++);return Sa=n.slice(e,1<r?1-r:void 0)}function xa(e){var t=e.keyCode;return"charCode"in e?(e=e.charCode,e===0&&t===13&&(e=13)):e=t,e===10&&(e=13),32<=e||e===13?e:0}function Ca(){return!0}function dh(){return!1}function jt(e){function t(i,r,n,m,L){this._reactName=i,this._targetInst=n,this.type=r,this.nativeEvent=m,this.target=L,this.currentTarget=null;for(var H in e)e.hasOwnProperty(H)&&(i=e[H],this[H]=i?i(m):m[H]);return this.isDefaultPrevented=(m.defaultPrevented!=null?m.defaultPrevented:m.returnValue===!1)?Ca:dh,this.isPropagationStopped=dh,this}return p(t.prototype,{preventDefault:function(){this.defaultPrevented=!0;var i=this.nativeEvent;i&&(i.preventDefault?i.preventDefault():typeof i.returnValue!="unknown"&&(i.returnValue=!1),this.isDefaultPrevented=Ca)},stopPropagation:function(){var i=this.nativeEvent;i&&(i.stopPropagation?i.stopPropagation():typeof i.cancelBubble!="unknown"&&(i.cancelBubble=!0),this.isPropagationStopped=Ca)},persist:function(){},isPersistent:Ca}),t}var Lr={eventPhase:0,bubbles:0,cancelable:0,timeStamp:function(e){return e.timeStamp||Date.now()},defaultPrevented:0,isTrusted:0},wa=jt(Lr),Qs=p({},Lr,{view:0,detail:0}),Pv=jt(Qs),so,no,Js,Ea=p({},Qs,{screenX:0,screenY:0,clientX:0,clientY:0,pageX:0,pageY:0,ctrlKey:0,shiftKey:0,altKey:0,metaKey:0,getModifierState:lo,button:0,buttons:0,relatedTarget:function(e){return e.relatedTarget===void 0?e.fromElement===e.srcElement?e.toElement:e.fromElement:e.relatedTarget},movementX:function(e){return"movementX"in e?e.movementX:(e!==Js&&(Js&&e.type==="mousemove"?(so=e.screenX-Js.screenX,no=e.screenY-Js.screenY):no=so=0,Js=e),so)},movementY:function(e){return"movementY"in e?e.movementY:no}}),fh=jt(Ea),Iv=p({},Ea,{dataTransfer:0}),Fv=jt(Iv),qv=p({},Qs,{relatedTarget:0}),ao=jt(qv),Vv=p({},Lr,{animationName:0,elapsedTime:0,pseudoElement:0}),Gv=jt(Vv),Yv=p({},Lr,{clipboardData:function(e){return"clipboardData"in e?e.clipboardData:window.clipboardData}}),$v=jt(Yv),Kv=p({},Lr,{data:0}),mh=jt(Kv),Xv={Esc:"Escape",Spacebar:" ",Left:"ArrowLeft",Up:"ArrowUp",Right:"ArrowRight",Down:"ArrowDown",Del:"Delete",Win:"OS",Menu:"ContextMenu",Apps:"ContextMenu",Scroll:"ScrollLock",MozPrintableKey:"Unidentified"},Wv={8:"Backspace",9:"Tab",12:"Clear",13:"Enter",16:"Shift",17:"Control",18:"Alt",19:"Pause",20:"CapsLock",27:"Escape",32:" ",33:"PageUp",34:"PageDown",35:"End",36:"Home",37:"ArrowLeft",38:"ArrowUp",39:"ArrowRight",40:"ArrowDown",45:"Insert",46:"Delete",112:"F1",113:"F2",114:"F3",115:"F4",116:"F5",117:"F6",118:"F7",119:"F8",120:"F9",121:"F10",122:"F11",123:"F12",144:"NumLock",145:"ScrollLock",224:"Meta"},Zv={Alt:"altKey",Control:"ctrlKey",Meta:"metaKey",Shift:"shiftKey"};function Qv(e){var t=this.nativeEvent;return t.getModifierState?t.getModifierState(e):(e=Zv[e])?!!t[e]:!1}function lo(){return Qv}var Jv=p({},Qs,{key:function(e){if(e.key){var t=Xv[e.key]||e.key;if(t!=="Unidentified")return t}return e.type==="keypress"?(e=xa(e),e===13?"Enter":String.fromCharCode(e)):e.type==="keydown"||e.type==="keyup"?Wv[e.keyCode]||"Unidentified":""},code:0,location:0,ctrlKey:0,shiftKey:0,altKey:0,metaKey:0,repeat:0,locale:0,getModifierState:lo,charCode:function(e){return e.type==="keypress"?xa(e):0},keyCode:function(e){return e.type==="keydown"||e.type==="keyup"?e.keyCode:0},which:function(e){return e.type==="keypress"?xa(e):e.type==="keydown"||e.type==="keyup"?e.keyCode:0}}),eg=jt(Jv),tg=p({},Ea,{pointerId:0,width:0,height:0,pressure:0,tangentialPressure:0,tiltX:0,tiltY:0,twist:0,pointerType:0,isPrimary:0}),_h=jt(tg),ig=p({},Qs,{touches:0,targetTouches:0,changedTouches:0,altKey:0,metaKey:0,ctrlKey:0,shiftKey:0,getModifierState:lo}),rg=jt(ig),sg=p({},Lr,{propertyName:0,elapsedTime:0,pseudoElement:0}),ng=jt(sg),ag=p({},Ea,{deltaX:function(e){return"deltaX"in e?e.deltaX:"wheelDeltaX"in e?-e.wheelDeltaX:0},deltaY:function(e){return"deltaY"in e?e.deltaY:"wheelDeltaY"in e?-e.wheelDeltaY:"wheelDelta"in e?-e.wheelDelta:0},deltaZ:0,deltaMode:0}),lg=jt(ag),og=p({},Lr,{newState:0,oldState:0}),cg=jt(og),ug=[9,13,27,32],oo=zi&&"CompositionEvent"in window,en=null;zi&&"documentMode"in document&&(en=document.documentMode);var hg=zi&&"TextEvent"in window&&!en,vh=zi&&(!oo||en&&8<en&&11>=en),gh=" ",ph=!1;function bh(e,t){switch(e){case"keyup":return ug.indexOf(t.keyCode)!==-1;case"keydown":return t.keyCode!==229;case"keypress":case"mousedown":case"focusout":return!0;default:return!1}}function yh(e){return e=e.detail,typeof e=="object"&&"data"in e?e.data:null}var os=!1;function dg(e,t){switch(e){case"compositionend":return yh(t);case"keypress":return t.which!==32?null:(ph=!0,gh);case"textInput":return e=t.data,e===gh&&ph?null:e;default:return null}}function fg(e,t){if(os)return e==="compositionend"||!oo&&bh(e,t)?(e=hh(),Sa=ro=sr=null,os=!1,e):null;switch(e){case"paste":return null;case"keypress":if(!(t.ctrlKey||t.altKey||t.metaKey)||t.ctrlKey&&t.altKey){if(t.char&&1<t.char.length)return t.char;if(t.which)return String.fromCharCode(t.which)}return null;case"compositionend":return vh&&t.locale!=="ko"?null:t.data;default:return null}}var mg={color:!0,date:!0,datetime:!0,"datetime-local":!0,email:!0,month:!0,number:!0,password:!0,range:!0,search:!0,tel:!0,text:!0,time:!0,url:!0,week:!0};function Sh(e){var t=e&&e.nodeName&&e.nodeName.toLowerCase();return t==="input"?!!mg[e.type]:t==="textarea"}function xh(e,t,i,r){as?ls?ls.push(r):ls=[r]:as=r,t=hl(t,"onChange"),0<t.length&&(i=new wa("onChange","change",null,i,r),e.push({event:i,listeners:t}))}var tn=null,rn=null;function _g(e){rm(e,0)}function ka(e){var t=Xs(e);if(rh(t))return e}function Ch(e,t){if(e==="change")return t}var wh=!1;if(zi){var co;if(zi){var uo="oninput"in document;if(!uo){var Eh=document.createElement("div");Eh.setAttribute("oninput","return;"),uo=typeof Eh.oninput=="function"}co=uo}else co=!1;wh=co&&(!document.documentMode||9<document.documentMode)}function kh(){tn&&(tn.detachEvent("onpropertychange",Rh),rn=tn=null)}function Rh(e){if(e.propertyName==="value"&&ka(rn)){var t=[];xh(t,rn,e,eo(e)),uh(_g,t)}}function vg(e,t,i){e==="focusin"?(kh(),tn=t,rn=i,tn.attachEvent("onpropertychange",Rh)):e==="focusout"&&kh()}function gg(e){if(e==="selectionchange"||e==="keyup"||e==="keydown")return ka(rn)}function pg(e,t){if(e==="click")return ka(t)}function bg(e,t){if(e==="input"||e==="change")return ka(t)}function yg(e,t){return e===t&&(e!==0||1/e===1/t)||e!==e&&t!==t}var $t=typeof Object.is=="function"?Object.is:yg;function sn(e,t){if($t(e,t))return!0;if(typeof e!="object"||e===null||typeof t!="object"||t===null)return!1;var i=Object.keys(e),r=Object.keys(t);if(i.length!==r.length)return!1;for(r=0;r<i.length;r++){var n=i[r];if(!de.call(t,n)||!$t(e[n],t[n]))return!1}return!0}function Dh(e){for(;e&&e.firstChild;)e=e.firstChild;return e}function Ah(e,t){var i=Dh(e);e=0;for(var r;i;){if(i.nodeType===3){if(r=e+i.textContent.length,e<=t&&r>=t)return{node:i,offset:t-e};e=r}e:{for(;i;){if(i.nextSibling){i=i.nextSibling;break e}i=i.parentNode}i=void 0}i=Dh(i)}}function Th(e,t){return e&&t?e===t?!0:e&&e.nodeType===3?!1:t&&t.nodeType===3?Th(e,t.parentNode):"contains"in e?e.contains(t):e.compareDocumentPosition?!!(e.compareDocumentPosition(t)&16):!1:!1}function Mh(e){e=e!=null&&e.ownerDocument!=null&&e.ownerDocument.defaultView!=null?e.ownerDocument.defaultView:window;for(var t=ba(e.document);t instanceof e.HTMLIFrameElement;){try{var i=typeof t.contentWindow.location.href=="string"}catch{i=!1}if(i)e=t.contentWindow;else break;t=ba(e.document)}return t}function ho(e){var t=e&&e.nodeName&&e.nodeName.toLowerCase();return t&&(t==="input"&&(e.type==="text"||e.type==="search"||e.type==="tel"||e.type==="url"||e.type==="password")||t==="textarea"||e.contentEditable==="true")}var Sg=zi&&"documentMode"in document&&11>=document.documentMode,cs=null,fo=null,nn=null,mo=!1;function Lh(e,t,i){var r=i.window===i?i.document:i.nodeType===9?i:i.ownerDocument;mo||cs==null||cs!==ba(r)||(r=cs,"selectionStart"in r&&ho(r)?r={start:r.selectionStart,end:r.selectionEnd}:(r=(r.ownerDocument&&r.ownerDocument.defaultView||window).getSelection(),r={anchorNode:r.anchorNode,anchorOffset:r.anchorOffset,focusNode:r.focusNode,focusOffset:r.focusOffset}),nn&&sn(nn,r)||(nn=r,r=hl(fo,"onSelect"),0<r.length&&(t=new wa("onSelect","select",null,t,i),e.push({event:t,listeners:r}),t.target=cs)))}function Or(e,t){var i={};return i[e.toLowerCase()]=t.toLowerCase(),i["Webkit"+e]="webkit"+t,i["Moz"+e]="moz"+t,i}var us={animationend:Or("Animation","AnimationEnd"),animationiteration:Or("Animation","AnimationIteration"),animationstart:Or("Animation","AnimationStart"),transitionrun:Or("Transition","TransitionRun"),transitionstart:Or("Transition","TransitionStart"),transitioncancel:Or("Transition","TransitionCancel"),transitionend:Or("Transition","TransitionEnd")},_o={},Oh={};zi&&(Oh=document.createElement("div").style,"AnimationEvent"in window||(delete us.animationend.animation,delete us.animationiteration.animation,delete us.animationstart.animation),"TransitionEvent"in window||delete us.transitionend.transition);function Nr(e){if(_o[e])return _o[e];if(!us[e])return e;var t=us[e],i;for(i in t)if(t.hasOwnProperty(i)&&i in Oh)return _o[e]=t[i];return e}var Nh=Nr("animationend"),Bh=Nr("animationiteration"),jh=Nr("animationstart"),xg=Nr("transitionrun"),Cg=Nr("transitionstart"),wg=Nr("transitioncancel"),Hh=Nr("transitionend"),zh=new Map,vo="abort auxClick beforeToggle cancel canPlay canPlayThrough click close contextMenu copy cut drag dragEnd dragEnter dragExit dragLeave dragOver dragStart drop durationChange emptied encrypted ended error gotPointerCapture input invalid keyDown keyPress keyUp load loadedData loadedMetadata loadStart lostPointerCapture mouseDown mouseMove mouseOut mouseOver mouseUp paste pause play playing pointerCancel pointerDown pointerMove pointerOut pointerOver pointerUp progress rateChange reset resize seeked seeking stalled submit suspend timeUpdate touchCancel touchEnd touchStart volumeChange scroll toggle touchMove waiting wheel".split(" ");vo.push("scrollEnd");function gi(e,t){zh.set(e,t),Mr(t,[e])}var Uh=new WeakMap;function si(e,t){if(typeof e=="object"&&e!==null){var i=Uh.get(e);return i!==void 0?i:(t={value:e,source:t,stack:th(t)},Uh.set(e,t),t)}return{value:e,source:t,stack:th(t)}}var ni=[],hs=0,go=0;function Ra(){for(var e=hs,t=go=hs=0;t<e;){var i=ni[t];ni[t++]=null;var r=ni[t];ni[t++]=null;var n=ni[t];ni[t++]=null;var m=ni[t];if(ni[t++]=null,r!==null&&n!==null){var L=r.pending;L===null?n.next=n:(n.next=L.next,L.next=n),r.pending=n}m!==0&&Ph(i,n,m)}}function Da(e,t,i,r){ni[hs++]=e,ni[hs++]=t,ni[hs++]=i,ni[hs++]=r,go|=r,e.lanes|=r,e=e.alternate,e!==null&&(e.lanes|=r)}function po(e,t,i,r){return Da(e,t,i,r),Aa(e)}function ds(e,t){return Da(e,null,null,t),Aa(e)}function Ph(e,t,i){e.lanes|=i;var r=e.alternate;r!==null&&(r.lanes|=i);for(var n=!1,m=e.return;m!==null;)m.childLanes|=i,r=m.alternate,r!==null&&(r.childLanes|=i),m.tag===22&&(e=m.stateNode,e===null||e._visibility&1||(n=!0)),e=m,m=m.return;return e.tag===3?(m=e.stateNode,n&&t!==null&&(n=31-Qe(i),e=m.hiddenUpdates,r=e[n],r===null?e[n]=[t]:r.push(t),t.lane=i|536870912),m):null}function Aa(e){if(50<Tn)throw Tn=0,wc=null,Error(g(185));for(var t=e.return;t!==null;)e=t,t=e.return;return e.tag===3?e.stateNode:null}var fs={};function Eg(e,t,i,r){this.tag=e,this.key=i,this.sibling=this.child=this.return=this.stateNode=this.type=this.elementType=null,this.index=0,this.refCleanup=this.ref=null,this.pendingProps=t,this.dependencies=this.memoizedState=this.updateQueue=this.memoizedProps=null,this.mode=r,this.subtreeFlags=this.flags=0,this.deletions=null,this.childLanes=this.lanes=0,this.alternate=null}function Kt(e,t,i,r){return new Eg(e,t,i,r)}function bo(e){return e=e.prototype,!(!e||!e.isReactComponent)}function Ui(e,t){var i=e.alternate;return i===null?(i=Kt(e.tag,t,e.key,e.mode),i.elementType=e.elementType,i.type=e.type,i.stateNode=e.stateNode,i.alternate=e,e.alternate=i):(i.pendingProps=t,i.type=e.type,i.flags=0,i.subtreeFlags=0,i.deletions=null),i.flags=e.flags&65011712,i.childLanes=e.childLanes,i.lanes=e.lanes,i.child=e.child,i.memoizedProps=e.memoizedProps,i.memoizedState=e.memoizedState,i.updateQueue=e.updateQueue,t=e.dependencies,i.dependencies=t===null?null:{lanes:t.lanes,firstContext:t.firstContext},i.sibling=e.sibling,i.index=e.index,i.ref=e.ref,i.refCleanup=e.refCleanup,i}function Ih(e,t){e.flags&=65011714;var i=e.alternate;return i===null?(e.childLanes=0,e.lanes=t,e.child=null,e.subtreeFlags=0,e.memoizedProps=null,e.memoizedState=null,e.updateQueue=null,e.dependencies=null,e.stateNode=null):(e.childLanes=i.childLanes,e.lanes=i.lanes,e.child=i.child,e.subtreeFlags=0,e.deletions=null,e.memoizedProps=i.memoizedProps,e.memoizedState=i.memoizedState,e.updateQueue=i.updateQueue,e.type=i.type,t=i.dependencies,e.dependencies=t===null?null:{lanes:t.lanes,firstContext:t.firstContext}),e}function Ta(e,t,i,r,n,m){var L=0;if(r=e,typeof e=="function")bo(e)&&(L=1);else if(typeof e=="string")L=Rp(e,i,le.current)?26:e==="html"||e==="head"||e==="body"?27:5;else e:switch(e){case N:return e=Kt(31,i,t,n),e.elementType=N,e.lanes=m,e;case k:return Br(i.children,n,m,t);case C:L=8,n|=24;break;case a:return e=Kt(12,i,t,n|2),e.elementType=a,e.lanes=m,e;case x:return e=Kt(13,i,t,n),e.elementType=x,e.lanes=m,e;case R:return e=Kt(19,i,t,n),e.elementType=R,e.lanes=m,e;default:if(typeof e=="object"&&e!==null)switch(e.$$typeof){case u:case f:L=10;break e;case d:L=9;break e;case S:L=11;break e;case O:L=14;break e;case y:L=16,r=null;break e}L=29,i=Error(g(130,e===null?"null":typeof e,"")),r=null}return t=Kt(L,i,t,n),t.elementType=e,t.type=r,t.lanes=m,t}function Br(e,t,i,r){return e=Kt(7,e,r,t),e.lanes=i,e}function yo(e,t,i){return e=Kt(6,e,null,t),e.lanes=i,e}function So(e,t,i){return t=Kt(4,e.children!==null?e.children:[],e.key,t),t.lanes=i,t.stateNode={containerInfo:e.containerInfo,pendingChildren:null,implementation:e.implementation},t}var ms=[],_s=0,Ma=null,La=0,ai=[],li=0,jr=null,Pi=1,Ii="";function Hr(e,t){ms[_s++]=La,ms[_s++]=Ma,Ma=e,La=t}function Fh(e,t,i){ai[li++]=Pi,ai[li++]=Ii,ai[li++]=jr,jr=e;var r=Pi;e=Ii;var n=32-Qe(r)-1;r&=~(1<<n),i+=1;var m=32-Qe(t)+n;if(30<m){var L=n-n%5;m=(r&(1<<L)-1).toString(32),r>>=L,n-=L,Pi=1<<32-Qe(t)+n|i<<n|r,Ii=m+e}else Pi=1<<m|i<<n|r,Ii=e}function xo(e){e.return!==null&&(Hr(e,1),Fh(e,1,0))}function Co(e){for(;e===Ma;)Ma=ms[--_s],ms[_s]=null,La=ms[--_s],ms[_s]=null;for(;e===jr;)jr=ai[--li],ai[li]=null,Ii=ai[--li],ai[li]=null,Pi=ai[--li],ai[li]=null}var Nt=null,it=null,Be=!1,zr=null,Ei=!1,wo=Error(g(519));function Ur(e){var t=Error(g(418,""));throw on(si(t,e)),wo}function qh(e){var t=e.stateNode,i=e.type,r=e.memoizedProps;switch(t[Ge]=e,t[Ye]=r,i){case"dialog":Te("cancel",t),Te("close",t);break;case"iframe":case"object":case"embed":Te("load",t);break;case"video":case"audio":for(i=0;i<Ln.length;i++)Te(Ln[i],t);break;case"source":Te("error",t);break;case"img":case"image":case"link":Te("error",t),Te("load",t);break;case"details":Te("toggle",t);break;case"input":Te("invalid",t),sh(t,r.value,r.defaultValue,r.checked,r.defaultChecked,r.type,r.name,!0),pa(t);break;case"select":Te("invalid",t);break;case"textarea":Te("invalid",t),ah(t,r.value,r.defaultValue,r.children),pa(t)}i=r.children,typeof i!="string"&&typeof i!="number"&&typeof i!="bigint"||t.textContent===""+i||r.suppressHydrationWarning===!0||lm(t.textContent,i)?(r.popover!=null&&(Te("beforetoggle",t),Te("toggle",t)),r.onScroll!=null&&Te("scroll",t),r.onScrollEnd!=null&&Te("scrollend",t),r.onClick!=null&&(t.onclick=dl),t=!0):t=!1,t||Ur(e)}function Vh(e){for(Nt=e.return;Nt;)switch(Nt.tag){case 5:case 13:Ei=!1;return;case 27:case 3:Ei=!0;return;default:Nt=Nt.return}}function an(e){if(e!==Nt)return!1;if(!Be)return Vh(e),Be=!0,!1;var t=e.tag,i;if((i=t!==3&&t!==27)&&((i=t===5)&&(i=e.type,i=!(i!=="form"&&i!=="button")||Pc(e.type,e.memoizedProps)),i=!i),i&&it&&Ur(e),Vh(e),t===13){if(e=e.memoizedState,e=e!==null?e.dehydrated:null,!e)throw Error(g(317));e:{for(e=e.nextSibling,t=0;e;){if(e.nodeType===8)if(i=e.data,i==="/$"){if(t===0){it=bi(e.nextSibling);break e}t--}else i!=="$"&&i!=="$!"&&i!=="$?"||t++;e=e.nextSibling}it=null}}else t===27?(t=it,yr(e.type)?(e=Vc,Vc=null,it=e):it=t):it=Nt?bi(e.stateNode.nextSibling):null;return!0}function ln(){it=Nt=null,Be=!1}function Gh(){var e=zr;return e!==null&&(Ut===null?Ut=e:Ut.push.apply(Ut,e),zr=null),e}function on(e){zr===null?zr=[e]:zr.push(e)}var Eo=P(null),Pr=null,Fi=null;function nr(e,t,i){J(Eo,t._currentValue),t._currentValue=i}function qi(e){e._currentValue=Eo.current,W(Eo)}function ko(e,t,i){for(;e!==null;){var r=e.alternate;if((e.childLanes&t)!==t?(e.childLanes|=t,r!==null&&(r.childLanes|=t)):r!==null&&(r.childLanes&t)!==t&&(r.childLanes|=t),e===i)break;e=e.return}}function Ro(e,t,i,r){var n=e.child;for(n!==null&&(n.return=e);n!==null;){var m=n.dependencies;if(m!==null){var L=n.child;m=m.firstContext;e:for(;m!==null;){var H=m;m=n;for(var $=0;$<t.length;$++)if(H.context===t[$]){m.lanes|=i,H=m.alternate,H!==null&&(H.lanes|=i),ko(m.return,i,e),r||(L=null);break e}m=H.next}}else if(n.tag===18){if(L=n.return,L===null)throw Error(g(341));L.lanes|=i,m=L.alternate,m!==null&&(m.lanes|=i),ko(L,i,e),L=null}else L=n.child;if(L!==null)L.return=n;else for(L=n;L!==null;){if(L===e){L=null;break}if(n=L.sibling,n!==null){n.return=L.return,L=n;break}L=L.return}n=L}}function cn(e,t,i,r){e=null;for(var n=t,m=!1;n!==null;){if(!m){if((n.flags&524288)!==0)m=!0;else if((n.flags&262144)!==0)break}if(n.tag===10){var L=n.alternate;if(L===null)throw Error(g(387));if(L=L.memoizedProps,L!==null){var H=n.type;$t(n.pendingProps.value,L.value)||(e!==null?e.push(H):e=[H])}}else if(n===j.current){if(L=n.alternate,L===null)throw Error(g(387));L.memoizedState.memoizedState!==n.memoizedState.memoizedState&&(e!==null?e.push(zn):e=[zn])}n=n.return}e!==null&&Ro(t,e,i,r),t.flags|=262144}function Oa(e){for(e=e.firstContext;e!==null;){if(!$t(e.context._currentValue,e.memoizedValue))return!0;e=e.next}return!1}function Ir(e){Pr=e,Fi=null,e=e.dependencies,e!==null&&(e.firstContext=null)}function kt(e){return Yh(Pr,e)}function Na(e,t){return Pr===null&&Ir(e),Yh(e,t)}function Yh(e,t){var i=t._currentValue;if(t={context:t,memoizedValue:i,next:null},Fi===null){if(e===null)throw Error(g(308));Fi=t,e.dependencies={lanes:0,firstContext:t},e.flags|=524288}else Fi=Fi.next=t;return i}var kg=typeof AbortController<"u"?AbortController:function(){var e=[],t=this.signal={aborted:!1,addEventListener:function(i,r){e.push(r)}};this.abort=function(){t.aborted=!0,e.forEach(function(i){return i()})}},Rg=s.unstable_scheduleCallback,Dg=s.unstable_NormalPriority,ot={$$typeof:f,Consumer:null,Provider:null,_currentValue:null,_currentValue2:null,_threadCount:0};function Do(){return{controller:new kg,data:new Map,refCount:0}}function un(e){e.refCount--,e.refCount===0&&Rg(Dg,function(){e.controller.abort()})}var hn=null,Ao=0,vs=0,gs=null;function Ag(e,t){if(hn===null){var i=hn=[];Ao=0,vs=Mc(),gs={status:"pending",value:void 0,then:function(r){i.push(r)}}}return Ao++,t.then($h,$h),t}function $h(){if(--Ao===0&&hn!==null){gs!==null&&(gs.status="fulfilled");var e=hn;hn=null,vs=0,gs=null;for(var t=0;t<e.length;t++)(0,e[t])()}}function Tg(e,t){var i=[],r={status:"pending",value:null,reason:null,then:function(n){i.push(n)}};return e.then(function(){r.status="fulfilled",r.value=t;for(var n=0;n<i.length;n++)(0,i[n])(t)},function(n){for(r.status="rejected",r.reason=n,n=0;n<i.length;n++)(0,i[n])(void 0)}),r}var Kh=I.S;I.S=function(e,t){typeof t=="object"&&t!==null&&typeof t.then=="function"&&Ag(e,t),Kh!==null&&Kh(e,t)};var Fr=P(null);function To(){var e=Fr.current;return e!==null?e:Ve.pooledCache}function Ba(e,t){t===null?J(Fr,Fr.current):J(Fr,t.pool)}function Xh(){var e=To();return e===null?null:{parent:ot._currentValue,pool:e}}var dn=Error(g(460)),Wh=Error(g(474)),ja=Error(g(542)),Mo={then:function(){}};function Zh(e){return e=e.status,e==="fulfilled"||e==="rejected"}function Ha(){}function Qh(e,t,i){switch(i=e[i],i===void 0?e.push(t):i!==t&&(t.then(Ha,Ha),t=i),t.status){case"fulfilled":return t.value;case"rejected":throw e=t.reason,ed(e),e;default:if(typeof t.status=="string")t.then(Ha,Ha);else{if(e=Ve,e!==null&&100<e.shellSuspendCounter)throw Error(g(482));e=t,e.status="pending",e.then(function(r){if(t.status==="pending"){var n=t;n.status="fulfilled",n.value=r}},function(r){if(t.status==="pending"){var n=t;n.status="rejected",n.reason=r}})}switch(t.status){case"fulfilled":return t.value;case"rejected":throw e=t.reason,ed(e),e}throw fn=t,dn}}var fn=null;function Jh(){if(fn===null)throw Error(g(459));var e=fn;return fn=null,e}function ed(e){if(e===dn||e===ja)throw Error(g(483))}var ar=!1;function Lo(e){e.updateQueue={baseState:e.memoizedState,firstBaseUpdate:null,lastBaseUpdate:null,shared:{pending:null,lanes:0,hiddenCallbacks:null},callbacks:null}}function Oo(e,t){e=e.updateQueue,t.updateQueue===e&&(t.updateQueue={baseState:e.baseState,firstBaseUpdate:e.firstBaseUpdate,lastBaseUpdate:e.lastBaseUpdate,shared:e.shared,callbacks:null})}function lr(e){return{lane:e,tag:0,payload:null,callback:null,next:null}}function or(e,t,i){var r=e.updateQueue;if(r===null)return null;if(r=r.shared,(je&2)!==0){var n=r.pending;return n===null?t.next=t:(t.next=n.next,n.next=t),r.pending=t,t=Aa(e),Ph(e,null,i),t}return Da(e,r,t,i),Aa(e)}function mn(e,t,i){if(t=t.updateQueue,t!==null&&(t=t.shared,(i&4194048)!==0)){var r=t.lanes;r&=e.pendingLanes,i|=r,t.lanes=i,vi(e,i)}}function No(e,t){var i=e.updateQueue,r=e.alternate;if(r!==null&&(r=r.updateQueue,i===r)){var n=null,m=null;if(i=i.firstBaseUpdate,i!==null){do{var L={lane:i.lane,tag:i.tag,payload:i.payload,callback:null,next:null};m===null?n=m=L:m=m.next=L,i=i.next}while(i!==null);m===null?n=m=t:m=m.next=t}else n=m=t;i={baseState:r.baseState,firstBaseUpdate:n,lastBaseUpdate:m,shared:r.shared,callbacks:r.callbacks},e.updateQueue=i;return}e=i.lastBaseUpdate,e===null?i.firstBaseUpdate=t:e.next=t,i.lastBaseUpdate=t}var Bo=!1;function _n(){if(Bo){var e=gs;if(e!==null)throw e}}function vn(e,t,i,r){Bo=!1;var n=e.updateQueue;ar=!1;var m=n.firstBaseUpdate,L=n.lastBaseUpdate,H=n.shared.pending;if(H!==null){n.shared.pending=null;var $=H,ie=$.next;$.next=null,L===null?m=ie:L.next=ie,L=$;var oe=e.alternate;oe!==null&&(oe=oe.updateQueue,H=oe.lastBaseUpdate,H!==L&&(H===null?oe.firstBaseUpdate=ie:H.next=ie,oe.lastBaseUpdate=$))}if(m!==null){var he=n.baseState;L=0,oe=ie=$=null,H=m;do{var se=H.lane&-536870913,ne=se!==H.lane;if(ne?(Le&se)===se:(r&se)===se){se!==0&&se===vs&&(Bo=!0),oe!==null&&(oe=oe.next={lane:0,tag:H.tag,payload:H.payload,callback:null,next:null});e:{var xe=e,ye=H;se=t;var Ie=i;switch(ye.tag){case 1:if(xe=ye.payload,typeof xe=="function"){he=xe.call(Ie,he,se);break e}he=xe;break e;case 3:xe.flags=xe.flags&-65537|128;case 0:if(xe=ye.payload,se=typeof xe=="function"?xe.call(Ie,he,se):xe,se==null)break e;he=p({},he,se);break e;case 2:ar=!0}}se=H.callback,se!==null&&(e.flags|=64,ne&&(e.flags|=8192),ne=n.callbacks,ne===null?n.callbacks=[se]:ne.push(se))}else ne={lane:se,tag:H.tag,payload:H.payload,callback:H.callback,next:null},oe===null?(ie=oe=ne,$=he):oe=oe.next=ne,L|=se;if(H=H.next,H===null){if(H=n.shared.pending,H===null)break;ne=H,H=ne.next,ne.next=null,n.lastBaseUpdate=ne,n.shared.pending=null}}while(!0);oe===null&&($=he),n.baseState=$,n.firstBaseUpdate=ie,n.lastBaseUpdate=oe,m===null&&(n.shared.lanes=0),vr|=L,e.lanes=L,e.memoizedState=he}}function td(e,t){if(typeof e!="function")throw Error(g(191,e));e.call(t)}function id(e,t){var i=e.callbacks;if(i!==null)for(e.callbacks=null,e=0;e<i.length;e++)td(i[e],t)}var ps=P(null),za=P(0);function rd(e,t){e=Wi,J(za,e),J(ps,t),Wi=e|t.baseLanes}function jo(){J(za,Wi),J(ps,ps.current)}function Ho(){Wi=za.current,W(ps),W(za)}var cr=0,ke=null,Ue=null,at=null,Ua=!1,bs=!1,qr=!1,Pa=0,gn=0,ys=null,Mg=0;function st(){throw Error(g(321))}function zo(e,t){if(t===null)return!1;for(var i=0;i<t.length&&i<e.length;i++)if(!$t(e[i],t[i]))return!1;return!0}function Uo(e,t,i,r,n,m){return cr=m,ke=t,t.memoizedState=null,t.updateQueue=null,t.lanes=0,I.H=e===null||e.memoizedState===null?Pd:Id,qr=!1,m=i(r,n),qr=!1,bs&&(m=nd(t,i,r,n)),sd(e),m}function sd(e){I.H=Ya;var t=Ue!==null&&Ue.next!==null;if(cr=0,at=Ue=ke=null,Ua=!1,gn=0,ys=null,t)throw Error(g(300));e===null||mt||(e=e.dependencies,e!==null&&Oa(e)&&(mt=!0))}function nd(e,t,i,r){ke=e;var n=0;do{if(bs&&(ys=null),gn=0,bs=!1,25<=n)throw Error(g(301));if(n+=1,at=Ue=null,e.updateQueue!=null){var m=e.updateQueue;m.lastEffect=null,m.events=null,m.stores=null,m.memoCache!=null&&(m.memoCache.index=0)}I.H=zg,m=t(i,r)}while(bs);return m}function Lg(){var e=I.H,t=e.useState()[0];return t=typeof t.then=="function"?pn(t):t,e=e.useState()[0],(Ue!==null?Ue.memoizedState:null)!==e&&(ke.flags|=1024),t}function Po(){var e=Pa!==0;return Pa=0,e}function Io(e,t,i){t.updateQueue=e.updateQueue,t.flags&=-2053,e.lanes&=~i}function Fo(e){if(Ua){for(e=e.memoizedState;e!==null;){var t=e.queue;t!==null&&(t.pending=null),e=e.next}Ua=!1}cr=0,at=Ue=ke=null,bs=!1,gn=Pa=0,ys=null}function Ht(){var e={memoizedState:null,baseState:null,baseQueue:null,queue:null,next:null};return at===null?ke.memoizedState=at=e:at=at.next=e,at}function lt(){if(Ue===null){var e=ke.alternate;e=e!==null?e.memoizedState:null}else e=Ue.next;var t=at===null?ke.memoizedState:at.next;if(t!==null)at=t,Ue=e;else{if(e===null)throw ke.alternate===null?Error(g(467)):Error(g(310));Ue=e,e={memoizedState:Ue.memoizedState,baseState:Ue.baseState,baseQueue:Ue.baseQueue,queue:Ue.queue,next:null},at===null?ke.memoizedState=at=e:at=at.next=e}return at}function qo(){return{lastEffect:null,events:null,stores:null,memoCache:null}}function pn(e){var t=gn;return gn+=1,ys===null&&(ys=[]),e=Qh(ys,e,t),t=ke,(at===null?t.memoizedState:at.next)===null&&(t=t.alternate,I.H=t===null||t.memoizedState===null?Pd:Id),e}function Ia(e){if(e!==null&&typeof e=="object"){if(typeof e.then=="function")return pn(e);if(e.$$typeof===f)return kt(e)}throw Error(g(438,String(e)))}function Vo(e){var t=null,i=ke.updateQueue;if(i!==null&&(t=i.memoCache),t==null){var r=ke.alternate;r!==null&&(r=r.updateQueue,r!==null&&(r=r.memoCache,r!=null&&(t={data:r.data.map(function(n){return n.slice()}),index:0})))}if(t==null&&(t={data:[],index:0}),i===null&&(i=qo(),ke.updateQueue=i),i.memoCache=t,i=t.data[t.index],i===void 0)for(i=t.data[t.index]=Array(e),r=0;r<e;r++)i[r]=F;return t.index++,i}function Vi(e,t){return typeof t=="function"?t(e):t}function Fa(e){var t=lt();return Go(t,Ue,e)}function Go(e,t,i){var r=e.queue;if(r===null)throw Error(g(311));r.lastRenderedReducer=i;var n=e.baseQueue,m=r.pending;if(m!==null){if(n!==null){var L=n.next;n.next=m.next,m.next=L}t.baseQueue=n=m,r.pending=null}if(m=e.baseState,n===null)e.memoizedState=m;else{t=n.next;var H=L=null,$=null,ie=t,oe=!1;do{var he=ie.lane&-536870913;if(he!==ie.lane?(Le&he)===he:(cr&he)===he){var se=ie.revertLane;if(se===0)$!==null&&($=$.next={lane:0,revertLane:0,action:ie.action,hasEagerState:ie.hasEagerState,eagerState:ie.eagerState,next:null}),he===vs&&(oe=!0);else if((cr&se)===se){ie=ie.next,se===vs&&(oe=!0);continue}else he={lane:0,revertLane:ie.revertLane,action:ie.action,hasEagerState:ie.hasEagerState,eagerState:ie.eagerState,next:null},$===null?(H=$=he,L=m):$=$.next=he,ke.lanes|=se,vr|=se;he=ie.action,qr&&i(m,he),m=ie.hasEagerState?ie.eagerState:i(m,he)}else se={lane:he,revertLane:ie.revertLane,action:ie.action,hasEagerState:ie.hasEagerState,eagerState:ie.eagerState,next:null},$===null?(H=$=se,L=m):$=$.next=se,ke.lanes|=he,vr|=he;ie=ie.next}while(ie!==null&&ie!==t);if($===null?L=m:$.next=H,!$t(m,e.memoizedState)&&(mt=!0,oe&&(i=gs,i!==null)))throw i;e.memoizedState=m,e.baseState=L,e.baseQueue=$,r.lastRenderedState=m}return n===null&&(r.lanes=0),[e.memoizedState,r.dispatch]}function Yo(e){var t=lt(),i=t.queue;if(i===null)throw Error(g(311));i.lastRenderedReducer=e;var r=i.dispatch,n=i.pending,m=t.memoizedState;if(n!==null){i.pending=null;var L=n=n.next;do m=e(m,L.action),L=L.next;while(L!==n);$t(m,t.memoizedState)||(mt=!0),t.memoizedState=m,t.baseQueue===null&&(t.baseState=m),i.lastRenderedState=m}return[m,r]}function ad(e,t,i){var r=ke,n=lt(),m=Be;if(m){if(i===void 0)throw Error(g(407));i=i()}else i=t();var L=!$t((Ue||n).memoizedState,i);L&&(n.memoizedState=i,mt=!0),n=n.queue;var H=cd.bind(null,r,n,e);if(bn(2048,8,H,[e]),n.getSnapshot!==t||L||at!==null&&at.memoizedState.tag&1){if(r.flags|=2048,Ss(9,qa(),od.bind(null,r,n,i,t),null),Ve===null)throw Error(g(349));m||(cr&124)!==0||ld(r,t,i)}return i}function ld(e,t,i){e.flags|=16384,e={getSnapshot:t,value:i},t=ke.updateQueue,t===null?(t=qo(),ke.updateQueue=t,t.stores=[e]):(i=t.stores,i===null?t.stores=[e]:i.push(e))}function od(e,t,i,r){t.value=i,t.getSnapshot=r,ud(t)&&hd(e)}function cd(e,t,i){return i(function(){ud(t)&&hd(e)})}function ud(e){var t=e.getSnapshot;e=e.value;try{var i=t();return!$t(e,i)}catch{return!0}}function hd(e){var t=ds(e,2);t!==null&&Jt(t,e,2)}function $o(e){var t=Ht();if(typeof e=="function"){var i=e;if(e=i(),qr){Xe(!0);try{i()}finally{Xe(!1)}}}return t.memoizedState=t.baseState=e,t.queue={pending:null,lanes:0,dispatch:null,lastRenderedReducer:Vi,lastRenderedState:e},t}function dd(e,t,i,r){return e.baseState=i,Go(e,Ue,typeof r=="function"?r:Vi)}function Og(e,t,i,r,n){if(Ga(e))throw Error(g(485));if(e=t.action,e!==null){var m={payload:n,action:e,next:null,isTransition:!0,status:"pending",value:null,reason:null,listeners:[],then:function(L){m.listeners.push(L)}};I.T!==null?i(!0):m.isTransition=!1,r(m),i=t.pending,i===null?(m.next=t.pending=m,fd(t,m)):(m.next=i.next,t.pending=i.next=m)}}function fd(e,t){var i=t.action,r=t.payload,n=e.state;if(t.isTransition){var m=I.T,L={};I.T=L;try{var H=i(n,r),$=I.S;$!==null&&$(L,H),md(e,t,H)}catch(ie){Ko(e,t,ie)}finally{I.T=m}}else try{m=i(n,r),md(e,t,m)}catch(ie){Ko(e,t,ie)}}function md(e,t,i){i!==null&&typeof i=="object"&&typeof i.then=="function"?i.then(function(r){_d(e,t,r)},function(r){return Ko(e,t,r)}):_d(e,t,i)}function _d(e,t,i){t.status="fulfilled",t.value=i,vd(t),e.state=i,t=e.pending,t!==null&&(i=t.next,i===t?e.pending=null:(i=i.next,t.next=i,fd(e,i)))}function Ko(e,t,i){var r=e.pending;if(e.pending=null,r!==null){r=r.next;do t.status="rejected",t.reason=i,vd(t),t=t.next;while(t!==r)}e.action=null}function vd(e){e=e.listeners;for(var t=0;t<e.length;t++)(0,e[t])()}function gd(e,t){return t}function pd(e,t){if(Be){var i=Ve.formState;if(i!==null){e:{var r=ke;if(Be){if(it){t:{for(var n=it,m=Ei;n.nodeType!==8;){if(!m){n=null;break t}if(n=bi(n.nextSibling),n===null){n=null;break t}}m=n.data,n=m==="F!"||m==="F"?n:null}if(n){it=bi(n.nextSibling),r=n.data==="F!";break e}}Ur(r)}r=!1}r&&(t=i[0])}}return i=Ht(),i.memoizedState=i.baseState=t,r={pending:null,lanes:0,dispatch:null,lastRenderedReducer:gd,lastRenderedState:t},i.queue=r,i=Hd.bind(null,ke,r),r.dispatch=i,r=$o(!1),m=Jo.bind(null,ke,!1,r.queue),r=Ht(),n={state:t,dispatch:null,action:e,pending:null},r.queue=n,i=Og.bind(null,ke,n,m,i),n.dispatch=i,r.memoizedState=e,[t,i,!1]}function bd(e){var t=lt();return yd(t,Ue,e)}function yd(e,t,i){if(t=Go(e,t,gd)[0],e=Fa(Vi)[0],typeof t=="object"&&t!==null&&typeof t.then=="function")try{var r=pn(t)}catch(L){throw L===dn?ja:L}else r=t;t=lt();var n=t.queue,m=n.dispatch;return i!==t.memoizedState&&(ke.flags|=2048,Ss(9,qa(),Ng.bind(null,n,i),null)),[r,m,e]}function Ng(e,t){e.action=t}function Sd(e){var t=lt(),i=Ue;if(i!==null)return yd(t,i,e);lt(),t=t.memoizedState,i=lt();var r=i.queue.dispatch;return i.memoizedState=e,[t,r,!1]}function Ss(e,t,i,r){return e={tag:e,create:i,deps:r,inst:t,next:null},t=ke.updateQueue,t===null&&(t=qo(),ke.updateQueue=t),i=t.lastEffect,i===null?t.lastEffect=e.next=e:(r=i.next,i.next=e,e.next=r,t.lastEffect=e),e}function qa(){return{destroy:void 0,resource:void 0}}function xd(){return lt().memoizedState}function Va(e,t,i,r){var n=Ht();r=r===void 0?null:r,ke.flags|=e,n.memoizedState=Ss(1|t,qa(),i,r)}function bn(e,t,i,r){var n=lt();r=r===void 0?null:r;var m=n.memoizedState.inst;Ue!==null&&r!==null&&zo(r,Ue.memoizedState.deps)?n.memoizedState=Ss(t,m,i,r):(ke.flags|=e,n.memoizedState=Ss(1|t,m,i,r))}function Cd(e,t){Va(8390656,8,e,t)}function wd(e,t){bn(2048,8,e,t)}function Ed(e,t){return bn(4,2,e,t)}function kd(e,t){return bn(4,4,e,t)}function Rd(e,t){if(typeof t=="function"){e=e();var i=t(e);return function(){typeof i=="function"?i():t(null)}}if(t!=null)return e=e(),t.current=e,function(){t.current=null}}function Dd(e,t,i){i=i!=null?i.concat([e]):null,bn(4,4,Rd.bind(null,t,e),i)}function Xo(){}function Ad(e,t){var i=lt();t=t===void 0?null:t;var r=i.memoizedState;return t!==null&&zo(t,r[1])?r[0]:(i.memoizedState=[e,t],e)}function Td(e,t){var i=lt();t=t===void 0?null:t;var r=i.memoizedState;if(t!==null&&zo(t,r[1]))return r[0];if(r=e(),qr){Xe(!0);try{e()}finally{Xe(!1)}}return i.memoizedState=[r,t],r}function Wo(e,t,i){return i===void 0||(cr&1073741824)!==0?e.memoizedState=t:(e.memoizedState=i,e=Nf(),ke.lanes|=e,vr|=e,i)}function Md(e,t,i,r){return $t(i,t)?i:ps.current!==null?(e=Wo(e,i,r),$t(e,t)||(mt=!0),e):(cr&42)===0?(mt=!0,e.memoizedState=i):(e=Nf(),ke.lanes|=e,vr|=e,t)}function Ld(e,t,i,r,n){var m=G.p;G.p=m!==0&&8>m?m:8;var L=I.T,H={};I.T=H,Jo(e,!1,t,i);try{var $=n(),ie=I.S;if(ie!==null&&ie(H,$),$!==null&&typeof $=="object"&&typeof $.then=="function"){var oe=Tg($,r);yn(e,t,oe,Qt(e))}else yn(e,t,r,Qt(e))}catch(he){yn(e,t,{then:function(){},status:"rejected",reason:he},Qt())}finally{G.p=m,I.T=L}}function Bg(){}function Zo(e,t,i,r){if(e.tag!==5)throw Error(g(476));var n=Od(e).queue;Ld(e,n,t,A,i===null?Bg:function(){return Nd(e),i(r)})}function Od(e){var t=e.memoizedState;if(t!==null)return t;t={memoizedState:A,baseState:A,baseQueue:null,queue:{pending:null,lanes:0,dispatch:null,lastRenderedReducer:Vi,lastRenderedState:A},next:null};var i={};return t.next={memoizedState:i,baseState:i,baseQueue:null,queue:{pending:null,lanes:0,dispatch:null,lastRenderedReducer:Vi,lastRenderedState:i},next:null},e.memoizedState=t,e=e.alternate,e!==null&&(e.memoizedState=t),t}function Nd(e){var t=Od(e).next.queue;yn(e,t,{},Qt())}function Qo(){return kt(zn)}function Bd(){return lt().memoizedState}function jd(){return lt().memoizedState}function jg(e){for(var t=e.return;t!==null;){switch(t.tag){case 24:case 3:var i=Qt();e=lr(i);var r=or(t,e,i);r!==null&&(Jt(r,t,i),mn(r,t,i)),t={cache:Do()},e.payload=t;return}t=t.return}}function Hg(e,t,i){var r=Qt();i={lane:r,revertLane:0,action:i,hasEagerState:!1,eagerState:null,next:null},Ga(e)?zd(t,i):(i=po(e,t,i,r),i!==null&&(Jt(i,e,r),Ud(i,t,r)))}function Hd(e,t,i){var r=Qt();yn(e,t,i,r)}function yn(e,t,i,r){var n={lane:r,revertLane:0,action:i,hasEagerState:!1,eagerState:null,next:null};if(Ga(e))zd(t,n);else{var m=e.alternate;if(e.lanes===0&&(m===null||m.lanes===0)&&(m=t.lastRenderedReducer,m!==null))try{var L=t.lastRenderedState,H=m(L,i);if(n.hasEagerState=!0,n.eagerState=H,$t(H,L))return Da(e,t,n,0),Ve===null&&Ra(),!1}catch{}finally{}if(i=po(e,t,n,r),i!==null)return Jt(i,e,r),Ud(i,t,r),!0}return!1}function Jo(e,t,i,r){if(r={lane:2,revertLane:Mc(),action:r,hasEagerState:!1,eagerState:null,next:null},Ga(e)){if(t)throw Error(g(479))}else t=po(e,i,r,2),t!==null&&Jt(t,e,2)}function Ga(e){var t=e.alternate;return e===ke||t!==null&&t===ke}function zd(e,t){bs=Ua=!0;var i=e.pending;i===null?t.next=t:(t.next=i.next,i.next=t),e.pending=t}function Ud(e,t,i){if((i&4194048)!==0){var r=t.lanes;r&=e.pendingLanes,i|=r,t.lanes=i,vi(e,i)}}var Ya={readContext:kt,use:Ia,useCallback:st,useContext:st,useEffect:st,useImperativeHandle:st,useLayoutEffect:st,useInsertionEffect:st,useMemo:st,useReducer:st,useRef:st,useState:st,useDebugValue:st,useDeferredValue:st,useTransition:st,useSyncExternalStore:st,useId:st,useHostTransitionStatus:st,useFormState:st,useActionState:st,useOptimistic:st,useMemoCache:st,useCacheRefresh:st},Pd={readContext:kt,use:Ia,useCallback:function(e,t){return Ht().memoizedState=[e,t===void 0?null:t],e},useContext:kt,useEffect:Cd,useImperativeHandle:function(e,t,i){i=i!=null?i.concat([e]):null,Va(4194308,4,Rd.bind(null,t,e),i)},useLayoutEffect:function(e,t){return Va(4194308,4,e,t)},useInsertionEffect:function(e,t){Va(4,2,e,t)},useMemo:function(e,t){var i=Ht();t=t===void 0?null:t;var r=e();if(qr){Xe(!0);try{e()}finally{Xe(!1)}}return i.memoizedState=[r,t],r},useReducer:function(e,t,i){var r=Ht();if(i!==void 0){var n=i(t);if(qr){Xe(!0);try{i(t)}finally{Xe(!1)}}}else n=t;return r.memoizedState=r.baseState=n,e={pending:null,lanes:0,dispatch:null,lastRenderedReducer:e,lastRenderedState:n},r.queue=e,e=e.dispatch=Hg.bind(null,ke,e),[r.memoizedState,e]},useRef:function(e){var t=Ht();return e={current:e},t.memoizedState=e},useState:function(e){e=$o(e);var t=e.queue,i=Hd.bind(null,ke,t);return t.dispatch=i,[e.memoizedState,i]},useDebugValue:Xo,useDeferredValue:function(e,t){var i=Ht();return Wo(i,e,t)},useTransition:function(){var e=$o(!1);return e=Ld.bind(null,ke,e.queue,!0,!1),Ht().memoizedState=e,[!1,e]},useSyncExternalStore:function(e,t,i){var r=ke,n=Ht();if(Be){if(i===void 0)throw Error(g(407));i=i()}else{if(i=t(),Ve===null)throw Error(g(349));(Le&124)!==0||ld(r,t,i)}n.memoizedState=i;var m={value:i,getSnapshot:t};return n.queue=m,Cd(cd.bind(null,r,m,e),[e]),r.flags|=2048,Ss(9,qa(),od.bind(null,r,m,i,t),null),i},useId:function(){var e=Ht(),t=Ve.identifierPrefix;if(Be){var i=Ii,r=Pi;i=(r&~(1<<32-Qe(r)-1)).toString(32)+i,t="«"+t+"R"+i,i=Pa++,0<i&&(t+="H"+i.toString(32)),t+="»"}else i=Mg++,t="«"+t+"r"+i.toString(32)+"»";return e.memoizedState=t},useHostTransitionStatus:Qo,useFormState:pd,useActionState:pd,useOptimistic:function(e){var t=Ht();t.memoizedState=t.baseState=e;var i={pending:null,lanes:0,dispatch:null,lastRenderedReducer:null,lastRenderedState:null};return t.queue=i,t=Jo.bind(null,ke,!0,i),i.dispatch=t,[e,t]},useMemoCache:Vo,useCacheRefresh:function(){return Ht().memoizedState=jg.bind(null,ke)}},Id={readContext:kt,use:Ia,useCallback:Ad,useContext:kt,useEffect:wd,useImperativeHandle:Dd,useInsertionEffect:Ed,useLayoutEffect:kd,useMemo:Td,useReducer:Fa,useRef:xd,useState:function(){return Fa(Vi)},useDebugValue:Xo,useDeferredValue:function(e,t){var i=lt();return Md(i,Ue.memoizedState,e,t)},useTransition:function(){var e=Fa(Vi)[0],t=lt().memoizedState;return[typeof e=="boolean"?e:pn(e),t]},useSyncExternalStore:ad,useId:Bd,useHostTransitionStatus:Qo,useFormState:bd,useActionState:bd,useOptimistic:function(e,t){var i=lt();return dd(i,Ue,e,t)},useMemoCache:Vo,useCacheRefresh:jd},zg={readContext:kt,use:Ia,useCallback:Ad,useContext:kt,useEffect:wd,useImperativeHandle:Dd,useInsertionEffect:Ed,useLayoutEffect:kd,useMemo:Td,useReducer:Yo,useRef:xd,useState:function(){return Yo(Vi)},useDebugValue:Xo,useDeferredValue:function(e,t){var i=lt();return Ue===null?Wo(i,e,t):Md(i,Ue.memoizedState,e,t)},useTransition:function(){var e=Yo(Vi)[0],t=lt().memoizedState;return[typeof e=="boolean"?e:pn(e),t]},useSyncExternalStore:ad,useId:Bd,useHostTransitionStatus:Qo,useFormState:Sd,useActionState:Sd,useOptimistic:function(e,t){var i=lt();return Ue!==null?dd(i,Ue,e,t):(i.baseState=e,[e,i.queue.dispatch])},useMemoCache:Vo,useCacheRefresh:jd},xs=null,Sn=0;function $a(e){var t=Sn;return Sn+=1,xs===null&&(xs=[]),Qh(xs,e,t)}function xn(e,t){t=t.props.ref,e.ref=t!==void 0?t:null}function Ka(e,t){throw t.$$typeof===v?Error(g(525)):(e=Object.prototype.toString.call(t),Error(g(31,e==="[object Object]"?"object with keys {"+Object.keys(t).join(", ")+"}":e)))}function Fd(e){var t=e._init;return t(e._payload)}function qd(e){function t(Q,X){if(e){var te=Q.deletions;te===null?(Q.deletions=[X],Q.flags|=16):te.push(X)}}function i(Q,X){if(!e)return null;for(;X!==null;)t(Q,X),X=X.sibling;return null}function r(Q){for(var X=new Map;Q!==null;)Q.key!==null?X.set(Q.key,Q):X.set(Q.index,Q),Q=Q.sibling;return X}function n(Q,X){return Q=Ui(Q,X),Q.index=0,Q.sibling=null,Q}function m(Q,X,te){return Q.index=te,e?(te=Q.alternate,te!==null?(te=te.index,te<X?(Q.flags|=67108866,X):te):(Q.flags|=67108866,X)):(Q.flags|=1048576,X)}function L(Q){return e&&Q.alternate===null&&(Q.flags|=67108866),Q}function H(Q,X,te,ce){return X===null||X.tag!==6?(X=yo(te,Q.mode,ce),X.return=Q,X):(X=n(X,te),X.return=Q,X)}function $(Q,X,te,ce){var me=te.type;return me===k?oe(Q,X,te.props.children,ce,te.key):X!==null&&(X.elementType===me||typeof me=="object"&&me!==null&&me.$$typeof===y&&Fd(me)===X.type)?(X=n(X,te.props),xn(X,te),X.return=Q,X):(X=Ta(te.type,te.key,te.props,null,Q.mode,ce),xn(X,te),X.return=Q,X)}function ie(Q,X,te,ce){return X===null||X.tag!==4||X.stateNode.containerInfo!==te.containerInfo||X.stateNode.implementation!==te.implementation?(X=So(te,Q.mode,ce),X.return=Q,X):(X=n(X,te.children||[]),X.return=Q,X)}function oe(Q,X,te,ce,me){return X===null||X.tag!==7?(X=Br(te,Q.mode,ce,me),X.return=Q,X):(X=n(X,te),X.return=Q,X)}function he(Q,X,te){if(typeof X=="string"&&X!==""||typeof X=="number"||typeof X=="bigint")return X=yo(""+X,Q.mode,te),X.return=Q,X;if(typeof X=="object"&&X!==null){switch(X.$$typeof){case b:return te=Ta(X.type,X.key,X.props,null,Q.mode,te),xn(te,X),te.return=Q,te;case w:return X=So(X,Q.mode,te),X.return=Q,X;case y:var ce=X._init;return X=ce(X._payload),he(Q,X,te)}if(K(X)||q(X))return X=Br(X,Q.mode,te,null),X.return=Q,X;if(typeof X.then=="function")return he(Q,$a(X),te);if(X.$$typeof===f)return he(Q,Na(Q,X),te);Ka(Q,X)}return null}function se(Q,X,te,ce){var me=X!==null?X.key:null;if(typeof te=="string"&&te!==""||typeof te=="number"||typeof te=="bigint")return me!==null?null:H(Q,X,""+te,ce);if(typeof te=="object"&&te!==null){switch(te.$$typeof){case b:return te.key===me?$(Q,X,te,ce):null;case w:return te.key===me?ie(Q,X,te,ce):null;case y:return me=te._init,te=me(te._payload),se(Q,X,te,ce)}if(K(te)||q(te))return me!==null?null:oe(Q,X,te,ce,null);if(typeof te.then=="function")return se(Q,X,$a(te),ce);if(te.$$typeof===f)return se(Q,X,Na(Q,te),ce);Ka(Q,te)}return null}function ne(Q,X,te,ce,me){if(typeof ce=="string"&&ce!==""||typeof ce=="number"||typeof ce=="bigint")return Q=Q.get(te)||null,H(X,Q,""+ce,me);if(typeof ce=="object"&&ce!==null){switch(ce.$$typeof){case b:return Q=Q.get(ce.key===null?te:ce.key)||null,$(X,Q,ce,me);case w:return Q=Q.get(ce.key===null?te:ce.key)||null,ie(X,Q,ce,me);case y:var De=ce._init;return ce=De(ce._payload),ne(Q,X,te,ce,me)}if(K(ce)||q(ce))return Q=Q.get(te)||null,oe(X,Q,ce,me,null);if(typeof ce.then=="function")return ne(Q,X,te,$a(ce),me);if(ce.$$typeof===f)return ne(Q,X,te,Na(X,ce),me);Ka(X,ce)}return null}function xe(Q,X,te,ce){for(var me=null,De=null,ge=X,Se=X=0,vt=null;ge!==null&&Se<te.length;Se++){ge.index>Se?(vt=ge,ge=null):vt=ge.sibling;var Oe=se(Q,ge,te[Se],ce);if(Oe===null){ge===null&&(ge=vt);break}e&&ge&&Oe.alternate===null&&t(Q,ge),X=m(Oe,X,Se),De===null?me=Oe:De.sibling=Oe,De=Oe,ge=vt}if(Se===te.length)return i(Q,ge),Be&&Hr(Q,Se),me;if(ge===null){for(;Se<te.length;Se++)ge=he(Q,te[Se],ce),ge!==null&&(X=m(ge,X,Se),De===null?me=ge:De.sibling=ge,De=ge);return Be&&Hr(Q,Se),me}for(ge=r(ge);Se<te.length;Se++)vt=ne(ge,Q,Se,te[Se],ce),vt!==null&&(e&&vt.alternate!==null&&ge.delete(vt.key===null?Se:vt.key),X=m(vt,X,Se),De===null?me=vt:De.sibling=vt,De=vt);return e&&ge.forEach(function(Er){return t(Q,Er)}),Be&&Hr(Q,Se),me}function ye(Q,X,te,ce){if(te==null)throw Error(g(151));for(var me=null,De=null,ge=X,Se=X=0,vt=null,Oe=te.next();ge!==null&&!Oe.done;Se++,Oe=te.next()){ge.index>Se?(vt=ge,ge=null):vt=ge.sibling;var Er=se(Q,ge,Oe.value,ce);if(Er===null){ge===null&&(ge=vt);break}e&&ge&&Er.alternate===null&&t(Q,ge),X=m(Er,X,Se),De===null?me=Er:De.sibling=Er,De=Er,ge=vt}if(Oe.done)return i(Q,ge),Be&&Hr(Q,Se),me;if(ge===null){for(;!Oe.done;Se++,Oe=te.next())Oe=he(Q,Oe.value,ce),Oe!==null&&(X=m(Oe,X,Se),De===null?me=Oe:De.sibling=Oe,De=Oe);return Be&&Hr(Q,Se),me}for(ge=r(ge);!Oe.done;Se++,Oe=te.next())Oe=ne(ge,Q,Se,Oe.value,ce),Oe!==null&&(e&&Oe.alternate!==null&&ge.delete(Oe.key===null?Se:Oe.key),X=m(Oe,X,Se),De===null?me=Oe:De.sibling=Oe,De=Oe);return e&&ge.forEach(function(Up){return t(Q,Up)}),Be&&Hr(Q,Se),me}function Ie(Q,X,te,ce){if(typeof te=="object"&&te!==null&&te.type===k&&te.key===null&&(te=te.props.children),typeof te=="object"&&te!==null){switch(te.$$typeof){case b:e:{for(var me=te.key;X!==null;){if(X.key===me){if(me=te.type,me===k){if(X.tag===7){i(Q,X.sibling),ce=n(X,te.props.children),ce.return=Q,Q=ce;break e}}else if(X.elementType===me||typeof me=="object"&&me!==null&&me.$$typeof===y&&Fd(me)===X.type){i(Q,X.sibling),ce=n(X,te.props),xn(ce,te),ce.return=Q,Q=ce;break e}i(Q,X);break}else t(Q,X);X=X.sibling}te.type===k?(ce=Br(te.props.children,Q.mode,ce,te.key),ce.return=Q,Q=ce):(ce=Ta(te.type,te.key,te.props,null,Q.mode,ce),xn(ce,te),ce.return=Q,Q=ce)}return L(Q);case w:e:{for(me=te.key;X!==null;){if(X.key===me)if(X.tag===4&&X.stateNode.containerInfo===te.containerInfo&&X.stateNode.implementation===te.implementation){i(Q,X.sibling),ce=n(X,te.children||[]),ce.return=Q,Q=ce;break e}else{i(Q,X);break}else t(Q,X);X=X.sibling}ce=So(te,Q.mode,ce),ce.return=Q,Q=ce}return L(Q);case y:return me=te._init,te=me(te._payload),Ie(Q,X,te,ce)}if(K(te))return xe(Q,X,te,ce);if(q(te)){if(me=q(te),typeof me!="function")throw Error(g(150));return te=me.call(te),ye(Q,X,te,ce)}if(typeof te.then=="function")return Ie(Q,X,$a(te),ce);if(te.$$typeof===f)return Ie(Q,X,Na(Q,te),ce);Ka(Q,te)}return typeof te=="string"&&te!==""||typeof te=="number"||typeof te=="bigint"?(te=""+te,X!==null&&X.tag===6?(i(Q,X.sibling),ce=n(X,te),ce.return=Q,Q=ce):(i(Q,X),ce=yo(te,Q.mode,ce),ce.return=Q,Q=ce),L(Q)):i(Q,X)}return function(Q,X,te,ce){try{Sn=0;var me=Ie(Q,X,te,ce);return xs=null,me}catch(ge){if(ge===dn||ge===ja)throw ge;var De=Kt(29,ge,null,Q.mode);return De.lanes=ce,De.return=Q,De}finally{}}}var Cs=qd(!0),Vd=qd(!1),oi=P(null),ki=null;function ur(e){var t=e.alternate;J(ct,ct.current&1),J(oi,e),ki===null&&(t===null||ps.current!==null||t.memoizedState!==null)&&(ki=e)}function Gd(e){if(e.tag===22){if(J(ct,ct.current),J(oi,e),ki===null){var t=e.alternate;t!==null&&t.memoizedState!==null&&(ki=e)}}else hr()}function hr(){J(ct,ct.current),J(oi,oi.current)}function Gi(e){W(oi),ki===e&&(ki=null),W(ct)}var ct=P(0);function Xa(e){for(var t=e;t!==null;){if(t.tag===13){var i=t.memoizedState;if(i!==null&&(i=i.dehydrated,i===null||i.data==="$?"||qc(i)))return t}else if(t.tag===19&&t.memoizedProps.revealOrder!==void 0){if((t.flags&128)!==0)return t}else if(t.child!==null){t.child.return=t,t=t.child;continue}if(t===e)break;for(;t.sibling===null;){if(t.return===null||t.return===e)return null;t=t.return}t.sibling.return=t.return,t=t.sibling}return null}function ec(e,t,i,r){t=e.memoizedState,i=i(r,t),i=i==null?t:p({},t,i),e.memoizedState=i,e.lanes===0&&(e.updateQueue.baseState=i)}var tc={enqueueSetState:function(e,t,i){e=e._reactInternals;var r=Qt(),n=lr(r);n.payload=t,i!=null&&(n.callback=i),t=or(e,n,r),t!==null&&(Jt(t,e,r),mn(t,e,r))},enqueueReplaceState:function(e,t,i){e=e._reactInternals;var r=Qt(),n=lr(r);n.tag=1,n.payload=t,i!=null&&(n.callback=i),t=or(e,n,r),t!==null&&(Jt(t,e,r),mn(t,e,r))},enqueueForceUpdate:function(e,t){e=e._reactInternals;var i=Qt(),r=lr(i);r.tag=2,t!=null&&(r.callback=t),t=or(e,r,i),t!==null&&(Jt(t,e,i),mn(t,e,i))}};function Yd(e,t,i,r,n,m,L){return e=e.stateNode,typeof e.shouldComponentUpdate=="function"?e.shouldComponentUpdate(r,m,L):t.prototype&&t.prototype.isPureReactComponent?!sn(i,r)||!sn(n,m):!0}function $d(e,t,i,r){e=t.state,typeof t.componentWillReceiveProps=="function"&&t.componentWillReceiveProps(i,r),typeof t.UNSAFE_componentWillReceiveProps=="function"&&t.UNSAFE_componentWillReceiveProps(i,r),t.state!==e&&tc.enqueueReplaceState(t,t.state,null)}function Vr(e,t){var i=t;if("ref"in t){i={};for(var r in t)r!=="ref"&&(i[r]=t[r])}if(e=e.defaultProps){i===t&&(i=p({},i));for(var n in e)i[n]===void 0&&(i[n]=e[n])}return i}var Wa=typeof reportError=="function"?reportError:function(e){if(typeof window=="object"&&typeof window.ErrorEvent=="function"){var t=new window.ErrorEvent("error",{bubbles:!0,cancelable:!0,message:typeof e=="object"&&e!==null&&typeof e.message=="string"?String(e.message):String(e),error:e});if(!window.dispatchEvent(t))return}else if(typeof process=="object"&&typeof process.emit=="function"){process.emit("uncaughtException",e);return}console.error(e)};function Kd(e){Wa(e)}function Xd(e){console.error(e)}function Wd(e){Wa(e)}function Za(e,t){try{var i=e.onUncaughtError;i(t.value,{componentStack:t.stack})}catch(r){setTimeout(function(){throw r})}}function Zd(e,t,i){try{var r=e.onCaughtError;r(i.value,{componentStack:i.stack,errorBoundary:t.tag===1?t.stateNode:null})}catch(n){setTimeout(function(){throw n})}}function ic(e,t,i){return i=lr(i),i.tag=3,i.payload={element:null},i.callback=function(){Za(e,t)},i}function Qd(e){return e=lr(e),e.tag=3,e}function Jd(e,t,i,r){var n=i.type.getDerivedStateFromError;if(typeof n=="function"){var m=r.value;e.payload=function(){return n(m)},e.callback=function(){Zd(t,i,r)}}var L=i.stateNode;L!==null&&typeof L.componentDidCatch=="function"&&(e.callback=function(){Zd(t,i,r),typeof n!="function"&&(gr===null?gr=new Set([this]):gr.add(this));var H=r.stack;this.componentDidCatch(r.value,{componentStack:H!==null?H:""})})}function Ug(e,t,i,r,n){if(i.flags|=32768,r!==null&&typeof r=="object"&&typeof r.then=="function"){if(t=i.alternate,t!==null&&cn(t,i,n,!0),i=oi.current,i!==null){switch(i.tag){case 13:return ki===null?kc():i.alternate===null&&rt===0&&(rt=3),i.flags&=-257,i.flags|=65536,i.lanes=n,r===Mo?i.flags|=16384:(t=i.updateQueue,t===null?i.updateQueue=new Set([r]):t.add(r),Dc(e,r,n)),!1;case 22:return i.flags|=65536,r===Mo?i.flags|=16384:(t=i.updateQueue,t===null?(t={transitions:null,markerInstances:null,retryQueue:new Set([r])},i.updateQueue=t):(i=t.retryQueue,i===null?t.retryQueue=new Set([r]):i.add(r)),Dc(e,r,n)),!1}throw Error(g(435,i.tag))}return Dc(e,r,n),kc(),!1}if(Be)return t=oi.current,t!==null?((t.flags&65536)===0&&(t.flags|=256),t.flags|=65536,t.lanes=n,r!==wo&&(e=Error(g(422),{cause:r}),on(si(e,i)))):(r!==wo&&(t=Error(g(423),{cause:r}),on(si(t,i))),e=e.current.alternate,e.flags|=65536,n&=-n,e.lanes|=n,r=si(r,i),n=ic(e.stateNode,r,n),No(e,n),rt!==4&&(rt=2)),!1;var m=Error(g(520),{cause:r});if(m=si(m,i),An===null?An=[m]:An.push(m),rt!==4&&(rt=2),t===null)return!0;r=si(r,i),i=t;do{switch(i.tag){case 3:return i.flags|=65536,e=n&-n,i.lanes|=e,e=ic(i.stateNode,r,e),No(i,e),!1;case 1:if(t=i.type,m=i.stateNode,(i.flags&128)===0&&(typeof t.getDerivedStateFromError=="function"||m!==null&&typeof m.componentDidCatch=="function"&&(gr===null||!gr.has(m))))return i.flags|=65536,n&=-n,i.lanes|=n,n=Qd(n),Jd(n,e,i,r),No(i,n),!1}i=i.return}while(i!==null);return!1}var ef=Error(g(461)),mt=!1;function bt(e,t,i,r){t.child=e===null?Vd(t,null,i,r):Cs(t,e.child,i,r)}function tf(e,t,i,r,n){i=i.render;var m=t.ref;if("ref"in r){var L={};for(var H in r)H!=="ref"&&(L[H]=r[H])}else L=r;return Ir(t),r=Uo(e,t,i,L,m,n),H=Po(),e!==null&&!mt?(Io(e,t,n),Yi(e,t,n)):(Be&&H&&xo(t),t.flags|=1,bt(e,t,r,n),t.child)}function rf(e,t,i,r,n){if(e===null){var m=i.type;return typeof m=="function"&&!bo(m)&&m.defaultProps===void 0&&i.compare===null?(t.tag=15,t.type=m,sf(e,t,m,r,n)):(e=Ta(i.type,null,r,t,t.mode,n),e.ref=t.ref,e.return=t,t.child=e)}if(m=e.child,!uc(e,n)){var L=m.memoizedProps;if(i=i.compare,i=i!==null?i:sn,i(L,r)&&e.ref===t.ref)return Yi(e,t,n)}return t.flags|=1,e=Ui(m,r),e.ref=t.ref,e.return=t,t.child=e}function sf(e,t,i,r,n){if(e!==null){var m=e.memoizedProps;if(sn(m,r)&&e.ref===t.ref)if(mt=!1,t.pendingProps=r=m,uc(e,n))(e.flags&131072)!==0&&(mt=!0);else return t.lanes=e.lanes,Yi(e,t,n)}return rc(e,t,i,r,n)}function nf(e,t,i){var r=t.pendingProps,n=r.children,m=e!==null?e.memoizedState:null;if(r.mode==="hidden"){if((t.flags&128)!==0){if(r=m!==null?m.baseLanes|i:i,e!==null){for(n=t.child=e.child,m=0;n!==null;)m=m|n.lanes|n.childLanes,n=n.sibling;t.childLanes=m&~r}else t.childLanes=0,t.child=null;return af(e,t,r,i)}if((i&536870912)!==0)t.memoizedState={baseLanes:0,cachePool:null},e!==null&&Ba(t,m!==null?m.cachePool:null),m!==null?rd(t,m):jo(),Gd(t);else return t.lanes=t.childLanes=536870912,af(e,t,m!==null?m.baseLanes|i:i,i)}else m!==null?(Ba(t,m.cachePool),rd(t,m),hr(),t.memoizedState=null):(e!==null&&Ba(t,null),jo(),hr());return bt(e,t,n,i),t.child}function af(e,t,i,r){var n=To();return n=n===null?null:{parent:ot._currentValue,pool:n},t.memoizedState={baseLanes:i,cachePool:n},e!==null&&Ba(t,null),jo(),Gd(t),e!==null&&cn(e,t,r,!0),null}function Qa(e,t){var i=t.ref;if(i===null)e!==null&&e.ref!==null&&(t.flags|=4194816);else{if(typeof i!="function"&&typeof i!="object")throw Error(g(284));(e===null||e.ref!==i)&&(t.flags|=4194816)}}function rc(e,t,i,r,n){return Ir(t),i=Uo(e,t,i,r,void 0,n),r=Po(),e!==null&&!mt?(Io(e,t,n),Yi(e,t,n)):(Be&&r&&xo(t),t.flags|=1,bt(e,t,i,n),t.child)}function lf(e,t,i,r,n,m){return Ir(t),t.updateQueue=null,i=nd(t,r,i,n),sd(e),r=Po(),e!==null&&!mt?(Io(e,t,m),Yi(e,t,m)):(Be&&r&&xo(t),t.flags|=1,bt(e,t,i,m),t.child)}function of(e,t,i,r,n){if(Ir(t),t.stateNode===null){var m=fs,L=i.contextType;typeof L=="object"&&L!==null&&(m=kt(L)),m=new i(r,m),t.memoizedState=m.state!==null&&m.state!==void 0?m.state:null,m.updater=tc,t.stateNode=m,m._reactInternals=t,m=t.stateNode,m.props=r,m.state=t.memoizedState,m.refs={},Lo(t),L=i.contextType,m.context=typeof L=="object"&&L!==null?kt(L):fs,m.state=t.memoizedState,L=i.getDerivedStateFromProps,typeof L=="function"&&(ec(t,i,L,r),m.state=t.memoizedState),typeof i.getDerivedStateFromProps=="function"||typeof m.getSnapshotBeforeUpdate=="function"||typeof m.UNSAFE_componentWillMount!="function"&&typeof m.componentWillMount!="function"||(L=m.state,typeof m.componentWillMount=="function"&&m.componentWillMount(),typeof m.UNSAFE_componentWillMount=="function"&&m.UNSAFE_componentWillMount(),L!==m.state&&tc.enqueueReplaceState(m,m.state,null),vn(t,r,m,n),_n(),m.state=t.memoizedState),typeof m.componentDidMount=="function"&&(t.flags|=4194308),r=!0}else if(e===null){m=t.stateNode;var H=t.memoizedProps,$=Vr(i,H);m.props=$;var ie=m.context,oe=i.contextType;L=fs,typeof oe=="object"&&oe!==null&&(L=kt(oe));var he=i.getDerivedStateFromProps;oe=typeof he=="function"||typeof m.getSnapshotBeforeUpdate=="function",H=t.pendingProps!==H,oe||typeof m.UNSAFE_componentWillReceiveProps!="function"&&typeof m.componentWillReceiveProps!="function"||(H||ie!==L)&&$d(t,m,r,L),ar=!1;var se=t.memoizedState;m.state=se,vn(t,r,m,n),_n(),ie=t.memoizedState,H||se!==ie||ar?(typeof he=="function"&&(ec(t,i,he,r),ie=t.memoizedState),($=ar||Yd(t,i,$,r,se,ie,L))?(oe||typeof m.UNSAFE_componentWillMount!="function"&&typeof m.componentWillMount!="function"||(typeof m.componentWillMount=="function"&&m.componentWillMount(),typeof m.UNSAFE_componentWillMount=="function"&&m.UNSAFE_componentWillMount()),typeof m.componentDidMount=="function"&&(t.flags|=4194308)):(typeof m.componentDidMount=="function"&&(t.flags|=4194308),t.memoizedProps=r,t.memoizedState=ie),m.props=r,m.state=ie,m.context=L,r=$):(typeof m.componentDidMount=="function"&&(t.flags|=4194308),r=!1)}else{m=t.stateNode,Oo(e,t),L=t.memoizedProps,oe=Vr(i,L),m.props=oe,he=t.pendingProps,se=m.context,ie=i.contextType,$=fs,typeof ie=="object"&&ie!==null&&($=kt(ie)),H=i.getDerivedStateFromProps,(ie=typeof H=="function"||typeof m.getSnapshotBeforeUpdate=="function")||typeof m.UNSAFE_componentWillReceiveProps!="function"&&typeof m.componentWillReceiveProps!="function"||(L!==he||se!==$)&&$d(t,m,r,$),ar=!1,se=t.memoizedState,m.state=se,vn(t,r,m,n),_n();var ne=t.memoizedState;L!==he||se!==ne||ar||e!==null&&e.dependencies!==null&&Oa(e.dependencies)?(typeof H=="function"&&(ec(t,i,H,r),ne=t.memoizedState),(oe=ar||Yd(t,i,oe,r,se,ne,$)||e!==null&&e.dependencies!==null&&Oa(e.dependencies))?(ie||typeof m.UNSAFE_componentWillUpdate!="function"&&typeof m.componentWillUpdate!="function"||(typeof m.componentWillUpdate=="function"&&m.componentWillUpdate(r,ne,$),typeof m.UNSAFE_componentWillUpdate=="function"&&m.UNSAFE_componentWillUpdate(r,ne,$)),typeof m.componentDidUpdate=="function"&&(t.flags|=4),typeof m.getSnapshotBeforeUpdate=="function"&&(t.flags|=1024)):(typeof m.componentDidUpdate!="function"||L===e.memoizedProps&&se===e.memoizedState||(t.flags|=4),typeof m.getSnapshotBeforeUpdate!="function"||L===e.memoizedProps&&se===e.memoizedState||(t.flags|=1024),t.memoizedProps=r,t.memoizedState=ne),m.props=r,m.state=ne,m.context=$,r=oe):(typeof m.componentDidUpdate!="function"||L===e.memoizedProps&&se===e.memoizedState||(t.flags|=4),typeof m.getSnapshotBeforeUpdate!="function"||L===e.memoizedProps&&se===e.memoizedState||(t.flags|=1024),r=!1)}return m=r,Qa(e,t),r=(t.flags&128)!==0,m||r?(m=t.stateNode,i=r&&typeof i.getDerivedStateFromError!="function"?null:m.render(),t.flags|=1,e!==null&&r?(t.child=Cs(t,e.child,null,n),t.child=Cs(t,null,i,n)):bt(e,t,i,n),t.memoizedState=m.state,e=t.child):e=Yi(e,t,n),e}function cf(e,t,i,r){return ln(),t.flags|=256,bt(e,t,i,r),t.child}var sc={dehydrated:null,treeContext:null,retryLane:0,hydrationErrors:null};function nc(e){return{baseLanes:e,cachePool:Xh()}}function ac(e,t,i){return e=e!==null?e.childLanes&~i:0,t&&(e|=ci),e}function uf(e,t,i){var r=t.pendingProps,n=!1,m=(t.flags&128)!==0,L;if((L=m)||(L=e!==null&&e.memoizedState===null?!1:(ct.current&2)!==0),L&&(n=!0,t.flags&=-129),L=(t.flags&32)!==0,t.flags&=-33,e===null){if(Be){if(n?ur(t):hr(),Be){var H=it,$;if($=H){e:{for($=H,H=Ei;$.nodeType!==8;){if(!H){H=null;break e}if($=bi($.nextSibling),$===null){H=null;break e}}H=$}H!==null?(t.memoizedState={dehydrated:H,treeContext:jr!==null?{id:Pi,overflow:Ii}:null,retryLane:536870912,hydrationErrors:null},$=Kt(18,null,null,0),$.stateNode=H,$.return=t,t.child=$,Nt=t,it=null,$=!0):$=!1}$||Ur(t)}if(H=t.memoizedState,H!==null&&(H=H.dehydrated,H!==null))return qc(H)?t.lanes=32:t.lanes=536870912,null;Gi(t)}return H=r.children,r=r.fallback,n?(hr(),n=t.mode,H=Ja({mode:"hidden",children:H},n),r=Br(r,n,i,null),H.return=t,r.return=t,H.sibling=r,t.child=H,n=t.child,n.memoizedState=nc(i),n.childLanes=ac(e,L,i),t.memoizedState=sc,r):(ur(t),lc(t,H))}if($=e.memoizedState,$!==null&&(H=$.dehydrated,H!==null)){if(m)t.flags&256?(ur(t),t.flags&=-257,t=oc(e,t,i)):t.memoizedState!==null?(hr(),t.child=e.child,t.flags|=128,t=null):(hr(),n=r.fallback,H=t.mode,r=Ja({mode:"visible",children:r.children},H),n=Br(n,H,i,null),n.flags|=2,r.return=t,n.return=t,r.sibling=n,t.child=r,Cs(t,e.child,null,i),r=t.child,r.memoizedState=nc(i),r.childLanes=ac(e,L,i),t.memoizedState=sc,t=n);else if(ur(t),qc(H)){if(L=H.nextSibling&&H.nextSibling.dataset,L)var ie=L.dgst;L=ie,r=Error(g(419)),r.stack="",r.digest=L,on({value:r,source:null,stack:null}),t=oc(e,t,i)}else if(mt||cn(e,t,i,!1),L=(i&e.childLanes)!==0,mt||L){if(L=Ve,L!==null&&(r=i&-i,r=(r&42)!==0?1:wt(r),r=(r&(L.suspendedLanes|i))!==0?0:r,r!==0&&r!==$.retryLane))throw $.retryLane=r,ds(e,r),Jt(L,e,r),ef;H.data==="$?"||kc(),t=oc(e,t,i)}else H.data==="$?"?(t.flags|=192,t.child=e.child,t=null):(e=$.treeContext,it=bi(H.nextSibling),Nt=t,Be=!0,zr=null,Ei=!1,e!==null&&(ai[li++]=Pi,ai[li++]=Ii,ai[li++]=jr,Pi=e.id,Ii=e.overflow,jr=t),t=lc(t,r.children),t.flags|=4096);return t}return n?(hr(),n=r.fallback,H=t.mode,$=e.child,ie=$.sibling,r=Ui($,{mode:"hidden",children:r.children}),r.subtreeFlags=$.subtreeFlags&65011712,ie!==null?n=Ui(ie,n):(n=Br(n,H,i,null),n.flags|=2),n.return=t,r.return=t,r.sibling=n,t.child=r,r=n,n=t.child,H=e.child.memoizedState,H===null?H=nc(i):($=H.cachePool,$!==null?(ie=ot._currentValue,$=$.parent!==ie?{parent:ie,pool:ie}:$):$=Xh(),H={baseLanes:H.baseLanes|i,cachePool:$}),n.memoizedState=H,n.childLanes=ac(e,L,i),t.memoizedState=sc,r):(ur(t),i=e.child,e=i.sibling,i=Ui(i,{mode:"visible",children:r.children}),i.return=t,i.sibling=null,e!==null&&(L=t.deletions,L===null?(t.deletions=[e],t.flags|=16):L.push(e)),t.child=i,t.memoizedState=null,i)}function lc(e,t){return t=Ja({mode:"visible",children:t},e.mode),t.return=e,e.child=t}function Ja(e,t){return e=Kt(22,e,null,t),e.lanes=0,e.stateNode={_visibility:1,_pendingMarkers:null,_retryCache:null,_transitions:null},e}function oc(e,t,i){return Cs(t,e.child,null,i),e=lc(t,t.pendingProps.children),e.flags|=2,t.memoizedState=null,e}function hf(e,t,i){e.lanes|=t;var r=e.alternate;r!==null&&(r.lanes|=t),ko(e.return,t,i)}function cc(e,t,i,r,n){var m=e.memoizedState;m===null?e.memoizedState={isBackwards:t,rendering:null,renderingStartTime:0,last:r,tail:i,tailMode:n}:(m.isBackwards=t,m.rendering=null,m.renderingStartTime=0,m.last=r,m.tail=i,m.tailMode=n)}function df(e,t,i){var r=t.pendingProps,n=r.revealOrder,m=r.tail;if(bt(e,t,r.children,i),r=ct.current,(r&2)!==0)r=r&1|2,t.flags|=128;else{if(e!==null&&(e.flags&128)!==0)e:for(e=t.child;e!==null;){if(e.tag===13)e.memoizedState!==null&&hf(e,i,t);else if(e.tag===19)hf(e,i,t);else if(e.child!==null){e.child.return=e,e=e.child;continue}if(e===t)break e;for(;e.sibling===null;){if(e.return===null||e.return===t)break e;e=e.return}e.sibling.return=e.return,e=e.sibling}r&=1}switch(J(ct,r),n){case"forwards":for(i=t.child,n=null;i!==null;)e=i.alternate,e!==null&&Xa(e)===null&&(n=i),i=i.sibling;i=n,i===null?(n=t.child,t.child=null):(n=i.sibling,i.sibling=null),cc(t,!1,n,i,m);break;case"backwards":for(i=null,n=t.child,t.child=null;n!==null;){if(e=n.alternate,e!==null&&Xa(e)===null){t.child=n;break}e=n.sibling,n.sibling=i,i=n,n=e}cc(t,!0,i,null,m);break;case"together":cc(t,!1,null,null,void 0);break;default:t.memoizedState=null}return t.child}function Yi(e,t,i){if(e!==null&&(t.dependencies=e.dependencies),vr|=t.lanes,(i&t.childLanes)===0)if(e!==null){if(cn(e,t,i,!1),(i&t.childLanes)===0)return null}else return null;if(e!==null&&t.child!==e.child)throw Error(g(153));if(t.child!==null){for(e=t.child,i=Ui(e,e.pendingProps),t.child=i,i.return=t;e.sibling!==null;)e=e.sibling,i=i.sibling=Ui(e,e.pendingProps),i.return=t;i.sibling=null}return t.child}function uc(e,t){return(e.lanes&t)!==0?!0:(e=e.dependencies,!!(e!==null&&Oa(e)))}function Pg(e,t,i){switch(t.tag){case 3:Z(t,t.stateNode.containerInfo),nr(t,ot,e.memoizedState.cache),ln();break;case 27:case 5:re(t);break;case 4:Z(t,t.stateNode.containerInfo);break;case 10:nr(t,t.type,t.memoizedProps.value);break;case 13:var r=t.memoizedState;if(r!==null)return r.dehydrated!==null?(ur(t),t.flags|=128,null):(i&t.child.childLanes)!==0?uf(e,t,i):(ur(t),e=Yi(e,t,i),e!==null?e.sibling:null);ur(t);break;case 19:var n=(e.flags&128)!==0;if(r=(i&t.childLanes)!==0,r||(cn(e,t,i,!1),r=(i&t.childLanes)!==0),n){if(r)return df(e,t,i);t.flags|=128}if(n=t.memoizedState,n!==null&&(n.rendering=null,n.tail=null,n.lastEffect=null),J(ct,ct.current),r)break;return null;case 22:case 23:return t.lanes=0,nf(e,t,i);case 24:nr(t,ot,e.memoizedState.cache)}return Yi(e,t,i)}function ff(e,t,i){if(e!==null)if(e.memoizedProps!==t.pendingProps)mt=!0;else{if(!uc(e,i)&&(t.flags&128)===0)return mt=!1,Pg(e,t,i);mt=(e.flags&131072)!==0}else mt=!1,Be&&(t.flags&1048576)!==0&&Fh(t,La,t.index);switch(t.lanes=0,t.tag){case 16:e:{e=t.pendingProps;var r=t.elementType,n=r._init;if(r=n(r._payload),t.type=r,typeof r=="function")bo(r)?(e=Vr(r,e),t.tag=1,t=of(null,t,r,e,i)):(t.tag=0,t=rc(null,t,r,e,i));else{if(r!=null){if(n=r.$$typeof,n===S){t.tag=11,t=tf(null,t,r,e,i);break e}else if(n===O){t.tag=14,t=rf(null,t,r,e,i);break e}}throw t=ee(r)||r,Error(g(306,t,""))}}return t;case 0:return rc(e,t,t.type,t.pendingProps,i);case 1:return r=t.type,n=Vr(r,t.pendingProps),of(e,t,r,n,i);case 3:e:{if(Z(t,t.stateNode.containerInfo),e===null)throw Error(g(387));r=t.pendingProps;var m=t.memoizedState;n=m.element,Oo(e,t),vn(t,r,null,i);var L=t.memoizedState;if(r=L.cache,nr(t,ot,r),r!==m.cache&&Ro(t,[ot],i,!0),_n(),r=L.element,m.isDehydrated)if(m={element:r,isDehydrated:!1,cache:L.cache},t.updateQueue.baseState=m,t.memoizedState=m,t.flags&256){t=cf(e,t,r,i);break e}else if(r!==n){n=si(Error(g(424)),t),on(n),t=cf(e,t,r,i);break e}else{switch(e=t.stateNode.containerInfo,e.nodeType){case 9:e=e.body;break;default:e=e.nodeName==="HTML"?e.ownerDocument.body:e}for(it=bi(e.firstChild),Nt=t,Be=!0,zr=null,Ei=!0,i=Vd(t,null,r,i),t.child=i;i;)i.flags=i.flags&-3|4096,i=i.sibling}else{if(ln(),r===n){t=Yi(e,t,i);break e}bt(e,t,r,i)}t=t.child}return t;case 26:return Qa(e,t),e===null?(i=gm(t.type,null,t.pendingProps,null))?t.memoizedState=i:Be||(i=t.type,e=t.pendingProps,r=fl(fe.current).createElement(i),r[Ge]=t,r[Ye]=e,St(r,i,e),ft(r),t.stateNode=r):t.memoizedState=gm(t.type,e.memoizedProps,t.pendingProps,e.memoizedState),null;case 27:return re(t),e===null&&Be&&(r=t.stateNode=mm(t.type,t.pendingProps,fe.current),Nt=t,Ei=!0,n=it,yr(t.type)?(Vc=n,it=bi(r.firstChild)):it=n),bt(e,t,t.pendingProps.children,i),Qa(e,t),e===null&&(t.flags|=4194304),t.child;case 5:return e===null&&Be&&((n=r=it)&&(r=mp(r,t.type,t.pendingProps,Ei),r!==null?(t.stateNode=r,Nt=t,it=bi(r.firstChild),Ei=!1,n=!0):n=!1),n||Ur(t)),re(t),n=t.type,m=t.pendingProps,L=e!==null?e.memoizedProps:null,r=m.children,Pc(n,m)?r=null:L!==null&&Pc(n,L)&&(t.flags|=32),t.memoizedState!==null&&(n=Uo(e,t,Lg,null,null,i),zn._currentValue=n),Qa(e,t),bt(e,t,r,i),t.child;case 6:return e===null&&Be&&((e=i=it)&&(i=_p(i,t.pendingProps,Ei),i!==null?(t.stateNode=i,Nt=t,it=null,e=!0):e=!1),e||Ur(t)),null;case 13:return uf(e,t,i);case 4:return Z(t,t.stateNode.containerInfo),r=t.pendingProps,e===null?t.child=Cs(t,null,r,i):bt(e,t,r,i),t.child;case 11:return tf(e,t,t.type,t.pendingProps,i);case 7:return bt(e,t,t.pendingProps,i),t.child;case 8:return bt(e,t,t.pendingProps.children,i),t.child;case 12:return bt(e,t,t.pendingProps.children,i),t.child;case 10:return r=t.pendingProps,nr(t,t.type,r.value),bt(e,t,r.children,i),t.child;case 9:return n=t.type._context,r=t.pendingProps.children,Ir(t),n=kt(n),r=r(n),t.flags|=1,bt(e,t,r,i),t.child;case 14:return rf(e,t,t.type,t.pendingProps,i);case 15:return sf(e,t,t.type,t.pendingProps,i);case 19:return df(e,t,i);case 31:return r=t.pendingProps,i=t.mode,r={mode:r.mode,children:r.children},e===null?(i=Ja(r,i),i.ref=t.ref,t.child=i,i.return=t,t=i):(i=Ui(e.child,r),i.ref=t.ref,t.child=i,i.return=t,t=i),t;case 22:return nf(e,t,i);case 24:return Ir(t),r=kt(ot),e===null?(n=To(),n===null&&(n=Ve,m=Do(),n.pooledCache=m,m.refCount++,m!==null&&(n.pooledCacheLanes|=i),n=m),t.memoizedState={parent:r,cache:n},Lo(t),nr(t,ot,n)):((e.lanes&i)!==0&&(Oo(e,t),vn(t,null,null,i),_n()),n=e.memoizedState,m=t.memoizedState,n.parent!==r?(n={parent:r,cache:r},t.memoizedState=n,t.lanes===0&&(t.memoizedState=t.updateQueue.baseState=n),nr(t,ot,r)):(r=m.cache,nr(t,ot,r),r!==n.cache&&Ro(t,[ot],i,!0))),bt(e,t,t.pendingProps.children,i),t.child;case 29:throw t.pendingProps}throw Error(g(156,t.tag))}function $i(e){e.flags|=4}function mf(e,t){if(t.type!=="stylesheet"||(t.state.loading&4)!==0)e.flags&=-16777217;else if(e.flags|=16777216,!xm(t)){if(t=oi.current,t!==null&&((Le&4194048)===Le?ki!==null:(Le&62914560)!==Le&&(Le&536870912)===0||t!==ki))throw fn=Mo,Wh;e.flags|=8192}}function el(e,t){t!==null&&(e.flags|=4),e.flags&16384&&(t=e.tag!==22?_a():536870912,e.lanes|=t,Rs|=t)}function Cn(e,t){if(!Be)switch(e.tailMode){case"hidden":t=e.tail;for(var i=null;t!==null;)t.alternate!==null&&(i=t),t=t.sibling;i===null?e.tail=null:i.sibling=null;break;case"collapsed":i=e.tail;for(var r=null;i!==null;)i.alternate!==null&&(r=i),i=i.sibling;r===null?t||e.tail===null?e.tail=null:e.tail.sibling=null:r.sibling=null}}function Je(e){var t=e.alternate!==null&&e.alternate.child===e.child,i=0,r=0;if(t)for(var n=e.child;n!==null;)i|=n.lanes|n.childLanes,r|=n.subtreeFlags&65011712,r|=n.flags&65011712,n.return=e,n=n.sibling;else for(n=e.child;n!==null;)i|=n.lanes|n.childLanes,r|=n.subtreeFlags,r|=n.flags,n.return=e,n=n.sibling;return e.subtreeFlags|=r,e.childLanes=i,t}function Ig(e,t,i){var r=t.pendingProps;switch(Co(t),t.tag){case 31:case 16:case 15:case 0:case 11:case 7:case 8:case 12:case 9:case 14:return Je(t),null;case 1:return Je(t),null;case 3:return i=t.stateNode,r=null,e!==null&&(r=e.memoizedState.cache),t.memoizedState.cache!==r&&(t.flags|=2048),qi(ot),ae(),i.pendingContext&&(i.context=i.pendingContext,i.pendingContext=null),(e===null||e.child===null)&&(an(t)?$i(t):e===null||e.memoizedState.isDehydrated&&(t.flags&256)===0||(t.flags|=1024,Gh())),Je(t),null;case 26:return i=t.memoizedState,e===null?($i(t),i!==null?(Je(t),mf(t,i)):(Je(t),t.flags&=-16777217)):i?i!==e.memoizedState?($i(t),Je(t),mf(t,i)):(Je(t),t.flags&=-16777217):(e.memoizedProps!==r&&$i(t),Je(t),t.flags&=-16777217),null;case 27:pe(t),i=fe.current;var n=t.type;if(e!==null&&t.stateNode!=null)e.memoizedProps!==r&&$i(t);else{if(!r){if(t.stateNode===null)throw Error(g(166));return Je(t),null}e=le.current,an(t)?qh(t):(e=mm(n,r,i),t.stateNode=e,$i(t))}return Je(t),null;case 5:if(pe(t),i=t.type,e!==null&&t.stateNode!=null)e.memoizedProps!==r&&$i(t);else{if(!r){if(t.stateNode===null)throw Error(g(166));return Je(t),null}if(e=le.current,an(t))qh(t);else{switch(n=fl(fe.current),e){case 1:e=n.createElementNS("http://www.w3.org/2000/svg",i);break;case 2:e=n.createElementNS("http://www.w3.org/1998/Math/MathML",i);break;default:switch(i){case"svg":e=n.createElementNS("http://www.w3.org/2000/svg",i);break;case"math":e=n.createElementNS("http://www.w3.org/1998/Math/MathML",i);break;case"script":e=n.createElement("div"),e.innerHTML="<script><\/script>",e=e.removeChild(e.firstChild);break;case"select":e=typeof r.is=="string"?n.createElement("select",{is:r.is}):n.createElement("select"),r.multiple?e.multiple=!0:r.size&&(e.size=r.size);break;default:e=typeof r.is=="string"?n.createElement(i,{is:r.is}):n.createElement(i)}}e[Ge]=t,e[Ye]=r;e:for(n=t.child;n!==null;){if(n.tag===5||n.tag===6)e.appendChild(n.stateNode);else if(n.tag!==4&&n.tag!==27&&n.child!==null){n.child.return=n,n=n.child;continue}if(n===t)break e;for(;n.sibling===null;){if(n.return===null||n.return===t)break e;n=n.return}n.sibling.return=n.return,n=n.sibling}t.stateNode=e;e:switch(St(e,i,r),i){case"button":case"input":case"select":case"textarea":e=!!r.autoFocus;break e;case"img":e=!0;break e;default:e=!1}e&&$i(t)}}return Je(t),t.flags&=-16777217,null;case 6:if(e&&t.stateNode!=null)e.memoizedProps!==r&&$i(t);else{if(typeof r!="string"&&t.stateNode===null)throw Error(g(166));if(e=fe.current,an(t)){if(e=t.stateNode,i=t.memoizedProps,r=null,n=Nt,n!==null)switch(n.tag){case 27:case 5:r=n.memoizedProps}e[Ge]=t,e=!!(e.nodeValue===i||r!==null&&r.suppressHydrationWarning===!0||lm(e.nodeValue,i)),e||Ur(t)}else e=fl(e).createTextNode(r),e[Ge]=t,t.stateNode=e}return Je(t),null;case 13:if(r=t.memoizedState,e===null||e.memoizedState!==null&&e.memoizedState.dehydrated!==null){if(n=an(t),r!==null&&r.dehydrated!==null){if(e===null){if(!n)throw Error(g(318));if(n=t.memoizedState,n=n!==null?n.dehydrated:null,!n)throw Error(g(317));n[Ge]=t}else ln(),(t.flags&128)===0&&(t.memoizedState=null),t.flags|=4;Je(t),n=!1}else n=Gh(),e!==null&&e.memoizedState!==null&&(e.memoizedState.hydrationErrors=n),n=!0;if(!n)return t.flags&256?(Gi(t),t):(Gi(t),null)}if(Gi(t),(t.flags&128)!==0)return t.lanes=i,t;if(i=r!==null,e=e!==null&&e.memoizedState!==null,i){r=t.child,n=null,r.alternate!==null&&r.alternate.memoizedState!==null&&r.alternate.memoizedState.cachePool!==null&&(n=r.alternate.memoizedState.cachePool.pool);var m=null;r.memoizedState!==null&&r.memoizedState.cachePool!==null&&(m=r.memoizedState.cachePool.pool),m!==n&&(r.flags|=2048)}return i!==e&&i&&(t.child.flags|=8192),el(t,t.updateQueue),Je(t),null;case 4:return ae(),e===null&&Bc(t.stateNode.containerInfo),Je(t),null;case 10:return qi(t.type),Je(t),null;case 19:if(W(ct),n=t.memoizedState,n===null)return Je(t),null;if(r=(t.flags&128)!==0,m=n.rendering,m===null)if(r)Cn(n,!1);else{if(rt!==0||e!==null&&(e.flags&128)!==0)for(e=t.child;e!==null;){if(m=Xa(e),m!==null){for(t.flags|=128,Cn(n,!1),e=m.updateQueue,t.updateQueue=e,el(t,e),t.subtreeFlags=0,e=i,i=t.child;i!==null;)Ih(i,e),i=i.sibling;return J(ct,ct.current&1|2),t.child}e=e.sibling}n.tail!==null&&be()>rl&&(t.flags|=128,r=!0,Cn(n,!1),t.lanes=4194304)}else{if(!r)if(e=Xa(m),e!==null){if(t.flags|=128,r=!0,e=e.updateQueue,t.updateQueue=e,el(t,e),Cn(n,!0),n.tail===null&&n.tailMode==="hidden"&&!m.alternate&&!Be)return Je(t),null}else 2*be()-n.renderingStartTime>rl&&i!==536870912&&(t.flags|=128,r=!0,Cn(n,!1),t.lanes=4194304);n.isBackwards?(m.sibling=t.child,t.child=m):(e=n.last,e!==null?e.sibling=m:t.child=m,n.last=m)}return n.tail!==null?(t=n.tail,n.rendering=t,n.tail=t.sibling,n.renderingStartTime=be(),t.sibling=null,e=ct.current,J(ct,r?e&1|2:e&1),t):(Je(t),null);case 22:case 23:return Gi(t),Ho(),r=t.memoizedState!==null,e!==null?e.memoizedState!==null!==r&&(t.flags|=8192):r&&(t.flags|=8192),r?(i&536870912)!==0&&(t.flags&128)===0&&(Je(t),t.subtreeFlags&6&&(t.flags|=8192)):Je(t),i=t.updateQueue,i!==null&&el(t,i.retryQueue),i=null,e!==null&&e.memoizedState!==null&&e.memoizedState.cachePool!==null&&(i=e.memoizedState.cachePool.pool),r=null,t.memoizedState!==null&&t.memoizedState.cachePool!==null&&(r=t.memoizedState.cachePool.pool),r!==i&&(t.flags|=2048),e!==null&&W(Fr),null;case 24:return i=null,e!==null&&(i=e.memoizedState.cache),t.memoizedState.cache!==i&&(t.flags|=2048),qi(ot),Je(t),null;case 25:return null;case 30:return null}throw Error(g(156,t.tag))}function Fg(e,t){switch(Co(t),t.tag){case 1:return e=t.flags,e&65536?(t.flags=e&-65537|128,t):null;case 3:return qi(ot),ae(),e=t.flags,(e&65536)!==0&&(e&128)===0?(t.flags=e&-65537|128,t):null;case 26:case 27:case 5:return pe(t),null;case 13:if(Gi(t),e=t.memoizedState,e!==null&&e.dehydrated!==null){if(t.alternate===null)throw Error(g(340));ln()}return e=t.flags,e&65536?(t.flags=e&-65537|128,t):null;case 19:return W(ct),null;case 4:return ae(),null;case 10:return qi(t.type),null;case 22:case 23:return Gi(t),Ho(),e!==null&&W(Fr),e=t.flags,e&65536?(t.flags=e&-65537|128,t):null;case 24:return qi(ot),null;case 25:return null;default:return null}}function _f(e,t){switch(Co(t),t.tag){case 3:qi(ot),ae();break;case 26:case 27:case 5:pe(t);break;case 4:ae();break;case 13:Gi(t);break;case 19:W(ct);break;case 10:qi(t.type);break;case 22:case 23:Gi(t),Ho(),e!==null&&W(Fr);break;case 24:qi(ot)}}function wn(e,t){try{var i=t.updateQueue,r=i!==null?i.lastEffect:null;if(r!==null){var n=r.next;i=n;do{if((i.tag&e)===e){r=void 0;var m=i.create,L=i.inst;r=m(),L.destroy=r}i=i.next}while(i!==n)}}catch(H){Fe(t,t.return,H)}}function dr(e,t,i){try{var r=t.updateQueue,n=r!==null?r.lastEffect:null;if(n!==null){var m=n.next;r=m;do{if((r.tag&e)===e){var L=r.inst,H=L.destroy;if(H!==void 0){L.destroy=void 0,n=t;var $=i,ie=H;try{ie()}catch(oe){Fe(n,$,oe)}}}r=r.next}while(r!==m)}}catch(oe){Fe(t,t.return,oe)}}function vf(e){var t=e.updateQueue;if(t!==null){var i=e.stateNode;try{id(t,i)}catch(r){Fe(e,e.return,r)}}}function gf(e,t,i){i.props=Vr(e.type,e.memoizedProps),i.state=e.memoizedState;try{i.componentWillUnmount()}catch(r){Fe(e,t,r)}}function En(e,t){try{var i=e.ref;if(i!==null){switch(e.tag){case 26:case 27:case 5:var r=e.stateNode;break;case 30:r=e.stateNode;break;default:r=e.stateNode}typeof i=="function"?e.refCleanup=i(r):i.current=r}}catch(n){Fe(e,t,n)}}function Ri(e,t){var i=e.ref,r=e.refCleanup;if(i!==null)if(typeof r=="function")try{r()}catch(n){Fe(e,t,n)}finally{e.refCleanup=null,e=e.alternate,e!=null&&(e.refCleanup=null)}else if(typeof i=="function")try{i(null)}catch(n){Fe(e,t,n)}else i.current=null}function pf(e){var t=e.type,i=e.memoizedProps,r=e.stateNode;try{e:switch(t){case"button":case"input":case"select":case"textarea":i.autoFocus&&r.focus();break e;case"img":i.src?r.src=i.src:i.srcSet&&(r.srcset=i.srcSet)}}catch(n){Fe(e,e.return,n)}}function hc(e,t,i){try{var r=e.stateNode;cp(r,e.type,i,t),r[Ye]=t}catch(n){Fe(e,e.return,n)}}function bf(e){return e.tag===5||e.tag===3||e.tag===26||e.tag===27&&yr(e.type)||e.tag===4}function dc(e){e:for(;;){for(;e.sibling===null;){if(e.return===null||bf(e.return))return null;e=e.return}for(e.sibling.return=e.return,e=e.sibling;e.tag!==5&&e.tag!==6&&e.tag!==18;){if(e.tag===27&&yr(e.type)||e.flags&2||e.child===null||e.tag===4)continue e;e.child.return=e,e=e.child}if(!(e.flags&2))return e.stateNode}}function fc(e,t,i){var r=e.tag;if(r===5||r===6)e=e.stateNode,t?(i.nodeType===9?i.body:i.nodeName==="HTML"?i.ownerDocument.body:i).insertBefore(e,t):(t=i.nodeType===9?i.body:i.nodeName==="HTML"?i.ownerDocument.body:i,t.appendChild(e),i=i._reactRootContainer,i!=null||t.onclick!==null||(t.onclick=dl));else if(r!==4&&(r===27&&yr(e.type)&&(i=e.stateNode,t=null),e=e.child,e!==null))for(fc(e,t,i),e=e.sibling;e!==null;)fc(e,t,i),e=e.sibling}function tl(e,t,i){var r=e.tag;if(r===5||r===6)e=e.stateNode,t?i.insertBefore(e,t):i.appendChild(e);else if(r!==4&&(r===27&&yr(e.type)&&(i=e.stateNode),e=e.child,e!==null))for(tl(e,t,i),e=e.sibling;e!==null;)tl(e,t,i),e=e.sibling}function yf(e){var t=e.stateNode,i=e.memoizedProps;try{for(var r=e.type,n=t.attributes;n.length;)t.removeAttributeNode(n[0]);St(t,r,i),t[Ge]=e,t[Ye]=i}catch(m){Fe(e,e.return,m)}}var Ki=!1,nt=!1,mc=!1,Sf=typeof WeakSet=="function"?WeakSet:Set,_t=null;function qg(e,t){if(e=e.containerInfo,zc=bl,e=Mh(e),ho(e)){if("selectionStart"in e)var i={start:e.selectionStart,end:e.selectionEnd};else e:{i=(i=e.ownerDocument)&&i.defaultView||window;var r=i.getSelection&&i.getSelection();if(r&&r.rangeCount!==0){i=r.anchorNode;var n=r.anchorOffset,m=r.focusNode;r=r.focusOffset;try{i.nodeType,m.nodeType}catch{i=null;break e}var L=0,H=-1,$=-1,ie=0,oe=0,he=e,se=null;t:for(;;){for(var ne;he!==i||n!==0&&he.nodeType!==3||(H=L+n),he!==m||r!==0&&he.nodeType!==3||($=L+r),he.nodeType===3&&(L+=he.nodeValue.length),(ne=he.firstChild)!==null;)se=he,he=ne;for(;;){if(he===e)break t;if(se===i&&++ie===n&&(H=L),se===m&&++oe===r&&($=L),(ne=he.nextSibling)!==null)break;he=se,se=he.parentNode}he=ne}i=H===-1||$===-1?null:{start:H,end:$}}else i=null}i=i||{start:0,end:0}}else i=null;for(Uc={focusedElem:e,selectionRange:i},bl=!1,_t=t;_t!==null;)if(t=_t,e=t.child,(t.subtreeFlags&1024)!==0&&e!==null)e.return=t,_t=e;else for(;_t!==null;){switch(t=_t,m=t.alternate,e=t.flags,t.tag){case 0:break;case 11:case 15:break;case 1:if((e&1024)!==0&&m!==null){e=void 0,i=t,n=m.memoizedProps,m=m.memoizedState,r=i.stateNode;try{var xe=Vr(i.type,n,i.elementType===i.type);e=r.getSnapshotBeforeUpdate(xe,m),r.__reactInternalSnapshotBeforeUpdate=e}catch(ye){Fe(i,i.return,ye)}}break;case 3:if((e&1024)!==0){if(e=t.stateNode.containerInfo,i=e.nodeType,i===9)Fc(e);else if(i===1)switch(e.nodeName){case"HEAD":case"HTML":case"BODY":Fc(e);break;default:e.textContent=""}}break;case 5:case 26:case 27:case 6:case 4:case 17:break;default:if((e&1024)!==0)throw Error(g(163))}if(e=t.sibling,e!==null){e.return=t.return,_t=e;break}_t=t.return}}function xf(e,t,i){var r=i.flags;switch(i.tag){case 0:case 11:case 15:fr(e,i),r&4&&wn(5,i);break;case 1:if(fr(e,i),r&4)if(e=i.stateNode,t===null)try{e.componentDidMount()}catch(L){Fe(i,i.return,L)}else{var n=Vr(i.type,t.memoizedProps);t=t.memoizedState;try{e.componentDidUpdate(n,t,e.__reactInternalSnapshotBeforeUpdate)}catch(L){Fe(i,i.return,L)}}r&64&&vf(i),r&512&&En(i,i.return);break;case 3:if(fr(e,i),r&64&&(e=i.updateQueue,e!==null)){if(t=null,i.child!==null)switch(i.child.tag){case 27:case 5:t=i.child.stateNode;break;case 1:t=i.child.stateNode}try{id(e,t)}catch(L){Fe(i,i.return,L)}}break;case 27:t===null&&r&4&&yf(i);case 26:case 5:fr(e,i),t===null&&r&4&&pf(i),r&512&&En(i,i.return);break;case 12:fr(e,i);break;case 13:fr(e,i),r&4&&Ef(e,i),r&64&&(e=i.memoizedState,e!==null&&(e=e.dehydrated,e!==null&&(i=Qg.bind(null,i),vp(e,i))));break;case 22:if(r=i.memoizedState!==null||Ki,!r){t=t!==null&&t.memoizedState!==null||nt,n=Ki;var m=nt;Ki=r,(nt=t)&&!m?mr(e,i,(i.subtreeFlags&8772)!==0):fr(e,i),Ki=n,nt=m}break;case 30:break;default:fr(e,i)}}function Cf(e){var t=e.alternate;t!==null&&(e.alternate=null,Cf(t)),e.child=null,e.deletions=null,e.sibling=null,e.tag===5&&(t=e.stateNode,t!==null&&Yl(t)),e.stateNode=null,e.return=null,e.dependencies=null,e.memoizedProps=null,e.memoizedState=null,e.pendingProps=null,e.stateNode=null,e.updateQueue=null}var We=null,zt=!1;function Xi(e,t,i){for(i=i.child;i!==null;)wf(e,t,i),i=i.sibling}function wf(e,t,i){if(Ke&&typeof Ke.onCommitFiberUnmount=="function")try{Ke.onCommitFiberUnmount(Vt,i)}catch{}switch(i.tag){case 26:nt||Ri(i,t),Xi(e,t,i),i.memoizedState?i.memoizedState.count--:i.stateNode&&(i=i.stateNode,i.parentNode.removeChild(i));break;case 27:nt||Ri(i,t);var r=We,n=zt;yr(i.type)&&(We=i.stateNode,zt=!1),Xi(e,t,i),Nn(i.stateNode),We=r,zt=n;break;case 5:nt||Ri(i,t);case 6:if(r=We,n=zt,We=null,Xi(e,t,i),We=r,zt=n,We!==null)if(zt)try{(We.nodeType===9?We.body:We.nodeName==="HTML"?We.ownerDocument.body:We).removeChild(i.stateNode)}catch(m){Fe(i,t,m)}else try{We.removeChild(i.stateNode)}catch(m){Fe(i,t,m)}break;case 18:We!==null&&(zt?(e=We,dm(e.nodeType===9?e.body:e.nodeName==="HTML"?e.ownerDocument.body:e,i.stateNode),Fn(e)):dm(We,i.stateNode));break;case 4:r=We,n=zt,We=i.stateNode.containerInfo,zt=!0,Xi(e,t,i),We=r,zt=n;break;case 0:case 11:case 14:case 15:nt||dr(2,i,t),nt||dr(4,i,t),Xi(e,t,i);break;case 1:nt||(Ri(i,t),r=i.stateNode,typeof r.componentWillUnmount=="function"&&gf(i,t,r)),Xi(e,t,i);break;case 21:Xi(e,t,i);break;case 22:nt=(r=nt)||i.memoizedState!==null,Xi(e,t,i),nt=r;break;default:Xi(e,t,i)}}function Ef(e,t){if(t.memoizedState===null&&(e=t.alternate,e!==null&&(e=e.memoizedState,e!==null&&(e=e.dehydrated,e!==null))))try{Fn(e)}catch(i){Fe(t,t.return,i)}}function Vg(e){switch(e.tag){case 13:case 19:var t=e.stateNode;return t===null&&(t=e.stateNode=new Sf),t;case 22:return e=e.stateNode,t=e._retryCache,t===null&&(t=e._retryCache=new Sf),t;default:throw Error(g(435,e.tag))}}function _c(e,t){var i=Vg(e);t.forEach(function(r){var n=Jg.bind(null,e,r);i.has(r)||(i.add(r),r.then(n,n))})}function Xt(e,t){var i=t.deletions;if(i!==null)for(var r=0;r<i.length;r++){var n=i[r],m=e,L=t,H=L;e:for(;H!==null;){switch(H.tag){case 27:if(yr(H.type)){We=H.stateNode,zt=!1;break e}break;case 5:We=H.stateNode,zt=!1;break e;case 3:case 4:We=H.stateNode.containerInfo,zt=!0;break e}H=H.return}if(We===null)throw Error(g(160));wf(m,L,n),We=null,zt=!1,m=n.alternate,m!==null&&(m.return=null),n.return=null}if(t.subtreeFlags&13878)for(t=t.child;t!==null;)kf(t,e),t=t.sibling}var pi=null;function kf(e,t){var i=e.alternate,r=e.flags;switch(e.tag){case 0:case 11:case 14:case 15:Xt(t,e),Wt(e),r&4&&(dr(3,e,e.return),wn(3,e),dr(5,e,e.return));break;case 1:Xt(t,e),Wt(e),r&512&&(nt||i===null||Ri(i,i.return)),r&64&&Ki&&(e=e.updateQueue,e!==null&&(r=e.callbacks,r!==null&&(i=e.shared.hiddenCallbacks,e.shared.hiddenCallbacks=i===null?r:i.concat(r))));break;case 26:var n=pi;if(Xt(t,e),Wt(e),r&512&&(nt||i===null||Ri(i,i.return)),r&4){var m=i!==null?i.memoizedState:null;if(r=e.memoizedState,i===null)if(r===null)if(e.stateNode===null){e:{r=e.type,i=e.memoizedProps,n=n.ownerDocument||n;t:switch(r){case"title":m=n.getElementsByTagName("title")[0],(!m||m[ji]||m[Ge]||m.namespaceURI==="http://www.w3.org/2000/svg"||m.hasAttribute("itemprop"))&&(m=n.createElement(r),n.head.insertBefore(m,n.querySelector("head > title"))),St(m,r,i),m[Ge]=e,ft(m),r=m;break e;case"link":var L=ym("link","href",n).get(r+(i.href||""));if(L){for(var H=0;H<L.length;H++)if(m=L[H],m.getAttribute("href")===(i.href==null||i.href===""?null:i.href)&&m.getAttribute("rel")===(i.rel==null?null:i.rel)&&m.getAttribute("title")===(i.title==null?null:i.title)&&m.getAttribute("crossorigin")===(i.crossOrigin==null?null:i.crossOrigin)){L.splice(H,1);break t}}m=n.createElement(r),St(m,r,i),n.head.appendChild(m);break;case"meta":if(L=ym("meta","content",n).get(r+(i.content||""))){for(H=0;H<L.length;H++)if(m=L[H],m.getAttribute("content")===(i.content==null?null:""+i.content)&&m.getAttribute("name")===(i.name==null?null:i.name)&&m.getAttribute("property")===(i.property==null?null:i.property)&&m.getAttribute("http-equiv")===(i.httpEquiv==null?null:i.httpEquiv)&&m.getAttribute("charset")===(i.charSet==null?null:i.charSet)){L.splice(H,1);break t}}m=n.createElement(r),St(m,r,i),n.head.appendChild(m);break;default:throw Error(g(468,r))}m[Ge]=e,ft(m),r=m}e.stateNode=r}else Sm(n,e.type,e.stateNode);else e.stateNode=bm(n,r,e.memoizedProps);else m!==r?(m===null?i.stateNode!==null&&(i=i.stateNode,i.parentNode.removeChild(i)):m.count--,r===null?Sm(n,e.type,e.stateNode):bm(n,r,e.memoizedProps)):r===null&&e.stateNode!==null&&hc(e,e.memoizedProps,i.memoizedProps)}break;case 27:Xt(t,e),Wt(e),r&512&&(nt||i===null||Ri(i,i.return)),i!==null&&r&4&&hc(e,e.memoizedProps,i.memoizedProps);break;case 5:if(Xt(t,e),Wt(e),r&512&&(nt||i===null||Ri(i,i.return)),e.flags&32){n=e.stateNode;try{ns(n,"")}catch(ne){Fe(e,e.return,ne)}}r&4&&e.stateNode!=null&&(n=e.memoizedProps,hc(e,n,i!==null?i.memoizedProps:n)),r&1024&&(mc=!0);break;case 6:if(Xt(t,e),Wt(e),r&4){if(e.stateNode===null)throw Error(g(162));r=e.memoizedProps,i=e.stateNode;try{i.nodeValue=r}catch(ne){Fe(e,e.return,ne)}}break;case 3:if(vl=null,n=pi,pi=ml(t.containerInfo),Xt(t,e),pi=n,Wt(e),r&4&&i!==null&&i.memoizedState.isDehydrated)try{Fn(t.containerInfo)}catch(ne){Fe(e,e.return,ne)}mc&&(mc=!1,Rf(e));break;case 4:r=pi,pi=ml(e.stateNode.containerInfo),Xt(t,e),Wt(e),pi=r;break;case 12:Xt(t,e),Wt(e);break;case 13:Xt(t,e),Wt(e),e.child.flags&8192&&e.memoizedState!==null!=(i!==null&&i.memoizedState!==null)&&(Sc=be()),r&4&&(r=e.updateQueue,r!==null&&(e.updateQueue=null,_c(e,r)));break;case 22:n=e.memoizedState!==null;var $=i!==null&&i.memoizedState!==null,ie=Ki,oe=nt;if(Ki=ie||n,nt=oe||$,Xt(t,e),nt=oe,Ki=ie,Wt(e),r&8192)e:for(t=e.stateNode,t._visibility=n?t._visibility&-2:t._visibility|1,n&&(i===null||$||Ki||nt||Gr(e)),i=null,t=e;;){if(t.tag===5||t.tag===26){if(i===null){$=i=t;try{if(m=$.stateNode,n)L=m.style,typeof L.setProperty=="function"?L.setProperty("display","none","important"):L.display="none";else{H=$.stateNode;var he=$.memoizedProps.style,se=he!=null&&he.hasOwnProperty("display")?he.display:null;H.style.display=se==null||typeof se=="boolean"?"":(""+se).trim()}}catch(ne){Fe($,$.return,ne)}}}else if(t.tag===6){if(i===null){$=t;try{$.stateNode.nodeValue=n?"":$.memoizedProps}catch(ne){Fe($,$.return,ne)}}}else if((t.tag!==22&&t.tag!==23||t.memoizedState===null||t===e)&&t.child!==null){t.child.return=t,t=t.child;continue}if(t===e)break e;for(;t.sibling===null;){if(t.return===null||t.return===e)break e;i===t&&(i=null),t=t.return}i===t&&(i=null),t.sibling.return=t.return,t=t.sibling}r&4&&(r=e.updateQueue,r!==null&&(i=r.retryQueue,i!==null&&(r.retryQueue=null,_c(e,i))));break;case 19:Xt(t,e),Wt(e),r&4&&(r=e.updateQueue,r!==null&&(e.updateQueue=null,_c(e,r)));break;case 30:break;case 21:break;default:Xt(t,e),Wt(e)}}function Wt(e){var t=e.flags;if(t&2){try{for(var i,r=e.return;r!==null;){if(bf(r)){i=r;break}r=r.return}if(i==null)throw Error(g(160));switch(i.tag){case 27:var n=i.stateNode,m=dc(e);tl(e,m,n);break;case 5:var L=i.stateNode;i.flags&32&&(ns(L,""),i.flags&=-33);var H=dc(e);tl(e,H,L);break;case 3:case 4:var $=i.stateNode.containerInfo,ie=dc(e);fc(e,ie,$);break;default:throw Error(g(161))}}catch(oe){Fe(e,e.return,oe)}e.flags&=-3}t&4096&&(e.flags&=-4097)}function Rf(e){if(e.subtreeFlags&1024)for(e=e.child;e!==null;){var t=e;Rf(t),t.tag===5&&t.flags&1024&&t.stateNode.reset(),e=e.sibling}}function fr(e,t){if(t.subtreeFlags&8772)for(t=t.child;t!==null;)xf(e,t.alternate,t),t=t.sibling}function Gr(e){for(e=e.child;e!==null;){var t=e;switch(t.tag){case 0:case 11:case 14:case 15:dr(4,t,t.return),Gr(t);break;case 1:Ri(t,t.return);var i=t.stateNode;typeof i.componentWillUnmount=="function"&&gf(t,t.return,i),Gr(t);break;case 27:Nn(t.stateNode);case 26:case 5:Ri(t,t.return),Gr(t);break;case 22:t.memoizedState===null&&Gr(t);break;case 30:Gr(t);break;default:Gr(t)}e=e.sibling}}function mr(e,t,i){for(i=i&&(t.subtreeFlags&8772)!==0,t=t.child;t!==null;){var r=t.alternate,n=e,m=t,L=m.flags;switch(m.tag){case 0:case 11:case 15:mr(n,m,i),wn(4,m);break;case 1:if(mr(n,m,i),r=m,n=r.stateNode,typeof n.componentDidMount=="function")try{n.componentDidMount()}catch(ie){Fe(r,r.return,ie)}if(r=m,n=r.updateQueue,n!==null){var H=r.stateNode;try{var $=n.shared.hiddenCallbacks;if($!==null)for(n.shared.hiddenCallbacks=null,n=0;n<$.length;n++)td($[n],H)}catch(ie){Fe(r,r.return,ie)}}i&&L&64&&vf(m),En(m,m.return);break;case 27:yf(m);case 26:case 5:mr(n,m,i),i&&r===null&&L&4&&pf(m),En(m,m.return);break;case 12:mr(n,m,i);break;case 13:mr(n,m,i),i&&L&4&&Ef(n,m);break;case 22:m.memoizedState===null&&mr(n,m,i),En(m,m.return);break;case 30:break;default:mr(n,m,i)}t=t.sibling}}function vc(e,t){var i=null;e!==null&&e.memoizedState!==null&&e.memoizedState.cachePool!==null&&(i=e.memoizedState.cachePool.pool),e=null,t.memoizedState!==null&&t.memoizedState.cachePool!==null&&(e=t.memoizedState.cachePool.pool),e!==i&&(e!=null&&e.refCount++,i!=null&&un(i))}function gc(e,t){e=null,t.alternate!==null&&(e=t.alternate.memoizedState.cache),t=t.memoizedState.cache,t!==e&&(t.refCount++,e!=null&&un(e))}function Di(e,t,i,r){if(t.subtreeFlags&10256)for(t=t.child;t!==null;)Df(e,t,i,r),t=t.sibling}function Df(e,t,i,r){var n=t.flags;switch(t.tag){case 0:case 11:case 15:Di(e,t,i,r),n&2048&&wn(9,t);break;case 1:Di(e,t,i,r);break;case 3:Di(e,t,i,r),n&2048&&(e=null,t.alternate!==null&&(e=t.alternate.memoizedState.cache),t=t.memoizedState.cache,t!==e&&(t.refCount++,e!=null&&un(e)));break;case 12:if(n&2048){Di(e,t,i,r),e=t.stateNode;try{var m=t.memoizedProps,L=m.id,H=m.onPostCommit;typeof H=="function"&&H(L,t.alternate===null?"mount":"update",e.passiveEffectDuration,-0)}catch($){Fe(t,t.return,$)}}else Di(e,t,i,r);break;case 13:Di(e,t,i,r);break;case 23:break;case 22:m=t.stateNode,L=t.alternate,t.memoizedState!==null?m._visibility&2?Di(e,t,i,r):kn(e,t):m._visibility&2?Di(e,t,i,r):(m._visibility|=2,ws(e,t,i,r,(t.subtreeFlags&10256)!==0)),n&2048&&vc(L,t);break;case 24:Di(e,t,i,r),n&2048&&gc(t.alternate,t);break;default:Di(e,t,i,r)}}function ws(e,t,i,r,n){for(n=n&&(t.subtreeFlags&10256)!==0,t=t.child;t!==null;){var m=e,L=t,H=i,$=r,ie=L.flags;switch(L.tag){case 0:case 11:case 15:ws(m,L,H,$,n),wn(8,L);break;case 23:break;case 22:var oe=L.stateNode;L.memoizedState!==null?oe._visibility&2?ws(m,L,H,$,n):kn(m,L):(oe._visibility|=2,ws(m,L,H,$,n)),n&&ie&2048&&vc(L.alternate,L);break;case 24:ws(m,L,H,$,n),n&&ie&2048&&gc(L.alternate,L);break;default:ws(m,L,H,$,n)}t=t.sibling}}function kn(e,t){if(t.subtreeFlags&10256)for(t=t.child;t!==null;){var i=e,r=t,n=r.flags;switch(r.tag){case 22:kn(i,r),n&2048&&vc(r.alternate,r);break;case 24:kn(i,r),n&2048&&gc(r.alternate,r);break;default:kn(i,r)}t=t.sibling}}var Rn=8192;function Es(e){if(e.subtreeFlags&Rn)for(e=e.child;e!==null;)Af(e),e=e.sibling}function Af(e){switch(e.tag){case 26:Es(e),e.flags&Rn&&e.memoizedState!==null&&Ap(pi,e.memoizedState,e.memoizedProps);break;case 5:Es(e);break;case 3:case 4:var t=pi;pi=ml(e.stateNode.containerInfo),Es(e),pi=t;break;case 22:e.memoizedState===null&&(t=e.alternate,t!==null&&t.memoizedState!==null?(t=Rn,Rn=16777216,Es(e),Rn=t):Es(e));break;default:Es(e)}}function Tf(e){var t=e.alternate;if(t!==null&&(e=t.child,e!==null)){t.child=null;do t=e.sibling,e.sibling=null,e=t;while(e!==null)}}function Dn(e){var t=e.deletions;if((e.flags&16)!==0){if(t!==null)for(var i=0;i<t.length;i++){var r=t[i];_t=r,Lf(r,e)}Tf(e)}if(e.subtreeFlags&10256)for(e=e.child;e!==null;)Mf(e),e=e.sibling}function Mf(e){switch(e.tag){case 0:case 11:case 15:Dn(e),e.flags&2048&&dr(9,e,e.return);break;case 3:Dn(e);break;case 12:Dn(e);break;case 22:var t=e.stateNode;e.memoizedState!==null&&t._visibility&2&&(e.return===null||e.return.tag!==13)?(t._visibility&=-3,il(e)):Dn(e);break;default:Dn(e)}}function il(e){var t=e.deletions;if((e.flags&16)!==0){if(t!==null)for(var i=0;i<t.length;i++){var r=t[i];_t=r,Lf(r,e)}Tf(e)}for(e=e.child;e!==null;){switch(t=e,t.tag){case 0:case 11:case 15:dr(8,t,t.return),il(t);break;case 22:i=t.stateNode,i._visibility&2&&(i._visibility&=-3,il(t));break;default:il(t)}e=e.sibling}}function Lf(e,t){for(;_t!==null;){var i=_t;switch(i.tag){case 0:case 11:case 15:dr(8,i,t);break;case 23:case 22:if(i.memoizedState!==null&&i.memoizedState.cachePool!==null){var r=i.memoizedState.cachePool.pool;r!=null&&r.refCount++}break;case 24:un(i.memoizedState.cache)}if(r=i.child,r!==null)r.return=i,_t=r;else e:for(i=e;_t!==null;){r=_t;var n=r.sibling,m=r.return;if(Cf(r),r===i){_t=null;break e}if(n!==null){n.return=m,_t=n;break e}_t=m}}}var Gg={getCacheForType:function(e){var t=kt(ot),i=t.data.get(e);return i===void 0&&(i=e(),t.data.set(e,i)),i}},Yg=typeof WeakMap=="function"?WeakMap:Map,je=0,Ve=null,Ae=null,Le=0,He=0,Zt=null,_r=!1,ks=!1,pc=!1,Wi=0,rt=0,vr=0,Yr=0,bc=0,ci=0,Rs=0,An=null,Ut=null,yc=!1,Sc=0,rl=1/0,sl=null,gr=null,yt=0,pr=null,Ds=null,As=0,xc=0,Cc=null,Of=null,Tn=0,wc=null;function Qt(){if((je&2)!==0&&Le!==0)return Le&-Le;if(I.T!==null){var e=vs;return e!==0?e:Mc()}return Yt()}function Nf(){ci===0&&(ci=(Le&536870912)===0||Be?ma():536870912);var e=oi.current;return e!==null&&(e.flags|=32),ci}function Jt(e,t,i){(e===Ve&&(He===2||He===9)||e.cancelPendingCommit!==null)&&(Ts(e,0),br(e,Le,ci,!1)),tt(e,i),((je&2)===0||e!==Ve)&&(e===Ve&&((je&2)===0&&(Yr|=i),rt===4&&br(e,Le,ci,!1)),Ai(e))}function Bf(e,t,i){if((je&6)!==0)throw Error(g(327));var r=!i&&(t&124)===0&&(t&e.expiredLanes)===0||Ar(e,t),n=r?Xg(e,t):Rc(e,t,!0),m=r;do{if(n===0){ks&&!r&&br(e,t,0,!1);break}else{if(i=e.current.alternate,m&&!$g(i)){n=Rc(e,t,!1),m=!1;continue}if(n===2){if(m=t,e.errorRecoveryDisabledLanes&m)var L=0;else L=e.pendingLanes&-536870913,L=L!==0?L:L&536870912?536870912:0;if(L!==0){t=L;e:{var H=e;n=An;var $=H.current.memoizedState.isDehydrated;if($&&(Ts(H,L).flags|=256),L=Rc(H,L,!1),L!==2){if(pc&&!$){H.errorRecoveryDisabledLanes|=m,Yr|=m,n=4;break e}m=Ut,Ut=n,m!==null&&(Ut===null?Ut=m:Ut.push.apply(Ut,m))}n=L}if(m=!1,n!==2)continue}}if(n===1){Ts(e,0),br(e,t,0,!0);break}e:{switch(r=e,m=n,m){case 0:case 1:throw Error(g(345));case 4:if((t&4194048)!==t)break;case 6:br(r,t,ci,!_r);break e;case 2:Ut=null;break;case 3:case 5:break;default:throw Error(g(329))}if((t&62914560)===t&&(n=Sc+300-be(),10<n)){if(br(r,t,ci,!_r),Dr(r,0,!0)!==0)break e;r.timeoutHandle=um(jf.bind(null,r,i,Ut,sl,yc,t,ci,Yr,Rs,_r,m,2,-0,0),n);break e}jf(r,i,Ut,sl,yc,t,ci,Yr,Rs,_r,m,0,-0,0)}}break}while(!0);Ai(e)}function jf(e,t,i,r,n,m,L,H,$,ie,oe,he,se,ne){if(e.timeoutHandle=-1,he=t.subtreeFlags,(he&8192||(he&16785408)===16785408)&&(Hn={stylesheets:null,count:0,unsuspend:Dp},Af(t),he=Tp(),he!==null)){e.cancelPendingCommit=he(qf.bind(null,e,t,m,i,r,n,L,H,$,oe,1,se,ne)),br(e,m,L,!ie);return}qf(e,t,m,i,r,n,L,H,$)}function $g(e){for(var t=e;;){var i=t.tag;if((i===0||i===11||i===15)&&t.flags&16384&&(i=t.updateQueue,i!==null&&(i=i.stores,i!==null)))for(var r=0;r<i.length;r++){var n=i[r],m=n.getSnapshot;n=n.value;try{if(!$t(m(),n))return!1}catch{return!1}}if(i=t.child,t.subtreeFlags&16384&&i!==null)i.return=t,t=i;else{if(t===e)break;for(;t.sibling===null;){if(t.return===null||t.return===e)return!0;t=t.return}t.sibling.return=t.return,t=t.sibling}}return!0}function br(e,t,i,r){t&=~bc,t&=~Yr,e.suspendedLanes|=t,e.pingedLanes&=~t,r&&(e.warmLanes|=t),r=e.expirationTimes;for(var n=t;0<n;){var m=31-Qe(n),L=1<<m;r[m]=-1,n&=~L}i!==0&&Ct(e,i,t)}function nl(){return(je&6)===0?(Mn(0),!1):!0}function Ec(){if(Ae!==null){if(He===0)var e=Ae.return;else e=Ae,Fi=Pr=null,Fo(e),xs=null,Sn=0,e=Ae;for(;e!==null;)_f(e.alternate,e),e=e.return;Ae=null}}function Ts(e,t){var i=e.timeoutHandle;i!==-1&&(e.timeoutHandle=-1,hp(i)),i=e.cancelPendingCommit,i!==null&&(e.cancelPendingCommit=null,i()),Ec(),Ve=e,Ae=i=Ui(e.current,null),Le=t,He=0,Zt=null,_r=!1,ks=Ar(e,t),pc=!1,Rs=ci=bc=Yr=vr=rt=0,Ut=An=null,yc=!1,(t&8)!==0&&(t|=t&32);var r=e.entangledLanes;if(r!==0)for(e=e.entanglements,r&=t;0<r;){var n=31-Qe(r),m=1<<n;t|=e[n],r&=~m}return Wi=t,Ra(),i}function Hf(e,t){ke=null,I.H=Ya,t===dn||t===ja?(t=Jh(),He=3):t===Wh?(t=Jh(),He=4):He=t===ef?8:t!==null&&typeof t=="object"&&typeof t.then=="function"?6:1,Zt=t,Ae===null&&(rt=1,Za(e,si(t,e.current)))}function zf(){var e=I.H;return I.H=Ya,e===null?Ya:e}function Uf(){var e=I.A;return I.A=Gg,e}function kc(){rt=4,_r||(Le&4194048)!==Le&&oi.current!==null||(ks=!0),(vr&134217727)===0&&(Yr&134217727)===0||Ve===null||br(Ve,Le,ci,!1)}function Rc(e,t,i){var r=je;je|=2;var n=zf(),m=Uf();(Ve!==e||Le!==t)&&(sl=null,Ts(e,t)),t=!1;var L=rt;e:do try{if(He!==0&&Ae!==null){var H=Ae,$=Zt;switch(He){case 8:Ec(),L=6;break e;case 3:case 2:case 9:case 6:oi.current===null&&(t=!0);var ie=He;if(He=0,Zt=null,Ms(e,H,$,ie),i&&ks){L=0;break e}break;default:ie=He,He=0,Zt=null,Ms(e,H,$,ie)}}Kg(),L=rt;break}catch(oe){Hf(e,oe)}while(!0);return t&&e.shellSuspendCounter++,Fi=Pr=null,je=r,I.H=n,I.A=m,Ae===null&&(Ve=null,Le=0,Ra()),L}function Kg(){for(;Ae!==null;)Pf(Ae)}function Xg(e,t){var i=je;je|=2;var r=zf(),n=Uf();Ve!==e||Le!==t?(sl=null,rl=be()+500,Ts(e,t)):ks=Ar(e,t);e:do try{if(He!==0&&Ae!==null){t=Ae;var m=Zt;t:switch(He){case 1:He=0,Zt=null,Ms(e,t,m,1);break;case 2:case 9:if(Zh(m)){He=0,Zt=null,If(t);break}t=function(){He!==2&&He!==9||Ve!==e||(He=7),Ai(e)},m.then(t,t);break e;case 3:He=7;break e;case 4:He=5;break e;case 7:Zh(m)?(He=0,Zt=null,If(t)):(He=0,Zt=null,Ms(e,t,m,7));break;case 5:var L=null;switch(Ae.tag){case 26:L=Ae.memoizedState;case 5:case 27:var H=Ae;if(!L||xm(L)){He=0,Zt=null;var $=H.sibling;if($!==null)Ae=$;else{var ie=H.return;ie!==null?(Ae=ie,al(ie)):Ae=null}break t}}He=0,Zt=null,Ms(e,t,m,5);break;case 6:He=0,Zt=null,Ms(e,t,m,6);break;case 8:Ec(),rt=6;break e;default:throw Error(g(462))}}Wg();break}catch(oe){Hf(e,oe)}while(!0);return Fi=Pr=null,I.H=r,I.A=n,je=i,Ae!==null?0:(Ve=null,Le=0,Ra(),rt)}function Wg(){for(;Ae!==null&&!Ze();)Pf(Ae)}function Pf(e){var t=ff(e.alternate,e,Wi);e.memoizedProps=e.pendingProps,t===null?al(e):Ae=t}function If(e){var t=e,i=t.alternate;switch(t.tag){case 15:case 0:t=lf(i,t,t.pendingProps,t.type,void 0,Le);break;case 11:t=lf(i,t,t.pendingProps,t.type.render,t.ref,Le);break;case 5:Fo(t);default:_f(i,t),t=Ae=Ih(t,Wi),t=ff(i,t,Wi)}e.memoizedProps=e.pendingProps,t===null?al(e):Ae=t}function Ms(e,t,i,r){Fi=Pr=null,Fo(t),xs=null,Sn=0;var n=t.return;try{if(Ug(e,n,t,i,Le)){rt=1,Za(e,si(i,e.current)),Ae=null;return}}catch(m){if(n!==null)throw Ae=n,m;rt=1,Za(e,si(i,e.current)),Ae=null;return}t.flags&32768?(Be||r===1?e=!0:ks||(Le&536870912)!==0?e=!1:(_r=e=!0,(r===2||r===9||r===3||r===6)&&(r=oi.current,r!==null&&r.tag===13&&(r.flags|=16384))),Ff(t,e)):al(t)}function al(e){var t=e;do{if((t.flags&32768)!==0){Ff(t,_r);return}e=t.return;var i=Ig(t.alternate,t,Wi);if(i!==null){Ae=i;return}if(t=t.sibling,t!==null){Ae=t;return}Ae=t=e}while(t!==null);rt===0&&(rt=5)}function Ff(e,t){do{var i=Fg(e.alternate,e);if(i!==null){i.flags&=32767,Ae=i;return}if(i=e.return,i!==null&&(i.flags|=32768,i.subtreeFlags=0,i.deletions=null),!t&&(e=e.sibling,e!==null)){Ae=e;return}Ae=e=i}while(e!==null);rt=6,Ae=null}function qf(e,t,i,r,n,m,L,H,$){e.cancelPendingCommit=null;do ll();while(yt!==0);if((je&6)!==0)throw Error(g(327));if(t!==null){if(t===e.current)throw Error(g(177));if(m=t.lanes|t.childLanes,m|=go,Ot(e,i,m,L,H,$),e===Ve&&(Ae=Ve=null,Le=0),Ds=t,pr=e,As=i,xc=m,Cc=n,Of=r,(t.subtreeFlags&10256)!==0||(t.flags&10256)!==0?(e.callbackNode=null,e.callbackPriority=0,ep(qt,function(){return Kf(),null})):(e.callbackNode=null,e.callbackPriority=0),r=(t.flags&13878)!==0,(t.subtreeFlags&13878)!==0||r){r=I.T,I.T=null,n=G.p,G.p=2,L=je,je|=4;try{qg(e,t,i)}finally{je=L,G.p=n,I.T=r}}yt=1,Vf(),Gf(),Yf()}}function Vf(){if(yt===1){yt=0;var e=pr,t=Ds,i=(t.flags&13878)!==0;if((t.subtreeFlags&13878)!==0||i){i=I.T,I.T=null;var r=G.p;G.p=2;var n=je;je|=4;try{kf(t,e);var m=Uc,L=Mh(e.containerInfo),H=m.focusedElem,$=m.selectionRange;if(L!==H&&H&&H.ownerDocument&&Th(H.ownerDocument.documentElement,H)){if($!==null&&ho(H)){var ie=$.start,oe=$.end;if(oe===void 0&&(oe=ie),"selectionStart"in H)H.selectionStart=ie,H.selectionEnd=Math.min(oe,H.value.length);else{var he=H.ownerDocument||document,se=he&&he.defaultView||window;if(se.getSelection){var ne=se.getSelection(),xe=H.textContent.length,ye=Math.min($.start,xe),Ie=$.end===void 0?ye:Math.min($.end,xe);!ne.extend&&ye>Ie&&(L=Ie,Ie=ye,ye=L);var Q=Ah(H,ye),X=Ah(H,Ie);if(Q&&X&&(ne.rangeCount!==1||ne.anchorNode!==Q.node||ne.anchorOffset!==Q.offset||ne.focusNode!==X.node||ne.focusOffset!==X.offset)){var te=he.createRange();te.setStart(Q.node,Q.offset),ne.removeAllRanges(),ye>Ie?(ne.addRange(te),ne.extend(X.node,X.offset)):(te.setEnd(X.node,X.offset),ne.addRange(te))}}}}for(he=[],ne=H;ne=ne.parentNode;)ne.nodeType===1&&he.push({element:ne,left:ne.scrollLeft,top:ne.scrollTop});for(typeof H.focus=="function"&&H.focus(),H=0;H<he.length;H++){var ce=he[H];ce.element.scrollLeft=ce.left,ce.element.scrollTop=ce.top}}bl=!!zc,Uc=zc=null}finally{je=n,G.p=r,I.T=i}}e.current=t,yt=2}}function Gf(){if(yt===2){yt=0;var e=pr,t=Ds,i=(t.flags&8772)!==0;if((t.subtreeFlags&8772)!==0||i){i=I.T,I.T=null;var r=G.p;G.p=2;var n=je;je|=4;try{xf(e,t.alternate,t)}finally{je=n,G.p=r,I.T=i}}yt=3}}function Yf(){if(yt===4||yt===3){yt=0,Mt();var e=pr,t=Ds,i=As,r=Of;(t.subtreeFlags&10256)!==0||(t.flags&10256)!==0?yt=5:(yt=0,Ds=pr=null,$f(e,e.pendingLanes));var n=e.pendingLanes;if(n===0&&(gr=null),pt(i),t=t.stateNode,Ke&&typeof Ke.onCommitFiberRoot=="function")try{Ke.onCommitFiberRoot(Vt,t,void 0,(t.current.flags&128)===128)}catch{}if(r!==null){t=I.T,n=G.p,G.p=2,I.T=null;try{for(var m=e.onRecoverableError,L=0;L<r.length;L++){var H=r[L];m(H.value,{componentStack:H.stack})}}finally{I.T=t,G.p=n}}(As&3)!==0&&ll(),Ai(e),n=e.pendingLanes,(i&4194090)!==0&&(n&42)!==0?e===wc?Tn++:(Tn=0,wc=e):Tn=0,Mn(0)}}function $f(e,t){(e.pooledCacheLanes&=t)===0&&(t=e.pooledCache,t!=null&&(e.pooledCache=null,un(t)))}function ll(e){return Vf(),Gf(),Yf(),Kf()}function Kf(){if(yt!==5)return!1;var e=pr,t=xc;xc=0;var i=pt(As),r=I.T,n=G.p;try{G.p=32>i?32:i,I.T=null,i=Cc,Cc=null;var m=pr,L=As;if(yt=0,Ds=pr=null,As=0,(je&6)!==0)throw Error(g(331));var H=je;if(je|=4,Mf(m.current),Df(m,m.current,L,i),je=H,Mn(0,!1),Ke&&typeof Ke.onPostCommitFiberRoot=="function")try{Ke.onPostCommitFiberRoot(Vt,m)}catch{}return!0}finally{G.p=n,I.T=r,$f(e,t)}}function Xf(e,t,i){t=si(i,t),t=ic(e.stateNode,t,2),e=or(e,t,2),e!==null&&(tt(e,2),Ai(e))}function Fe(e,t,i){if(e.tag===3)Xf(e,e,i);else for(;t!==null;){if(t.tag===3){Xf(t,e,i);break}else if(t.tag===1){var r=t.stateNode;if(typeof t.type.getDerivedStateFromError=="function"||typeof r.componentDidCatch=="function"&&(gr===null||!gr.has(r))){e=si(i,e),i=Qd(2),r=or(t,i,2),r!==null&&(Jd(i,r,t,e),tt(r,2),Ai(r));break}}t=t.return}}function Dc(e,t,i){var r=e.pingCache;if(r===null){r=e.pingCache=new Yg;var n=new Set;r.set(t,n)}else n=r.get(t),n===void 0&&(n=new Set,r.set(t,n));n.has(i)||(pc=!0,n.add(i),e=Zg.bind(null,e,t,i),t.then(e,e))}function Zg(e,t,i){var r=e.pingCache;r!==null&&r.delete(t),e.pingedLanes|=e.suspendedLanes&i,e.warmLanes&=~i,Ve===e&&(Le&i)===i&&(rt===4||rt===3&&(Le&62914560)===Le&&300>be()-Sc?(je&2)===0&&Ts(e,0):bc|=i,Rs===Le&&(Rs=0)),Ai(e)}function Wf(e,t){t===0&&(t=_a()),e=ds(e,t),e!==null&&(tt(e,t),Ai(e))}function Qg(e){var t=e.memoizedState,i=0;t!==null&&(i=t.retryLane),Wf(e,i)}function Jg(e,t){var i=0;switch(e.tag){case 13:var r=e.stateNode,n=e.memoizedState;n!==null&&(i=n.retryLane);break;case 19:r=e.stateNode;break;case 22:r=e.stateNode._retryCache;break;default:throw Error(g(314))}r!==null&&r.delete(t),Wf(e,i)}function ep(e,t){return Ee(e,t)}var ol=null,Ls=null,Ac=!1,cl=!1,Tc=!1,$r=0;function Ai(e){e!==Ls&&e.next===null&&(Ls===null?ol=Ls=e:Ls=Ls.next=e),cl=!0,Ac||(Ac=!0,ip())}function Mn(e,t){if(!Tc&&cl){Tc=!0;do for(var i=!1,r=ol;r!==null;){if(e!==0){var n=r.pendingLanes;if(n===0)var m=0;else{var L=r.suspendedLanes,H=r.pingedLanes;m=(1<<31-Qe(42|e)+1)-1,m&=n&~(L&~H),m=m&201326741?m&201326741|1:m?m|2:0}m!==0&&(i=!0,em(r,m))}else m=Le,m=Dr(r,r===Ve?m:0,r.cancelPendingCommit!==null||r.timeoutHandle!==-1),(m&3)===0||Ar(r,m)||(i=!0,em(r,m));r=r.next}while(i);Tc=!1}}function tp(){Zf()}function Zf(){cl=Ac=!1;var e=0;$r!==0&&(up()&&(e=$r),$r=0);for(var t=be(),i=null,r=ol;r!==null;){var n=r.next,m=Qf(r,t);m===0?(r.next=null,i===null?ol=n:i.next=n,n===null&&(Ls=i)):(i=r,(e!==0||(m&3)!==0)&&(cl=!0)),r=n}Mn(e)}function Qf(e,t){for(var i=e.suspendedLanes,r=e.pingedLanes,n=e.expirationTimes,m=e.pendingLanes&-62914561;0<m;){var L=31-Qe(m),H=1<<L,$=n[L];$===-1?((H&i)===0||(H&r)!==0)&&(n[L]=fa(H,t)):$<=t&&(e.expiredLanes|=H),m&=~H}if(t=Ve,i=Le,i=Dr(e,e===t?i:0,e.cancelPendingCommit!==null||e.timeoutHandle!==-1),r=e.callbackNode,i===0||e===t&&(He===2||He===9)||e.cancelPendingCommit!==null)return r!==null&&r!==null&&Ne(r),e.callbackNode=null,e.callbackPriority=0;if((i&3)===0||Ar(e,i)){if(t=i&-i,t===e.callbackPriority)return t;switch(r!==null&&Ne(r),pt(i)){case 2:case 8:i=Lt;break;case 32:i=qt;break;case 268435456:i=dt;break;default:i=qt}return r=Jf.bind(null,e),i=Ee(i,r),e.callbackPriority=t,e.callbackNode=i,t}return r!==null&&r!==null&&Ne(r),e.callbackPriority=2,e.callbackNode=null,2}function Jf(e,t){if(yt!==0&&yt!==5)return e.callbackNode=null,e.callbackPriority=0,null;var i=e.callbackNode;if(ll()&&e.callbackNode!==i)return null;var r=Le;return r=Dr(e,e===Ve?r:0,e.cancelPendingCommit!==null||e.timeoutHandle!==-1),r===0?null:(Bf(e,r,t),Qf(e,be()),e.callbackNode!=null&&e.callbackNode===i?Jf.bind(null,e):null)}function em(e,t){if(ll())return null;Bf(e,t,!0)}function ip(){dp(function(){(je&6)!==0?Ee(xi,tp):Zf()})}function Mc(){return $r===0&&($r=ma()),$r}function tm(e){return e==null||typeof e=="symbol"||typeof e=="boolean"?null:typeof e=="function"?e:ya(""+e)}function im(e,t){var i=t.ownerDocument.createElement("input");return i.name=t.name,i.value=t.value,e.id&&i.setAttribute("form",e.id),t.parentNode.insertBefore(i,t),e=new FormData(e),i.parentNode.removeChild(i),e}function rp(e,t,i,r,n){if(t==="submit"&&i&&i.stateNode===n){var m=tm((n[Ye]||null).action),L=r.submitter;L&&(t=(t=L[Ye]||null)?tm(t.formAction):L.getAttribute("formAction"),t!==null&&(m=t,L=null));var H=new wa("action","action",null,r,n);e.push({event:H,listeners:[{instance:null,listener:function(){if(r.defaultPrevented){if($r!==0){var $=L?im(n,L):new FormData(n);Zo(i,{pending:!0,data:$,method:n.method,action:m},null,$)}}else typeof m=="function"&&(H.preventDefault(),$=L?im(n,L):new FormData(n),Zo(i,{pending:!0,data:$,method:n.method,action:m},m,$))},currentTarget:n}]})}}for(var Lc=0;Lc<vo.length;Lc++){var Oc=vo[Lc],sp=Oc.toLowerCase(),np=Oc[0].toUpperCase()+Oc.slice(1);gi(sp,"on"+np)}gi(Nh,"onAnimationEnd"),gi(Bh,"onAnimationIteration"),gi(jh,"onAnimationStart"),gi("dblclick","onDoubleClick"),gi("focusin","onFocus"),gi("focusout","onBlur"),gi(xg,"onTransitionRun"),gi(Cg,"onTransitionStart"),gi(wg,"onTransitionCancel"),gi(Hh,"onTransitionEnd"),is("onMouseEnter",["mouseout","mouseover"]),is("onMouseLeave",["mouseout","mouseover"]),is("onPointerEnter",["pointerout","pointerover"]),is("onPointerLeave",["pointerout","pointerover"]),Mr("onChange","change click focusin focusout input keydown keyup selectionchange".split(" ")),Mr("onSelect","focusout contextmenu dragend focusin keydown keyup mousedown mouseup selectionchange".split(" ")),Mr("onBeforeInput",["compositionend","keypress","textInput","paste"]),Mr("onCompositionEnd","compositionend focusout keydown keypress keyup mousedown".split(" ")),Mr("onCompositionStart","compositionstart focusout keydown keypress keyup mousedown".split(" ")),Mr("onCompositionUpdate","compositionupdate focusout keydown keypress keyup mousedown".split(" "));var Ln="abort canplay canplaythrough durationchange emptied encrypted ended error loadeddata loadedmetadata loadstart pause play playing progress ratechange resize seeked seeking stalled suspend timeupdate volumechange waiting".split(" "),ap=new Set("beforetoggle cancel close invalid load scroll scrollend toggle".split(" ").concat(Ln));function rm(e,t){t=(t&4)!==0;for(var i=0;i<e.length;i++){var r=e[i],n=r.event;r=r.listeners;e:{var m=void 0;if(t)for(var L=r.length-1;0<=L;L--){var H=r[L],$=H.instance,ie=H.currentTarget;if(H=H.listener,$!==m&&n.isPropagationStopped())break e;m=H,n.currentTarget=ie;try{m(n)}catch(oe){Wa(oe)}n.currentTarget=null,m=$}else for(L=0;L<r.length;L++){if(H=r[L],$=H.instance,ie=H.currentTarget,H=H.listener,$!==m&&n.isPropagationStopped())break e;m=H,n.currentTarget=ie;try{m(n)}catch(oe){Wa(oe)}n.currentTarget=null,m=$}}}}function Te(e,t){var i=t[Bi];i===void 0&&(i=t[Bi]=new Set);var r=e+"__bubble";i.has(r)||(sm(t,e,2,!1),i.add(r))}function Nc(e,t,i){var r=0;t&&(r|=4),sm(i,e,r,t)}var ul="_reactListening"+Math.random().toString(36).slice(2);function Bc(e){if(!e[ul]){e[ul]=!0,Wu.forEach(function(i){i!=="selectionchange"&&(ap.has(i)||Nc(i,!1,e),Nc(i,!0,e))});var t=e.nodeType===9?e:e.ownerDocument;t===null||t[ul]||(t[ul]=!0,Nc("selectionchange",!1,t))}}function sm(e,t,i,r){switch(Dm(t)){case 2:var n=Op;break;case 8:n=Np;break;default:n=Xc}i=n.bind(null,t,i,e),n=void 0,!io||t!=="touchstart"&&t!=="touchmove"&&t!=="wheel"||(n=!0),r?n!==void 0?e.addEventListener(t,i,{capture:!0,passive:n}):e.addEventListener(t,i,!0):n!==void 0?e.addEventListener(t,i,{passive:n}):e.addEventListener(t,i,!1)}function jc(e,t,i,r,n){var m=r;if((t&1)===0&&(t&2)===0&&r!==null)e:for(;;){if(r===null)return;var L=r.tag;if(L===3||L===4){var H=r.stateNode.containerInfo;if(H===n)break;if(L===4)for(L=r.return;L!==null;){var $=L.tag;if(($===3||$===4)&&L.stateNode.containerInfo===n)return;L=L.return}for(;H!==null;){if(L=Jr(H),L===null)return;if($=L.tag,$===5||$===6||$===26||$===27){r=m=L;continue e}H=H.parentNode}}r=r.return}uh(function(){var ie=m,oe=eo(i),he=[];e:{var se=zh.get(e);if(se!==void 0){var ne=wa,xe=e;switch(e){case"keypress":if(xa(i)===0)break e;case"keydown":case"keyup":ne=eg;break;case"focusin":xe="focus",ne=ao;break;case"focusout":xe="blur",ne=ao;break;case"beforeblur":case"afterblur":ne=ao;break;case"click":if(i.button===2)break e;case"auxclick":case"dblclick":case"mousedown":case"mousemove":case"mouseup":case"mouseout":case"mouseover":case"contextmenu":ne=fh;break;case"drag":case"dragend":case"dragenter":case"dragexit":case"dragleave":case"dragover":case"dragstart":case"drop":ne=Fv;break;case"touchcancel":case"touchend":case"touchmove":case"touchstart":ne=rg;break;case Nh:case Bh:case jh:ne=Gv;break;case Hh:ne=ng;break;case"scroll":case"scrollend":ne=Pv;break;case"wheel":ne=lg;break;case"copy":case"cut":case"paste":ne=$v;break;case"gotpointercapture":case"lostpointercapture":case"pointercancel":case"pointerdown":case"pointermove":case"pointerout":case"pointerover":case"pointerup":ne=_h;break;case"toggle":case"beforetoggle":ne=cg}var ye=(t&4)!==0,Ie=!ye&&(e==="scroll"||e==="scrollend"),Q=ye?se!==null?se+"Capture":null:se;ye=[];for(var X=ie,te;X!==null;){var ce=X;if(te=ce.stateNode,ce=ce.tag,ce!==5&&ce!==26&&ce!==27||te===null||Q===null||(ce=Ws(X,Q),ce!=null&&ye.push(On(X,ce,te))),Ie)break;X=X.return}0<ye.length&&(se=new ne(se,xe,null,i,oe),he.push({event:se,listeners:ye}))}}if((t&7)===0){e:{if(se=e==="mouseover"||e==="pointerover",ne=e==="mouseout"||e==="pointerout",se&&i!==Jl&&(xe=i.relatedTarget||i.fromElement)&&(Jr(xe)||xe[Et]))break e;if((ne||se)&&(se=oe.window===oe?oe:(se=oe.ownerDocument)?se.defaultView||se.parentWindow:window,ne?(xe=i.relatedTarget||i.toElement,ne=ie,xe=xe?Jr(xe):null,xe!==null&&(Ie=T(xe),ye=xe.tag,xe!==Ie||ye!==5&&ye!==27&&ye!==6)&&(xe=null)):(ne=null,xe=ie),ne!==xe)){if(ye=fh,ce="onMouseLeave",Q="onMouseEnter",X="mouse",(e==="pointerout"||e==="pointerover")&&(ye=_h,ce="onPointerLeave",Q="onPointerEnter",X="pointer"),Ie=ne==null?se:Xs(ne),te=xe==null?se:Xs(xe),se=new ye(ce,X+"leave",ne,i,oe),se.target=Ie,se.relatedTarget=te,ce=null,Jr(oe)===ie&&(ye=new ye(Q,X+"enter",xe,i,oe),ye.target=te,ye.relatedTarget=Ie,ce=ye),Ie=ce,ne&&xe)t:{for(ye=ne,Q=xe,X=0,te=ye;te;te=Os(te))X++;for(te=0,ce=Q;ce;ce=Os(ce))te++;for(;0<X-te;)ye=Os(ye),X--;for(;0<te-X;)Q=Os(Q),te--;for(;X--;){if(ye===Q||Q!==null&&ye===Q.alternate)break t;ye=Os(ye),Q=Os(Q)}ye=null}else ye=null;ne!==null&&nm(he,se,ne,ye,!1),xe!==null&&Ie!==null&&nm(he,Ie,xe,ye,!0)}}e:{if(se=ie?Xs(ie):window,ne=se.nodeName&&se.nodeName.toLowerCase(),ne==="select"||ne==="input"&&se.type==="file")var me=Ch;else if(Sh(se))if(wh)me=bg;else{me=gg;var De=vg}else ne=se.nodeName,!ne||ne.toLowerCase()!=="input"||se.type!=="checkbox"&&se.type!=="radio"?ie&&Ql(ie.elementType)&&(me=Ch):me=pg;if(me&&(me=me(e,ie))){xh(he,me,i,oe);break e}De&&De(e,se,ie),e==="focusout"&&ie&&se.type==="number"&&ie.memoizedProps.value!=null&&Zl(se,"number",se.value)}switch(De=ie?Xs(ie):window,e){case"focusin":(Sh(De)||De.contentEditable==="true")&&(cs=De,fo=ie,nn=null);break;case"focusout":nn=fo=cs=null;break;case"mousedown":mo=!0;break;case"contextmenu":case"mouseup":case"dragend":mo=!1,Lh(he,i,oe);break;case"selectionchange":if(Sg)break;case"keydown":case"keyup":Lh(he,i,oe)}var ge;if(oo)e:{switch(e){case"compositionstart":var Se="onCompositionStart";break e;case"compositionend":Se="onCompositionEnd";break e;case"compositionupdate":Se="onCompositionUpdate";break e}Se=void 0}else os?bh(e,i)&&(Se="onCompositionEnd"):e==="keydown"&&i.keyCode===229&&(Se="onCompositionStart");Se&&(vh&&i.locale!=="ko"&&(os||Se!=="onCompositionStart"?Se==="onCompositionEnd"&&os&&(ge=hh()):(sr=oe,ro="value"in sr?sr.value:sr.textContent,os=!0)),De=hl(ie,Se),0<De.length&&(Se=new mh(Se,e,null,i,oe),he.push({event:Se,listeners:De}),ge?Se.data=ge:(ge=yh(i),ge!==null&&(Se.data=ge)))),(ge=hg?dg(e,i):fg(e,i))&&(Se=hl(ie,"onBeforeInput"),0<Se.length&&(De=new mh("onBeforeInput","beforeinput",null,i,oe),he.push({event:De,listeners:Se}),De.data=ge)),rp(he,e,ie,i,oe)}rm(he,t)})}function On(e,t,i){return{instance:e,listener:t,currentTarget:i}}function hl(e,t){for(var i=t+"Capture",r=[];e!==null;){var n=e,m=n.stateNode;if(n=n.tag,n!==5&&n!==26&&n!==27||m===null||(n=Ws(e,i),n!=null&&r.unshift(On(e,n,m)),n=Ws(e,t),n!=null&&r.push(On(e,n,m))),e.tag===3)return r;e=e.return}return[]}function Os(e){if(e===null)return null;do e=e.return;while(e&&e.tag!==5&&e.tag!==27);return e||null}function nm(e,t,i,r,n){for(var m=t._reactName,L=[];i!==null&&i!==r;){var H=i,$=H.alternate,ie=H.stateNode;if(H=H.tag,$!==null&&$===r)break;H!==5&&H!==26&&H!==27||ie===null||($=ie,n?(ie=Ws(i,m),ie!=null&&L.unshift(On(i,ie,$))):n||(ie=Ws(i,m),ie!=null&&L.push(On(i,ie,$)))),i=i.return}L.length!==0&&e.push({event:t,listeners:L})}var lp=/\r\n?/g,op=/\u0000|\uFFFD/g;function am(e){return(typeof e=="string"?e:""+e).replace(lp,`
 `).replace(op,"")}function lm(e,t){return t=am(t),am(e)===t}function dl(){}function Pe(e,t,i,r,n,m){switch(i){case"children":typeof r=="string"?t==="body"||t==="textarea"&&r===""||ns(e,r):(typeof r=="number"||typeof r=="bigint")&&t!=="body"&&ns(e,""+r);break;case"className":ga(e,"class",r);break;case"tabIndex":ga(e,"tabindex",r);break;case"dir":case"role":case"viewBox":case"width":case"height":ga(e,i,r);break;case"style":oh(e,r,m);break;case"data":if(t!=="object"){ga(e,"data",r);break}case"src":case"href":if(r===""&&(t!=="a"||i!=="href")){e.removeAttribute(i);break}if(r==null||typeof r=="function"||typeof r=="symbol"||typeof r=="boolean"){e.removeAttribute(i);break}r=ya(""+r),e.setAttribute(i,r);break;case"action":case"formAction":if(typeof r=="function"){e.setAttribute(i,"javascript:throw new Error('A React form was unexpectedly submitted. If you called form.submit() manually, consider using form.requestSubmit() instead. If you\\'re trying to use event.stopPropagation() in a submit event handler, consider also calling event.preventDefault().')");break}else typeof m=="function"&&(i==="formAction"?(t!=="input"&&Pe(e,t,"name",n.name,n,null),Pe(e,t,"formEncType",n.formEncType,n,null),Pe(e,t,"formMethod",n.formMethod,n,null),Pe(e,t,"formTarget",n.formTarget,n,null)):(Pe(e,t,"encType",n.encType,n,null),Pe(e,t,"method",n.method,n,null),Pe(e,t,"target",n.target,n,null)));if(r==null||typeof r=="symbol"||typeof r=="boolean"){e.removeAttribute(i);break}r=ya(""+r),e.setAttribute(i,r);break;case"onClick":r!=null&&(e.onclick=dl);break;case"onScroll":r!=null&&Te("scroll",e);break;case"onScrollEnd":r!=null&&Te("scrollend",e);break;case"dangerouslySetInnerHTML":if(r!=null){if(typeof r!="object"||!("__html"in r))throw Error(g(61));if(i=r.__html,i!=null){if(n.children!=null)throw Error(g(60));e.innerHTML=i}}break;case"multiple":e.multiple=r&&typeof r!="function"&&typeof r!="symbol";break;case"muted":e.muted=r&&typeof r!="function"&&typeof r!="symbol";break;case"suppressContentEditableWarning":case"suppressHydrationWarning":case"defaultValue":case"defaultChecked":case"innerHTML":case"ref":break;case"autoFocus":break;case"xlinkHref":if(r==null||typeof r=="function"||typeof r=="boolean"||typeof r=="symbol"){e.removeAttribute("xlink:href");break}i=ya(""+r),e.setAttributeNS("http://www.w3.org/1999/xlink","xlink:href",i);break;case"contentEditable":case"spellCheck":case"draggable":case"value":case"autoReverse":case"externalResourcesRequired":case"focusable":case"preserveAlpha":r!=null&&typeof r!="function"&&typeof r!="symbol"?e.setAttribute(i,""+r):e.removeAttribute(i);break;case"inert":case"allowFullScreen":case"async":case"autoPlay":case"controls":case"default":case"defer":case"disabled":case"disablePictureInPicture":case"disableRemotePlayback":case"formNoValidate":case"hidden":case"loop":case"noModule":case"noValidate":case"open":case"playsInline":case"readOnly":case"required":case"reversed":case"scoped":case"seamless":case"itemScope":r&&typeof r!="function"&&typeof r!="symbol"?e.setAttribute(i,""):e.removeAttribute(i);break;case"capture":case"download":r===!0?e.setAttribute(i,""):r!==!1&&r!=null&&typeof r!="function"&&typeof r!="symbol"?e.setAttribute(i,r):e.removeAttribute(i);break;case"cols":case"rows":case"size":case"span":r!=null&&typeof r!="function"&&typeof r!="symbol"&&!isNaN(r)&&1<=r?e.setAttribute(i,r):e.removeAttribute(i);break;case"rowSpan":case"start":r==null||typeof r=="function"||typeof r=="symbol"||isNaN(r)?e.removeAttribute(i):e.setAttribute(i,r);break;case"popover":Te("beforetoggle",e),Te("toggle",e),va(e,"popover",r);break;case"xlinkActuate":Hi(e,"http://www.w3.org/1999/xlink","xlink:actuate",r);break;case"xlinkArcrole":Hi(e,"http://www.w3.org/1999/xlink","xlink:arcrole",r);break;case"xlinkRole":Hi(e,"http://www.w3.org/1999/xlink","xlink:role",r);break;case"xlinkShow":Hi(e,"http://www.w3.org/1999/xlink","xlink:show",r);break;case"xlinkTitle":Hi(e,"http://www.w3.org/1999/xlink","xlink:title",r);break;case"xlinkType":Hi(e,"http://www.w3.org/1999/xlink","xlink:type",r);break;case"xmlBase":Hi(e,"http://www.w3.org/XML/1998/namespace","xml:base",r);break;case"xmlLang":Hi(e,"http://www.w3.org/XML/1998/namespace","xml:lang",r);break;case"xmlSpace":Hi(e,"http://www.w3.org/XML/1998/namespace","xml:space",r);break;case"is":va(e,"is",r);break;case"innerText":case"textContent":break;default:(!(2<i.length)||i[0]!=="o"&&i[0]!=="O"||i[1]!=="n"&&i[1]!=="N")&&(i=zv.get(i)||i,va(e,i,r))}}function Hc(e,t,i,r,n,m){switch(i){case"style":oh(e,r,m);break;case"dangerouslySetInnerHTML":if(r!=null){if(typeof r!="object"||!("__html"in r))throw Error(g(61));if(i=r.__html,i!=null){if(n.children!=null)throw Error(g(60));e.innerHTML=i}}break;case"children":typeof r=="string"?ns(e,r):(typeof r=="number"||typeof r=="bigint")&&ns(e,""+r);break;case"onScroll":r!=null&&Te("scroll",e);break;case"onScrollEnd":r!=null&&Te("scrollend",e);break;case"onClick":r!=null&&(e.onclick=dl);break;case"suppressContentEditableWarning":case"suppressHydrationWarning":case"innerHTML":case"ref":break;case"innerText":case"textContent":break;default:if(!Zu.hasOwnProperty(i))e:{if(i[0]==="o"&&i[1]==="n"&&(n=i.endsWith("Capture"),t=i.slice(2,n?i.length-7:void 0),m=e[Ye]||null,m=m!=null?m[i]:null,typeof m=="function"&&e.removeEventListener(t,m,n),typeof r=="function")){typeof m!="function"&&m!==null&&(i in e?e[i]=null:e.hasAttribute(i)&&e.removeAttribute(i)),e.addEventListener(t,r,n);break e}i in e?e[i]=r:r===!0?e.setAttribute(i,""):va(e,i,r)}}}function St(e,t,i){switch(t){case"div":case"span":case"svg":case"path":case"a":case"g":case"p":case"li":break;case"img":Te("error",e),Te("load",e);var r=!1,n=!1,m;for(m in i)if(i.hasOwnProperty(m)){var L=i[m];if(L!=null)switch(m){case"src":r=!0;break;case"srcSet":n=!0;break;case"children":case"dangerouslySetInnerHTML":throw Error(g(137,t));default:Pe(e,t,m,L,i,null)}}n&&Pe(e,t,"srcSet",i.srcSet,i,null),r&&Pe(e,t,"src",i.src,i,null);return;case"input":Te("invalid",e);var H=m=L=n=null,$=null,ie=null;for(r in i)if(i.hasOwnProperty(r)){var oe=i[r];if(oe!=null)switch(r){case"name":n=oe;break;case"type":L=oe;break;case"checked":$=oe;break;case"defaultChecked":ie=oe;break;case"value":m=oe;break;case"defaultValue":H=oe;break;case"children":case"dangerouslySetInnerHTML":if(oe!=null)throw Error(g(137,t));break;default:Pe(e,t,r,oe,i,null)}}sh(e,m,H,$,ie,L,n,!1),pa(e);return;case"select":Te("invalid",e),r=L=m=null;for(n in i)if(i.hasOwnProperty(n)&&(H=i[n],H!=null))switch(n){case"value":m=H;break;case"defaultValue":L=H;break;case"multiple":r=H;default:Pe(e,t,n,H,i,null)}t=m,i=L,e.multiple=!!r,t!=null?ss(e,!!r,t,!1):i!=null&&ss(e,!!r,i,!0);return;case"textarea":Te("invalid",e),m=n=r=null;for(L in i)if(i.hasOwnProperty(L)&&(H=i[L],H!=null))switch(L){case"value":r=H;break;case"defaultValue":n=H;break;case"children":m=H;break;case"dangerouslySetInnerHTML":if(H!=null)throw Error(g(91));break;default:Pe(e,t,L,H,i,null)}ah(e,r,n,m),pa(e);return;case"option":for($ in i)if(i.hasOwnProperty($)&&(r=i[$],r!=null))switch($){case"selected":e.selected=r&&typeof r!="function"&&typeof r!="symbol";break;default:Pe(e,t,$,r,i,null)}return;case"dialog":Te("beforetoggle",e),Te("toggle",e),Te("cancel",e),Te("close",e);break;case"iframe":case"object":Te("load",e);break;case"video":case"audio":for(r=0;r<Ln.length;r++)Te(Ln[r],e);break;case"image":Te("error",e),Te("load",e);break;case"details":Te("toggle",e);break;case"embed":case"source":case"link":Te("error",e),Te("load",e);case"area":case"base":case"br":case"col":case"hr":case"keygen":case"meta":case"param":case"track":case"wbr":case"menuitem":for(ie in i)if(i.hasOwnProperty(ie)&&(r=i[ie],r!=null))switch(ie){case"children":case"dangerouslySetInnerHTML":throw Error(g(137,t));default:Pe(e,t,ie,r,i,null)}return;default:if(Ql(t)){for(oe in i)i.hasOwnProperty(oe)&&(r=i[oe],r!==void 0&&Hc(e,t,oe,r,i,void 0));return}}for(H in i)i.hasOwnProperty(H)&&(r=i[H],r!=null&&Pe(e,t,H,r,i,null))}function cp(e,t,i,r){switch(t){case"div":case"span":case"svg":case"path":case"a":case"g":case"p":case"li":break;case"input":var n=null,m=null,L=null,H=null,$=null,ie=null,oe=null;for(ne in i){var he=i[ne];if(i.hasOwnProperty(ne)&&he!=null)switch(ne){case"checked":break;case"value":break;case"defaultValue":$=he;default:r.hasOwnProperty(ne)||Pe(e,t,ne,null,r,he)}}for(var se in r){var ne=r[se];if(he=i[se],r.hasOwnProperty(se)&&(ne!=null||he!=null))switch(se){case"type":m=ne;break;case"name":n=ne;break;case"checked":ie=ne;break;case"defaultChecked":oe=ne;break;case"value":L=ne;break;case"defaultValue":H=ne;break;case"children":case"dangerouslySetInnerHTML":if(ne!=null)throw Error(g(137,t));break;default:ne!==he&&Pe(e,t,se,ne,r,he)}}Wl(e,L,H,$,ie,oe,m,n);return;case"select":ne=L=H=se=null;for(m in i)if($=i[m],i.hasOwnProperty(m)&&$!=null)switch(m){case"value":break;case"multiple":ne=$;default:r.hasOwnProperty(m)||Pe(e,t,m,null,r,$)}for(n in r)if(m=r[n],$=i[n],r.hasOwnProperty(n)&&(m!=null||$!=null))switch(n){case"value":se=m;break;case"defaultValue":H=m;break;case"multiple":L=m;default:m!==$&&Pe(e,t,n,m,r,$)}t=H,i=L,r=ne,se!=null?ss(e,!!i,se,!1):!!r!=!!i&&(t!=null?ss(e,!!i,t,!0):ss(e,!!i,i?[]:"",!1));return;case"textarea":ne=se=null;for(H in i)if(n=i[H],i.hasOwnProperty(H)&&n!=null&&!r.hasOwnProperty(H))switch(H){case"value":break;case"children":break;default:Pe(e,t,H,null,r,n)}for(L in r)if(n=r[L],m=i[L],r.hasOwnProperty(L)&&(n!=null||m!=null))switch(L){case"value":se=n;break;case"defaultValue":ne=n;break;case"children":break;case"dangerouslySetInnerHTML":if(n!=null)throw Error(g(91));break;default:n!==m&&Pe(e,t,L,n,r,m)}nh(e,se,ne);return;case"option":for(var xe in i)if(se=i[xe],i.hasOwnProperty(xe)&&se!=null&&!r.hasOwnProperty(xe))switch(xe){case"selected":e.selected=!1;break;default:Pe(e,t,xe,null,r,se)}for($ in r)if(se=r[$],ne=i[$],r.hasOwnProperty($)&&se!==ne&&(se!=null||ne!=null))switch($){case"selected":e.selected=se&&typeof se!="function"&&typeof se!="symbol";break;default:Pe(e,t,$,se,r,ne)}return;case"img":case"link":case"area":case"base":case"br":case"col":case"embed":case"hr":case"keygen":case"meta":case"param":case"source":case"track":case"wbr":case"menuitem":for(var ye in i)se=i[ye],i.hasOwnProperty(ye)&&se!=null&&!r.hasOwnProperty(ye)&&Pe(e,t,ye,null,r,se);for(ie in r)if(se=r[ie],ne=i[ie],r.hasOwnProperty(ie)&&se!==ne&&(se!=null||ne!=null))switch(ie){case"children":case"dangerouslySetInnerHTML":if(se!=null)throw Error(g(137,t));break;default:Pe(e,t,ie,se,r,ne)}return;default:if(Ql(t)){for(var Ie in i)se=i[Ie],i.hasOwnProperty(Ie)&&se!==void 0&&!r.hasOwnProperty(Ie)&&Hc(e,t,Ie,void 0,r,se);for(oe in r)se=r[oe],ne=i[oe],!r.hasOwnProperty(oe)||se===ne||se===void 0&&ne===void 0||Hc(e,t,oe,se,r,ne);return}}for(var Q in i)se=i[Q],i.hasOwnProperty(Q)&&se!=null&&!r.hasOwnProperty(Q)&&Pe(e,t,Q,null,r,se);for(he in r)se=r[he],ne=i[he],!r.hasOwnProperty(he)||se===ne||se==null&&ne==null||Pe(e,t,he,se,r,ne)}var zc=null,Uc=null;function fl(e){return e.nodeType===9?e:e.ownerDocument}function om(e){switch(e){case"http://www.w3.org/2000/svg":return 1;case"http://www.w3.org/1998/Math/MathML":return 2;default:return 0}}function cm(e,t){if(e===0)switch(t){case"svg":return 1;case"math":return 2;default:return 0}return e===1&&t==="foreignObject"?0:e}function Pc(e,t){return e==="textarea"||e==="noscript"||typeof t.children=="string"||typeof t.children=="number"||typeof t.children=="bigint"||typeof t.dangerouslySetInnerHTML=="object"&&t.dangerouslySetInnerHTML!==null&&t.dangerouslySetInnerHTML.__html!=null}var Ic=null;function up(){var e=window.event;return e&&e.type==="popstate"?e===Ic?!1:(Ic=e,!0):(Ic=null,!1)}var um=typeof setTimeout=="function"?setTimeout:void 0,hp=typeof clearTimeout=="function"?clearTimeout:void 0,hm=typeof Promise=="function"?Promise:void 0,dp=typeof queueMicrotask=="function"?queueMicrotask:typeof hm<"u"?function(e){return hm.resolve(null).then(e).catch(fp)}:um;function fp(e){setTimeout(function(){throw e})}function yr(e){return e==="head"}function dm(e,t){var i=t,r=0,n=0;do{var m=i.nextSibling;if(e.removeChild(i),m&&m.nodeType===8)if(i=m.data,i==="/$"){if(0<r&&8>r){i=r;var L=e.ownerDocument;if(i&1&&Nn(L.documentElement),i&2&&Nn(L.body),i&4)for(i=L.head,Nn(i),L=i.firstChild;L;){var H=L.nextSibling,$=L.nodeName;L[ji]||$==="SCRIPT"||$==="STYLE"||$==="LINK"&&L.rel.toLowerCase()==="stylesheet"||i.removeChild(L),L=H}}if(n===0){e.removeChild(m),Fn(t);return}n--}else i==="$"||i==="$?"||i==="$!"?n++:r=i.charCodeAt(0)-48;else r=0;i=m}while(i);Fn(t)}function Fc(e){var t=e.firstChild;for(t&&t.nodeType===10&&(t=t.nextSibling);t;){var i=t;switch(t=t.nextSibling,i.nodeName){case"HTML":case"HEAD":case"BODY":Fc(i),Yl(i);continue;case"SCRIPT":case"STYLE":continue;case"LINK":if(i.rel.toLowerCase()==="stylesheet")continue}e.removeChild(i)}}function mp(e,t,i,r){for(;e.nodeType===1;){var n=i;if(e.nodeName.toLowerCase()!==t.toLowerCase()){if(!r&&(e.nodeName!=="INPUT"||e.type!=="hidden"))break}else if(r){if(!e[ji])switch(t){case"meta":if(!e.hasAttribute("itemprop"))break;return e;case"link":if(m=e.getAttribute("rel"),m==="stylesheet"&&e.hasAttribute("data-precedence"))break;if(m!==n.rel||e.getAttribute("href")!==(n.href==null||n.href===""?null:n.href)||e.getAttribute("crossorigin")!==(n.crossOrigin==null?null:n.crossOrigin)||e.getAttribute("title")!==(n.title==null?null:n.title))break;return e;case"style":if(e.hasAttribute("data-precedence"))break;return e;case"script":if(m=e.getAttribute("src"),(m!==(n.src==null?null:n.src)||e.getAttribute("type")!==(n.type==null?null:n.type)||e.getAttribute("crossorigin")!==(n.crossOrigin==null?null:n.crossOrigin))&&m&&e.hasAttribute("async")&&!e.hasAttribute("itemprop"))break;return e;default:return e}}else if(t==="input"&&e.type==="hidden"){var m=n.name==null?null:""+n.name;if(n.type==="hidden"&&e.getAttribute("name")===m)return e}else return e;if(e=bi(e.nextSibling),e===null)break}return null}function _p(e,t,i){if(t==="")return null;for(;e.nodeType!==3;)if((e.nodeType!==1||e.nodeName!=="INPUT"||e.type!=="hidden")&&!i||(e=bi(e.nextSibling),e===null))return null;return e}function qc(e){return e.data==="$!"||e.data==="$?"&&e.ownerDocument.readyState==="complete"}function vp(e,t){var i=e.ownerDocument;if(e.data!=="$?"||i.readyState==="complete")t();else{var r=function(){t(),i.removeEventListener("DOMContentLoaded",r)};i.addEventListener("DOMContentLoaded",r),e._reactRetry=r}}function bi(e){for(;e!=null;e=e.nextSibling){var t=e.nodeType;if(t===1||t===3)break;if(t===8){if(t=e.data,t==="$"||t==="$!"||t==="$?"||t==="F!"||t==="F")break;if(t==="/$")return null}}return e}var Vc=null;function fm(e){e=e.previousSibling;for(var t=0;e;){if(e.nodeType===8){var i=e.data;if(i==="$"||i==="$!"||i==="$?"){if(t===0)return e;t--}else i==="/$"&&t++}e=e.previousSibling}return null}function mm(e,t,i){switch(t=fl(i),e){case"html":if(e=t.documentElement,!e)throw Error(g(452));return e;case"head":if(e=t.head,!e)throw Error(g(453));return e;case"body":if(e=t.body,!e)throw Error(g(454));return e;default:throw Error(g(451))}}function Nn(e){for(var t=e.attributes;t.length;)e.removeAttributeNode(t[0]);Yl(e)}var ui=new Map,_m=new Set;function ml(e){return typeof e.getRootNode=="function"?e.getRootNode():e.nodeType===9?e:e.ownerDocument}var Zi=G.d;G.d={f:gp,r:pp,D:bp,C:yp,L:Sp,m:xp,X:wp,S:Cp,M:Ep};function gp(){var e=Zi.f(),t=nl();return e||t}function pp(e){var t=es(e);t!==null&&t.tag===5&&t.type==="form"?Nd(t):Zi.r(e)}var Ns=typeof document>"u"?null:document;function vm(e,t,i){var r=Ns;if(r&&typeof t=="string"&&t){var n=ri(t);n='link[rel="'+e+'"][href="'+n+'"]',typeof i=="string"&&(n+='[crossorigin="'+i+'"]'),_m.has(n)||(_m.add(n),e={rel:e,crossOrigin:i,href:t},r.querySelector(n)===null&&(t=r.createElement("link"),St(t,"link",e),ft(t),r.head.appendChild(t)))}}function bp(e){Zi.D(e),vm("dns-prefetch",e,null)}function yp(e,t){Zi.C(e,t),vm("preconnect",e,t)}function Sp(e,t,i){Zi.L(e,t,i);var r=Ns;if(r&&e&&t){var n='link[rel="preload"][as="'+ri(t)+'"]';t==="image"&&i&&i.imageSrcSet?(n+='[imagesrcset="'+ri(i.imageSrcSet)+'"]',typeof i.imageSizes=="string"&&(n+='[imagesizes="'+ri(i.imageSizes)+'"]')):n+='[href="'+ri(e)+'"]';var m=n;switch(t){case"style":m=Bs(e);break;case"script":m=js(e)}ui.has(m)||(e=p({rel:"preload",href:t==="image"&&i&&i.imageSrcSet?void 0:e,as:t},i),ui.set(m,e),r.querySelector(n)!==null||t==="style"&&r.querySelector(Bn(m))||t==="script"&&r.querySelector(jn(m))||(t=r.createElement("link"),St(t,"link",e),ft(t),r.head.appendChild(t)))}}function xp(e,t){Zi.m(e,t);var i=Ns;if(i&&e){var r=t&&typeof t.as=="string"?t.as:"script",n='link[rel="modulepreload"][as="'+ri(r)+'"][href="'+ri(e)+'"]',m=n;switch(r){case"audioworklet":case"paintworklet":case"serviceworker":case"sharedworker":case"worker":case"script":m=js(e)}if(!ui.has(m)&&(e=p({rel:"modulepreload",href:e},t),ui.set(m,e),i.querySelector(n)===null)){switch(r){case"audioworklet":case"paintworklet":case"serviceworker":case"sharedworker":case"worker":case"script":if(i.querySelector(jn(m)))return}r=i.createElement("link"),St(r,"link",e),ft(r),i.head.appendChild(r)}}}function Cp(e,t,i){Zi.S(e,t,i);var r=Ns;if(r&&e){var n=ts(r).hoistableStyles,m=Bs(e);t=t||"default";var L=n.get(m);if(!L){var H={loading:0,preload:null};if(L=r.querySelector(Bn(m)))H.loading=5;else{e=p({rel:"stylesheet",href:e,"data-precedence":t},i),(i=ui.get(m))&&Gc(e,i);var $=L=r.createElement("link");ft($),St($,"link",e),$._p=new Promise(function(ie,oe){$.onload=ie,$.onerror=oe}),$.addEventListener("load",function(){H.loading|=1}),$.addEventListener("error",function(){H.loading|=2}),H.loading|=4,_l(L,t,r)}L={type:"stylesheet",instance:L,count:1,state:H},n.set(m,L)}}}function wp(e,t){Zi.X(e,t);var i=Ns;if(i&&e){var r=ts(i).hoistableScripts,n=js(e),m=r.get(n);m||(m=i.querySelector(jn(n)),m||(e=p({src:e,async:!0},t),(t=ui.get(n))&&Yc(e,t),m=i.createElement("script"),ft(m),St(m,"link",e),i.head.appendChild(m)),m={type:"script",instance:m,count:1,state:null},r.set(n,m))}}function Ep(e,t){Zi.M(e,t);var i=Ns;if(i&&e){var r=ts(i).hoistableScripts,n=js(e),m=r.get(n);m||(m=i.querySelector(jn(n)),m||(e=p({src:e,async:!0,type:"module"},t),(t=ui.get(n))&&Yc(e,t),m=i.createElement("script"),ft(m),St(m,"link",e),i.head.appendChild(m)),m={type:"script",instance:m,count:1,state:null},r.set(n,m))}}function gm(e,t,i,r){var n=(n=fe.current)?ml(n):null;if(!n)throw Error(g(446));switch(e){case"meta":case"title":return null;case"style":return typeof i.precedence=="string"&&typeof i.href=="string"?(t=Bs(i.href),i=ts(n).hoistableStyles,r=i.get(t),r||(r={type:"style",instance:null,count:0,state:null},i.set(t,r)),r):{type:"void",instance:null,count:0,state:null};case"link":if(i.rel==="stylesheet"&&typeof i.href=="string"&&typeof i.precedence=="string"){e=Bs(i.href);var m=ts(n).hoistableStyles,L=m.get(e);if(L||(n=n.ownerDocument||n,L={type:"stylesheet",instance:null,count:0,state:{loading:0,preload:null}},m.set(e,L),(m=n.querySelector(Bn(e)))&&!m._p&&(L.instance=m,L.state.loading=5),ui.has(e)||(i={rel:"preload",as:"style",href:i.href,crossOrigin:i.crossOrigin,integrity:i.integrity,media:i.media,hrefLang:i.hrefLang,referrerPolicy:i.referrerPolicy},ui.set(e,i),m||kp(n,e,i,L.state))),t&&r===null)throw Error(g(528,""));return L}if(t&&r!==null)throw Error(g(529,""));return null;case"script":return t=i.async,i=i.src,typeof i=="string"&&t&&typeof t!="function"&&typeof t!="symbol"?(t=js(i),i=ts(n).hoistableScripts,r=i.get(t),r||(r={type:"script",instance:null,count:0,state:null},i.set(t,r)),r):{type:"void",instance:null,count:0,state:null};default:throw Error(g(444,e))}}function Bs(e){return'href="'+ri(e)+'"'}function Bn(e){return'link[rel="stylesheet"]['+e+"]"}function pm(e){return p({},e,{"data-precedence":e.precedence,precedence:null})}function kp(e,t,i,r){e.querySelector('link[rel="preload"][as="style"]['+t+"]")?r.loading=1:(t=e.createElement("link"),r.preload=t,t.addEventListener("load",function(){return r.loading|=1}),t.addEventListener("error",function(){return r.loading|=2}),St(t,"link",i),ft(t),e.head.appendChild(t))}function js(e){return'[src="'+ri(e)+'"]'}function jn(e){return"script[async]"+e}function bm(e,t,i){if(t.count++,t.instance===null)switch(t.type){case"style":var r=e.querySelector('style[data-href~="'+ri(i.href)+'"]');if(r)return t.instance=r,ft(r),r;var n=p({},i,{"data-href":i.href,"data-precedence":i.precedence,href:null,precedence:null});return r=(e.ownerDocument||e).createElement("style"),ft(r),St(r,"style",n),_l(r,i.precedence,e),t.instance=r;case"stylesheet":n=Bs(i.href);var m=e.querySelector(Bn(n));if(m)return t.state.loading|=4,t.instance=m,ft(m),m;r=pm(i),(n=ui.get(n))&&Gc(r,n),m=(e.ownerDocument||e).createElement("link"),ft(m);var L=m;return L._p=new Promise(function(H,$){L.onload=H,L.onerror=$}),St(m,"link",r),t.state.loading|=4,_l(m,i.precedence,e),t.instance=m;case"script":return m=js(i.src),(n=e.querySelector(jn(m)))?(t.instance=n,ft(n),n):(r=i,(n=ui.get(m))&&(r=p({},i),Yc(r,n)),e=e.ownerDocument||e,n=e.createElement("script"),ft(n),St(n,"link",r),e.head.appendChild(n),t.instance=n);case"void":return null;default:throw Error(g(443,t.type))}else t.type==="stylesheet"&&(t.state.loading&4)===0&&(r=t.instance,t.state.loading|=4,_l(r,i.precedence,e));return t.instance}function _l(e,t,i){for(var r=i.querySelectorAll('link[rel="stylesheet"][data-precedence],style[data-precedence]'),n=r.length?r[r.length-1]:null,m=n,L=0;L<r.length;L++){var H=r[L];if(H.dataset.precedence===t)m=H;else if(m!==n)break}m?m.parentNode.insertBefore(e,m.nextSibling):(t=i.nodeType===9?i.head:i,t.insertBefore(e,t.firstChild))}function Gc(e,t){e.crossOrigin==null&&(e.crossOrigin=t.crossOrigin),e.referrerPolicy==null&&(e.referrerPolicy=t.referrerPolicy),e.title==null&&(e.title=t.title)}function Yc(e,t){e.crossOrigin==null&&(e.crossOrigin=t.crossOrigin),e.referrerPolicy==null&&(e.referrerPolicy=t.referrerPolicy),e.integrity==null&&(e.integrity=t.integrity)}var vl=null;function ym(e,t,i){if(vl===null){var r=new Map,n=vl=new Map;n.set(i,r)}else n=vl,r=n.get(i),r||(r=new Map,n.set(i,r));if(r.has(e))return r;for(r.set(e,null),i=i.getElementsByTagName(e),n=0;n<i.length;n++){var m=i[n];if(!(m[ji]||m[Ge]||e==="link"&&m.getAttribute("rel")==="stylesheet")&&m.namespaceURI!=="http://www.w3.org/2000/svg"){var L=m.getAttribute(t)||"";L=e+L;var H=r.get(L);H?H.push(m):r.set(L,[m])}}return r}function Sm(e,t,i){e=e.ownerDocument||e,e.head.insertBefore(i,t==="title"?e.querySelector("head > title"):null)}function Rp(e,t,i){if(i===1||t.itemProp!=null)return!1;switch(e){case"meta":case"title":return!0;case"style":if(typeof t.precedence!="string"||typeof t.href!="string"||t.href==="")break;return!0;case"link":if(typeof t.rel!="string"||typeof t.href!="string"||t.href===""||t.onLoad||t.onError)break;switch(t.rel){case"stylesheet":return e=t.disabled,typeof t.precedence=="string"&&e==null;default:return!0}case"script":if(t.async&&typeof t.async!="function"&&typeof t.async!="symbol"&&!t.onLoad&&!t.onError&&t.src&&typeof t.src=="string")return!0}return!1}function xm(e){return!(e.type==="stylesheet"&&(e.state.loading&3)===0)}var Hn=null;function Dp(){}function Ap(e,t,i){if(Hn===null)throw Error(g(475));var r=Hn;if(t.type==="stylesheet"&&(typeof i.media!="string"||matchMedia(i.media).matches!==!1)&&(t.state.loading&4)===0){if(t.instance===null){var n=Bs(i.href),m=e.querySelector(Bn(n));if(m){e=m._p,e!==null&&typeof e=="object"&&typeof e.then=="function"&&(r.count++,r=gl.bind(r),e.then(r,r)),t.state.loading|=4,t.instance=m,ft(m);return}m=e.ownerDocument||e,i=pm(i),(n=ui.get(n))&&Gc(i,n),m=m.createElement("link"),ft(m);var L=m;L._p=new Promise(function(H,$){L.onload=H,L.onerror=$}),St(m,"link",i),t.instance=m}r.stylesheets===null&&(r.stylesheets=new Map),r.stylesheets.set(t,e),(e=t.state.preload)&&(t.state.loading&3)===0&&(r.count++,t=gl.bind(r),e.addEventListener("load",t),e.addEventListener("error",t))}}function Tp(){if(Hn===null)throw Error(g(475));var e=Hn;return e.stylesheets&&e.count===0&&$c(e,e.stylesheets),0<e.count?function(t){var i=setTimeout(function(){if(e.stylesheets&&$c(e,e.stylesheets),e.unsuspend){var r=e.unsuspend;e.unsuspend=null,r()}},6e4);return e.unsuspend=t,function(){e.unsuspend=null,clearTimeout(i)}}:null}function gl(){if(this.count--,this.count===0){if(this.stylesheets)$c(this,this.stylesheets);else if(this.unsuspend){var e=this.unsuspend;this.unsuspend=null,e()}}}var pl=null;function $c(e,t){e.stylesheets=null,e.unsuspend!==null&&(e.count++,pl=new Map,t.forEach(Mp,e),pl=null,gl.call(e))}function Mp(e,t){if(!(t.state.loading&4)){var i=pl.get(e);if(i)var r=i.get(null);else{i=new Map,pl.set(e,i);for(var n=e.querySelectorAll("link[data-precedence],style[data-precedence]"),m=0;m<n.length;m++){var L=n[m];(L.nodeName==="LINK"||L.getAttribute("media")!=="not all")&&(i.set(L.dataset.precedence,L),r=L)}r&&i.set(null,r)}n=t.instance,L=n.getAttribute("data-precedence"),m=i.get(L)||r,m===r&&i.set(null,n),i.set(L,n),this.count++,r=gl.bind(this),n.addEventListener("load",r),n.addEventListener("error",r),m?m.parentNode.insertBefore(n,m.nextSibling):(e=e.nodeType===9?e.head:e,e.insertBefore(n,e.firstChild)),t.state.loading|=4}}var zn={$$typeof:f,Provider:null,Consumer:null,_currentValue:A,_currentValue2:A,_threadCount:0};function Lp(e,t,i,r,n,m,L,H){this.tag=1,this.containerInfo=e,this.pingCache=this.current=this.pendingChildren=null,this.timeoutHandle=-1,this.callbackNode=this.next=this.pendingContext=this.context=this.cancelPendingCommit=null,this.callbackPriority=0,this.expirationTimes=Ks(-1),this.entangledLanes=this.shellSuspendCounter=this.errorRecoveryDisabledLanes=this.expiredLanes=this.warmLanes=this.pingedLanes=this.suspendedLanes=this.pendingLanes=0,this.entanglements=Ks(0),this.hiddenUpdates=Ks(null),this.identifierPrefix=r,this.onUncaughtError=n,this.onCaughtError=m,this.onRecoverableError=L,this.pooledCache=null,this.pooledCacheLanes=0,this.formState=H,this.incompleteTransitions=new Map}function Cm(e,t,i,r,n,m,L,H,$,ie,oe,he){return e=new Lp(e,t,i,L,H,$,ie,he),t=1,m===!0&&(t|=24),m=Kt(3,null,null,t),e.current=m,m.stateNode=e,t=Do(),t.refCount++,e.pooledCache=t,t.refCount++,m.memoizedState={element:r,isDehydrated:i,cache:t},Lo(m),e}function wm(e){return e?(e=fs,e):fs}function Em(e,t,i,r,n,m){n=wm(n),r.context===null?r.context=n:r.pendingContext=n,r=lr(t),r.payload={element:i},m=m===void 0?null:m,m!==null&&(r.callback=m),i=or(e,r,t),i!==null&&(Jt(i,e,t),mn(i,e,t))}function km(e,t){if(e=e.memoizedState,e!==null&&e.dehydrated!==null){var i=e.retryLane;e.retryLane=i!==0&&i<t?i:t}}function Kc(e,t){km(e,t),(e=e.alternate)&&km(e,t)}function Rm(e){if(e.tag===13){var t=ds(e,67108864);t!==null&&Jt(t,e,67108864),Kc(e,67108864)}}var bl=!0;function Op(e,t,i,r){var n=I.T;I.T=null;var m=G.p;try{G.p=2,Xc(e,t,i,r)}finally{G.p=m,I.T=n}}function Np(e,t,i,r){var n=I.T;I.T=null;var m=G.p;try{G.p=8,Xc(e,t,i,r)}finally{G.p=m,I.T=n}}function Xc(e,t,i,r){if(bl){var n=Wc(r);if(n===null)jc(e,t,r,yl,i),Am(e,r);else if(jp(n,e,t,i,r))r.stopPropagation();else if(Am(e,r),t&4&&-1<Bp.indexOf(e)){for(;n!==null;){var m=es(n);if(m!==null)switch(m.tag){case 3:if(m=m.stateNode,m.current.memoizedState.isDehydrated){var L=_i(m.pendingLanes);if(L!==0){var H=m;for(H.pendingLanes|=2,H.entangledLanes|=2;L;){var $=1<<31-Qe(L);H.entanglements[1]|=$,L&=~$}Ai(m),(je&6)===0&&(rl=be()+500,Mn(0))}}break;case 13:H=ds(m,2),H!==null&&Jt(H,m,2),nl(),Kc(m,2)}if(m=Wc(r),m===null&&jc(e,t,r,yl,i),m===n)break;n=m}n!==null&&r.stopPropagation()}else jc(e,t,r,null,i)}}function Wc(e){return e=eo(e),Zc(e)}var yl=null;function Zc(e){if(yl=null,e=Jr(e),e!==null){var t=T(e);if(t===null)e=null;else{var i=t.tag;if(i===13){if(e=B(t),e!==null)return e;e=null}else if(i===3){if(t.stateNode.current.memoizedState.isDehydrated)return t.tag===3?t.stateNode.containerInfo:null;e=null}else t!==e&&(e=null)}}return yl=e,null}function Dm(e){switch(e){case"beforetoggle":case"cancel":case"click":case"close":case"contextmenu":case"copy":case"cut":case"auxclick":case"dblclick":case"dragend":case"dragstart":case"drop":case"focusin":case"focusout":case"input":case"invalid":case"keydown":case"keypress":case"keyup":case"mousedown":case"mouseup":case"paste":case"pause":case"play":case"pointercancel":case"pointerdown":case"pointerup":case"ratechange":case"reset":case"resize":case"seeked":case"submit":case"toggle":case"touchcancel":case"touchend":case"touchstart":case"volumechange":case"change":case"selectionchange":case"textInput":case"compositionstart":case"compositionend":case"compositionupdate":case"beforeblur":case"afterblur":case"beforeinput":case"blur":case"fullscreenchange":case"focus":case"hashchange":case"popstate":case"select":case"selectstart":return 2;case"drag":case"dragenter":case"dragexit":case"dragleave":case"dragover":case"mousemove":case"mouseout":case"mouseover":case"pointermove":case"pointerout":case"pointerover":case"scroll":case"touchmove":case"wheel":case"mouseenter":case"mouseleave":case"pointerenter":case"pointerleave":return 8;case"message":switch(Si()){case xi:return 2;case Lt:return 8;case qt:case mi:return 32;case dt:return 268435456;default:return 32}default:return 32}}var Qc=!1,Sr=null,xr=null,Cr=null,Un=new Map,Pn=new Map,wr=[],Bp="mousedown mouseup touchcancel touchend touchstart auxclick dblclick pointercancel pointerdown pointerup dragend dragstart drop compositionend compositionstart keydown keypress keyup input textInput copy cut paste click change contextmenu reset".split(" ");function Am(e,t){switch(e){case"focusin":case"focusout":Sr=null;break;case"dragenter":case"dragleave":xr=null;break;case"mouseover":case"mouseout":Cr=null;break;case"pointerover":case"pointerout":Un.delete(t.pointerId);break;case"gotpointercapture":case"lostpointercapture":Pn.delete(t.pointerId)}}function In(e,t,i,r,n,m){return e===null||e.nativeEvent!==m?(e={blockedOn:t,domEventName:i,eventSystemFlags:r,nativeEvent:m,targetContainers:[n]},t!==null&&(t=es(t),t!==null&&Rm(t)),e):(e.eventSystemFlags|=r,t=e.targetContainers,n!==null&&t.indexOf(n)===-1&&t.push(n),e)}function jp(e,t,i,r,n){switch(t){case"focusin":return Sr=In(Sr,e,t,i,r,n),!0;case"dragenter":return xr=In(xr,e,t,i,r,n),!0;case"mouseover":return Cr=In(Cr,e,t,i,r,n),!0;case"pointerover":var m=n.pointerId;return Un.set(m,In(Un.get(m)||null,e,t,i,r,n)),!0;case"gotpointercapture":return m=n.pointerId,Pn.set(m,In(Pn.get(m)||null,e,t,i,r,n)),!0}return!1}function Tm(e){var t=Jr(e.target);if(t!==null){var i=T(t);if(i!==null){if(t=i.tag,t===13){if(t=B(i),t!==null){e.blockedOn=t,ir(e.priority,function(){if(i.tag===13){var r=Qt();r=wt(r);var n=ds(i,r);n!==null&&Jt(n,i,r),Kc(i,r)}});return}}else if(t===3&&i.stateNode.current.memoizedState.isDehydrated){e.blockedOn=i.tag===3?i.stateNode.containerInfo:null;return}}}e.blockedOn=null}function Sl(e){if(e.blockedOn!==null)return!1;for(var t=e.targetContainers;0<t.length;){var i=Wc(e.nativeEvent);if(i===null){i=e.nativeEvent;var r=new i.constructor(i.type,i);Jl=r,i.target.dispatchEvent(r),Jl=null}else return t=es(i),t!==null&&Rm(t),e.blockedOn=i,!1;t.shift()}return!0}function Mm(e,t,i){Sl(e)&&i.delete(t)}function Hp(){Qc=!1,Sr!==null&&Sl(Sr)&&(Sr=null),xr!==null&&Sl(xr)&&(xr=null),Cr!==null&&Sl(Cr)&&(Cr=null),Un.forEach(Mm),Pn.forEach(Mm)}function xl(e,t){e.blockedOn===t&&(e.blockedOn=null,Qc||(Qc=!0,s.unstable_scheduleCallback(s.unstable_NormalPriority,Hp)))}var Cl=null;function Lm(e){Cl!==e&&(Cl=e,s.unstable_scheduleCallback(s.unstable_NormalPriority,function(){Cl===e&&(Cl=null);for(var t=0;t<e.length;t+=3){var i=e[t],r=e[t+1],n=e[t+2];if(typeof r!="function"){if(Zc(r||i)===null)continue;break}var m=es(i);m!==null&&(e.splice(t,3),t-=3,Zo(m,{pending:!0,data:n,method:i.method,action:r},r,n))}}))}function Fn(e){function t($){return xl($,e)}Sr!==null&&xl(Sr,e),xr!==null&&xl(xr,e),Cr!==null&&xl(Cr,e),Un.forEach(t),Pn.forEach(t);for(var i=0;i<wr.length;i++){var r=wr[i];r.blockedOn===e&&(r.blockedOn=null)}for(;0<wr.length&&(i=wr[0],i.blockedOn===null);)Tm(i),i.blockedOn===null&&wr.shift();if(i=(e.ownerDocument||e).$$reactFormReplay,i!=null)for(r=0;r<i.length;r+=3){var n=i[r],m=i[r+1],L=n[Ye]||null;if(typeof m=="function")L||Lm(i);else if(L){var H=null;if(m&&m.hasAttribute("formAction")){if(n=m,L=m[Ye]||null)H=L.formAction;else if(Zc(n)!==null)continue}else H=L.action;typeof H=="function"?i[r+1]=H:(i.splice(r,3),r-=3),Lm(i)}}}function Jc(e){this._internalRoot=e}wl.prototype.render=Jc.prototype.render=function(e){var t=this._internalRoot;if(t===null)throw Error(g(409));var i=t.current,r=Qt();Em(i,r,e,t,null,null)},wl.prototype.unmount=Jc.prototype.unmount=function(){var e=this._internalRoot;if(e!==null){this._internalRoot=null;var t=e.containerInfo;Em(e.current,2,null,e,null,null),nl(),t[Et]=null}};function wl(e){this._internalRoot=e}wl.prototype.unstable_scheduleHydration=function(e){if(e){var t=Yt();e={blockedOn:null,target:e,priority:t};for(var i=0;i<wr.length&&t!==0&&t<wr[i].priority;i++);wr.splice(i,0,e),i===0&&Tm(e)}};var Om=o.version;if(Om!=="19.1.0")throw Error(g(527,Om,"19.1.0"));G.findDOMNode=function(e){var t=e._reactInternals;if(t===void 0)throw typeof e.render=="function"?Error(g(188)):(e=Object.keys(e).join(","),Error(g(268,e)));return e=c(t),e=e!==null?h(e):null,e=e===null?null:e.stateNode,e};var zp={bundleType:0,version:"19.1.0",rendererPackageName:"react-dom",currentDispatcherRef:I,reconcilerVersion:"19.1.0"};if(typeof __REACT_DEVTOOLS_GLOBAL_HOOK__<"u"){var El=__REACT_DEVTOOLS_GLOBAL_HOOK__;if(!El.isDisabled&&El.supportsFiber)try{Vt=El.inject(zp),Ke=El}catch{}}return Vn.createRoot=function(e,t){if(!E(e))throw Error(g(299));var i=!1,r="",n=Kd,m=Xd,L=Wd,H=null;return t!=null&&(t.unstable_strictMode===!0&&(i=!0),t.identifierPrefix!==void 0&&(r=t.identifierPrefix),t.onUncaughtError!==void 0&&(n=t.onUncaughtError),t.onCaughtError!==void 0&&(m=t.onCaughtError),t.onRecoverableError!==void 0&&(L=t.onRecoverableError),t.unstable_transitionCallbacks!==void 0&&(H=t.unstable_transitionCallbacks)),t=Cm(e,1,!1,null,null,i,r,n,m,L,H,null),e[Et]=t.current,Bc(e),new Jc(t)},Vn.hydrateRoot=function(e,t,i){if(!E(e))throw Error(g(299));var r=!1,n="",m=Kd,L=Xd,H=Wd,$=null,ie=null;return i!=null&&(i.unstable_strictMode===!0&&(r=!0),i.identifierPrefix!==void 0&&(n=i.identifierPrefix),i.onUncaughtError!==void 0&&(m=i.onUncaughtError),i.onCaughtError!==void 0&&(L=i.onCaughtError),i.onRecoverableError!==void 0&&(H=i.onRecoverableError),i.unstable_transitionCallbacks!==void 0&&($=i.unstable_transitionCallbacks),i.formState!==void 0&&(ie=i.formState)),t=Cm(e,1,!0,t,i??null,r,n,m,L,H,$,ie),t.context=wm(null),i=t.current,r=Qt(),r=wt(r),n=lr(r),n.callback=null,or(i,n,r),i=r,t.current.lanes=i,tt(t,i),Ai(t),e[Et]=t.current,Bc(e),new wl(t)},Vn.version="19.1.0",Vn}var qm;function Kp(){if(qm)return tu.exports;qm=1;function s(){if(!(typeof __REACT_DEVTOOLS_GLOBAL_HOOK__>"u"||typeof __REACT_DEVTOOLS_GLOBAL_HOOK__.checkDCE!="function"))try{__REACT_DEVTOOLS_GLOBAL_HOOK__.checkDCE(s)}catch(o){console.error(o)}}return s(),tu.exports=$p(),tu.exports}var Xp=Kp(),U=Mu();const Pt=Pp(U);var Gn={},Vm;function Wp(){if(Vm)return Gn;Vm=1,Object.defineProperty(Gn,"__esModule",{value:!0}),Gn.parse=B,Gn.serialize=h;const s=/^[\u0021-\u003A\u003C\u003E-\u007E]+$/,o=/^[\u0021-\u003A\u003C-\u007E]*$/,_=/^([.]?[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?)([.][a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?)*$/i,g=/^[\u0020-\u003A\u003D-\u007E]*$/,E=Object.prototype.toString,T=(()=>{const b=function(){};return b.prototype=Object.create(null),b})();function B(b,w){const k=new T,C=b.length;if(C<2)return k;const a=w?.decode||p;let u=0;do{const d=b.indexOf("=",u);if(d===-1)break;const f=b.indexOf(";",u),S=f===-1?C:f;if(d>S){u=b.lastIndexOf(";",d-1)+1;continue}const x=l(b,u,d),R=c(b,d,x),O=b.slice(x,R);if(k[O]===void 0){let y=l(b,d+1,S),N=c(b,S,y);const F=a(b.slice(y,N));k[O]=F}u=S+1}while(u<C);return k}function l(b,w,k){do{const C=b.charCodeAt(w);if(C!==32&&C!==9)return w}while(++w<k);return k}function c(b,w,k){for(;w>k;){const C=b.charCodeAt(--w);if(C!==32&&C!==9)return w+1}return k}function h(b,w,k){const C=k?.encode||encodeURIComponent;if(!s.test(b))throw new TypeError(`argument name is invalid: ${b}`);const a=C(w);if(!o.test(a))throw new TypeError(`argument val is invalid: ${w}`);let u=b+"="+a;if(!k)return u;if(k.maxAge!==void 0){if(!Number.isInteger(k.maxAge))throw new TypeError(`option maxAge is invalid: ${k.maxAge}`);u+="; Max-Age="+k.maxAge}if(k.domain){if(!_.test(k.domain))throw new TypeError(`option domain is invalid: ${k.domain}`);u+="; Domain="+k.domain}if(k.path){if(!g.test(k.path))throw new TypeError(`option path is invalid: ${k.path}`);u+="; Path="+k.path}if(k.expires){if(!v(k.expires)||!Number.isFinite(k.expires.valueOf()))throw new TypeError(`option expires is invalid: ${k.expires}`);u+="; Expires="+k.expires.toUTCString()}if(k.httpOnly&&(u+="; HttpOnly"),k.secure&&(u+="; Secure"),k.partitioned&&(u+="; Partitioned"),k.priority)switch(typeof k.priority=="string"?k.priority.toLowerCase():void 0){case"low":u+="; Priority=Low";break;case"medium":u+="; Priority=Medium";break;case"high":u+="; Priority=High";break;default:throw new TypeError(`option priority is invalid: ${k.priority}`)}if(k.sameSite)switch(typeof k.sameSite=="string"?k.sameSite.toLowerCase():k.sameSite){case!0:case"strict":u+="; SameSite=Strict";break;case"lax":u+="; SameSite=Lax";break;case"none":u+="; SameSite=None";break;default:throw new TypeError(`option sameSite is invalid: ${k.sameSite}`)}return u}function p(b){if(b.indexOf("%")===-1)return b;try{return decodeURIComponent(b)}catch{return b}}function v(b){return E.call(b)==="[object Date]"}return Gn}Wp();var Gm="popstate";function Zp(s={}){function o(g,E){let{pathname:T,search:B,hash:l}=g.location;return gu("",{pathname:T,search:B,hash:l},E.state&&E.state.usr||null,E.state&&E.state.key||"default")}function _(g,E){return typeof E=="string"?E:Qn(E)}return Jp(o,_,null,s)}function et(s,o){if(s===!1||s===null||typeof s>"u")throw new Error(o)}function Mi(s,o){if(!s){typeof console<"u"&&console.warn(o);try{throw new Error(o)}catch{}}}function Qp(){return Math.random().toString(36).substring(2,10)}function Ym(s,o){return{usr:s.state,key:s.key,idx:o}}function gu(s,o,_=null,g){return{pathname:typeof s=="string"?s:s.pathname,search:"",hash:"",...typeof o=="string"?Is(o):o,state:_,key:o&&o.key||g||Qp()}}function Qn({pathname:s="/",search:o="",hash:_=""}){return o&&o!=="?"&&(s+=o.charAt(0)==="?"?o:"?"+o),_&&_!=="#"&&(s+=_.charAt(0)==="#"?_:"#"+_),s}function Is(s){let o={};if(s){let _=s.indexOf("#");_>=0&&(o.hash=s.substring(_),s=s.substring(0,_));let g=s.indexOf("?");g>=0&&(o.search=s.substring(g),s=s.substring(0,g)),s&&(o.pathname=s)}return o}function Jp(s,o,_,g={}){let{window:E=document.defaultView,v5Compat:T=!1}=g,B=E.history,l="POP",c=null,h=p();h==null&&(h=0,B.replaceState({...B.state,idx:h},""));function p(){return(B.state||{idx:null}).idx}function v(){l="POP";let a=p(),u=a==null?null:a-h;h=a,c&&c({action:l,location:C.location,delta:u})}function b(a,u){l="PUSH";let d=gu(C.location,a,u);h=p()+1;let f=Ym(d,h),S=C.createHref(d);try{B.pushState(f,"",S)}catch(x){if(x instanceof DOMException&&x.name==="DataCloneError")throw x;E.location.assign(S)}T&&c&&c({action:l,location:C.location,delta:1})}function w(a,u){l="REPLACE";let d=gu(C.location,a,u);h=p();let f=Ym(d,h),S=C.createHref(d);B.replaceState(f,"",S),T&&c&&c({action:l,location:C.location,delta:0})}function k(a){return e0(a)}let C={get action(){return l},get location(){return s(E,B)},listen(a){if(c)throw new Error("A history only accepts one active listener");return E.addEventListener(Gm,v),c=a,()=>{E.removeEventListener(Gm,v),c=null}},createHref(a){return o(E,a)},createURL:k,encodeLocation(a){let u=k(a);return{pathname:u.pathname,search:u.search,hash:u.hash}},push:b,replace:w,go(a){return B.go(a)}};return C}function e0(s,o=!1){let _="http://localhost";typeof window<"u"&&(_=window.location.origin!=="null"?window.location.origin:window.location.href),et(_,"No window.location.(origin|href) available to create URL");let g=typeof s=="string"?s:Qn(s);return g=g.replace(/ $/,"%20"),!o&&g.startsWith("//")&&(g=_+g),new URL(g,_)}function R_(s,o,_="/"){return t0(s,o,_,!1)}function t0(s,o,_,g){let E=typeof o=="string"?Is(o):o,T=er(E.pathname||"/",_);if(T==null)return null;let B=D_(s);i0(B);let l=null;for(let c=0;l==null&&c<B.length;++c){let h=f0(T);l=h0(B[c],h,g)}return l}function D_(s,o=[],_=[],g=""){let E=(T,B,l)=>{let c={relativePath:l===void 0?T.path||"":l,caseSensitive:T.caseSensitive===!0,childrenIndex:B,route:T};c.relativePath.startsWith("/")&&(et(c.relativePath.startsWith(g),`Absolute route path "${c.relativePath}" nested under path "${g}" is not valid. An absolute child route path must start with the combined path of all its parent routes.`),c.relativePath=c.relativePath.slice(g.length));let h=Ji([g,c.relativePath]),p=_.concat(c);T.children&&T.children.length>0&&(et(T.index!==!0,`Index routes must not have child routes. Please remove all child routes from route path "${h}".`),D_(T.children,o,p,h)),!(T.path==null&&!T.index)&&o.push({path:h,score:c0(h,T.index),routesMeta:p})};return s.forEach((T,B)=>{if(T.path===""||!T.path?.includes("?"))E(T,B);else for(let l of A_(T.path))E(T,B,l)}),o}function A_(s){let o=s.split("/");if(o.length===0)return[];let[_,...g]=o,E=_.endsWith("?"),T=_.replace(/\?$/,"");if(g.length===0)return E?[T,""]:[T];let B=A_(g.join("/")),l=[];return l.push(...B.map(c=>c===""?T:[T,c].join("/"))),E&&l.push(...B),l.map(c=>s.startsWith("/")&&c===""?"/":c)}function i0(s){s.sort((o,_)=>o.score!==_.score?_.score-o.score:u0(o.routesMeta.map(g=>g.childrenIndex),_.routesMeta.map(g=>g.childrenIndex)))}var r0=/^:[\w-]+$/,s0=3,n0=2,a0=1,l0=10,o0=-2,$m=s=>s==="*";function c0(s,o){let _=s.split("/"),g=_.length;return _.some($m)&&(g+=o0),o&&(g+=n0),_.filter(E=>!$m(E)).reduce((E,T)=>E+(r0.test(T)?s0:T===""?a0:l0),g)}function u0(s,o){return s.length===o.length&&s.slice(0,-1).every((g,E)=>g===o[E])?s[s.length-1]-o[o.length-1]:0}function h0(s,o,_=!1){let{routesMeta:g}=s,E={},T="/",B=[];for(let l=0;l<g.length;++l){let c=g[l],h=l===g.length-1,p=T==="/"?o:o.slice(T.length)||"/",v=Nl({path:c.relativePath,caseSensitive:c.caseSensitive,end:h},p),b=c.route;if(!v&&h&&_&&!g[g.length-1].route.index&&(v=Nl({path:c.relativePath,caseSensitive:c.caseSensitive,end:!1},p)),!v)return null;Object.assign(E,v.params),B.push({params:E,pathname:Ji([T,v.pathname]),pathnameBase:g0(Ji([T,v.pathnameBase])),route:b}),v.pathnameBase!=="/"&&(T=Ji([T,v.pathnameBase]))}return B}function Nl(s,o){typeof s=="string"&&(s={path:s,caseSensitive:!1,end:!0});let[_,g]=d0(s.path,s.caseSensitive,s.end),E=o.match(_);if(!E)return null;let T=E[0],B=T.replace(/(.)\/+$/,"$1"),l=E.slice(1);return{params:g.reduce((h,{paramName:p,isOptional:v},b)=>{if(p==="*"){let k=l[b]||"";B=T.slice(0,T.length-k.length).replace(/(.)\/+$/,"$1")}const w=l[b];return v&&!w?h[p]=void 0:h[p]=(w||"").replace(/%2F/g,"/"),h},{}),pathname:T,pathnameBase:B,pattern:s}}function d0(s,o=!1,_=!0){Mi(s==="*"||!s.endsWith("*")||s.endsWith("/*"),`Route path "${s}" will be treated as if it were "${s.replace(/\*$/,"/*")}" because the \`*\` character must always follow a \`/\` in the pattern. To get rid of this warning, please change the route path to "${s.replace(/\*$/,"/*")}".`);let g=[],E="^"+s.replace(/\/*\*?$/,"").replace(/^\/*/,"/").replace(/[\\.*+^${}|()[\]]/g,"\\$&").replace(/\/:([\w-]+)(\?)?/g,(B,l,c)=>(g.push({paramName:l,isOptional:c!=null}),c?"/?([^\\/]+)?":"/([^\\/]+)"));return s.endsWith("*")?(g.push({paramName:"*"}),E+=s==="*"||s==="/*"?"(.*)$":"(?:\\/(.+)|\\/*)$"):_?E+="\\/*$":s!==""&&s!=="/"&&(E+="(?:(?=\\/|$))"),[new RegExp(E,o?void 0:"i"),g]}function f0(s){try{return s.split("/").map(o=>decodeURIComponent(o).replace(/\//g,"%2F")).join("/")}catch(o){return Mi(!1,`The URL path "${s}" could not be decoded because it is a malformed URL segment. This is probably due to a bad percent encoding (${o}).`),s}}function er(s,o){if(o==="/")return s;if(!s.toLowerCase().startsWith(o.toLowerCase()))return null;let _=o.endsWith("/")?o.length-1:o.length,g=s.charAt(_);return g&&g!=="/"?null:s.slice(_)||"/"}function m0(s,o="/"){let{pathname:_,search:g="",hash:E=""}=typeof s=="string"?Is(s):s;return{pathname:_?_.startsWith("/")?_:_0(_,o):o,search:p0(g),hash:b0(E)}}function _0(s,o){let _=o.replace(/\/+$/,"").split("/");return s.split("/").forEach(E=>{E===".."?_.length>1&&_.pop():E!=="."&&_.push(E)}),_.length>1?_.join("/"):"/"}function au(s,o,_,g){return`Cannot include a '${s}' character in a manually specified \`to.${o}\` field [${JSON.stringify(g)}].  Please separate it out to the \`to.${_}\` field. Alternatively you may provide the full path as a string in <Link to="..."> and the router will parse it for you.`}function v0(s){return s.filter((o,_)=>_===0||o.route.path&&o.route.path.length>0)}function T_(s){let o=v0(s);return o.map((_,g)=>g===o.length-1?_.pathname:_.pathnameBase)}function M_(s,o,_,g=!1){let E;typeof s=="string"?E=Is(s):(E={...s},et(!E.pathname||!E.pathname.includes("?"),au("?","pathname","search",E)),et(!E.pathname||!E.pathname.includes("#"),au("#","pathname","hash",E)),et(!E.search||!E.search.includes("#"),au("#","search","hash",E)));let T=s===""||E.pathname==="",B=T?"/":E.pathname,l;if(B==null)l=_;else{let v=o.length-1;if(!g&&B.startsWith("..")){let b=B.split("/");for(;b[0]==="..";)b.shift(),v-=1;E.pathname=b.join("/")}l=v>=0?o[v]:"/"}let c=m0(E,l),h=B&&B!=="/"&&B.endsWith("/"),p=(T||B===".")&&_.endsWith("/");return!c.pathname.endsWith("/")&&(h||p)&&(c.pathname+="/"),c}var Ji=s=>s.join("/").replace(/\/\/+/g,"/"),g0=s=>s.replace(/\/+$/,"").replace(/^\/*/,"/"),p0=s=>!s||s==="?"?"":s.startsWith("?")?s:"?"+s,b0=s=>!s||s==="#"?"":s.startsWith("#")?s:"#"+s;function y0(s){return s!=null&&typeof s.status=="number"&&typeof s.statusText=="string"&&typeof s.internal=="boolean"&&"data"in s}var L_=["POST","PUT","PATCH","DELETE"];new Set(L_);var S0=["GET",...L_];new Set(S0);var Fs=U.createContext(null);Fs.displayName="DataRouter";var Pl=U.createContext(null);Pl.displayName="DataRouterState";var O_=U.createContext({isTransitioning:!1});O_.displayName="ViewTransition";var x0=U.createContext(new Map);x0.displayName="Fetchers";var C0=U.createContext(null);C0.displayName="Await";var Oi=U.createContext(null);Oi.displayName="Navigation";var la=U.createContext(null);la.displayName="Location";var Ni=U.createContext({outlet:null,matches:[],isDataRoute:!1});Ni.displayName="Route";var Lu=U.createContext(null);Lu.displayName="RouteError";function w0(s,{relative:o}={}){et(oa(),"useHref() may be used only in the context of a <Router> component.");let{basename:_,navigator:g}=U.useContext(Oi),{hash:E,pathname:T,search:B}=ca(s,{relative:o}),l=T;return _!=="/"&&(l=T==="/"?_:Ji([_,T])),g.createHref({pathname:l,search:B,hash:E})}function oa(){return U.useContext(la)!=null}function Zr(){return et(oa(),"useLocation() may be used only in the context of a <Router> component."),U.useContext(la).location}var N_="You should call navigate() in a React.useEffect(), not when your component is first rendered.";function B_(s){U.useContext(Oi).static||U.useLayoutEffect(s)}function E0(){let{isDataRoute:s}=U.useContext(Ni);return s?U0():k0()}function k0(){et(oa(),"useNavigate() may be used only in the context of a <Router> component.");let s=U.useContext(Fs),{basename:o,navigator:_}=U.useContext(Oi),{matches:g}=U.useContext(Ni),{pathname:E}=Zr(),T=JSON.stringify(T_(g)),B=U.useRef(!1);return B_(()=>{B.current=!0}),U.useCallback((c,h={})=>{if(Mi(B.current,N_),!B.current)return;if(typeof c=="number"){_.go(c);return}let p=M_(c,JSON.parse(T),E,h.relative==="path");s==null&&o!=="/"&&(p.pathname=p.pathname==="/"?o:Ji([o,p.pathname])),(h.replace?_.replace:_.push)(p,h.state,h)},[o,_,T,E,s])}U.createContext(null);function R0(){let{matches:s}=U.useContext(Ni),o=s[s.length-1];return o?o.params:{}}function ca(s,{relative:o}={}){let{matches:_}=U.useContext(Ni),{pathname:g}=Zr(),E=JSON.stringify(T_(_));return U.useMemo(()=>M_(s,JSON.parse(E),g,o==="path"),[s,E,g,o])}function D0(s,o){return j_(s,o)}function j_(s,o,_,g){et(oa(),"useRoutes() may be used only in the context of a <Router> component.");let{navigator:E}=U.useContext(Oi),{matches:T}=U.useContext(Ni),B=T[T.length-1],l=B?B.params:{},c=B?B.pathname:"/",h=B?B.pathnameBase:"/",p=B&&B.route;{let u=p&&p.path||"";H_(c,!p||u.endsWith("*")||u.endsWith("*?"),`You rendered descendant <Routes> (or called \`useRoutes()\`) at "${c}" (under <Route path="${u}">) but the parent route path has no trailing "*". This means if you navigate deeper, the parent won't match anymore and therefore the child routes will never render.
 
-Please change the parent <Route path="${u}"> to <Route path="${u==="/"?"*":`${u}/*`}">.`)}let v=Zr(),b;if(o){let u=typeof o=="string"?Is(o):o;et(h==="/"||u.pathname?.startsWith(h),`When overriding the location using \`<Routes location>\` or \`useRoutes(routes, location)\`, the location pathname must begin with the portion of the URL pathname that was matched by all parent routes. The current pathname base is "${h}" but pathname "${u.pathname}" was given in the \`location\` prop.`),b=u}else b=v;let w=b.pathname||"/",k=w;if(h!=="/"){let u=h.replace(/^\//,"").split("/");k="/"+w.replace(/^\//,"").split("/").slice(u.length).join("/")}let C=R_(s,{pathname:k});Mi(p||C!=null,`No routes matched location "${b.pathname}${b.search}${b.hash}" `),Mi(C==null||C[C.length-1].route.element!==void 0||C[C.length-1].route.Component!==void 0||C[C.length-1].route.lazy!==void 0,`Matched leaf route at location "${b.pathname}${b.search}${b.hash}" does not have an element or Component. This means it will render an <Outlet /> with a null value by default resulting in an "empty" page.`);let a=O0(C&&C.map(u=>Object.assign({},u,{params:Object.assign({},l,u.params),pathname:Ji([h,E.encodeLocation?E.encodeLocation(u.pathname).pathname:u.pathname]),pathnameBase:u.pathnameBase==="/"?h:Ji([h,E.encodeLocation?E.encodeLocation(u.pathnameBase).pathname:u.pathnameBase])})),T,_,g);return o&&a?U.createElement(la.Provider,{value:{location:{pathname:"/",search:"",hash:"",state:null,key:"default",...b},navigationType:"POP"}},a):a}function A0(){let s=z0(),o=y0(s)?`${s.status} ${s.statusText}`:s instanceof Error?s.message:JSON.stringify(s),_=s instanceof Error?s.stack:null,g="rgba(200,200,200, 0.5)",E={padding:"0.5rem",backgroundColor:g},T={padding:"2px 4px",backgroundColor:g},B=null;return console.error("Error handled by React Router default ErrorBoundary:",s),B=U.createElement(U.Fragment,null,U.createElement("p",null,"💿 Hey developer 👋"),U.createElement("p",null,"You can provide a way better UX than this when your app throws errors by providing your own ",U.createElement("code",{style:T},"ErrorBoundary")," or"," ",U.createElement("code",{style:T},"errorElement")," prop on your route.")),U.createElement(U.Fragment,null,U.createElement("h2",null,"Unexpected Application Error!"),U.createElement("h3",{style:{fontStyle:"italic"}},o),_?U.createElement("pre",{style:E},_):null,B)}var T0=U.createElement(A0,null),M0=class extends U.Component{constructor(s){super(s),this.state={location:s.location,revalidation:s.revalidation,error:s.error}}static getDerivedStateFromError(s){return{error:s}}static getDerivedStateFromProps(s,o){return o.location!==s.location||o.revalidation!=="idle"&&s.revalidation==="idle"?{error:s.error,location:s.location,revalidation:s.revalidation}:{error:s.error!==void 0?s.error:o.error,location:o.location,revalidation:s.revalidation||o.revalidation}}componentDidCatch(s,o){console.error("React Router caught the following error during render",s,o)}render(){return this.state.error!==void 0?U.createElement(Ni.Provider,{value:this.props.routeContext},U.createElement(Lu.Provider,{value:this.state.error,children:this.props.component})):this.props.children}};function L0({routeContext:s,match:o,children:_}){let g=U.useContext(Fs);return g&&g.static&&g.staticContext&&(o.route.errorElement||o.route.ErrorBoundary)&&(g.staticContext._deepestRenderedBoundaryId=o.route.id),U.createElement(Ni.Provider,{value:s},_)}function O0(s,o=[],_=null,g=null){if(s==null){if(!_)return null;if(_.errors)s=_.matches;else if(o.length===0&&!_.initialized&&_.matches.length>0)s=_.matches;else return null}let E=s,T=_?.errors;if(T!=null){let c=E.findIndex(h=>h.route.id&&T?.[h.route.id]!==void 0);et(c>=0,`Could not find a matching route for errors on route IDs: ${Object.keys(T).join(",")}`),E=E.slice(0,Math.min(E.length,c+1))}let B=!1,l=-1;if(_)for(let c=0;c<E.length;c++){let h=E[c];if((h.route.HydrateFallback||h.route.hydrateFallbackElement)&&(l=c),h.route.id){let{loaderData:p,errors:v}=_,b=h.route.loader&&!p.hasOwnProperty(h.route.id)&&(!v||v[h.route.id]===void 0);if(h.route.lazy||b){B=!0,l>=0?E=E.slice(0,l+1):E=[E[0]];break}}}return E.reduceRight((c,h,p)=>{let v,b=!1,w=null,k=null;_&&(v=T&&h.route.id?T[h.route.id]:void 0,w=h.route.errorElement||T0,B&&(l<0&&p===0?(H_("route-fallback",!1,"No `HydrateFallback` element provided to render during initial hydration"),b=!0,k=null):l===p&&(b=!0,k=h.route.hydrateFallbackElement||null)));let C=o.concat(E.slice(0,p+1)),a=()=>{let u;return v?u=w:b?u=k:h.route.Component?u=U.createElement(h.route.Component,null):h.route.element?u=h.route.element:u=c,U.createElement(L0,{match:h,routeContext:{outlet:c,matches:C,isDataRoute:_!=null},children:u})};return _&&(h.route.ErrorBoundary||h.route.errorElement||p===0)?U.createElement(M0,{location:_.location,revalidation:_.revalidation,component:w,error:v,children:a(),routeContext:{outlet:null,matches:C,isDataRoute:!0}}):a()},null)}function Ou(s){return`${s} must be used within a data router.  See https://reactrouter.com/en/main/routers/picking-a-router.`}function N0(s){let o=U.useContext(Fs);return et(o,Ou(s)),o}function B0(s){let o=U.useContext(Pl);return et(o,Ou(s)),o}function j0(s){let o=U.useContext(Ni);return et(o,Ou(s)),o}function Nu(s){let o=j0(s),_=o.matches[o.matches.length-1];return et(_.route.id,`${s} can only be used on routes that contain a unique "id"`),_.route.id}function H0(){return Nu("useRouteId")}function z0(){let s=U.useContext(Lu),o=B0("useRouteError"),_=Nu("useRouteError");return s!==void 0?s:o.errors?.[_]}function U0(){let{router:s}=N0("useNavigate"),o=Nu("useNavigate"),_=U.useRef(!1);return B_(()=>{_.current=!0}),U.useCallback(async(E,T={})=>{Mi(_.current,N_),_.current&&(typeof E=="number"?s.navigate(E):await s.navigate(E,{fromRouteId:o,...T}))},[s,o])}var Km={};function H_(s,o,_){!o&&!Km[s]&&(Km[s]=!0,Mi(!1,_))}U.memo(P0);function P0({routes:s,future:o,state:_}){return j_(s,void 0,_,o)}function pu(s){et(!1,"A <Route> is only ever to be used as the child of <Routes> element, never rendered directly. Please wrap your <Route> in a <Routes>.")}function I0({basename:s="/",children:o=null,location:_,navigationType:g="POP",navigator:E,static:T=!1}){et(!oa(),"You cannot render a <Router> inside another <Router>. You should never have more than one in your app.");let B=s.replace(/^\/*/,"/"),l=U.useMemo(()=>({basename:B,navigator:E,static:T,future:{}}),[B,E,T]);typeof _=="string"&&(_=Is(_));let{pathname:c="/",search:h="",hash:p="",state:v=null,key:b="default"}=_,w=U.useMemo(()=>{let k=er(c,B);return k==null?null:{location:{pathname:k,search:h,hash:p,state:v,key:b},navigationType:g}},[B,c,h,p,v,b,g]);return Mi(w!=null,`<Router basename="${B}"> is not able to match the URL "${c}${h}${p}" because it does not start with the basename, so the <Router> won't render anything.`),w==null?null:U.createElement(Oi.Provider,{value:l},U.createElement(la.Provider,{children:o,value:w}))}function F0({children:s,location:o}){return D0(bu(s),o)}function bu(s,o=[]){let _=[];return U.Children.forEach(s,(g,E)=>{if(!U.isValidElement(g))return;let T=[...o,E];if(g.type===U.Fragment){_.push.apply(_,bu(g.props.children,T));return}et(g.type===pu,`[${typeof g.type=="string"?g.type:g.type.name}] is not a <Route> component. All component children of <Routes> must be a <Route> or <React.Fragment>`),et(!g.props.index||!g.props.children,"An index route cannot have child routes.");let B={id:g.props.id||T.join("-"),caseSensitive:g.props.caseSensitive,element:g.props.element,Component:g.props.Component,index:g.props.index,path:g.props.path,loader:g.props.loader,action:g.props.action,hydrateFallbackElement:g.props.hydrateFallbackElement,HydrateFallback:g.props.HydrateFallback,errorElement:g.props.errorElement,ErrorBoundary:g.props.ErrorBoundary,hasErrorBoundary:g.props.hasErrorBoundary===!0||g.props.ErrorBoundary!=null||g.props.errorElement!=null,shouldRevalidate:g.props.shouldRevalidate,handle:g.props.handle,lazy:g.props.lazy};g.props.children&&(B.children=bu(g.props.children,T)),_.push(B)}),_}var Ml="get",Ll="application/x-www-form-urlencoded";function Il(s){return s!=null&&typeof s.tagName=="string"}function q0(s){return Il(s)&&s.tagName.toLowerCase()==="button"}function V0(s){return Il(s)&&s.tagName.toLowerCase()==="form"}function G0(s){return Il(s)&&s.tagName.toLowerCase()==="input"}function Y0(s){return!!(s.metaKey||s.altKey||s.ctrlKey||s.shiftKey)}function $0(s,o){return s.button===0&&(!o||o==="_self")&&!Y0(s)}var kl=null;function K0(){if(kl===null)try{new FormData(document.createElement("form"),0),kl=!1}catch{kl=!0}return kl}var X0=new Set(["application/x-www-form-urlencoded","multipart/form-data","text/plain"]);function lu(s){return s!=null&&!X0.has(s)?(Mi(!1,`"${s}" is not a valid \`encType\` for \`<Form>\`/\`<fetcher.Form>\` and will default to "${Ll}"`),null):s}function W0(s,o){let _,g,E,T,B;if(V0(s)){let l=s.getAttribute("action");g=l?er(l,o):null,_=s.getAttribute("method")||Ml,E=lu(s.getAttribute("enctype"))||Ll,T=new FormData(s)}else if(q0(s)||G0(s)&&(s.type==="submit"||s.type==="image")){let l=s.form;if(l==null)throw new Error('Cannot submit a <button> or <input type="submit"> without a <form>');let c=s.getAttribute("formaction")||l.getAttribute("action");if(g=c?er(c,o):null,_=s.getAttribute("formmethod")||l.getAttribute("method")||Ml,E=lu(s.getAttribute("formenctype"))||lu(l.getAttribute("enctype"))||Ll,T=new FormData(l,s),!K0()){let{name:h,type:p,value:v}=s;if(p==="image"){let b=h?`${h}.`:"";T.append(`${b}x`,"0"),T.append(`${b}y`,"0")}else h&&T.append(h,v)}}else{if(Il(s))throw new Error('Cannot submit element that is not <form>, <button>, or <input type="submit|image">');_=Ml,g=null,E=Ll,B=s}return T&&E==="text/plain"&&(B=T,T=void 0),{action:g,method:_.toLowerCase(),encType:E,formData:T,body:B}}function Bu(s,o){if(s===!1||s===null||typeof s>"u")throw new Error(o)}async function Z0(s,o){if(s.id in o)return o[s.id];try{let _=await import(s.module);return o[s.id]=_,_}catch(_){return console.error(`Error loading route module \`${s.module}\`, reloading page...`),console.error(_),window.__reactRouterContext&&window.__reactRouterContext.isSpaMode,window.location.reload(),new Promise(()=>{})}}function Q0(s){return s==null?!1:s.href==null?s.rel==="preload"&&typeof s.imageSrcSet=="string"&&typeof s.imageSizes=="string":typeof s.rel=="string"&&typeof s.href=="string"}async function J0(s,o,_){let g=await Promise.all(s.map(async E=>{let T=o.routes[E.route.id];if(T){let B=await Z0(T,_);return B.links?B.links():[]}return[]}));return rb(g.flat(1).filter(Q0).filter(E=>E.rel==="stylesheet"||E.rel==="preload").map(E=>E.rel==="stylesheet"?{...E,rel:"prefetch",as:"style"}:{...E,rel:"prefetch"}))}function Xm(s,o,_,g,E,T){let B=(c,h)=>_[h]?c.route.id!==_[h].route.id:!0,l=(c,h)=>_[h].pathname!==c.pathname||_[h].route.path?.endsWith("*")&&_[h].params["*"]!==c.params["*"];return T==="assets"?o.filter((c,h)=>B(c,h)||l(c,h)):T==="data"?o.filter((c,h)=>{let p=g.routes[c.route.id];if(!p||!p.hasLoader)return!1;if(B(c,h)||l(c,h))return!0;if(c.route.shouldRevalidate){let v=c.route.shouldRevalidate({currentUrl:new URL(E.pathname+E.search+E.hash,window.origin),currentParams:_[0]?.params||{},nextUrl:new URL(s,window.origin),nextParams:c.params,defaultShouldRevalidate:!0});if(typeof v=="boolean")return v}return!0}):[]}function eb(s,o,{includeHydrateFallback:_}={}){return tb(s.map(g=>{let E=o.routes[g.route.id];if(!E)return[];let T=[E.module];return E.clientActionModule&&(T=T.concat(E.clientActionModule)),E.clientLoaderModule&&(T=T.concat(E.clientLoaderModule)),_&&E.hydrateFallbackModule&&(T=T.concat(E.hydrateFallbackModule)),E.imports&&(T=T.concat(E.imports)),T}).flat(1))}function tb(s){return[...new Set(s)]}function ib(s){let o={},_=Object.keys(s).sort();for(let g of _)o[g]=s[g];return o}function rb(s,o){let _=new Set;return new Set(o),s.reduce((g,E)=>{let T=JSON.stringify(ib(E));return _.has(T)||(_.add(T),g.push({key:T,link:E})),g},[])}Object.getOwnPropertyNames(Object.prototype).sort().join("\0");var sb=new Set([100,101,204,205]);function nb(s,o){let _=typeof s=="string"?new URL(s,typeof window>"u"?"server://singlefetch/":window.location.origin):s;return _.pathname==="/"?_.pathname="_root.data":o&&er(_.pathname,o)==="/"?_.pathname=`${o.replace(/\/$/,"")}/_root.data`:_.pathname=`${_.pathname.replace(/\/$/,"")}.data`,_}function z_(){let s=U.useContext(Fs);return Bu(s,"You must render this element inside a <DataRouterContext.Provider> element"),s}function ab(){let s=U.useContext(Pl);return Bu(s,"You must render this element inside a <DataRouterStateContext.Provider> element"),s}var ju=U.createContext(void 0);ju.displayName="FrameworkContext";function U_(){let s=U.useContext(ju);return Bu(s,"You must render this element inside a <HydratedRouter> element"),s}function lb(s,o){let _=U.useContext(ju),[g,E]=U.useState(!1),[T,B]=U.useState(!1),{onFocus:l,onBlur:c,onMouseEnter:h,onMouseLeave:p,onTouchStart:v}=o,b=U.useRef(null);U.useEffect(()=>{if(s==="render"&&B(!0),s==="viewport"){let C=u=>{u.forEach(d=>{B(d.isIntersecting)})},a=new IntersectionObserver(C,{threshold:.5});return b.current&&a.observe(b.current),()=>{a.disconnect()}}},[s]),U.useEffect(()=>{if(g){let C=setTimeout(()=>{B(!0)},100);return()=>{clearTimeout(C)}}},[g]);let w=()=>{E(!0)},k=()=>{E(!1),B(!1)};return _?s!=="intent"?[T,b,{}]:[T,b,{onFocus:Yn(l,w),onBlur:Yn(c,k),onMouseEnter:Yn(h,w),onMouseLeave:Yn(p,k),onTouchStart:Yn(v,w)}]:[!1,b,{}]}function Yn(s,o){return _=>{s&&s(_),_.defaultPrevented||o(_)}}function ob({page:s,...o}){let{router:_}=z_(),g=U.useMemo(()=>R_(_.routes,s,_.basename),[_.routes,s,_.basename]);return g?U.createElement(ub,{page:s,matches:g,...o}):null}function cb(s){let{manifest:o,routeModules:_}=U_(),[g,E]=U.useState([]);return U.useEffect(()=>{let T=!1;return J0(s,o,_).then(B=>{T||E(B)}),()=>{T=!0}},[s,o,_]),g}function ub({page:s,matches:o,..._}){let g=Zr(),{manifest:E,routeModules:T}=U_(),{basename:B}=z_(),{loaderData:l,matches:c}=ab(),h=U.useMemo(()=>Xm(s,o,c,E,g,"data"),[s,o,c,E,g]),p=U.useMemo(()=>Xm(s,o,c,E,g,"assets"),[s,o,c,E,g]),v=U.useMemo(()=>{if(s===g.pathname+g.search+g.hash)return[];let k=new Set,C=!1;if(o.forEach(u=>{let d=E.routes[u.route.id];!d||!d.hasLoader||(!h.some(f=>f.route.id===u.route.id)&&u.route.id in l&&T[u.route.id]?.shouldRevalidate||d.hasClientLoader?C=!0:k.add(u.route.id))}),k.size===0)return[];let a=nb(s,B);return C&&k.size>0&&a.searchParams.set("_routes",o.filter(u=>k.has(u.route.id)).map(u=>u.route.id).join(",")),[a.pathname+a.search]},[B,l,g,E,h,o,s,T]),b=U.useMemo(()=>eb(p,E),[p,E]),w=cb(p);return U.createElement(U.Fragment,null,v.map(k=>U.createElement("link",{key:k,rel:"prefetch",as:"fetch",href:k,..._})),b.map(k=>U.createElement("link",{key:k,rel:"modulepreload",href:k,..._})),w.map(({key:k,link:C})=>U.createElement("link",{key:k,...C})))}function hb(...s){return o=>{s.forEach(_=>{typeof _=="function"?_(o):_!=null&&(_.current=o)})}}var P_=typeof window<"u"&&typeof window.document<"u"&&typeof window.document.createElement<"u";try{P_&&(window.__reactRouterVersion="7.6.3")}catch{}function db({basename:s,children:o,window:_}){let g=U.useRef();g.current==null&&(g.current=Zp({window:_,v5Compat:!0}));let E=g.current,[T,B]=U.useState({action:E.action,location:E.location}),l=U.useCallback(c=>{U.startTransition(()=>B(c))},[B]);return U.useLayoutEffect(()=>E.listen(l),[E,l]),U.createElement(I0,{basename:s,children:o,location:T.location,navigationType:T.action,navigator:E})}var I_=/^(?:[a-z][a-z0-9+.-]*:|\/\/)/i,zs=U.forwardRef(function({onClick:o,discover:_="render",prefetch:g="none",relative:E,reloadDocument:T,replace:B,state:l,target:c,to:h,preventScrollReset:p,viewTransition:v,...b},w){let{basename:k}=U.useContext(Oi),C=typeof h=="string"&&I_.test(h),a,u=!1;if(typeof h=="string"&&C&&(a=h,P_))try{let N=new URL(window.location.href),F=h.startsWith("//")?new URL(N.protocol+h):new URL(h),Y=er(F.pathname,k);F.origin===N.origin&&Y!=null?h=Y+F.search+F.hash:u=!0}catch{Mi(!1,`<Link to="${h}"> contains an invalid URL which will probably break when clicked - please update to a valid URL path.`)}let d=w0(h,{relative:E}),[f,S,x]=lb(g,b),R=vb(h,{replace:B,state:l,target:c,preventScrollReset:p,relative:E,viewTransition:v});function O(N){o&&o(N),N.defaultPrevented||R(N)}let y=U.createElement("a",{...b,...x,href:a||d,onClick:u||T?o:O,ref:hb(w,S),target:c,"data-discover":!C&&_==="render"?"true":void 0});return f&&!C?U.createElement(U.Fragment,null,y,U.createElement(ob,{page:d})):y});zs.displayName="Link";var fb=U.forwardRef(function({"aria-current":o="page",caseSensitive:_=!1,className:g="",end:E=!1,style:T,to:B,viewTransition:l,children:c,...h},p){let v=ca(B,{relative:h.relative}),b=Zr(),w=U.useContext(Pl),{navigator:k,basename:C}=U.useContext(Oi),a=w!=null&&Sb(v)&&l===!0,u=k.encodeLocation?k.encodeLocation(v).pathname:v.pathname,d=b.pathname,f=w&&w.navigation&&w.navigation.location?w.navigation.location.pathname:null;_||(d=d.toLowerCase(),f=f?f.toLowerCase():null,u=u.toLowerCase()),f&&C&&(f=er(f,C)||f);const S=u!=="/"&&u.endsWith("/")?u.length-1:u.length;let x=d===u||!E&&d.startsWith(u)&&d.charAt(S)==="/",R=f!=null&&(f===u||!E&&f.startsWith(u)&&f.charAt(u.length)==="/"),O={isActive:x,isPending:R,isTransitioning:a},y=x?o:void 0,N;typeof g=="function"?N=g(O):N=[g,x?"active":null,R?"pending":null,a?"transitioning":null].filter(Boolean).join(" ");let F=typeof T=="function"?T(O):T;return U.createElement(zs,{...h,"aria-current":y,className:N,ref:p,style:F,to:B,viewTransition:l},typeof c=="function"?c(O):c)});fb.displayName="NavLink";var mb=U.forwardRef(({discover:s="render",fetcherKey:o,navigate:_,reloadDocument:g,replace:E,state:T,method:B=Ml,action:l,onSubmit:c,relative:h,preventScrollReset:p,viewTransition:v,...b},w)=>{let k=bb(),C=yb(l,{relative:h}),a=B.toLowerCase()==="get"?"get":"post",u=typeof l=="string"&&I_.test(l),d=f=>{if(c&&c(f),f.defaultPrevented)return;f.preventDefault();let S=f.nativeEvent.submitter,x=S?.getAttribute("formmethod")||B;k(S||f.currentTarget,{fetcherKey:o,method:x,navigate:_,replace:E,state:T,relative:h,preventScrollReset:p,viewTransition:v})};return U.createElement("form",{ref:w,method:a,action:C,onSubmit:g?c:d,...b,"data-discover":!u&&s==="render"?"true":void 0})});mb.displayName="Form";function _b(s){return`${s} must be used within a data router.  See https://reactrouter.com/en/main/routers/picking-a-router.`}function F_(s){let o=U.useContext(Fs);return et(o,_b(s)),o}function vb(s,{target:o,replace:_,state:g,preventScrollReset:E,relative:T,viewTransition:B}={}){let l=E0(),c=Zr(),h=ca(s,{relative:T});return U.useCallback(p=>{if($0(p,o)){p.preventDefault();let v=_!==void 0?_:Qn(c)===Qn(h);l(s,{replace:v,state:g,preventScrollReset:E,relative:T,viewTransition:B})}},[c,l,h,_,g,o,s,E,T,B])}var gb=0,pb=()=>`__${String(++gb)}__`;function bb(){let{router:s}=F_("useSubmit"),{basename:o}=U.useContext(Oi),_=H0();return U.useCallback(async(g,E={})=>{let{action:T,method:B,encType:l,formData:c,body:h}=W0(g,o);if(E.navigate===!1){let p=E.fetcherKey||pb();await s.fetch(p,_,E.action||T,{preventScrollReset:E.preventScrollReset,formData:c,body:h,formMethod:E.method||B,formEncType:E.encType||l,flushSync:E.flushSync})}else await s.navigate(E.action||T,{preventScrollReset:E.preventScrollReset,formData:c,body:h,formMethod:E.method||B,formEncType:E.encType||l,replace:E.replace,state:E.state,fromRouteId:_,flushSync:E.flushSync,viewTransition:E.viewTransition})},[s,o,_])}function yb(s,{relative:o}={}){let{basename:_}=U.useContext(Oi),g=U.useContext(Ni);et(g,"useFormAction must be used inside a RouteContext");let[E]=g.matches.slice(-1),T={...ca(s||".",{relative:o})},B=Zr();if(s==null){T.search=B.search;let l=new URLSearchParams(T.search),c=l.getAll("index");if(c.some(p=>p==="")){l.delete("index"),c.filter(v=>v).forEach(v=>l.append("index",v));let p=l.toString();T.search=p?`?${p}`:""}}return(!s||s===".")&&E.route.index&&(T.search=T.search?T.search.replace(/^\?/,"?index&"):"?index"),_!=="/"&&(T.pathname=T.pathname==="/"?_:Ji([_,T.pathname])),Qn(T)}function Sb(s,o={}){let _=U.useContext(O_);et(_!=null,"`useViewTransitionState` must be used within `react-router-dom`'s `RouterProvider`.  Did you accidentally import `RouterProvider` from `react-router`?");let{basename:g}=F_("useViewTransitionState"),E=ca(s,{relative:o.relative});if(!_.isTransitioning)return!1;let T=er(_.currentLocation.pathname,g)||_.currentLocation.pathname,B=er(_.nextLocation.pathname,g)||_.nextLocation.pathname;return Nl(E.pathname,B)!=null||Nl(E.pathname,T)!=null}[...sb];var Kn=k_();const fi="http://localhost:24050/api",Xr=async()=>{console.log("fetchFlows API called");const s=await fetch(`${fi}/flows`);if(console.log("Fetch flows API response status:",s.status),!s.ok){const _=await s.text();throw console.error("Fetch flows API error:",_),new Error(`Failed to fetch flows: ${_}`)}const o=await s.json();return console.log("Fetch flows API response data:",o),o},xb=async s=>{console.log("createFlow API called with data:",s);const o=await fetch(`${fi}/flows`,{method:"POST",headers:{"Content-Type":"application/json"},body:JSON.stringify(s)});if(console.log("Create flow API response status:",o.status),!o.ok){const g=await o.text();throw console.error("Create flow API error:",g),new Error(`Failed to create flow: ${g}`)}const _=await o.json();return console.log("Create flow API response data:",_),_},Cb=async(s,o)=>{console.log("updateFlow API called with data:",{flowId:s,flowData:o});const _=await fetch(`${fi}/flows/${s}`,{method:"PUT",headers:{"Content-Type":"application/json"},body:JSON.stringify(o)});if(console.log("Update flow API response status:",_.status),!_.ok){const E=await _.text();throw console.error("Update flow API error:",E),new Error(`Failed to update flow: ${E}`)}const g=await _.json();return console.log("Update flow API response data:",g),g},wb=async s=>{console.log("deleteFlow API called with flowId:",s);const o=await fetch(`${fi}/flows/${s}`,{method:"DELETE"});if(console.log("Delete flow API response status:",o.status),!o.ok){const g=await o.text();throw console.error("Delete flow API error:",g),new Error(`Failed to delete flow: ${g}`)}const _=await o.json();return console.log("Delete flow API response data:",_),_},Wm=async(s,o)=>{console.log("updateStep API called with data:",{stepId:s,stepData:o});const _=await fetch(`${fi}/steps/${s}`,{method:"PUT",headers:{"Content-Type":"application/json"},body:JSON.stringify(o)});if(console.log("Update step API response status:",_.status),!_.ok){const E=await _.text();throw console.error("Update step API error:",E),new Error(`Failed to update step: ${E}`)}const g=await _.json();return console.log("Update step API response data:",g),g},Eb=async s=>{console.log("createStep API called with data:",s);const o=await fetch(`${fi}/steps`,{method:"POST",headers:{"Content-Type":"application/json"},body:JSON.stringify(s)});if(console.log("Create step API response status:",o.status),!o.ok){const g=await o.text();throw console.error("Create step API error:",g),new Error(`Failed to create step: ${g}`)}const _=await o.json();return console.log("Create step API response data:",_),_},kb=async s=>{console.log("deleteStep API called with stepId:",s);const o=await fetch(`${fi}/steps/${s}`,{method:"DELETE"});if(console.log("Delete step API response status:",o.status),!o.ok){const g=await o.text();throw console.error("Delete step API error:",g),new Error(`Failed to delete step: ${g}`)}const _=await o.json();return console.log("Delete step API response data:",_),_},yu=async(s,o,_)=>{console.log("updateVariable API called with data:",{flowId:s,key:o,variableData:_});const g=await fetch(`${fi}/variables/${s}/${o}`,{method:"PUT",headers:{"Content-Type":"application/json"},body:JSON.stringify(_)});if(console.log("Update variable API response status:",g.status),!g.ok){const T=await g.text();throw console.error("Update variable API error:",T),new Error(`Failed to update variable: ${T}`)}const E=await g.json();return console.log("Update variable API response data:",E),E},q_=async(s,o)=>{console.log("deleteVariable API called with data:",{flowId:s,key:o});const _=await fetch(`${fi}/variables/${s}/${o}`,{method:"DELETE"});if(console.log("Delete variable API response status:",_.status),!_.ok){const g=await _.json();throw console.error("Delete variable API error:",g),new Error(g.error||"Failed to delete variable")}return _.json()},Zm=async s=>{console.log("executeStep API called with stepId:",s);const o=await fetch(`${fi}/execute-step`,{method:"POST",headers:{"Content-Type":"application/json"},body:JSON.stringify({step_id:s})});if(console.log("Execute step API response status:",o.status),!o.ok){const g=await o.text();throw console.error("Execute step API error:",g),new Error(`Failed to execute step: ${g}`)}const _=await o.json();return console.log("Execute step API response data:",_),_},Rb=async s=>{const o=await fetch(`${fi}/flows/${s}/export`);if(!o.ok){const _=await o.json();throw new Error(_.error||"Failed to export flow")}return o.blob()},Db=async s=>{const o=await fetch(`${fi}/flows/import`,{method:"POST",headers:{"Content-Type":"application/json"},body:JSON.stringify(s)});if(!o.ok){const _=await o.json();throw new Error(_.error||"Failed to import flow")}return o.json()};function Qm(s,o){if(typeof s=="function")return s(o);s!=null&&(s.current=o)}function Ab(...s){return o=>{let _=!1;const g=s.map(E=>{const T=Qm(E,o);return!_&&typeof T=="function"&&(_=!0),T});if(_)return()=>{for(let E=0;E<g.length;E++){const T=g[E];typeof T=="function"?T():Qm(s[E],null)}}}}function V_(s){const o=Mb(s),_=U.forwardRef((g,E)=>{const{children:T,...B}=g,l=U.Children.toArray(T),c=l.find(Ob);if(c){const h=c.props.children,p=l.map(v=>v===c?U.Children.count(h)>1?U.Children.only(null):U.isValidElement(h)?h.props.children:null:v);return D.jsx(o,{...B,ref:E,children:U.isValidElement(h)?U.cloneElement(h,void 0,p):null})}return D.jsx(o,{...B,ref:E,children:T})});return _.displayName=`${s}.Slot`,_}var Tb=V_("Slot");function Mb(s){const o=U.forwardRef((_,g)=>{const{children:E,...T}=_;if(U.isValidElement(E)){const B=Bb(E),l=Nb(T,E.props);return E.type!==U.Fragment&&(l.ref=g?Ab(g,B):B),U.cloneElement(E,l)}return U.Children.count(E)>1?U.Children.only(null):null});return o.displayName=`${s}.SlotClone`,o}var Lb=Symbol("radix.slottable");function Ob(s){return U.isValidElement(s)&&typeof s.type=="function"&&"__radixId"in s.type&&s.type.__radixId===Lb}function Nb(s,o){const _={...o};for(const g in o){const E=s[g],T=o[g];/^on[A-Z]/.test(g)?E&&T?_[g]=(...l)=>{const c=T(...l);return E(...l),c}:E&&(_[g]=E):g==="style"?_[g]={...E,...T}:g==="className"&&(_[g]=[E,T].filter(Boolean).join(" "))}return{...s,..._}}function Bb(s){let o=Object.getOwnPropertyDescriptor(s.props,"ref")?.get,_=o&&"isReactWarning"in o&&o.isReactWarning;return _?s.ref:(o=Object.getOwnPropertyDescriptor(s,"ref")?.get,_=o&&"isReactWarning"in o&&o.isReactWarning,_?s.props.ref:s.props.ref||s.ref)}function G_(s){var o,_,g="";if(typeof s=="string"||typeof s=="number")g+=s;else if(typeof s=="object")if(Array.isArray(s)){var E=s.length;for(o=0;o<E;o++)s[o]&&(_=G_(s[o]))&&(g&&(g+=" "),g+=_)}else for(_ in s)s[_]&&(g&&(g+=" "),g+=_);return g}function Y_(){for(var s,o,_=0,g="",E=arguments.length;_<E;_++)(s=arguments[_])&&(o=G_(s))&&(g&&(g+=" "),g+=o);return g}const Jm=s=>typeof s=="boolean"?`${s}`:s===0?"0":s,e_=Y_,$_=(s,o)=>_=>{var g;if(o?.variants==null)return e_(s,_?.class,_?.className);const{variants:E,defaultVariants:T}=o,B=Object.keys(E).map(h=>{const p=_?.[h],v=T?.[h];if(p===null)return null;const b=Jm(p)||Jm(v);return E[h][b]}),l=_&&Object.entries(_).reduce((h,p)=>{let[v,b]=p;return b===void 0||(h[v]=b),h},{}),c=o==null||(g=o.compoundVariants)===null||g===void 0?void 0:g.reduce((h,p)=>{let{class:v,className:b,...w}=p;return Object.entries(w).every(k=>{let[C,a]=k;return Array.isArray(a)?a.includes({...T,...l}[C]):{...T,...l}[C]===a})?[...h,v,b]:h},[]);return e_(s,B,c,_?.class,_?.className)},Hu="-",jb=s=>{const o=zb(s),{conflictingClassGroups:_,conflictingClassGroupModifiers:g}=s;return{getClassGroupId:B=>{const l=B.split(Hu);return l[0]===""&&l.length!==1&&l.shift(),K_(l,o)||Hb(B)},getConflictingClassGroupIds:(B,l)=>{const c=_[B]||[];return l&&g[B]?[...c,...g[B]]:c}}},K_=(s,o)=>{if(s.length===0)return o.classGroupId;const _=s[0],g=o.nextPart.get(_),E=g?K_(s.slice(1),g):void 0;if(E)return E;if(o.validators.length===0)return;const T=s.join(Hu);return o.validators.find(({validator:B})=>B(T))?.classGroupId},t_=/^\[(.+)\]$/,Hb=s=>{if(t_.test(s)){const o=t_.exec(s)[1],_=o?.substring(0,o.indexOf(":"));if(_)return"arbitrary.."+_}},zb=s=>{const{theme:o,classGroups:_}=s,g={nextPart:new Map,validators:[]};for(const E in _)Su(_[E],g,E,o);return g},Su=(s,o,_,g)=>{s.forEach(E=>{if(typeof E=="string"){const T=E===""?o:i_(o,E);T.classGroupId=_;return}if(typeof E=="function"){if(Ub(E)){Su(E(g),o,_,g);return}o.validators.push({validator:E,classGroupId:_});return}Object.entries(E).forEach(([T,B])=>{Su(B,i_(o,T),_,g)})})},i_=(s,o)=>{let _=s;return o.split(Hu).forEach(g=>{_.nextPart.has(g)||_.nextPart.set(g,{nextPart:new Map,validators:[]}),_=_.nextPart.get(g)}),_},Ub=s=>s.isThemeGetter,Pb=s=>{if(s<1)return{get:()=>{},set:()=>{}};let o=0,_=new Map,g=new Map;const E=(T,B)=>{_.set(T,B),o++,o>s&&(o=0,g=_,_=new Map)};return{get(T){let B=_.get(T);if(B!==void 0)return B;if((B=g.get(T))!==void 0)return E(T,B),B},set(T,B){_.has(T)?_.set(T,B):E(T,B)}}},xu="!",Cu=":",Ib=Cu.length,Fb=s=>{const{prefix:o,experimentalParseClassName:_}=s;let g=E=>{const T=[];let B=0,l=0,c=0,h;for(let k=0;k<E.length;k++){let C=E[k];if(B===0&&l===0){if(C===Cu){T.push(E.slice(c,k)),c=k+Ib;continue}if(C==="/"){h=k;continue}}C==="["?B++:C==="]"?B--:C==="("?l++:C===")"&&l--}const p=T.length===0?E:E.substring(c),v=qb(p),b=v!==p,w=h&&h>c?h-c:void 0;return{modifiers:T,hasImportantModifier:b,baseClassName:v,maybePostfixModifierPosition:w}};if(o){const E=o+Cu,T=g;g=B=>B.startsWith(E)?T(B.substring(E.length)):{isExternal:!0,modifiers:[],hasImportantModifier:!1,baseClassName:B,maybePostfixModifierPosition:void 0}}if(_){const E=g;g=T=>_({className:T,parseClassName:E})}return g},qb=s=>s.endsWith(xu)?s.substring(0,s.length-1):s.startsWith(xu)?s.substring(1):s,Vb=s=>{const o=Object.fromEntries(s.orderSensitiveModifiers.map(g=>[g,!0]));return g=>{if(g.length<=1)return g;const E=[];let T=[];return g.forEach(B=>{B[0]==="["||o[B]?(E.push(...T.sort(),B),T=[]):T.push(B)}),E.push(...T.sort()),E}},Gb=s=>({cache:Pb(s.cacheSize),parseClassName:Fb(s),sortModifiers:Vb(s),...jb(s)}),Yb=/\s+/,$b=(s,o)=>{const{parseClassName:_,getClassGroupId:g,getConflictingClassGroupIds:E,sortModifiers:T}=o,B=[],l=s.trim().split(Yb);let c="";for(let h=l.length-1;h>=0;h-=1){const p=l[h],{isExternal:v,modifiers:b,hasImportantModifier:w,baseClassName:k,maybePostfixModifierPosition:C}=_(p);if(v){c=p+(c.length>0?" "+c:c);continue}let a=!!C,u=g(a?k.substring(0,C):k);if(!u){if(!a){c=p+(c.length>0?" "+c:c);continue}if(u=g(k),!u){c=p+(c.length>0?" "+c:c);continue}a=!1}const d=T(b).join(":"),f=w?d+xu:d,S=f+u;if(B.includes(S))continue;B.push(S);const x=E(u,a);for(let R=0;R<x.length;++R){const O=x[R];B.push(f+O)}c=p+(c.length>0?" "+c:c)}return c};function Kb(){let s=0,o,_,g="";for(;s<arguments.length;)(o=arguments[s++])&&(_=X_(o))&&(g&&(g+=" "),g+=_);return g}const X_=s=>{if(typeof s=="string")return s;let o,_="";for(let g=0;g<s.length;g++)s[g]&&(o=X_(s[g]))&&(_&&(_+=" "),_+=o);return _};function Xb(s,...o){let _,g,E,T=B;function B(c){const h=o.reduce((p,v)=>v(p),s());return _=Gb(h),g=_.cache.get,E=_.cache.set,T=l,l(c)}function l(c){const h=g(c);if(h)return h;const p=$b(c,_);return E(c,p),p}return function(){return T(Kb.apply(null,arguments))}}const ut=s=>{const o=_=>_[s]||[];return o.isThemeGetter=!0,o},W_=/^\[(?:(\w[\w-]*):)?(.+)\]$/i,Z_=/^\((?:(\w[\w-]*):)?(.+)\)$/i,Wb=/^\d+\/\d+$/,Zb=/^(\d+(\.\d+)?)?(xs|sm|md|lg|xl)$/,Qb=/\d+(%|px|r?em|[sdl]?v([hwib]|min|max)|pt|pc|in|cm|mm|cap|ch|ex|r?lh|cq(w|h|i|b|min|max))|\b(calc|min|max|clamp)\(.+\)|^0$/,Jb=/^(rgba?|hsla?|hwb|(ok)?(lab|lch)|color-mix)\(.+\)$/,ey=/^(inset_)?-?((\d+)?\.?(\d+)[a-z]+|0)_-?((\d+)?\.?(\d+)[a-z]+|0)/,ty=/^(url|image|image-set|cross-fade|element|(repeating-)?(linear|radial|conic)-gradient)\(.+\)$/,Hs=s=>Wb.test(s),Re=s=>!!s&&!Number.isNaN(Number(s)),kr=s=>!!s&&Number.isInteger(Number(s)),ou=s=>s.endsWith("%")&&Re(s.slice(0,-1)),Qi=s=>Zb.test(s),iy=()=>!0,ry=s=>Qb.test(s)&&!Jb.test(s),Q_=()=>!1,sy=s=>ey.test(s),ny=s=>ty.test(s),ay=s=>!_e(s)&&!ve(s),ly=s=>qs(s,tv,Q_),_e=s=>W_.test(s),Kr=s=>qs(s,iv,ry),cu=s=>qs(s,dy,Re),r_=s=>qs(s,J_,Q_),oy=s=>qs(s,ev,ny),Rl=s=>qs(s,rv,sy),ve=s=>Z_.test(s),$n=s=>Vs(s,iv),cy=s=>Vs(s,fy),s_=s=>Vs(s,J_),uy=s=>Vs(s,tv),hy=s=>Vs(s,ev),Dl=s=>Vs(s,rv,!0),qs=(s,o,_)=>{const g=W_.exec(s);return g?g[1]?o(g[1]):_(g[2]):!1},Vs=(s,o,_=!1)=>{const g=Z_.exec(s);return g?g[1]?o(g[1]):_:!1},J_=s=>s==="position"||s==="percentage",ev=s=>s==="image"||s==="url",tv=s=>s==="length"||s==="size"||s==="bg-size",iv=s=>s==="length",dy=s=>s==="number",fy=s=>s==="family-name",rv=s=>s==="shadow",my=()=>{const s=ut("color"),o=ut("font"),_=ut("text"),g=ut("font-weight"),E=ut("tracking"),T=ut("leading"),B=ut("breakpoint"),l=ut("container"),c=ut("spacing"),h=ut("radius"),p=ut("shadow"),v=ut("inset-shadow"),b=ut("text-shadow"),w=ut("drop-shadow"),k=ut("blur"),C=ut("perspective"),a=ut("aspect"),u=ut("ease"),d=ut("animate"),f=()=>["auto","avoid","all","avoid-page","page","left","right","column"],S=()=>["center","top","bottom","left","right","top-left","left-top","top-right","right-top","bottom-right","right-bottom","bottom-left","left-bottom"],x=()=>[...S(),ve,_e],R=()=>["auto","hidden","clip","visible","scroll"],O=()=>["auto","contain","none"],y=()=>[ve,_e,c],N=()=>[Hs,"full","auto",...y()],F=()=>[kr,"none","subgrid",ve,_e],Y=()=>["auto",{span:["full",kr,ve,_e]},kr,ve,_e],q=()=>[kr,"auto",ve,_e],V=()=>["auto","min","max","fr",ve,_e],ee=()=>["start","end","center","between","around","evenly","stretch","baseline","center-safe","end-safe"],K=()=>["start","end","center","stretch","center-safe","end-safe"],I=()=>["auto",...y()],G=()=>[Hs,"auto","full","dvw","dvh","lvw","lvh","svw","svh","min","max","fit",...y()],A=()=>[s,ve,_e],z=()=>[...S(),s_,r_,{position:[ve,_e]}],M=()=>["no-repeat",{repeat:["","x","y","space","round"]}],P=()=>["auto","cover","contain",uy,ly,{size:[ve,_e]}],W=()=>[ou,$n,Kr],J=()=>["","none","full",h,ve,_e],le=()=>["",Re,$n,Kr],ue=()=>["solid","dashed","dotted","double"],fe=()=>["normal","multiply","screen","overlay","darken","lighten","color-dodge","color-burn","hard-light","soft-light","difference","exclusion","hue","saturation","color","luminosity"],j=()=>[Re,ou,s_,r_],Z=()=>["","none",k,ve,_e],ae=()=>["none",Re,ve,_e],re=()=>["none",Re,ve,_e],pe=()=>[Re,ve,_e],de=()=>[Hs,"full",...y()];return{cacheSize:500,theme:{animate:["spin","ping","pulse","bounce"],aspect:["video"],blur:[Qi],breakpoint:[Qi],color:[iy],container:[Qi],"drop-shadow":[Qi],ease:["in","out","in-out"],font:[ay],"font-weight":["thin","extralight","light","normal","medium","semibold","bold","extrabold","black"],"inset-shadow":[Qi],leading:["none","tight","snug","normal","relaxed","loose"],perspective:["dramatic","near","normal","midrange","distant","none"],radius:[Qi],shadow:[Qi],spacing:["px",Re],text:[Qi],"text-shadow":[Qi],tracking:["tighter","tight","normal","wide","wider","widest"]},classGroups:{aspect:[{aspect:["auto","square",Hs,_e,ve,a]}],container:["container"],columns:[{columns:[Re,_e,ve,l]}],"break-after":[{"break-after":f()}],"break-before":[{"break-before":f()}],"break-inside":[{"break-inside":["auto","avoid","avoid-page","avoid-column"]}],"box-decoration":[{"box-decoration":["slice","clone"]}],box:[{box:["border","content"]}],display:["block","inline-block","inline","flex","inline-flex","table","inline-table","table-caption","table-cell","table-column","table-column-group","table-footer-group","table-header-group","table-row-group","table-row","flow-root","grid","inline-grid","contents","list-item","hidden"],sr:["sr-only","not-sr-only"],float:[{float:["right","left","none","start","end"]}],clear:[{clear:["left","right","both","none","start","end"]}],isolation:["isolate","isolation-auto"],"object-fit":[{object:["contain","cover","fill","none","scale-down"]}],"object-position":[{object:x()}],overflow:[{overflow:R()}],"overflow-x":[{"overflow-x":R()}],"overflow-y":[{"overflow-y":R()}],overscroll:[{overscroll:O()}],"overscroll-x":[{"overscroll-x":O()}],"overscroll-y":[{"overscroll-y":O()}],position:["static","fixed","absolute","relative","sticky"],inset:[{inset:N()}],"inset-x":[{"inset-x":N()}],"inset-y":[{"inset-y":N()}],start:[{start:N()}],end:[{end:N()}],top:[{top:N()}],right:[{right:N()}],bottom:[{bottom:N()}],left:[{left:N()}],visibility:["visible","invisible","collapse"],z:[{z:[kr,"auto",ve,_e]}],basis:[{basis:[Hs,"full","auto",l,...y()]}],"flex-direction":[{flex:["row","row-reverse","col","col-reverse"]}],"flex-wrap":[{flex:["nowrap","wrap","wrap-reverse"]}],flex:[{flex:[Re,Hs,"auto","initial","none",_e]}],grow:[{grow:["",Re,ve,_e]}],shrink:[{shrink:["",Re,ve,_e]}],order:[{order:[kr,"first","last","none",ve,_e]}],"grid-cols":[{"grid-cols":F()}],"col-start-end":[{col:Y()}],"col-start":[{"col-start":q()}],"col-end":[{"col-end":q()}],"grid-rows":[{"grid-rows":F()}],"row-start-end":[{row:Y()}],"row-start":[{"row-start":q()}],"row-end":[{"row-end":q()}],"grid-flow":[{"grid-flow":["row","col","dense","row-dense","col-dense"]}],"auto-cols":[{"auto-cols":V()}],"auto-rows":[{"auto-rows":V()}],gap:[{gap:y()}],"gap-x":[{"gap-x":y()}],"gap-y":[{"gap-y":y()}],"justify-content":[{justify:[...ee(),"normal"]}],"justify-items":[{"justify-items":[...K(),"normal"]}],"justify-self":[{"justify-self":["auto",...K()]}],"align-content":[{content:["normal",...ee()]}],"align-items":[{items:[...K(),{baseline:["","last"]}]}],"align-self":[{self:["auto",...K(),{baseline:["","last"]}]}],"place-content":[{"place-content":ee()}],"place-items":[{"place-items":[...K(),"baseline"]}],"place-self":[{"place-self":["auto",...K()]}],p:[{p:y()}],px:[{px:y()}],py:[{py:y()}],ps:[{ps:y()}],pe:[{pe:y()}],pt:[{pt:y()}],pr:[{pr:y()}],pb:[{pb:y()}],pl:[{pl:y()}],m:[{m:I()}],mx:[{mx:I()}],my:[{my:I()}],ms:[{ms:I()}],me:[{me:I()}],mt:[{mt:I()}],mr:[{mr:I()}],mb:[{mb:I()}],ml:[{ml:I()}],"space-x":[{"space-x":y()}],"space-x-reverse":["space-x-reverse"],"space-y":[{"space-y":y()}],"space-y-reverse":["space-y-reverse"],size:[{size:G()}],w:[{w:[l,"screen",...G()]}],"min-w":[{"min-w":[l,"screen","none",...G()]}],"max-w":[{"max-w":[l,"screen","none","prose",{screen:[B]},...G()]}],h:[{h:["screen","lh",...G()]}],"min-h":[{"min-h":["screen","lh","none",...G()]}],"max-h":[{"max-h":["screen","lh",...G()]}],"font-size":[{text:["base",_,$n,Kr]}],"font-smoothing":["antialiased","subpixel-antialiased"],"font-style":["italic","not-italic"],"font-weight":[{font:[g,ve,cu]}],"font-stretch":[{"font-stretch":["ultra-condensed","extra-condensed","condensed","semi-condensed","normal","semi-expanded","expanded","extra-expanded","ultra-expanded",ou,_e]}],"font-family":[{font:[cy,_e,o]}],"fvn-normal":["normal-nums"],"fvn-ordinal":["ordinal"],"fvn-slashed-zero":["slashed-zero"],"fvn-figure":["lining-nums","oldstyle-nums"],"fvn-spacing":["proportional-nums","tabular-nums"],"fvn-fraction":["diagonal-fractions","stacked-fractions"],tracking:[{tracking:[E,ve,_e]}],"line-clamp":[{"line-clamp":[Re,"none",ve,cu]}],leading:[{leading:[T,...y()]}],"list-image":[{"list-image":["none",ve,_e]}],"list-style-position":[{list:["inside","outside"]}],"list-style-type":[{list:["disc","decimal","none",ve,_e]}],"text-alignment":[{text:["left","center","right","justify","start","end"]}],"placeholder-color":[{placeholder:A()}],"text-color":[{text:A()}],"text-decoration":["underline","overline","line-through","no-underline"],"text-decoration-style":[{decoration:[...ue(),"wavy"]}],"text-decoration-thickness":[{decoration:[Re,"from-font","auto",ve,Kr]}],"text-decoration-color":[{decoration:A()}],"underline-offset":[{"underline-offset":[Re,"auto",ve,_e]}],"text-transform":["uppercase","lowercase","capitalize","normal-case"],"text-overflow":["truncate","text-ellipsis","text-clip"],"text-wrap":[{text:["wrap","nowrap","balance","pretty"]}],indent:[{indent:y()}],"vertical-align":[{align:["baseline","top","middle","bottom","text-top","text-bottom","sub","super",ve,_e]}],whitespace:[{whitespace:["normal","nowrap","pre","pre-line","pre-wrap","break-spaces"]}],break:[{break:["normal","words","all","keep"]}],wrap:[{wrap:["break-word","anywhere","normal"]}],hyphens:[{hyphens:["none","manual","auto"]}],content:[{content:["none",ve,_e]}],"bg-attachment":[{bg:["fixed","local","scroll"]}],"bg-clip":[{"bg-clip":["border","padding","content","text"]}],"bg-origin":[{"bg-origin":["border","padding","content"]}],"bg-position":[{bg:z()}],"bg-repeat":[{bg:M()}],"bg-size":[{bg:P()}],"bg-image":[{bg:["none",{linear:[{to:["t","tr","r","br","b","bl","l","tl"]},kr,ve,_e],radial:["",ve,_e],conic:[kr,ve,_e]},hy,oy]}],"bg-color":[{bg:A()}],"gradient-from-pos":[{from:W()}],"gradient-via-pos":[{via:W()}],"gradient-to-pos":[{to:W()}],"gradient-from":[{from:A()}],"gradient-via":[{via:A()}],"gradient-to":[{to:A()}],rounded:[{rounded:J()}],"rounded-s":[{"rounded-s":J()}],"rounded-e":[{"rounded-e":J()}],"rounded-t":[{"rounded-t":J()}],"rounded-r":[{"rounded-r":J()}],"rounded-b":[{"rounded-b":J()}],"rounded-l":[{"rounded-l":J()}],"rounded-ss":[{"rounded-ss":J()}],"rounded-se":[{"rounded-se":J()}],"rounded-ee":[{"rounded-ee":J()}],"rounded-es":[{"rounded-es":J()}],"rounded-tl":[{"rounded-tl":J()}],"rounded-tr":[{"rounded-tr":J()}],"rounded-br":[{"rounded-br":J()}],"rounded-bl":[{"rounded-bl":J()}],"border-w":[{border:le()}],"border-w-x":[{"border-x":le()}],"border-w-y":[{"border-y":le()}],"border-w-s":[{"border-s":le()}],"border-w-e":[{"border-e":le()}],"border-w-t":[{"border-t":le()}],"border-w-r":[{"border-r":le()}],"border-w-b":[{"border-b":le()}],"border-w-l":[{"border-l":le()}],"divide-x":[{"divide-x":le()}],"divide-x-reverse":["divide-x-reverse"],"divide-y":[{"divide-y":le()}],"divide-y-reverse":["divide-y-reverse"],"border-style":[{border:[...ue(),"hidden","none"]}],"divide-style":[{divide:[...ue(),"hidden","none"]}],"border-color":[{border:A()}],"border-color-x":[{"border-x":A()}],"border-color-y":[{"border-y":A()}],"border-color-s":[{"border-s":A()}],"border-color-e":[{"border-e":A()}],"border-color-t":[{"border-t":A()}],"border-color-r":[{"border-r":A()}],"border-color-b":[{"border-b":A()}],"border-color-l":[{"border-l":A()}],"divide-color":[{divide:A()}],"outline-style":[{outline:[...ue(),"none","hidden"]}],"outline-offset":[{"outline-offset":[Re,ve,_e]}],"outline-w":[{outline:["",Re,$n,Kr]}],"outline-color":[{outline:A()}],shadow:[{shadow:["","none",p,Dl,Rl]}],"shadow-color":[{shadow:A()}],"inset-shadow":[{"inset-shadow":["none",v,Dl,Rl]}],"inset-shadow-color":[{"inset-shadow":A()}],"ring-w":[{ring:le()}],"ring-w-inset":["ring-inset"],"ring-color":[{ring:A()}],"ring-offset-w":[{"ring-offset":[Re,Kr]}],"ring-offset-color":[{"ring-offset":A()}],"inset-ring-w":[{"inset-ring":le()}],"inset-ring-color":[{"inset-ring":A()}],"text-shadow":[{"text-shadow":["none",b,Dl,Rl]}],"text-shadow-color":[{"text-shadow":A()}],opacity:[{opacity:[Re,ve,_e]}],"mix-blend":[{"mix-blend":[...fe(),"plus-darker","plus-lighter"]}],"bg-blend":[{"bg-blend":fe()}],"mask-clip":[{"mask-clip":["border","padding","content","fill","stroke","view"]},"mask-no-clip"],"mask-composite":[{mask:["add","subtract","intersect","exclude"]}],"mask-image-linear-pos":[{"mask-linear":[Re]}],"mask-image-linear-from-pos":[{"mask-linear-from":j()}],"mask-image-linear-to-pos":[{"mask-linear-to":j()}],"mask-image-linear-from-color":[{"mask-linear-from":A()}],"mask-image-linear-to-color":[{"mask-linear-to":A()}],"mask-image-t-from-pos":[{"mask-t-from":j()}],"mask-image-t-to-pos":[{"mask-t-to":j()}],"mask-image-t-from-color":[{"mask-t-from":A()}],"mask-image-t-to-color":[{"mask-t-to":A()}],"mask-image-r-from-pos":[{"mask-r-from":j()}],"mask-image-r-to-pos":[{"mask-r-to":j()}],"mask-image-r-from-color":[{"mask-r-from":A()}],"mask-image-r-to-color":[{"mask-r-to":A()}],"mask-image-b-from-pos":[{"mask-b-from":j()}],"mask-image-b-to-pos":[{"mask-b-to":j()}],"mask-image-b-from-color":[{"mask-b-from":A()}],"mask-image-b-to-color":[{"mask-b-to":A()}],"mask-image-l-from-pos":[{"mask-l-from":j()}],"mask-image-l-to-pos":[{"mask-l-to":j()}],"mask-image-l-from-color":[{"mask-l-from":A()}],"mask-image-l-to-color":[{"mask-l-to":A()}],"mask-image-x-from-pos":[{"mask-x-from":j()}],"mask-image-x-to-pos":[{"mask-x-to":j()}],"mask-image-x-from-color":[{"mask-x-from":A()}],"mask-image-x-to-color":[{"mask-x-to":A()}],"mask-image-y-from-pos":[{"mask-y-from":j()}],"mask-image-y-to-pos":[{"mask-y-to":j()}],"mask-image-y-from-color":[{"mask-y-from":A()}],"mask-image-y-to-color":[{"mask-y-to":A()}],"mask-image-radial":[{"mask-radial":[ve,_e]}],"mask-image-radial-from-pos":[{"mask-radial-from":j()}],"mask-image-radial-to-pos":[{"mask-radial-to":j()}],"mask-image-radial-from-color":[{"mask-radial-from":A()}],"mask-image-radial-to-color":[{"mask-radial-to":A()}],"mask-image-radial-shape":[{"mask-radial":["circle","ellipse"]}],"mask-image-radial-size":[{"mask-radial":[{closest:["side","corner"],farthest:["side","corner"]}]}],"mask-image-radial-pos":[{"mask-radial-at":S()}],"mask-image-conic-pos":[{"mask-conic":[Re]}],"mask-image-conic-from-pos":[{"mask-conic-from":j()}],"mask-image-conic-to-pos":[{"mask-conic-to":j()}],"mask-image-conic-from-color":[{"mask-conic-from":A()}],"mask-image-conic-to-color":[{"mask-conic-to":A()}],"mask-mode":[{mask:["alpha","luminance","match"]}],"mask-origin":[{"mask-origin":["border","padding","content","fill","stroke","view"]}],"mask-position":[{mask:z()}],"mask-repeat":[{mask:M()}],"mask-size":[{mask:P()}],"mask-type":[{"mask-type":["alpha","luminance"]}],"mask-image":[{mask:["none",ve,_e]}],filter:[{filter:["","none",ve,_e]}],blur:[{blur:Z()}],brightness:[{brightness:[Re,ve,_e]}],contrast:[{contrast:[Re,ve,_e]}],"drop-shadow":[{"drop-shadow":["","none",w,Dl,Rl]}],"drop-shadow-color":[{"drop-shadow":A()}],grayscale:[{grayscale:["",Re,ve,_e]}],"hue-rotate":[{"hue-rotate":[Re,ve,_e]}],invert:[{invert:["",Re,ve,_e]}],saturate:[{saturate:[Re,ve,_e]}],sepia:[{sepia:["",Re,ve,_e]}],"backdrop-filter":[{"backdrop-filter":["","none",ve,_e]}],"backdrop-blur":[{"backdrop-blur":Z()}],"backdrop-brightness":[{"backdrop-brightness":[Re,ve,_e]}],"backdrop-contrast":[{"backdrop-contrast":[Re,ve,_e]}],"backdrop-grayscale":[{"backdrop-grayscale":["",Re,ve,_e]}],"backdrop-hue-rotate":[{"backdrop-hue-rotate":[Re,ve,_e]}],"backdrop-invert":[{"backdrop-invert":["",Re,ve,_e]}],"backdrop-opacity":[{"backdrop-opacity":[Re,ve,_e]}],"backdrop-saturate":[{"backdrop-saturate":[Re,ve,_e]}],"backdrop-sepia":[{"backdrop-sepia":["",Re,ve,_e]}],"border-collapse":[{border:["collapse","separate"]}],"border-spacing":[{"border-spacing":y()}],"border-spacing-x":[{"border-spacing-x":y()}],"border-spacing-y":[{"border-spacing-y":y()}],"table-layout":[{table:["auto","fixed"]}],caption:[{caption:["top","bottom"]}],transition:[{transition:["","all","colors","opacity","shadow","transform","none",ve,_e]}],"transition-behavior":[{transition:["normal","discrete"]}],duration:[{duration:[Re,"initial",ve,_e]}],ease:[{ease:["linear","initial",u,ve,_e]}],delay:[{delay:[Re,ve,_e]}],animate:[{animate:["none",d,ve,_e]}],backface:[{backface:["hidden","visible"]}],perspective:[{perspective:[C,ve,_e]}],"perspective-origin":[{"perspective-origin":x()}],rotate:[{rotate:ae()}],"rotate-x":[{"rotate-x":ae()}],"rotate-y":[{"rotate-y":ae()}],"rotate-z":[{"rotate-z":ae()}],scale:[{scale:re()}],"scale-x":[{"scale-x":re()}],"scale-y":[{"scale-y":re()}],"scale-z":[{"scale-z":re()}],"scale-3d":["scale-3d"],skew:[{skew:pe()}],"skew-x":[{"skew-x":pe()}],"skew-y":[{"skew-y":pe()}],transform:[{transform:[ve,_e,"","none","gpu","cpu"]}],"transform-origin":[{origin:x()}],"transform-style":[{transform:["3d","flat"]}],translate:[{translate:de()}],"translate-x":[{"translate-x":de()}],"translate-y":[{"translate-y":de()}],"translate-z":[{"translate-z":de()}],"translate-none":["translate-none"],accent:[{accent:A()}],appearance:[{appearance:["none","auto"]}],"caret-color":[{caret:A()}],"color-scheme":[{scheme:["normal","dark","light","light-dark","only-dark","only-light"]}],cursor:[{cursor:["auto","default","pointer","wait","text","move","help","not-allowed","none","context-menu","progress","cell","crosshair","vertical-text","alias","copy","no-drop","grab","grabbing","all-scroll","col-resize","row-resize","n-resize","e-resize","s-resize","w-resize","ne-resize","nw-resize","se-resize","sw-resize","ew-resize","ns-resize","nesw-resize","nwse-resize","zoom-in","zoom-out",ve,_e]}],"field-sizing":[{"field-sizing":["fixed","content"]}],"pointer-events":[{"pointer-events":["auto","none"]}],resize:[{resize:["none","","y","x"]}],"scroll-behavior":[{scroll:["auto","smooth"]}],"scroll-m":[{"scroll-m":y()}],"scroll-mx":[{"scroll-mx":y()}],"scroll-my":[{"scroll-my":y()}],"scroll-ms":[{"scroll-ms":y()}],"scroll-me":[{"scroll-me":y()}],"scroll-mt":[{"scroll-mt":y()}],"scroll-mr":[{"scroll-mr":y()}],"scroll-mb":[{"scroll-mb":y()}],"scroll-ml":[{"scroll-ml":y()}],"scroll-p":[{"scroll-p":y()}],"scroll-px":[{"scroll-px":y()}],"scroll-py":[{"scroll-py":y()}],"scroll-ps":[{"scroll-ps":y()}],"scroll-pe":[{"scroll-pe":y()}],"scroll-pt":[{"scroll-pt":y()}],"scroll-pr":[{"scroll-pr":y()}],"scroll-pb":[{"scroll-pb":y()}],"scroll-pl":[{"scroll-pl":y()}],"snap-align":[{snap:["start","end","center","align-none"]}],"snap-stop":[{snap:["normal","always"]}],"snap-type":[{snap:["none","x","y","both"]}],"snap-strictness":[{snap:["mandatory","proximity"]}],touch:[{touch:["auto","none","manipulation"]}],"touch-x":[{"touch-pan":["x","left","right"]}],"touch-y":[{"touch-pan":["y","up","down"]}],"touch-pz":["touch-pinch-zoom"],select:[{select:["none","text","all","auto"]}],"will-change":[{"will-change":["auto","scroll","contents","transform",ve,_e]}],fill:[{fill:["none",...A()]}],"stroke-w":[{stroke:[Re,$n,Kr,cu]}],stroke:[{stroke:["none",...A()]}],"forced-color-adjust":[{"forced-color-adjust":["auto","none"]}]},conflictingClassGroups:{overflow:["overflow-x","overflow-y"],overscroll:["overscroll-x","overscroll-y"],inset:["inset-x","inset-y","start","end","top","right","bottom","left"],"inset-x":["right","left"],"inset-y":["top","bottom"],flex:["basis","grow","shrink"],gap:["gap-x","gap-y"],p:["px","py","ps","pe","pt","pr","pb","pl"],px:["pr","pl"],py:["pt","pb"],m:["mx","my","ms","me","mt","mr","mb","ml"],mx:["mr","ml"],my:["mt","mb"],size:["w","h"],"font-size":["leading"],"fvn-normal":["fvn-ordinal","fvn-slashed-zero","fvn-figure","fvn-spacing","fvn-fraction"],"fvn-ordinal":["fvn-normal"],"fvn-slashed-zero":["fvn-normal"],"fvn-figure":["fvn-normal"],"fvn-spacing":["fvn-normal"],"fvn-fraction":["fvn-normal"],"line-clamp":["display","overflow"],rounded:["rounded-s","rounded-e","rounded-t","rounded-r","rounded-b","rounded-l","rounded-ss","rounded-se","rounded-ee","rounded-es","rounded-tl","rounded-tr","rounded-br","rounded-bl"],"rounded-s":["rounded-ss","rounded-es"],"rounded-e":["rounded-se","rounded-ee"],"rounded-t":["rounded-tl","rounded-tr"],"rounded-r":["rounded-tr","rounded-br"],"rounded-b":["rounded-br","rounded-bl"],"rounded-l":["rounded-tl","rounded-bl"],"border-spacing":["border-spacing-x","border-spacing-y"],"border-w":["border-w-x","border-w-y","border-w-s","border-w-e","border-w-t","border-w-r","border-w-b","border-w-l"],"border-w-x":["border-w-r","border-w-l"],"border-w-y":["border-w-t","border-w-b"],"border-color":["border-color-x","border-color-y","border-color-s","border-color-e","border-color-t","border-color-r","border-color-b","border-color-l"],"border-color-x":["border-color-r","border-color-l"],"border-color-y":["border-color-t","border-color-b"],translate:["translate-x","translate-y","translate-none"],"translate-none":["translate","translate-x","translate-y","translate-z"],"scroll-m":["scroll-mx","scroll-my","scroll-ms","scroll-me","scroll-mt","scroll-mr","scroll-mb","scroll-ml"],"scroll-mx":["scroll-mr","scroll-ml"],"scroll-my":["scroll-mt","scroll-mb"],"scroll-p":["scroll-px","scroll-py","scroll-ps","scroll-pe","scroll-pt","scroll-pr","scroll-pb","scroll-pl"],"scroll-px":["scroll-pr","scroll-pl"],"scroll-py":["scroll-pt","scroll-pb"],touch:["touch-x","touch-y","touch-pz"],"touch-x":["touch"],"touch-y":["touch"],"touch-pz":["touch"]},conflictingClassGroupModifiers:{"font-size":["leading"]},orderSensitiveModifiers:["*","**","after","backdrop","before","details-content","file","first-letter","first-line","marker","placeholder","selection"]}},_y=Xb(my);function It(...s){return _y(Y_(s))}const vy=$_("inline-flex items-center justify-center whitespace-nowrap rounded-md text-sm font-medium ring-offset-background transition-colors focus-visible:outline-none focus-visible:ring-2 focus-visible:ring-ring focus-visible:ring-offset-2 disabled:pointer-events-none disabled:opacity-50",{variants:{variant:{default:"bg-primary text-primary-foreground hover:bg-primary/90",destructive:"bg-destructive text-destructive-foreground hover:bg-destructive/90",outline:"border border-input bg-background hover:bg-accent hover:text-accent-foreground",secondary:"bg-secondary text-secondary-foreground hover:bg-secondary/80",ghost:"hover:bg-accent hover:text-accent-foreground",link:"text-primary underline-offset-4 hover:underline"},size:{default:"h-10 px-4 py-2",sm:"h-9 rounded-md px-3",lg:"h-11 rounded-md px-8",icon:"h-10 w-10"}},defaultVariants:{variant:"default",size:"default"}}),Ce=U.forwardRef(({className:s,variant:o,size:_,asChild:g=!1,...E},T)=>{const B=g?Tb:"button";return D.jsx(B,{className:It(vy({variant:o,size:_,className:s})),ref:T,...E})});Ce.displayName="Button";const Dt=U.forwardRef(({className:s,...o},_)=>D.jsx("div",{ref:_,className:It("rounded-lg border bg-card text-card-foreground shadow-sm",s),...o}));Dt.displayName="Card";const ti=U.forwardRef(({className:s,...o},_)=>D.jsx("div",{ref:_,className:It("flex flex-col space-y-1.5 p-6",s),...o}));ti.displayName="CardHeader";const di=U.forwardRef(({className:s,...o},_)=>D.jsx("h3",{ref:_,className:It("text-2xl font-semibold leading-none tracking-tight",s),...o}));di.displayName="CardTitle";const Bl=U.forwardRef(({className:s,...o},_)=>D.jsx("p",{ref:_,className:It("text-sm text-muted-foreground",s),...o}));Bl.displayName="CardDescription";const At=U.forwardRef(({className:s,...o},_)=>D.jsx("div",{ref:_,className:It("p-6 pt-0",s),...o}));At.displayName="CardContent";const gy=U.forwardRef(({className:s,...o},_)=>D.jsx("div",{ref:_,className:It("flex items-center p-6 pt-0",s),...o}));gy.displayName="CardFooter";const py=$_("inline-flex items-center rounded-full border px-2.5 py-0.5 text-xs font-semibold transition-colors focus:outline-none focus:ring-2 focus:ring-ring focus:ring-offset-2",{variants:{variant:{default:"border-transparent bg-primary text-primary-foreground hover:bg-primary/80",secondary:"border-transparent bg-secondary text-secondary-foreground hover:bg-secondary/80",destructive:"border-transparent bg-destructive text-destructive-foreground hover:bg-destructive/80",outline:"text-foreground"}},defaultVariants:{variant:"default"}});function Tt({className:s,variant:o,..._}){return D.jsx("div",{className:It(py({variant:o}),s),..._})}var by=["a","button","div","form","h2","h3","img","input","label","li","nav","ol","p","select","span","svg","ul"],yy=by.reduce((s,o)=>{const _=V_(`Primitive.${o}`),g=U.forwardRef((E,T)=>{const{asChild:B,...l}=E,c=B?_:o;return typeof window<"u"&&(window[Symbol.for("radix-ui")]=!0),D.jsx(c,{...l,ref:T})});return g.displayName=`Primitive.${o}`,{...s,[o]:g}},{}),Sy="Separator",n_="horizontal",xy=["horizontal","vertical"],sv=U.forwardRef((s,o)=>{const{decorative:_,orientation:g=n_,...E}=s,T=Cy(g)?g:n_,l=_?{role:"none"}:{"aria-orientation":T==="vertical"?T:void 0,role:"separator"};return D.jsx(yy.div,{"data-orientation":T,...l,...E,ref:o})});sv.displayName=Sy;function Cy(s){return xy.includes(s)}var nv=sv;const zu=U.forwardRef(({className:s,orientation:o="horizontal",decorative:_=!0,...g},E)=>D.jsx(nv,{ref:E,decorative:_,orientation:o,className:It("shrink-0 bg-border",o==="horizontal"?"h-[1px] w-full":"h-full w-[1px]",s),...g}));zu.displayName=nv.displayName;/**
+Please change the parent <Route path="${u}"> to <Route path="${u==="/"?"*":`${u}/*`}">.`)}let v=Zr(),b;if(o){let u=typeof o=="string"?Is(o):o;et(h==="/"||u.pathname?.startsWith(h),`When overriding the location using \`<Routes location>\` or \`useRoutes(routes, location)\`, the location pathname must begin with the portion of the URL pathname that was matched by all parent routes. The current pathname base is "${h}" but pathname "${u.pathname}" was given in the \`location\` prop.`),b=u}else b=v;let w=b.pathname||"/",k=w;if(h!=="/"){let u=h.replace(/^\//,"").split("/");k="/"+w.replace(/^\//,"").split("/").slice(u.length).join("/")}let C=R_(s,{pathname:k});Mi(p||C!=null,`No routes matched location "${b.pathname}${b.search}${b.hash}" `),Mi(C==null||C[C.length-1].route.element!==void 0||C[C.length-1].route.Component!==void 0||C[C.length-1].route.lazy!==void 0,`Matched leaf route at location "${b.pathname}${b.search}${b.hash}" does not have an element or Component. This means it will render an <Outlet /> with a null value by default resulting in an "empty" page.`);let a=O0(C&&C.map(u=>Object.assign({},u,{params:Object.assign({},l,u.params),pathname:Ji([h,E.encodeLocation?E.encodeLocation(u.pathname).pathname:u.pathname]),pathnameBase:u.pathnameBase==="/"?h:Ji([h,E.encodeLocation?E.encodeLocation(u.pathnameBase).pathname:u.pathnameBase])})),T,_,g);return o&&a?U.createElement(la.Provider,{value:{location:{pathname:"/",search:"",hash:"",state:null,key:"default",...b},navigationType:"POP"}},a):a}function A0(){let s=z0(),o=y0(s)?`${s.status} ${s.statusText}`:s instanceof Error?s.message:JSON.stringify(s),_=s instanceof Error?s.stack:null,g="rgba(200,200,200, 0.5)",E={padding:"0.5rem",backgroundColor:g},T={padding:"2px 4px",backgroundColor:g},B=null;return console.error("Error handled by React Router default ErrorBoundary:",s),B=U.createElement(U.Fragment,null,U.createElement("p",null,"💿 Hey developer 👋"),U.createElement("p",null,"You can provide a way better UX than this when your app throws errors by providing your own ",U.createElement("code",{style:T},"ErrorBoundary")," or"," ",U.createElement("code",{style:T},"errorElement")," prop on your route.")),U.createElement(U.Fragment,null,U.createElement("h2",null,"Unexpected Application Error!"),U.createElement("h3",{style:{fontStyle:"italic"}},o),_?U.createElement("pre",{style:E},_):null,B)}var T0=U.createElement(A0,null),M0=class extends U.Component{constructor(s){super(s),this.state={location:s.location,revalidation:s.revalidation,error:s.error}}static getDerivedStateFromError(s){return{error:s}}static getDerivedStateFromProps(s,o){return o.location!==s.location||o.revalidation!=="idle"&&s.revalidation==="idle"?{error:s.error,location:s.location,revalidation:s.revalidation}:{error:s.error!==void 0?s.error:o.error,location:o.location,revalidation:s.revalidation||o.revalidation}}componentDidCatch(s,o){console.error("React Router caught the following error during render",s,o)}render(){return this.state.error!==void 0?U.createElement(Ni.Provider,{value:this.props.routeContext},U.createElement(Lu.Provider,{value:this.state.error,children:this.props.component})):this.props.children}};function L0({routeContext:s,match:o,children:_}){let g=U.useContext(Fs);return g&&g.static&&g.staticContext&&(o.route.errorElement||o.route.ErrorBoundary)&&(g.staticContext._deepestRenderedBoundaryId=o.route.id),U.createElement(Ni.Provider,{value:s},_)}function O0(s,o=[],_=null,g=null){if(s==null){if(!_)return null;if(_.errors)s=_.matches;else if(o.length===0&&!_.initialized&&_.matches.length>0)s=_.matches;else return null}let E=s,T=_?.errors;if(T!=null){let c=E.findIndex(h=>h.route.id&&T?.[h.route.id]!==void 0);et(c>=0,`Could not find a matching route for errors on route IDs: ${Object.keys(T).join(",")}`),E=E.slice(0,Math.min(E.length,c+1))}let B=!1,l=-1;if(_)for(let c=0;c<E.length;c++){let h=E[c];if((h.route.HydrateFallback||h.route.hydrateFallbackElement)&&(l=c),h.route.id){let{loaderData:p,errors:v}=_,b=h.route.loader&&!p.hasOwnProperty(h.route.id)&&(!v||v[h.route.id]===void 0);if(h.route.lazy||b){B=!0,l>=0?E=E.slice(0,l+1):E=[E[0]];break}}}return E.reduceRight((c,h,p)=>{let v,b=!1,w=null,k=null;_&&(v=T&&h.route.id?T[h.route.id]:void 0,w=h.route.errorElement||T0,B&&(l<0&&p===0?(H_("route-fallback",!1,"No `HydrateFallback` element provided to render during initial hydration"),b=!0,k=null):l===p&&(b=!0,k=h.route.hydrateFallbackElement||null)));let C=o.concat(E.slice(0,p+1)),a=()=>{let u;return v?u=w:b?u=k:h.route.Component?u=U.createElement(h.route.Component,null):h.route.element?u=h.route.element:u=c,U.createElement(L0,{match:h,routeContext:{outlet:c,matches:C,isDataRoute:_!=null},children:u})};return _&&(h.route.ErrorBoundary||h.route.errorElement||p===0)?U.createElement(M0,{location:_.location,revalidation:_.revalidation,component:w,error:v,children:a(),routeContext:{outlet:null,matches:C,isDataRoute:!0}}):a()},null)}function Ou(s){return`${s} must be used within a data router.  See https://reactrouter.com/en/main/routers/picking-a-router.`}function N0(s){let o=U.useContext(Fs);return et(o,Ou(s)),o}function B0(s){let o=U.useContext(Pl);return et(o,Ou(s)),o}function j0(s){let o=U.useContext(Ni);return et(o,Ou(s)),o}function Nu(s){let o=j0(s),_=o.matches[o.matches.length-1];return et(_.route.id,`${s} can only be used on routes that contain a unique "id"`),_.route.id}function H0(){return Nu("useRouteId")}function z0(){let s=U.useContext(Lu),o=B0("useRouteError"),_=Nu("useRouteError");return s!==void 0?s:o.errors?.[_]}function U0(){let{router:s}=N0("useNavigate"),o=Nu("useNavigate"),_=U.useRef(!1);return B_(()=>{_.current=!0}),U.useCallback(async(E,T={})=>{Mi(_.current,N_),_.current&&(typeof E=="number"?s.navigate(E):await s.navigate(E,{fromRouteId:o,...T}))},[s,o])}var Km={};function H_(s,o,_){!o&&!Km[s]&&(Km[s]=!0,Mi(!1,_))}U.memo(P0);function P0({routes:s,future:o,state:_}){return j_(s,void 0,_,o)}function pu(s){et(!1,"A <Route> is only ever to be used as the child of <Routes> element, never rendered directly. Please wrap your <Route> in a <Routes>.")}function I0({basename:s="/",children:o=null,location:_,navigationType:g="POP",navigator:E,static:T=!1}){et(!oa(),"You cannot render a <Router> inside another <Router>. You should never have more than one in your app.");let B=s.replace(/^\/*/,"/"),l=U.useMemo(()=>({basename:B,navigator:E,static:T,future:{}}),[B,E,T]);typeof _=="string"&&(_=Is(_));let{pathname:c="/",search:h="",hash:p="",state:v=null,key:b="default"}=_,w=U.useMemo(()=>{let k=er(c,B);return k==null?null:{location:{pathname:k,search:h,hash:p,state:v,key:b},navigationType:g}},[B,c,h,p,v,b,g]);return Mi(w!=null,`<Router basename="${B}"> is not able to match the URL "${c}${h}${p}" because it does not start with the basename, so the <Router> won't render anything.`),w==null?null:U.createElement(Oi.Provider,{value:l},U.createElement(la.Provider,{children:o,value:w}))}function F0({children:s,location:o}){return D0(bu(s),o)}function bu(s,o=[]){let _=[];return U.Children.forEach(s,(g,E)=>{if(!U.isValidElement(g))return;let T=[...o,E];if(g.type===U.Fragment){_.push.apply(_,bu(g.props.children,T));return}et(g.type===pu,`[${typeof g.type=="string"?g.type:g.type.name}] is not a <Route> component. All component children of <Routes> must be a <Route> or <React.Fragment>`),et(!g.props.index||!g.props.children,"An index route cannot have child routes.");let B={id:g.props.id||T.join("-"),caseSensitive:g.props.caseSensitive,element:g.props.element,Component:g.props.Component,index:g.props.index,path:g.props.path,loader:g.props.loader,action:g.props.action,hydrateFallbackElement:g.props.hydrateFallbackElement,HydrateFallback:g.props.HydrateFallback,errorElement:g.props.errorElement,ErrorBoundary:g.props.ErrorBoundary,hasErrorBoundary:g.props.hasErrorBoundary===!0||g.props.ErrorBoundary!=null||g.props.errorElement!=null,shouldRevalidate:g.props.shouldRevalidate,handle:g.props.handle,lazy:g.props.lazy};g.props.children&&(B.children=bu(g.props.children,T)),_.push(B)}),_}var Ml="get",Ll="application/x-www-form-urlencoded";function Il(s){return s!=null&&typeof s.tagName=="string"}function q0(s){return Il(s)&&s.tagName.toLowerCase()==="button"}function V0(s){return Il(s)&&s.tagName.toLowerCase()==="form"}function G0(s){return Il(s)&&s.tagName.toLowerCase()==="input"}function Y0(s){return!!(s.metaKey||s.altKey||s.ctrlKey||s.shiftKey)}function $0(s,o){return s.button===0&&(!o||o==="_self")&&!Y0(s)}var kl=null;function K0(){if(kl===null)try{new FormData(document.createElement("form"),0),kl=!1}catch{kl=!0}return kl}var X0=new Set(["application/x-www-form-urlencoded","multipart/form-data","text/plain"]);function lu(s){return s!=null&&!X0.has(s)?(Mi(!1,`"${s}" is not a valid \`encType\` for \`<Form>\`/\`<fetcher.Form>\` and will default to "${Ll}"`),null):s}function W0(s,o){let _,g,E,T,B;if(V0(s)){let l=s.getAttribute("action");g=l?er(l,o):null,_=s.getAttribute("method")||Ml,E=lu(s.getAttribute("enctype"))||Ll,T=new FormData(s)}else if(q0(s)||G0(s)&&(s.type==="submit"||s.type==="image")){let l=s.form;if(l==null)throw new Error('Cannot submit a <button> or <input type="submit"> without a <form>');let c=s.getAttribute("formaction")||l.getAttribute("action");if(g=c?er(c,o):null,_=s.getAttribute("formmethod")||l.getAttribute("method")||Ml,E=lu(s.getAttribute("formenctype"))||lu(l.getAttribute("enctype"))||Ll,T=new FormData(l,s),!K0()){let{name:h,type:p,value:v}=s;if(p==="image"){let b=h?`${h}.`:"";T.append(`${b}x`,"0"),T.append(`${b}y`,"0")}else h&&T.append(h,v)}}else{if(Il(s))throw new Error('Cannot submit element that is not <form>, <button>, or <input type="submit|image">');_=Ml,g=null,E=Ll,B=s}return T&&E==="text/plain"&&(B=T,T=void 0),{action:g,method:_.toLowerCase(),encType:E,formData:T,body:B}}function Bu(s,o){if(s===!1||s===null||typeof s>"u")throw new Error(o)}async function Z0(s,o){if(s.id in o)return o[s.id];try{let _=await import(s.module);return o[s.id]=_,_}catch(_){return console.error(`Error loading route module \`${s.module}\`, reloading page...`),console.error(_),window.__reactRouterContext&&window.__reactRouterContext.isSpaMode,window.location.reload(),new Promise(()=>{})}}function Q0(s){return s==null?!1:s.href==null?s.rel==="preload"&&typeof s.imageSrcSet=="string"&&typeof s.imageSizes=="string":typeof s.rel=="string"&&typeof s.href=="string"}async function J0(s,o,_){let g=await Promise.all(s.map(async E=>{let T=o.routes[E.route.id];if(T){let B=await Z0(T,_);return B.links?B.links():[]}return[]}));return rb(g.flat(1).filter(Q0).filter(E=>E.rel==="stylesheet"||E.rel==="preload").map(E=>E.rel==="stylesheet"?{...E,rel:"prefetch",as:"style"}:{...E,rel:"prefetch"}))}function Xm(s,o,_,g,E,T){let B=(c,h)=>_[h]?c.route.id!==_[h].route.id:!0,l=(c,h)=>_[h].pathname!==c.pathname||_[h].route.path?.endsWith("*")&&_[h].params["*"]!==c.params["*"];return T==="assets"?o.filter((c,h)=>B(c,h)||l(c,h)):T==="data"?o.filter((c,h)=>{let p=g.routes[c.route.id];if(!p||!p.hasLoader)return!1;if(B(c,h)||l(c,h))return!0;if(c.route.shouldRevalidate){let v=c.route.shouldRevalidate({currentUrl:new URL(E.pathname+E.search+E.hash,window.origin),currentParams:_[0]?.params||{},nextUrl:new URL(s,window.origin),nextParams:c.params,defaultShouldRevalidate:!0});if(typeof v=="boolean")return v}return!0}):[]}function eb(s,o,{includeHydrateFallback:_}={}){return tb(s.map(g=>{let E=o.routes[g.route.id];if(!E)return[];let T=[E.module];return E.clientActionModule&&(T=T.concat(E.clientActionModule)),E.clientLoaderModule&&(T=T.concat(E.clientLoaderModule)),_&&E.hydrateFallbackModule&&(T=T.concat(E.hydrateFallbackModule)),E.imports&&(T=T.concat(E.imports)),T}).flat(1))}function tb(s){return[...new Set(s)]}function ib(s){let o={},_=Object.keys(s).sort();for(let g of _)o[g]=s[g];return o}function rb(s,o){let _=new Set;return new Set(o),s.reduce((g,E)=>{let T=JSON.stringify(ib(E));return _.has(T)||(_.add(T),g.push({key:T,link:E})),g},[])}Object.getOwnPropertyNames(Object.prototype).sort().join("\0");var sb=new Set([100,101,204,205]);function nb(s,o){let _=typeof s=="string"?new URL(s,typeof window>"u"?"server://singlefetch/":window.location.origin):s;return _.pathname==="/"?_.pathname="_root.data":o&&er(_.pathname,o)==="/"?_.pathname=`${o.replace(/\/$/,"")}/_root.data`:_.pathname=`${_.pathname.replace(/\/$/,"")}.data`,_}function z_(){let s=U.useContext(Fs);return Bu(s,"You must render this element inside a <DataRouterContext.Provider> element"),s}function ab(){let s=U.useContext(Pl);return Bu(s,"You must render this element inside a <DataRouterStateContext.Provider> element"),s}var ju=U.createContext(void 0);ju.displayName="FrameworkContext";function U_(){let s=U.useContext(ju);return Bu(s,"You must render this element inside a <HydratedRouter> element"),s}function lb(s,o){let _=U.useContext(ju),[g,E]=U.useState(!1),[T,B]=U.useState(!1),{onFocus:l,onBlur:c,onMouseEnter:h,onMouseLeave:p,onTouchStart:v}=o,b=U.useRef(null);U.useEffect(()=>{if(s==="render"&&B(!0),s==="viewport"){let C=u=>{u.forEach(d=>{B(d.isIntersecting)})},a=new IntersectionObserver(C,{threshold:.5});return b.current&&a.observe(b.current),()=>{a.disconnect()}}},[s]),U.useEffect(()=>{if(g){let C=setTimeout(()=>{B(!0)},100);return()=>{clearTimeout(C)}}},[g]);let w=()=>{E(!0)},k=()=>{E(!1),B(!1)};return _?s!=="intent"?[T,b,{}]:[T,b,{onFocus:Yn(l,w),onBlur:Yn(c,k),onMouseEnter:Yn(h,w),onMouseLeave:Yn(p,k),onTouchStart:Yn(v,w)}]:[!1,b,{}]}function Yn(s,o){return _=>{s&&s(_),_.defaultPrevented||o(_)}}function ob({page:s,...o}){let{router:_}=z_(),g=U.useMemo(()=>R_(_.routes,s,_.basename),[_.routes,s,_.basename]);return g?U.createElement(ub,{page:s,matches:g,...o}):null}function cb(s){let{manifest:o,routeModules:_}=U_(),[g,E]=U.useState([]);return U.useEffect(()=>{let T=!1;return J0(s,o,_).then(B=>{T||E(B)}),()=>{T=!0}},[s,o,_]),g}function ub({page:s,matches:o,..._}){let g=Zr(),{manifest:E,routeModules:T}=U_(),{basename:B}=z_(),{loaderData:l,matches:c}=ab(),h=U.useMemo(()=>Xm(s,o,c,E,g,"data"),[s,o,c,E,g]),p=U.useMemo(()=>Xm(s,o,c,E,g,"assets"),[s,o,c,E,g]),v=U.useMemo(()=>{if(s===g.pathname+g.search+g.hash)return[];let k=new Set,C=!1;if(o.forEach(u=>{let d=E.routes[u.route.id];!d||!d.hasLoader||(!h.some(f=>f.route.id===u.route.id)&&u.route.id in l&&T[u.route.id]?.shouldRevalidate||d.hasClientLoader?C=!0:k.add(u.route.id))}),k.size===0)return[];let a=nb(s,B);return C&&k.size>0&&a.searchParams.set("_routes",o.filter(u=>k.has(u.route.id)).map(u=>u.route.id).join(",")),[a.pathname+a.search]},[B,l,g,E,h,o,s,T]),b=U.useMemo(()=>eb(p,E),[p,E]),w=cb(p);return U.createElement(U.Fragment,null,v.map(k=>U.createElement("link",{key:k,rel:"prefetch",as:"fetch",href:k,..._})),b.map(k=>U.createElement("link",{key:k,rel:"modulepreload",href:k,..._})),w.map(({key:k,link:C})=>U.createElement("link",{key:k,...C})))}function hb(...s){return o=>{s.forEach(_=>{typeof _=="function"?_(o):_!=null&&(_.current=o)})}}var P_=typeof window<"u"&&typeof window.document<"u"&&typeof window.document.createElement<"u";try{P_&&(window.__reactRouterVersion="7.6.3")}catch{}function db({basename:s,children:o,window:_}){let g=U.useRef();g.current==null&&(g.current=Zp({window:_,v5Compat:!0}));let E=g.current,[T,B]=U.useState({action:E.action,location:E.location}),l=U.useCallback(c=>{U.startTransition(()=>B(c))},[B]);return U.useLayoutEffect(()=>E.listen(l),[E,l]),U.createElement(I0,{basename:s,children:o,location:T.location,navigationType:T.action,navigator:E})}var I_=/^(?:[a-z][a-z0-9+.-]*:|\/\/)/i,zs=U.forwardRef(function({onClick:o,discover:_="render",prefetch:g="none",relative:E,reloadDocument:T,replace:B,state:l,target:c,to:h,preventScrollReset:p,viewTransition:v,...b},w){let{basename:k}=U.useContext(Oi),C=typeof h=="string"&&I_.test(h),a,u=!1;if(typeof h=="string"&&C&&(a=h,P_))try{let N=new URL(window.location.href),F=h.startsWith("//")?new URL(N.protocol+h):new URL(h),Y=er(F.pathname,k);F.origin===N.origin&&Y!=null?h=Y+F.search+F.hash:u=!0}catch{Mi(!1,`<Link to="${h}"> contains an invalid URL which will probably break when clicked - please update to a valid URL path.`)}let d=w0(h,{relative:E}),[f,S,x]=lb(g,b),R=vb(h,{replace:B,state:l,target:c,preventScrollReset:p,relative:E,viewTransition:v});function O(N){o&&o(N),N.defaultPrevented||R(N)}let y=U.createElement("a",{...b,...x,href:a||d,onClick:u||T?o:O,ref:hb(w,S),target:c,"data-discover":!C&&_==="render"?"true":void 0});return f&&!C?U.createElement(U.Fragment,null,y,U.createElement(ob,{page:d})):y});zs.displayName="Link";var fb=U.forwardRef(function({"aria-current":o="page",caseSensitive:_=!1,className:g="",end:E=!1,style:T,to:B,viewTransition:l,children:c,...h},p){let v=ca(B,{relative:h.relative}),b=Zr(),w=U.useContext(Pl),{navigator:k,basename:C}=U.useContext(Oi),a=w!=null&&Sb(v)&&l===!0,u=k.encodeLocation?k.encodeLocation(v).pathname:v.pathname,d=b.pathname,f=w&&w.navigation&&w.navigation.location?w.navigation.location.pathname:null;_||(d=d.toLowerCase(),f=f?f.toLowerCase():null,u=u.toLowerCase()),f&&C&&(f=er(f,C)||f);const S=u!=="/"&&u.endsWith("/")?u.length-1:u.length;let x=d===u||!E&&d.startsWith(u)&&d.charAt(S)==="/",R=f!=null&&(f===u||!E&&f.startsWith(u)&&f.charAt(u.length)==="/"),O={isActive:x,isPending:R,isTransitioning:a},y=x?o:void 0,N;typeof g=="function"?N=g(O):N=[g,x?"active":null,R?"pending":null,a?"transitioning":null].filter(Boolean).join(" ");let F=typeof T=="function"?T(O):T;return U.createElement(zs,{...h,"aria-current":y,className:N,ref:p,style:F,to:B,viewTransition:l},typeof c=="function"?c(O):c)});fb.displayName="NavLink";var mb=U.forwardRef(({discover:s="render",fetcherKey:o,navigate:_,reloadDocument:g,replace:E,state:T,method:B=Ml,action:l,onSubmit:c,relative:h,preventScrollReset:p,viewTransition:v,...b},w)=>{let k=bb(),C=yb(l,{relative:h}),a=B.toLowerCase()==="get"?"get":"post",u=typeof l=="string"&&I_.test(l),d=f=>{if(c&&c(f),f.defaultPrevented)return;f.preventDefault();let S=f.nativeEvent.submitter,x=S?.getAttribute("formmethod")||B;k(S||f.currentTarget,{fetcherKey:o,method:x,navigate:_,replace:E,state:T,relative:h,preventScrollReset:p,viewTransition:v})};return U.createElement("form",{ref:w,method:a,action:C,onSubmit:g?c:d,...b,"data-discover":!u&&s==="render"?"true":void 0})});mb.displayName="Form";function _b(s){return`${s} must be used within a data router.  See https://reactrouter.com/en/main/routers/picking-a-router.`}function F_(s){let o=U.useContext(Fs);return et(o,_b(s)),o}function vb(s,{target:o,replace:_,state:g,preventScrollReset:E,relative:T,viewTransition:B}={}){let l=E0(),c=Zr(),h=ca(s,{relative:T});return U.useCallback(p=>{if($0(p,o)){p.preventDefault();let v=_!==void 0?_:Qn(c)===Qn(h);l(s,{replace:v,state:g,preventScrollReset:E,relative:T,viewTransition:B})}},[c,l,h,_,g,o,s,E,T,B])}var gb=0,pb=()=>`__${String(++gb)}__`;function bb(){let{router:s}=F_("useSubmit"),{basename:o}=U.useContext(Oi),_=H0();return U.useCallback(async(g,E={})=>{let{action:T,method:B,encType:l,formData:c,body:h}=W0(g,o);if(E.navigate===!1){let p=E.fetcherKey||pb();await s.fetch(p,_,E.action||T,{preventScrollReset:E.preventScrollReset,formData:c,body:h,formMethod:E.method||B,formEncType:E.encType||l,flushSync:E.flushSync})}else await s.navigate(E.action||T,{preventScrollReset:E.preventScrollReset,formData:c,body:h,formMethod:E.method||B,formEncType:E.encType||l,replace:E.replace,state:E.state,fromRouteId:_,flushSync:E.flushSync,viewTransition:E.viewTransition})},[s,o,_])}function yb(s,{relative:o}={}){let{basename:_}=U.useContext(Oi),g=U.useContext(Ni);et(g,"useFormAction must be used inside a RouteContext");let[E]=g.matches.slice(-1),T={...ca(s||".",{relative:o})},B=Zr();if(s==null){T.search=B.search;let l=new URLSearchParams(T.search),c=l.getAll("index");if(c.some(p=>p==="")){l.delete("index"),c.filter(v=>v).forEach(v=>l.append("index",v));let p=l.toString();T.search=p?`?${p}`:""}}return(!s||s===".")&&E.route.index&&(T.search=T.search?T.search.replace(/^\?/,"?index&"):"?index"),_!=="/"&&(T.pathname=T.pathname==="/"?_:Ji([_,T.pathname])),Qn(T)}function Sb(s,o={}){let _=U.useContext(O_);et(_!=null,"`useViewTransitionState` must be used within `react-router-dom`'s `RouterProvider`.  Did you accidentally import `RouterProvider` from `react-router`?");let{basename:g}=F_("useViewTransitionState"),E=ca(s,{relative:o.relative});if(!_.isTransitioning)return!1;let T=er(_.currentLocation.pathname,g)||_.currentLocation.pathname,B=er(_.nextLocation.pathname,g)||_.nextLocation.pathname;return Nl(E.pathname,B)!=null||Nl(E.pathname,T)!=null}[...sb];var Kn=k_();const fi="/api",apiFetch=async(s,o)=>{const r=await fetch(s,{credentials:"same-origin",...o});if(r.status===401)throw window.location.assign("/login"),new Error("Authentication required");return r},shellSocketUrl=s=>`${window.location.protocol==="https:"?"wss:":"ws:"}//${window.location.host}${fi}/shell${s?"?"+s:""}`,Xr=async()=>{console.log("fetchFlows API called");const s=await apiFetch(`${fi}/flows`);if(console.log("Fetch flows API response status:",s.status),!s.ok){const _=await s.text();throw console.error("Fetch flows API error:",_),new Error(`Failed to fetch flows: ${_}`)}const o=await s.json();return console.log("Fetch flows API response data:",o),o},xb=async s=>{console.log("createFlow API called with data:",s);const o=await apiFetch(`${fi}/flows`,{method:"POST",headers:{"Content-Type":"application/json"},body:JSON.stringify(s)});if(console.log("Create flow API response status:",o.status),!o.ok){const g=await o.text();throw console.error("Create flow API error:",g),new Error(`Failed to create flow: ${g}`)}const _=await o.json();return console.log("Create flow API response data:",_),_},Cb=async(s,o)=>{console.log("updateFlow API called with data:",{flowId:s,flowData:o});const _=await apiFetch(`${fi}/flows/${s}`,{method:"PUT",headers:{"Content-Type":"application/json"},body:JSON.stringify(o)});if(console.log("Update flow API response status:",_.status),!_.ok){const E=await _.text();throw console.error("Update flow API error:",E),new Error(`Failed to update flow: ${E}`)}const g=await _.json();return console.log("Update flow API response data:",g),g},wb=async s=>{console.log("deleteFlow API called with flowId:",s);const o=await apiFetch(`${fi}/flows/${s}`,{method:"DELETE"});if(console.log("Delete flow API response status:",o.status),!o.ok){const g=await o.text();throw console.error("Delete flow API error:",g),new Error(`Failed to delete flow: ${g}`)}const _=await o.json();return console.log("Delete flow API response data:",_),_},Wm=async(s,o)=>{console.log("updateStep API called with data:",{stepId:s,stepData:o});const _=await apiFetch(`${fi}/steps/${s}`,{method:"PUT",headers:{"Content-Type":"application/json"},body:JSON.stringify(o)});if(console.log("Update step API response status:",_.status),!_.ok){const E=await _.text();throw console.error("Update step API error:",E),new Error(`Failed to update step: ${E}`)}const g=await _.json();return console.log("Update step API response data:",g),g},Eb=async s=>{console.log("createStep API called with data:",s);const o=await apiFetch(`${fi}/steps`,{method:"POST",headers:{"Content-Type":"application/json"},body:JSON.stringify(s)});if(console.log("Create step API response status:",o.status),!o.ok){const g=await o.text();throw console.error("Create step API error:",g),new Error(`Failed to create step: ${g}`)}const _=await o.json();return console.log("Create step API response data:",_),_},kb=async s=>{console.log("deleteStep API called with stepId:",s);const o=await apiFetch(`${fi}/steps/${s}`,{method:"DELETE"});if(console.log("Delete step API response status:",o.status),!o.ok){const g=await o.text();throw console.error("Delete step API error:",g),new Error(`Failed to delete step: ${g}`)}const _=await o.json();return console.log("Delete step API response data:",_),_},yu=async(s,o,_)=>{console.log("updateVariable API called with data:",{flowId:s,key:o,variableData:_});const g=await apiFetch(`${fi}/variables/${s}/${o}`,{method:"PUT",headers:{"Content-Type":"application/json"},body:JSON.stringify(_)});if(console.log("Update variable API response status:",g.status),!g.ok){const T=await g.text();throw console.error("Update variable API error:",T),new Error(`Failed to update variable: ${T}`)}const E=await g.json();return console.log("Update variable API response data:",E),E},q_=async(s,o)=>{console.log("deleteVariable API called with data:",{flowId:s,key:o});const _=await apiFetch(`${fi}/variables/${s}/${o}`,{method:"DELETE"});if(console.log("Delete variable API response status:",_.status),!_.ok){const g=await _.json();throw console.error("Delete variable API error:",g),new Error(g.error||"Failed to delete variable")}return _.json()},Zm=async s=>{console.log("executeStep API called with stepId:",s);const o=await apiFetch(`${fi}/execute-step`,{method:"POST",headers:{"Content-Type":"application/json"},body:JSON.stringify({step_id:s})});if(console.log("Execute step API response status:",o.status),!o.ok){const g=await o.text();throw console.error("Execute step API error:",g),new Error(`Failed to execute step: ${g}`)}const _=await o.json();return console.log("Execute step API response data:",_),_},Rb=async s=>{const o=await apiFetch(`${fi}/flows/${s}/export`);if(!o.ok){const _=await o.json();throw new Error(_.error||"Failed to export flow")}return o.blob()},Db=async s=>{const o=await apiFetch(`${fi}/flows/import`,{method:"POST",headers:{"Content-Type":"application/json"},body:JSON.stringify(s)});if(!o.ok){const _=await o.json();throw new Error(_.error||"Failed to import flow")}return o.json()};function Qm(s,o){if(typeof s=="function")return s(o);s!=null&&(s.current=o)}function Ab(...s){return o=>{let _=!1;const g=s.map(E=>{const T=Qm(E,o);return!_&&typeof T=="function"&&(_=!0),T});if(_)return()=>{for(let E=0;E<g.length;E++){const T=g[E];typeof T=="function"?T():Qm(s[E],null)}}}}function V_(s){const o=Mb(s),_=U.forwardRef((g,E)=>{const{children:T,...B}=g,l=U.Children.toArray(T),c=l.find(Ob);if(c){const h=c.props.children,p=l.map(v=>v===c?U.Children.count(h)>1?U.Children.only(null):U.isValidElement(h)?h.props.children:null:v);return D.jsx(o,{...B,ref:E,children:U.isValidElement(h)?U.cloneElement(h,void 0,p):null})}return D.jsx(o,{...B,ref:E,children:T})});return _.displayName=`${s}.Slot`,_}var Tb=V_("Slot");function Mb(s){const o=U.forwardRef((_,g)=>{const{children:E,...T}=_;if(U.isValidElement(E)){const B=Bb(E),l=Nb(T,E.props);return E.type!==U.Fragment&&(l.ref=g?Ab(g,B):B),U.cloneElement(E,l)}return U.Children.count(E)>1?U.Children.only(null):null});return o.displayName=`${s}.SlotClone`,o}var Lb=Symbol("radix.slottable");function Ob(s){return U.isValidElement(s)&&typeof s.type=="function"&&"__radixId"in s.type&&s.type.__radixId===Lb}function Nb(s,o){const _={...o};for(const g in o){const E=s[g],T=o[g];/^on[A-Z]/.test(g)?E&&T?_[g]=(...l)=>{const c=T(...l);return E(...l),c}:E&&(_[g]=E):g==="style"?_[g]={...E,...T}:g==="className"&&(_[g]=[E,T].filter(Boolean).join(" "))}return{...s,..._}}function Bb(s){let o=Object.getOwnPropertyDescriptor(s.props,"ref")?.get,_=o&&"isReactWarning"in o&&o.isReactWarning;return _?s.ref:(o=Object.getOwnPropertyDescriptor(s,"ref")?.get,_=o&&"isReactWarning"in o&&o.isReactWarning,_?s.props.ref:s.props.ref||s.ref)}function G_(s){var o,_,g="";if(typeof s=="string"||typeof s=="number")g+=s;else if(typeof s=="object")if(Array.isArray(s)){var E=s.length;for(o=0;o<E;o++)s[o]&&(_=G_(s[o]))&&(g&&(g+=" "),g+=_)}else for(_ in s)s[_]&&(g&&(g+=" "),g+=_);return g}function Y_(){for(var s,o,_=0,g="",E=arguments.length;_<E;_++)(s=arguments[_])&&(o=G_(s))&&(g&&(g+=" "),g+=o);return g}const Jm=s=>typeof s=="boolean"?`${s}`:s===0?"0":s,e_=Y_,$_=(s,o)=>_=>{var g;if(o?.variants==null)return e_(s,_?.class,_?.className);const{variants:E,defaultVariants:T}=o,B=Object.keys(E).map(h=>{const p=_?.[h],v=T?.[h];if(p===null)return null;const b=Jm(p)||Jm(v);return E[h][b]}),l=_&&Object.entries(_).reduce((h,p)=>{let[v,b]=p;return b===void 0||(h[v]=b),h},{}),c=o==null||(g=o.compoundVariants)===null||g===void 0?void 0:g.reduce((h,p)=>{let{class:v,className:b,...w}=p;return Object.entries(w).every(k=>{let[C,a]=k;return Array.isArray(a)?a.includes({...T,...l}[C]):{...T,...l}[C]===a})?[...h,v,b]:h},[]);return e_(s,B,c,_?.class,_?.className)},Hu="-",jb=s=>{const o=zb(s),{conflictingClassGroups:_,conflictingClassGroupModifiers:g}=s;return{getClassGroupId:B=>{const l=B.split(Hu);return l[0]===""&&l.length!==1&&l.shift(),K_(l,o)||Hb(B)},getConflictingClassGroupIds:(B,l)=>{const c=_[B]||[];return l&&g[B]?[...c,...g[B]]:c}}},K_=(s,o)=>{if(s.length===0)return o.classGroupId;const _=s[0],g=o.nextPart.get(_),E=g?K_(s.slice(1),g):void 0;if(E)return E;if(o.validators.length===0)return;const T=s.join(Hu);return o.validators.find(({validator:B})=>B(T))?.classGroupId},t_=/^\[(.+)\]$/,Hb=s=>{if(t_.test(s)){const o=t_.exec(s)[1],_=o?.substring(0,o.indexOf(":"));if(_)return"arbitrary.."+_}},zb=s=>{const{theme:o,classGroups:_}=s,g={nextPart:new Map,validators:[]};for(const E in _)Su(_[E],g,E,o);return g},Su=(s,o,_,g)=>{s.forEach(E=>{if(typeof E=="string"){const T=E===""?o:i_(o,E);T.classGroupId=_;return}if(typeof E=="function"){if(Ub(E)){Su(E(g),o,_,g);return}o.validators.push({validator:E,classGroupId:_});return}Object.entries(E).forEach(([T,B])=>{Su(B,i_(o,T),_,g)})})},i_=(s,o)=>{let _=s;return o.split(Hu).forEach(g=>{_.nextPart.has(g)||_.nextPart.set(g,{nextPart:new Map,validators:[]}),_=_.nextPart.get(g)}),_},Ub=s=>s.isThemeGetter,Pb=s=>{if(s<1)return{get:()=>{},set:()=>{}};let o=0,_=new Map,g=new Map;const E=(T,B)=>{_.set(T,B),o++,o>s&&(o=0,g=_,_=new Map)};return{get(T){let B=_.get(T);if(B!==void 0)return B;if((B=g.get(T))!==void 0)return E(T,B),B},set(T,B){_.has(T)?_.set(T,B):E(T,B)}}},xu="!",Cu=":",Ib=Cu.length,Fb=s=>{const{prefix:o,experimentalParseClassName:_}=s;let g=E=>{const T=[];let B=0,l=0,c=0,h;for(let k=0;k<E.length;k++){let C=E[k];if(B===0&&l===0){if(C===Cu){T.push(E.slice(c,k)),c=k+Ib;continue}if(C==="/"){h=k;continue}}C==="["?B++:C==="]"?B--:C==="("?l++:C===")"&&l--}const p=T.length===0?E:E.substring(c),v=qb(p),b=v!==p,w=h&&h>c?h-c:void 0;return{modifiers:T,hasImportantModifier:b,baseClassName:v,maybePostfixModifierPosition:w}};if(o){const E=o+Cu,T=g;g=B=>B.startsWith(E)?T(B.substring(E.length)):{isExternal:!0,modifiers:[],hasImportantModifier:!1,baseClassName:B,maybePostfixModifierPosition:void 0}}if(_){const E=g;g=T=>_({className:T,parseClassName:E})}return g},qb=s=>s.endsWith(xu)?s.substring(0,s.length-1):s.startsWith(xu)?s.substring(1):s,Vb=s=>{const o=Object.fromEntries(s.orderSensitiveModifiers.map(g=>[g,!0]));return g=>{if(g.length<=1)return g;const E=[];let T=[];return g.forEach(B=>{B[0]==="["||o[B]?(E.push(...T.sort(),B),T=[]):T.push(B)}),E.push(...T.sort()),E}},Gb=s=>({cache:Pb(s.cacheSize),parseClassName:Fb(s),sortModifiers:Vb(s),...jb(s)}),Yb=/\s+/,$b=(s,o)=>{const{parseClassName:_,getClassGroupId:g,getConflictingClassGroupIds:E,sortModifiers:T}=o,B=[],l=s.trim().split(Yb);let c="";for(let h=l.length-1;h>=0;h-=1){const p=l[h],{isExternal:v,modifiers:b,hasImportantModifier:w,baseClassName:k,maybePostfixModifierPosition:C}=_(p);if(v){c=p+(c.length>0?" "+c:c);continue}let a=!!C,u=g(a?k.substring(0,C):k);if(!u){if(!a){c=p+(c.length>0?" "+c:c);continue}if(u=g(k),!u){c=p+(c.length>0?" "+c:c);continue}a=!1}const d=T(b).join(":"),f=w?d+xu:d,S=f+u;if(B.includes(S))continue;B.push(S);const x=E(u,a);for(let R=0;R<x.length;++R){const O=x[R];B.push(f+O)}c=p+(c.length>0?" "+c:c)}return c};function Kb(){let s=0,o,_,g="";for(;s<arguments.length;)(o=arguments[s++])&&(_=X_(o))&&(g&&(g+=" "),g+=_);return g}const X_=s=>{if(typeof s=="string")return s;let o,_="";for(let g=0;g<s.length;g++)s[g]&&(o=X_(s[g]))&&(_&&(_+=" "),_+=o);return _};function Xb(s,...o){let _,g,E,T=B;function B(c){const h=o.reduce((p,v)=>v(p),s());return _=Gb(h),g=_.cache.get,E=_.cache.set,T=l,l(c)}function l(c){const h=g(c);if(h)return h;const p=$b(c,_);return E(c,p),p}return function(){return T(Kb.apply(null,arguments))}}const ut=s=>{const o=_=>_[s]||[];return o.isThemeGetter=!0,o},W_=/^\[(?:(\w[\w-]*):)?(.+)\]$/i,Z_=/^\((?:(\w[\w-]*):)?(.+)\)$/i,Wb=/^\d+\/\d+$/,Zb=/^(\d+(\.\d+)?)?(xs|sm|md|lg|xl)$/,Qb=/\d+(%|px|r?em|[sdl]?v([hwib]|min|max)|pt|pc|in|cm|mm|cap|ch|ex|r?lh|cq(w|h|i|b|min|max))|\b(calc|min|max|clamp)\(.+\)|^0$/,Jb=/^(rgba?|hsla?|hwb|(ok)?(lab|lch)|color-mix)\(.+\)$/,ey=/^(inset_)?-?((\d+)?\.?(\d+)[a-z]+|0)_-?((\d+)?\.?(\d+)[a-z]+|0)/,ty=/^(url|image|image-set|cross-fade|element|(repeating-)?(linear|radial|conic)-gradient)\(.+\)$/,Hs=s=>Wb.test(s),Re=s=>!!s&&!Number.isNaN(Number(s)),kr=s=>!!s&&Number.isInteger(Number(s)),ou=s=>s.endsWith("%")&&Re(s.slice(0,-1)),Qi=s=>Zb.test(s),iy=()=>!0,ry=s=>Qb.test(s)&&!Jb.test(s),Q_=()=>!1,sy=s=>ey.test(s),ny=s=>ty.test(s),ay=s=>!_e(s)&&!ve(s),ly=s=>qs(s,tv,Q_),_e=s=>W_.test(s),Kr=s=>qs(s,iv,ry),cu=s=>qs(s,dy,Re),r_=s=>qs(s,J_,Q_),oy=s=>qs(s,ev,ny),Rl=s=>qs(s,rv,sy),ve=s=>Z_.test(s),$n=s=>Vs(s,iv),cy=s=>Vs(s,fy),s_=s=>Vs(s,J_),uy=s=>Vs(s,tv),hy=s=>Vs(s,ev),Dl=s=>Vs(s,rv,!0),qs=(s,o,_)=>{const g=W_.exec(s);return g?g[1]?o(g[1]):_(g[2]):!1},Vs=(s,o,_=!1)=>{const g=Z_.exec(s);return g?g[1]?o(g[1]):_:!1},J_=s=>s==="position"||s==="percentage",ev=s=>s==="image"||s==="url",tv=s=>s==="length"||s==="size"||s==="bg-size",iv=s=>s==="length",dy=s=>s==="number",fy=s=>s==="family-name",rv=s=>s==="shadow",my=()=>{const s=ut("color"),o=ut("font"),_=ut("text"),g=ut("font-weight"),E=ut("tracking"),T=ut("leading"),B=ut("breakpoint"),l=ut("container"),c=ut("spacing"),h=ut("radius"),p=ut("shadow"),v=ut("inset-shadow"),b=ut("text-shadow"),w=ut("drop-shadow"),k=ut("blur"),C=ut("perspective"),a=ut("aspect"),u=ut("ease"),d=ut("animate"),f=()=>["auto","avoid","all","avoid-page","page","left","right","column"],S=()=>["center","top","bottom","left","right","top-left","left-top","top-right","right-top","bottom-right","right-bottom","bottom-left","left-bottom"],x=()=>[...S(),ve,_e],R=()=>["auto","hidden","clip","visible","scroll"],O=()=>["auto","contain","none"],y=()=>[ve,_e,c],N=()=>[Hs,"full","auto",...y()],F=()=>[kr,"none","subgrid",ve,_e],Y=()=>["auto",{span:["full",kr,ve,_e]},kr,ve,_e],q=()=>[kr,"auto",ve,_e],V=()=>["auto","min","max","fr",ve,_e],ee=()=>["start","end","center","between","around","evenly","stretch","baseline","center-safe","end-safe"],K=()=>["start","end","center","stretch","center-safe","end-safe"],I=()=>["auto",...y()],G=()=>[Hs,"auto","full","dvw","dvh","lvw","lvh","svw","svh","min","max","fit",...y()],A=()=>[s,ve,_e],z=()=>[...S(),s_,r_,{position:[ve,_e]}],M=()=>["no-repeat",{repeat:["","x","y","space","round"]}],P=()=>["auto","cover","contain",uy,ly,{size:[ve,_e]}],W=()=>[ou,$n,Kr],J=()=>["","none","full",h,ve,_e],le=()=>["",Re,$n,Kr],ue=()=>["solid","dashed","dotted","double"],fe=()=>["normal","multiply","screen","overlay","darken","lighten","color-dodge","color-burn","hard-light","soft-light","difference","exclusion","hue","saturation","color","luminosity"],j=()=>[Re,ou,s_,r_],Z=()=>["","none",k,ve,_e],ae=()=>["none",Re,ve,_e],re=()=>["none",Re,ve,_e],pe=()=>[Re,ve,_e],de=()=>[Hs,"full",...y()];return{cacheSize:500,theme:{animate:["spin","ping","pulse","bounce"],aspect:["video"],blur:[Qi],breakpoint:[Qi],color:[iy],container:[Qi],"drop-shadow":[Qi],ease:["in","out","in-out"],font:[ay],"font-weight":["thin","extralight","light","normal","medium","semibold","bold","extrabold","black"],"inset-shadow":[Qi],leading:["none","tight","snug","normal","relaxed","loose"],perspective:["dramatic","near","normal","midrange","distant","none"],radius:[Qi],shadow:[Qi],spacing:["px",Re],text:[Qi],"text-shadow":[Qi],tracking:["tighter","tight","normal","wide","wider","widest"]},classGroups:{aspect:[{aspect:["auto","square",Hs,_e,ve,a]}],container:["container"],columns:[{columns:[Re,_e,ve,l]}],"break-after":[{"break-after":f()}],"break-before":[{"break-before":f()}],"break-inside":[{"break-inside":["auto","avoid","avoid-page","avoid-column"]}],"box-decoration":[{"box-decoration":["slice","clone"]}],box:[{box:["border","content"]}],display:["block","inline-block","inline","flex","inline-flex","table","inline-table","table-caption","table-cell","table-column","table-column-group","table-footer-group","table-header-group","table-row-group","table-row","flow-root","grid","inline-grid","contents","list-item","hidden"],sr:["sr-only","not-sr-only"],float:[{float:["right","left","none","start","end"]}],clear:[{clear:["left","right","both","none","start","end"]}],isolation:["isolate","isolation-auto"],"object-fit":[{object:["contain","cover","fill","none","scale-down"]}],"object-position":[{object:x()}],overflow:[{overflow:R()}],"overflow-x":[{"overflow-x":R()}],"overflow-y":[{"overflow-y":R()}],overscroll:[{overscroll:O()}],"overscroll-x":[{"overscroll-x":O()}],"overscroll-y":[{"overscroll-y":O()}],position:["static","fixed","absolute","relative","sticky"],inset:[{inset:N()}],"inset-x":[{"inset-x":N()}],"inset-y":[{"inset-y":N()}],start:[{start:N()}],end:[{end:N()}],top:[{top:N()}],right:[{right:N()}],bottom:[{bottom:N()}],left:[{left:N()}],visibility:["visible","invisible","collapse"],z:[{z:[kr,"auto",ve,_e]}],basis:[{basis:[Hs,"full","auto",l,...y()]}],"flex-direction":[{flex:["row","row-reverse","col","col-reverse"]}],"flex-wrap":[{flex:["nowrap","wrap","wrap-reverse"]}],flex:[{flex:[Re,Hs,"auto","initial","none",_e]}],grow:[{grow:["",Re,ve,_e]}],shrink:[{shrink:["",Re,ve,_e]}],order:[{order:[kr,"first","last","none",ve,_e]}],"grid-cols":[{"grid-cols":F()}],"col-start-end":[{col:Y()}],"col-start":[{"col-start":q()}],"col-end":[{"col-end":q()}],"grid-rows":[{"grid-rows":F()}],"row-start-end":[{row:Y()}],"row-start":[{"row-start":q()}],"row-end":[{"row-end":q()}],"grid-flow":[{"grid-flow":["row","col","dense","row-dense","col-dense"]}],"auto-cols":[{"auto-cols":V()}],"auto-rows":[{"auto-rows":V()}],gap:[{gap:y()}],"gap-x":[{"gap-x":y()}],"gap-y":[{"gap-y":y()}],"justify-content":[{justify:[...ee(),"normal"]}],"justify-items":[{"justify-items":[...K(),"normal"]}],"justify-self":[{"justify-self":["auto",...K()]}],"align-content":[{content:["normal",...ee()]}],"align-items":[{items:[...K(),{baseline:["","last"]}]}],"align-self":[{self:["auto",...K(),{baseline:["","last"]}]}],"place-content":[{"place-content":ee()}],"place-items":[{"place-items":[...K(),"baseline"]}],"place-self":[{"place-self":["auto",...K()]}],p:[{p:y()}],px:[{px:y()}],py:[{py:y()}],ps:[{ps:y()}],pe:[{pe:y()}],pt:[{pt:y()}],pr:[{pr:y()}],pb:[{pb:y()}],pl:[{pl:y()}],m:[{m:I()}],mx:[{mx:I()}],my:[{my:I()}],ms:[{ms:I()}],me:[{me:I()}],mt:[{mt:I()}],mr:[{mr:I()}],mb:[{mb:I()}],ml:[{ml:I()}],"space-x":[{"space-x":y()}],"space-x-reverse":["space-x-reverse"],"space-y":[{"space-y":y()}],"space-y-reverse":["space-y-reverse"],size:[{size:G()}],w:[{w:[l,"screen",...G()]}],"min-w":[{"min-w":[l,"screen","none",...G()]}],"max-w":[{"max-w":[l,"screen","none","prose",{screen:[B]},...G()]}],h:[{h:["screen","lh",...G()]}],"min-h":[{"min-h":["screen","lh","none",...G()]}],"max-h":[{"max-h":["screen","lh",...G()]}],"font-size":[{text:["base",_,$n,Kr]}],"font-smoothing":["antialiased","subpixel-antialiased"],"font-style":["italic","not-italic"],"font-weight":[{font:[g,ve,cu]}],"font-stretch":[{"font-stretch":["ultra-condensed","extra-condensed","condensed","semi-condensed","normal","semi-expanded","expanded","extra-expanded","ultra-expanded",ou,_e]}],"font-family":[{font:[cy,_e,o]}],"fvn-normal":["normal-nums"],"fvn-ordinal":["ordinal"],"fvn-slashed-zero":["slashed-zero"],"fvn-figure":["lining-nums","oldstyle-nums"],"fvn-spacing":["proportional-nums","tabular-nums"],"fvn-fraction":["diagonal-fractions","stacked-fractions"],tracking:[{tracking:[E,ve,_e]}],"line-clamp":[{"line-clamp":[Re,"none",ve,cu]}],leading:[{leading:[T,...y()]}],"list-image":[{"list-image":["none",ve,_e]}],"list-style-position":[{list:["inside","outside"]}],"list-style-type":[{list:["disc","decimal","none",ve,_e]}],"text-alignment":[{text:["left","center","right","justify","start","end"]}],"placeholder-color":[{placeholder:A()}],"text-color":[{text:A()}],"text-decoration":["underline","overline","line-through","no-underline"],"text-decoration-style":[{decoration:[...ue(),"wavy"]}],"text-decoration-thickness":[{decoration:[Re,"from-font","auto",ve,Kr]}],"text-decoration-color":[{decoration:A()}],"underline-offset":[{"underline-offset":[Re,"auto",ve,_e]}],"text-transform":["uppercase","lowercase","capitalize","normal-case"],"text-overflow":["truncate","text-ellipsis","text-clip"],"text-wrap":[{text:["wrap","nowrap","balance","pretty"]}],indent:[{indent:y()}],"vertical-align":[{align:["baseline","top","middle","bottom","text-top","text-bottom","sub","super",ve,_e]}],whitespace:[{whitespace:["normal","nowrap","pre","pre-line","pre-wrap","break-spaces"]}],break:[{break:["normal","words","all","keep"]}],wrap:[{wrap:["break-word","anywhere","normal"]}],hyphens:[{hyphens:["none","manual","auto"]}],content:[{content:["none",ve,_e]}],"bg-attachment":[{bg:["fixed","local","scroll"]}],"bg-clip":[{"bg-clip":["border","padding","content","text"]}],"bg-origin":[{"bg-origin":["border","padding","content"]}],"bg-position":[{bg:z()}],"bg-repeat":[{bg:M()}],"bg-size":[{bg:P()}],"bg-image":[{bg:["none",{linear:[{to:["t","tr","r","br","b","bl","l","tl"]},kr,ve,_e],radial:["",ve,_e],conic:[kr,ve,_e]},hy,oy]}],"bg-color":[{bg:A()}],"gradient-from-pos":[{from:W()}],"gradient-via-pos":[{via:W()}],"gradient-to-pos":[{to:W()}],"gradient-from":[{from:A()}],"gradient-via":[{via:A()}],"gradient-to":[{to:A()}],rounded:[{rounded:J()}],"rounded-s":[{"rounded-s":J()}],"rounded-e":[{"rounded-e":J()}],"rounded-t":[{"rounded-t":J()}],"rounded-r":[{"rounded-r":J()}],"rounded-b":[{"rounded-b":J()}],"rounded-l":[{"rounded-l":J()}],"rounded-ss":[{"rounded-ss":J()}],"rounded-se":[{"rounded-se":J()}],"rounded-ee":[{"rounded-ee":J()}],"rounded-es":[{"rounded-es":J()}],"rounded-tl":[{"rounded-tl":J()}],"rounded-tr":[{"rounded-tr":J()}],"rounded-br":[{"rounded-br":J()}],"rounded-bl":[{"rounded-bl":J()}],"border-w":[{border:le()}],"border-w-x":[{"border-x":le()}],"border-w-y":[{"border-y":le()}],"border-w-s":[{"border-s":le()}],"border-w-e":[{"border-e":le()}],"border-w-t":[{"border-t":le()}],"border-w-r":[{"border-r":le()}],"border-w-b":[{"border-b":le()}],"border-w-l":[{"border-l":le()}],"divide-x":[{"divide-x":le()}],"divide-x-reverse":["divide-x-reverse"],"divide-y":[{"divide-y":le()}],"divide-y-reverse":["divide-y-reverse"],"border-style":[{border:[...ue(),"hidden","none"]}],"divide-style":[{divide:[...ue(),"hidden","none"]}],"border-color":[{border:A()}],"border-color-x":[{"border-x":A()}],"border-color-y":[{"border-y":A()}],"border-color-s":[{"border-s":A()}],"border-color-e":[{"border-e":A()}],"border-color-t":[{"border-t":A()}],"border-color-r":[{"border-r":A()}],"border-color-b":[{"border-b":A()}],"border-color-l":[{"border-l":A()}],"divide-color":[{divide:A()}],"outline-style":[{outline:[...ue(),"none","hidden"]}],"outline-offset":[{"outline-offset":[Re,ve,_e]}],"outline-w":[{outline:["",Re,$n,Kr]}],"outline-color":[{outline:A()}],shadow:[{shadow:["","none",p,Dl,Rl]}],"shadow-color":[{shadow:A()}],"inset-shadow":[{"inset-shadow":["none",v,Dl,Rl]}],"inset-shadow-color":[{"inset-shadow":A()}],"ring-w":[{ring:le()}],"ring-w-inset":["ring-inset"],"ring-color":[{ring:A()}],"ring-offset-w":[{"ring-offset":[Re,Kr]}],"ring-offset-color":[{"ring-offset":A()}],"inset-ring-w":[{"inset-ring":le()}],"inset-ring-color":[{"inset-ring":A()}],"text-shadow":[{"text-shadow":["none",b,Dl,Rl]}],"text-shadow-color":[{"text-shadow":A()}],opacity:[{opacity:[Re,ve,_e]}],"mix-blend":[{"mix-blend":[...fe(),"plus-darker","plus-lighter"]}],"bg-blend":[{"bg-blend":fe()}],"mask-clip":[{"mask-clip":["border","padding","content","fill","stroke","view"]},"mask-no-clip"],"mask-composite":[{mask:["add","subtract","intersect","exclude"]}],"mask-image-linear-pos":[{"mask-linear":[Re]}],"mask-image-linear-from-pos":[{"mask-linear-from":j()}],"mask-image-linear-to-pos":[{"mask-linear-to":j()}],"mask-image-linear-from-color":[{"mask-linear-from":A()}],"mask-image-linear-to-color":[{"mask-linear-to":A()}],"mask-image-t-from-pos":[{"mask-t-from":j()}],"mask-image-t-to-pos":[{"mask-t-to":j()}],"mask-image-t-from-color":[{"mask-t-from":A()}],"mask-image-t-to-color":[{"mask-t-to":A()}],"mask-image-r-from-pos":[{"mask-r-from":j()}],"mask-image-r-to-pos":[{"mask-r-to":j()}],"mask-image-r-from-color":[{"mask-r-from":A()}],"mask-image-r-to-color":[{"mask-r-to":A()}],"mask-image-b-from-pos":[{"mask-b-from":j()}],"mask-image-b-to-pos":[{"mask-b-to":j()}],"mask-image-b-from-color":[{"mask-b-from":A()}],"mask-image-b-to-color":[{"mask-b-to":A()}],"mask-image-l-from-pos":[{"mask-l-from":j()}],"mask-image-l-to-pos":[{"mask-l-to":j()}],"mask-image-l-from-color":[{"mask-l-from":A()}],"mask-image-l-to-color":[{"mask-l-to":A()}],"mask-image-x-from-pos":[{"mask-x-from":j()}],"mask-image-x-to-pos":[{"mask-x-to":j()}],"mask-image-x-from-color":[{"mask-x-from":A()}],"mask-image-x-to-color":[{"mask-x-to":A()}],"mask-image-y-from-pos":[{"mask-y-from":j()}],"mask-image-y-to-pos":[{"mask-y-to":j()}],"mask-image-y-from-color":[{"mask-y-from":A()}],"mask-image-y-to-color":[{"mask-y-to":A()}],"mask-image-radial":[{"mask-radial":[ve,_e]}],"mask-image-radial-from-pos":[{"mask-radial-from":j()}],"mask-image-radial-to-pos":[{"mask-radial-to":j()}],"mask-image-radial-from-color":[{"mask-radial-from":A()}],"mask-image-radial-to-color":[{"mask-radial-to":A()}],"mask-image-radial-shape":[{"mask-radial":["circle","ellipse"]}],"mask-image-radial-size":[{"mask-radial":[{closest:["side","corner"],farthest:["side","corner"]}]}],"mask-image-radial-pos":[{"mask-radial-at":S()}],"mask-image-conic-pos":[{"mask-conic":[Re]}],"mask-image-conic-from-pos":[{"mask-conic-from":j()}],"mask-image-conic-to-pos":[{"mask-conic-to":j()}],"mask-image-conic-from-color":[{"mask-conic-from":A()}],"mask-image-conic-to-color":[{"mask-conic-to":A()}],"mask-mode":[{mask:["alpha","luminance","match"]}],"mask-origin":[{"mask-origin":["border","padding","content","fill","stroke","view"]}],"mask-position":[{mask:z()}],"mask-repeat":[{mask:M()}],"mask-size":[{mask:P()}],"mask-type":[{"mask-type":["alpha","luminance"]}],"mask-image":[{mask:["none",ve,_e]}],filter:[{filter:["","none",ve,_e]}],blur:[{blur:Z()}],brightness:[{brightness:[Re,ve,_e]}],contrast:[{contrast:[Re,ve,_e]}],"drop-shadow":[{"drop-shadow":["","none",w,Dl,Rl]}],"drop-shadow-color":[{"drop-shadow":A()}],grayscale:[{grayscale:["",Re,ve,_e]}],"hue-rotate":[{"hue-rotate":[Re,ve,_e]}],invert:[{invert:["",Re,ve,_e]}],saturate:[{saturate:[Re,ve,_e]}],sepia:[{sepia:["",Re,ve,_e]}],"backdrop-filter":[{"backdrop-filter":["","none",ve,_e]}],"backdrop-blur":[{"backdrop-blur":Z()}],"backdrop-brightness":[{"backdrop-brightness":[Re,ve,_e]}],"backdrop-contrast":[{"backdrop-contrast":[Re,ve,_e]}],"backdrop-grayscale":[{"backdrop-grayscale":["",Re,ve,_e]}],"backdrop-hue-rotate":[{"backdrop-hue-rotate":[Re,ve,_e]}],"backdrop-invert":[{"backdrop-invert":["",Re,ve,_e]}],"backdrop-opacity":[{"backdrop-opacity":[Re,ve,_e]}],"backdrop-saturate":[{"backdrop-saturate":[Re,ve,_e]}],"backdrop-sepia":[{"backdrop-sepia":["",Re,ve,_e]}],"border-collapse":[{border:["collapse","separate"]}],"border-spacing":[{"border-spacing":y()}],"border-spacing-x":[{"border-spacing-x":y()}],"border-spacing-y":[{"border-spacing-y":y()}],"table-layout":[{table:["auto","fixed"]}],caption:[{caption:["top","bottom"]}],transition:[{transition:["","all","colors","opacity","shadow","transform","none",ve,_e]}],"transition-behavior":[{transition:["normal","discrete"]}],duration:[{duration:[Re,"initial",ve,_e]}],ease:[{ease:["linear","initial",u,ve,_e]}],delay:[{delay:[Re,ve,_e]}],animate:[{animate:["none",d,ve,_e]}],backface:[{backface:["hidden","visible"]}],perspective:[{perspective:[C,ve,_e]}],"perspective-origin":[{"perspective-origin":x()}],rotate:[{rotate:ae()}],"rotate-x":[{"rotate-x":ae()}],"rotate-y":[{"rotate-y":ae()}],"rotate-z":[{"rotate-z":ae()}],scale:[{scale:re()}],"scale-x":[{"scale-x":re()}],"scale-y":[{"scale-y":re()}],"scale-z":[{"scale-z":re()}],"scale-3d":["scale-3d"],skew:[{skew:pe()}],"skew-x":[{"skew-x":pe()}],"skew-y":[{"skew-y":pe()}],transform:[{transform:[ve,_e,"","none","gpu","cpu"]}],"transform-origin":[{origin:x()}],"transform-style":[{transform:["3d","flat"]}],translate:[{translate:de()}],"translate-x":[{"translate-x":de()}],"translate-y":[{"translate-y":de()}],"translate-z":[{"translate-z":de()}],"translate-none":["translate-none"],accent:[{accent:A()}],appearance:[{appearance:["none","auto"]}],"caret-color":[{caret:A()}],"color-scheme":[{scheme:["normal","dark","light","light-dark","only-dark","only-light"]}],cursor:[{cursor:["auto","default","pointer","wait","text","move","help","not-allowed","none","context-menu","progress","cell","crosshair","vertical-text","alias","copy","no-drop","grab","grabbing","all-scroll","col-resize","row-resize","n-resize","e-resize","s-resize","w-resize","ne-resize","nw-resize","se-resize","sw-resize","ew-resize","ns-resize","nesw-resize","nwse-resize","zoom-in","zoom-out",ve,_e]}],"field-sizing":[{"field-sizing":["fixed","content"]}],"pointer-events":[{"pointer-events":["auto","none"]}],resize:[{resize:["none","","y","x"]}],"scroll-behavior":[{scroll:["auto","smooth"]}],"scroll-m":[{"scroll-m":y()}],"scroll-mx":[{"scroll-mx":y()}],"scroll-my":[{"scroll-my":y()}],"scroll-ms":[{"scroll-ms":y()}],"scroll-me":[{"scroll-me":y()}],"scroll-mt":[{"scroll-mt":y()}],"scroll-mr":[{"scroll-mr":y()}],"scroll-mb":[{"scroll-mb":y()}],"scroll-ml":[{"scroll-ml":y()}],"scroll-p":[{"scroll-p":y()}],"scroll-px":[{"scroll-px":y()}],"scroll-py":[{"scroll-py":y()}],"scroll-ps":[{"scroll-ps":y()}],"scroll-pe":[{"scroll-pe":y()}],"scroll-pt":[{"scroll-pt":y()}],"scroll-pr":[{"scroll-pr":y()}],"scroll-pb":[{"scroll-pb":y()}],"scroll-pl":[{"scroll-pl":y()}],"snap-align":[{snap:["start","end","center","align-none"]}],"snap-stop":[{snap:["normal","always"]}],"snap-type":[{snap:["none","x","y","both"]}],"snap-strictness":[{snap:["mandatory","proximity"]}],touch:[{touch:["auto","none","manipulation"]}],"touch-x":[{"touch-pan":["x","left","right"]}],"touch-y":[{"touch-pan":["y","up","down"]}],"touch-pz":["touch-pinch-zoom"],select:[{select:["none","text","all","auto"]}],"will-change":[{"will-change":["auto","scroll","contents","transform",ve,_e]}],fill:[{fill:["none",...A()]}],"stroke-w":[{stroke:[Re,$n,Kr,cu]}],stroke:[{stroke:["none",...A()]}],"forced-color-adjust":[{"forced-color-adjust":["auto","none"]}]},conflictingClassGroups:{overflow:["overflow-x","overflow-y"],overscroll:["overscroll-x","overscroll-y"],inset:["inset-x","inset-y","start","end","top","right","bottom","left"],"inset-x":["right","left"],"inset-y":["top","bottom"],flex:["basis","grow","shrink"],gap:["gap-x","gap-y"],p:["px","py","ps","pe","pt","pr","pb","pl"],px:["pr","pl"],py:["pt","pb"],m:["mx","my","ms","me","mt","mr","mb","ml"],mx:["mr","ml"],my:["mt","mb"],size:["w","h"],"font-size":["leading"],"fvn-normal":["fvn-ordinal","fvn-slashed-zero","fvn-figure","fvn-spacing","fvn-fraction"],"fvn-ordinal":["fvn-normal"],"fvn-slashed-zero":["fvn-normal"],"fvn-figure":["fvn-normal"],"fvn-spacing":["fvn-normal"],"fvn-fraction":["fvn-normal"],"line-clamp":["display","overflow"],rounded:["rounded-s","rounded-e","rounded-t","rounded-r","rounded-b","rounded-l","rounded-ss","rounded-se","rounded-ee","rounded-es","rounded-tl","rounded-tr","rounded-br","rounded-bl"],"rounded-s":["rounded-ss","rounded-es"],"rounded-e":["rounded-se","rounded-ee"],"rounded-t":["rounded-tl","rounded-tr"],"rounded-r":["rounded-tr","rounded-br"],"rounded-b":["rounded-br","rounded-bl"],"rounded-l":["rounded-tl","rounded-bl"],"border-spacing":["border-spacing-x","border-spacing-y"],"border-w":["border-w-x","border-w-y","border-w-s","border-w-e","border-w-t","border-w-r","border-w-b","border-w-l"],"border-w-x":["border-w-r","border-w-l"],"border-w-y":["border-w-t","border-w-b"],"border-color":["border-color-x","border-color-y","border-color-s","border-color-e","border-color-t","border-color-r","border-color-b","border-color-l"],"border-color-x":["border-color-r","border-color-l"],"border-color-y":["border-color-t","border-color-b"],translate:["translate-x","translate-y","translate-none"],"translate-none":["translate","translate-x","translate-y","translate-z"],"scroll-m":["scroll-mx","scroll-my","scroll-ms","scroll-me","scroll-mt","scroll-mr","scroll-mb","scroll-ml"],"scroll-mx":["scroll-mr","scroll-ml"],"scroll-my":["scroll-mt","scroll-mb"],"scroll-p":["scroll-px","scroll-py","scroll-ps","scroll-pe","scroll-pt","scroll-pr","scroll-pb","scroll-pl"],"scroll-px":["scroll-pr","scroll-pl"],"scroll-py":["scroll-pt","scroll-pb"],touch:["touch-x","touch-y","touch-pz"],"touch-x":["touch"],"touch-y":["touch"],"touch-pz":["touch"]},conflictingClassGroupModifiers:{"font-size":["leading"]},orderSensitiveModifiers:["*","**","after","backdrop","before","details-content","file","first-letter","first-line","marker","placeholder","selection"]}},_y=Xb(my);function It(...s){return _y(Y_(s))}const vy=$_("inline-flex items-center justify-center whitespace-nowrap rounded-md text-sm font-medium ring-offset-background transition-colors focus-visible:outline-none focus-visible:ring-2 focus-visible:ring-ring focus-visible:ring-offset-2 disabled:pointer-events-none disabled:opacity-50",{variants:{variant:{default:"bg-primary text-primary-foreground hover:bg-primary/90",destructive:"bg-destructive text-destructive-foreground hover:bg-destructive/90",outline:"border border-input bg-background hover:bg-accent hover:text-accent-foreground",secondary:"bg-secondary text-secondary-foreground hover:bg-secondary/80",ghost:"hover:bg-accent hover:text-accent-foreground",link:"text-primary underline-offset-4 hover:underline"},size:{default:"h-10 px-4 py-2",sm:"h-9 rounded-md px-3",lg:"h-11 rounded-md px-8",icon:"h-10 w-10"}},defaultVariants:{variant:"default",size:"default"}}),Ce=U.forwardRef(({className:s,variant:o,size:_,asChild:g=!1,...E},T)=>{const B=g?Tb:"button";return D.jsx(B,{className:It(vy({variant:o,size:_,className:s})),ref:T,...E})});Ce.displayName="Button";const Dt=U.forwardRef(({className:s,...o},_)=>D.jsx("div",{ref:_,className:It("rounded-lg border bg-card text-card-foreground shadow-sm",s),...o}));Dt.displayName="Card";const ti=U.forwardRef(({className:s,...o},_)=>D.jsx("div",{ref:_,className:It("flex flex-col space-y-1.5 p-6",s),...o}));ti.displayName="CardHeader";const di=U.forwardRef(({className:s,...o},_)=>D.jsx("h3",{ref:_,className:It("text-2xl font-semibold leading-none tracking-tight",s),...o}));di.displayName="CardTitle";const Bl=U.forwardRef(({className:s,...o},_)=>D.jsx("p",{ref:_,className:It("text-sm text-muted-foreground",s),...o}));Bl.displayName="CardDescription";const At=U.forwardRef(({className:s,...o},_)=>D.jsx("div",{ref:_,className:It("p-6 pt-0",s),...o}));At.displayName="CardContent";const gy=U.forwardRef(({className:s,...o},_)=>D.jsx("div",{ref:_,className:It("flex items-center p-6 pt-0",s),...o}));gy.displayName="CardFooter";const py=$_("inline-flex items-center rounded-full border px-2.5 py-0.5 text-xs font-semibold transition-colors focus:outline-none focus:ring-2 focus:ring-ring focus:ring-offset-2",{variants:{variant:{default:"border-transparent bg-primary text-primary-foreground hover:bg-primary/80",secondary:"border-transparent bg-secondary text-secondary-foreground hover:bg-secondary/80",destructive:"border-transparent bg-destructive text-destructive-foreground hover:bg-destructive/80",outline:"text-foreground"}},defaultVariants:{variant:"default"}});function Tt({className:s,variant:o,..._}){return D.jsx("div",{className:It(py({variant:o}),s),..._})}var by=["a","button","div","form","h2","h3","img","input","label","li","nav","ol","p","select","span","svg","ul"],yy=by.reduce((s,o)=>{const _=V_(`Primitive.${o}`),g=U.forwardRef((E,T)=>{const{asChild:B,...l}=E,c=B?_:o;return typeof window<"u"&&(window[Symbol.for("radix-ui")]=!0),D.jsx(c,{...l,ref:T})});return g.displayName=`Primitive.${o}`,{...s,[o]:g}},{}),Sy="Separator",n_="horizontal",xy=["horizontal","vertical"],sv=U.forwardRef((s,o)=>{const{decorative:_,orientation:g=n_,...E}=s,T=Cy(g)?g:n_,l=_?{role:"none"}:{"aria-orientation":T==="vertical"?T:void 0,role:"separator"};return D.jsx(yy.div,{"data-orientation":T,...l,...E,ref:o})});sv.displayName=Sy;function Cy(s){return xy.includes(s)}var nv=sv;const zu=U.forwardRef(({className:s,orientation:o="horizontal",decorative:_=!0,...g},E)=>D.jsx(nv,{ref:E,decorative:_,orientation:o,className:It("shrink-0 bg-border",o==="horizontal"?"h-[1px] w-full":"h-full w-[1px]",s),...g}));zu.displayName=nv.displayName;/**
  * @license lucide-react v0.525.0 - ISC
  *
  * This source code is licensed under the ISC license.
@@ -205,7 +205,7 @@ WARNING: This link could potentially be dangerous`)){const u=window.open();if(u)
 `:`
 `)}clearSelection(){this._model.clearSelection(),this._removeMouseDownListeners(),this.refresh(),this._onSelectionChange.fire()}refresh(y){this._refreshAnimationFrame||(this._refreshAnimationFrame=this._coreBrowserService.window.requestAnimationFrame(()=>this._refresh())),u.isLinux&&y&&this.selectionText.length&&this._onLinuxMouseSelection.fire(this.selectionText)}_refresh(){this._refreshAnimationFrame=void 0,this._onRedrawRequest.fire({start:this._model.finalSelectionStart,end:this._model.finalSelectionEnd,columnSelectMode:this._activeSelectionMode===3})}_isClickInSelection(y){const N=this._getMouseBufferCoords(y),F=this._model.finalSelectionStart,Y=this._model.finalSelectionEnd;return!!(F&&Y&&N)&&this._areCoordsInSelection(N,F,Y)}isCellInSelection(y,N){const F=this._model.finalSelectionStart,Y=this._model.finalSelectionEnd;return!(!F||!Y)&&this._areCoordsInSelection([y,N],F,Y)}_areCoordsInSelection(y,N,F){return y[1]>N[1]&&y[1]<F[1]||N[1]===F[1]&&y[1]===N[1]&&y[0]>=N[0]&&y[0]<F[0]||N[1]<F[1]&&y[1]===F[1]&&y[0]<F[0]||N[1]<F[1]&&y[1]===N[1]&&y[0]>=N[0]}_selectWordAtCursor(y,N){var F,Y;const q=(Y=(F=this._linkifier.currentLink)===null||F===void 0?void 0:F.link)===null||Y===void 0?void 0:Y.range;if(q)return this._model.selectionStart=[q.start.x-1,q.start.y-1],this._model.selectionStartLength=(0,d.getRangeLength)(q,this._bufferService.cols),this._model.selectionEnd=void 0,!0;const V=this._getMouseBufferCoords(y);return!!V&&(this._selectWordAt(V,N),this._model.selectionEnd=void 0,!0)}selectAll(){this._model.isSelectAllActive=!0,this.refresh(),this._onSelectionChange.fire()}selectLines(y,N){this._model.clearSelection(),y=Math.max(y,0),N=Math.min(N,this._bufferService.buffer.lines.length-1),this._model.selectionStart=[0,y],this._model.selectionEnd=[this._bufferService.cols,N],this.refresh(),this._onSelectionChange.fire()}_handleTrim(y){this._model.handleTrim(y)&&this.refresh()}_getMouseBufferCoords(y){const N=this._mouseService.getCoords(y,this._screenElement,this._bufferService.cols,this._bufferService.rows,!0);if(N)return N[0]--,N[1]--,N[1]+=this._bufferService.buffer.ydisp,N}_getMouseEventScrollAmount(y){let N=(0,v.getCoordsRelativeToElement)(this._coreBrowserService.window,y,this._screenElement)[1];const F=this._renderService.dimensions.css.canvas.height;return N>=0&&N<=F?0:(N>F&&(N-=F),N=Math.min(Math.max(N,-50),50),N/=50,N/Math.abs(N)+Math.round(14*N))}shouldForceSelection(y){return u.isMac?y.altKey&&this._optionsService.rawOptions.macOptionClickForcesSelection:y.shiftKey}handleMouseDown(y){if(this._mouseDownTimeStamp=y.timeStamp,(y.button!==2||!this.hasSelection)&&y.button===0){if(!this._enabled){if(!this.shouldForceSelection(y))return;y.stopPropagation()}y.preventDefault(),this._dragScrollAmount=0,this._enabled&&y.shiftKey?this._handleIncrementalClick(y):y.detail===1?this._handleSingleClick(y):y.detail===2?this._handleDoubleClick(y):y.detail===3&&this._handleTripleClick(y),this._addMouseDownListeners(),this.refresh(!0)}}_addMouseDownListeners(){this._screenElement.ownerDocument&&(this._screenElement.ownerDocument.addEventListener("mousemove",this._mouseMoveListener),this._screenElement.ownerDocument.addEventListener("mouseup",this._mouseUpListener)),this._dragScrollIntervalTimer=this._coreBrowserService.window.setInterval(()=>this._dragScroll(),50)}_removeMouseDownListeners(){this._screenElement.ownerDocument&&(this._screenElement.ownerDocument.removeEventListener("mousemove",this._mouseMoveListener),this._screenElement.ownerDocument.removeEventListener("mouseup",this._mouseUpListener)),this._coreBrowserService.window.clearInterval(this._dragScrollIntervalTimer),this._dragScrollIntervalTimer=void 0}_handleIncrementalClick(y){this._model.selectionStart&&(this._model.selectionEnd=this._getMouseBufferCoords(y))}_handleSingleClick(y){if(this._model.selectionStartLength=0,this._model.isSelectAllActive=!1,this._activeSelectionMode=this.shouldColumnSelect(y)?3:0,this._model.selectionStart=this._getMouseBufferCoords(y),!this._model.selectionStart)return;this._model.selectionEnd=void 0;const N=this._bufferService.buffer.lines.get(this._model.selectionStart[1]);N&&N.length!==this._model.selectionStart[0]&&N.hasWidth(this._model.selectionStart[0])===0&&this._model.selectionStart[0]++}_handleDoubleClick(y){this._selectWordAtCursor(y,!0)&&(this._activeSelectionMode=1)}_handleTripleClick(y){const N=this._getMouseBufferCoords(y);N&&(this._activeSelectionMode=2,this._selectLineAt(N[1]))}shouldColumnSelect(y){return y.altKey&&!(u.isMac&&this._optionsService.rawOptions.macOptionClickForcesSelection)}_handleMouseMove(y){if(y.stopImmediatePropagation(),!this._model.selectionStart)return;const N=this._model.selectionEnd?[this._model.selectionEnd[0],this._model.selectionEnd[1]]:null;if(this._model.selectionEnd=this._getMouseBufferCoords(y),!this._model.selectionEnd)return void this.refresh(!0);this._activeSelectionMode===2?this._model.selectionEnd[1]<this._model.selectionStart[1]?this._model.selectionEnd[0]=0:this._model.selectionEnd[0]=this._bufferService.cols:this._activeSelectionMode===1&&this._selectToWordAt(this._model.selectionEnd),this._dragScrollAmount=this._getMouseEventScrollAmount(y),this._activeSelectionMode!==3&&(this._dragScrollAmount>0?this._model.selectionEnd[0]=this._bufferService.cols:this._dragScrollAmount<0&&(this._model.selectionEnd[0]=0));const F=this._bufferService.buffer;if(this._model.selectionEnd[1]<F.lines.length){const Y=F.lines.get(this._model.selectionEnd[1]);Y&&Y.hasWidth(this._model.selectionEnd[0])===0&&this._model.selectionEnd[0]++}N&&N[0]===this._model.selectionEnd[0]&&N[1]===this._model.selectionEnd[1]||this.refresh(!0)}_dragScroll(){if(this._model.selectionEnd&&this._model.selectionStart&&this._dragScrollAmount){this._onRequestScrollLines.fire({amount:this._dragScrollAmount,suppressScrollEvent:!1});const y=this._bufferService.buffer;this._dragScrollAmount>0?(this._activeSelectionMode!==3&&(this._model.selectionEnd[0]=this._bufferService.cols),this._model.selectionEnd[1]=Math.min(y.ydisp+this._bufferService.rows,y.lines.length-1)):(this._activeSelectionMode!==3&&(this._model.selectionEnd[0]=0),this._model.selectionEnd[1]=y.ydisp),this.refresh()}}_handleMouseUp(y){const N=y.timeStamp-this._mouseDownTimeStamp;if(this._removeMouseDownListeners(),this.selectionText.length<=1&&N<500&&y.altKey&&this._optionsService.rawOptions.altClickMovesCursor){if(this._bufferService.buffer.ybase===this._bufferService.buffer.ydisp){const F=this._mouseService.getCoords(y,this._element,this._bufferService.cols,this._bufferService.rows,!1);if(F&&F[0]!==void 0&&F[1]!==void 0){const Y=(0,b.moveToCellSequence)(F[0]-1,F[1]-1,this._bufferService,this._coreService.decPrivateModes.applicationCursorKeys);this._coreService.triggerDataEvent(Y,!0)}}}else this._fireEventIfSelectionChanged()}_fireEventIfSelectionChanged(){const y=this._model.finalSelectionStart,N=this._model.finalSelectionEnd,F=!(!y||!N||y[0]===N[0]&&y[1]===N[1]);F?y&&N&&(this._oldSelectionStart&&this._oldSelectionEnd&&y[0]===this._oldSelectionStart[0]&&y[1]===this._oldSelectionStart[1]&&N[0]===this._oldSelectionEnd[0]&&N[1]===this._oldSelectionEnd[1]||this._fireOnSelectionChange(y,N,F)):this._oldHasSelection&&this._fireOnSelectionChange(y,N,F)}_fireOnSelectionChange(y,N,F){this._oldSelectionStart=y,this._oldSelectionEnd=N,this._oldHasSelection=F,this._onSelectionChange.fire()}_handleBufferActivate(y){this.clearSelection(),this._trimListener.dispose(),this._trimListener=y.activeBuffer.lines.onTrim(N=>this._handleTrim(N))}_convertViewportColToCharacterIndex(y,N){let F=N;for(let Y=0;N>=Y;Y++){const q=y.loadCell(Y,this._workCell).getChars().length;this._workCell.getWidth()===0?F--:q>1&&N!==Y&&(F+=q-1)}return F}setSelection(y,N,F){this._model.clearSelection(),this._removeMouseDownListeners(),this._model.selectionStart=[y,N],this._model.selectionStartLength=F,this.refresh(),this._fireEventIfSelectionChanged()}rightClickSelect(y){this._isClickInSelection(y)||(this._selectWordAtCursor(y,!1)&&this.refresh(!0),this._fireEventIfSelectionChanged())}_getWordAt(y,N,F=!0,Y=!0){if(y[0]>=this._bufferService.cols)return;const q=this._bufferService.buffer,V=q.lines.get(y[1]);if(!V)return;const ee=q.translateBufferLineToString(y[1],!1);let K=this._convertViewportColToCharacterIndex(V,y[0]),I=K;const G=y[0]-K;let A=0,z=0,M=0,P=0;if(ee.charAt(K)===" "){for(;K>0&&ee.charAt(K-1)===" ";)K--;for(;I<ee.length&&ee.charAt(I+1)===" ";)I++}else{let le=y[0],ue=y[0];V.getWidth(le)===0&&(A++,le--),V.getWidth(ue)===2&&(z++,ue++);const fe=V.getString(ue).length;for(fe>1&&(P+=fe-1,I+=fe-1);le>0&&K>0&&!this._isCharWordSeparator(V.loadCell(le-1,this._workCell));){V.loadCell(le-1,this._workCell);const j=this._workCell.getChars().length;this._workCell.getWidth()===0?(A++,le--):j>1&&(M+=j-1,K-=j-1),K--,le--}for(;ue<V.length&&I+1<ee.length&&!this._isCharWordSeparator(V.loadCell(ue+1,this._workCell));){V.loadCell(ue+1,this._workCell);const j=this._workCell.getChars().length;this._workCell.getWidth()===2?(z++,ue++):j>1&&(P+=j-1,I+=j-1),I++,ue++}}I++;let W=K+G-A+M,J=Math.min(this._bufferService.cols,I-K+A+z-M-P);if(N||ee.slice(K,I).trim()!==""){if(F&&W===0&&V.getCodePoint(0)!==32){const le=q.lines.get(y[1]-1);if(le&&V.isWrapped&&le.getCodePoint(this._bufferService.cols-1)!==32){const ue=this._getWordAt([this._bufferService.cols-1,y[1]-1],!1,!0,!1);if(ue){const fe=this._bufferService.cols-ue.start;W-=fe,J+=fe}}}if(Y&&W+J===this._bufferService.cols&&V.getCodePoint(this._bufferService.cols-1)!==32){const le=q.lines.get(y[1]+1);if(le?.isWrapped&&le.getCodePoint(0)!==32){const ue=this._getWordAt([0,y[1]+1],!1,!1,!0);ue&&(J+=ue.length)}}return{start:W,length:J}}}_selectWordAt(y,N){const F=this._getWordAt(y,N);if(F){for(;F.start<0;)F.start+=this._bufferService.cols,y[1]--;this._model.selectionStart=[F.start,y[1]],this._model.selectionStartLength=F.length}}_selectToWordAt(y){const N=this._getWordAt(y,!0);if(N){let F=y[1];for(;N.start<0;)N.start+=this._bufferService.cols,F--;if(!this._model.areSelectionValuesReversed())for(;N.start+N.length>this._bufferService.cols;)N.length-=this._bufferService.cols,F++;this._model.selectionEnd=[this._model.areSelectionValuesReversed()?N.start:N.start+N.length,F]}}_isCharWordSeparator(y){return y.getWidth()!==0&&this._optionsService.rawOptions.wordSeparator.indexOf(y.getChars())>=0}_selectLineAt(y){const N=this._bufferService.buffer.getWrappedRangeForLine(y),F={start:{x:0,y:N.first},end:{x:this._bufferService.cols-1,y:N.last}};this._model.selectionStart=[0,N.first],this._model.selectionEnd=void 0,this._model.selectionStartLength=(0,d.getRangeLength)(F,this._bufferService.cols)}};l.SelectionService=O=h([p(3,S.IBufferService),p(4,S.ICoreService),p(5,k.IMouseService),p(6,S.IOptionsService),p(7,k.IRenderService),p(8,k.ICoreBrowserService)],O)},4725:(B,l,c)=>{Object.defineProperty(l,"__esModule",{value:!0}),l.IThemeService=l.ICharacterJoinerService=l.ISelectionService=l.IRenderService=l.IMouseService=l.ICoreBrowserService=l.ICharSizeService=void 0;const h=c(8343);l.ICharSizeService=(0,h.createDecorator)("CharSizeService"),l.ICoreBrowserService=(0,h.createDecorator)("CoreBrowserService"),l.IMouseService=(0,h.createDecorator)("MouseService"),l.IRenderService=(0,h.createDecorator)("RenderService"),l.ISelectionService=(0,h.createDecorator)("SelectionService"),l.ICharacterJoinerService=(0,h.createDecorator)("CharacterJoinerService"),l.IThemeService=(0,h.createDecorator)("ThemeService")},6731:function(B,l,c){var h=this&&this.__decorate||function(O,y,N,F){var Y,q=arguments.length,V=q<3?y:F===null?F=Object.getOwnPropertyDescriptor(y,N):F;if(typeof Reflect=="object"&&typeof Reflect.decorate=="function")V=Reflect.decorate(O,y,N,F);else for(var ee=O.length-1;ee>=0;ee--)(Y=O[ee])&&(V=(q<3?Y(V):q>3?Y(y,N,V):Y(y,N))||V);return q>3&&V&&Object.defineProperty(y,N,V),V},p=this&&this.__param||function(O,y){return function(N,F){y(N,F,O)}};Object.defineProperty(l,"__esModule",{value:!0}),l.ThemeService=l.DEFAULT_ANSI_COLORS=void 0;const v=c(7239),b=c(8055),w=c(8460),k=c(844),C=c(2585),a=b.css.toColor("#ffffff"),u=b.css.toColor("#000000"),d=b.css.toColor("#ffffff"),f=b.css.toColor("#000000"),S={css:"rgba(255, 255, 255, 0.3)",rgba:4294967117};l.DEFAULT_ANSI_COLORS=Object.freeze((()=>{const O=[b.css.toColor("#2e3436"),b.css.toColor("#cc0000"),b.css.toColor("#4e9a06"),b.css.toColor("#c4a000"),b.css.toColor("#3465a4"),b.css.toColor("#75507b"),b.css.toColor("#06989a"),b.css.toColor("#d3d7cf"),b.css.toColor("#555753"),b.css.toColor("#ef2929"),b.css.toColor("#8ae234"),b.css.toColor("#fce94f"),b.css.toColor("#729fcf"),b.css.toColor("#ad7fa8"),b.css.toColor("#34e2e2"),b.css.toColor("#eeeeec")],y=[0,95,135,175,215,255];for(let N=0;N<216;N++){const F=y[N/36%6|0],Y=y[N/6%6|0],q=y[N%6];O.push({css:b.channels.toCss(F,Y,q),rgba:b.channels.toRgba(F,Y,q)})}for(let N=0;N<24;N++){const F=8+10*N;O.push({css:b.channels.toCss(F,F,F),rgba:b.channels.toRgba(F,F,F)})}return O})());let x=l.ThemeService=class extends k.Disposable{get colors(){return this._colors}constructor(O){super(),this._optionsService=O,this._contrastCache=new v.ColorContrastCache,this._halfContrastCache=new v.ColorContrastCache,this._onChangeColors=this.register(new w.EventEmitter),this.onChangeColors=this._onChangeColors.event,this._colors={foreground:a,background:u,cursor:d,cursorAccent:f,selectionForeground:void 0,selectionBackgroundTransparent:S,selectionBackgroundOpaque:b.color.blend(u,S),selectionInactiveBackgroundTransparent:S,selectionInactiveBackgroundOpaque:b.color.blend(u,S),ansi:l.DEFAULT_ANSI_COLORS.slice(),contrastCache:this._contrastCache,halfContrastCache:this._halfContrastCache},this._updateRestoreColors(),this._setTheme(this._optionsService.rawOptions.theme),this.register(this._optionsService.onSpecificOptionChange("minimumContrastRatio",()=>this._contrastCache.clear())),this.register(this._optionsService.onSpecificOptionChange("theme",()=>this._setTheme(this._optionsService.rawOptions.theme)))}_setTheme(O={}){const y=this._colors;if(y.foreground=R(O.foreground,a),y.background=R(O.background,u),y.cursor=R(O.cursor,d),y.cursorAccent=R(O.cursorAccent,f),y.selectionBackgroundTransparent=R(O.selectionBackground,S),y.selectionBackgroundOpaque=b.color.blend(y.background,y.selectionBackgroundTransparent),y.selectionInactiveBackgroundTransparent=R(O.selectionInactiveBackground,y.selectionBackgroundTransparent),y.selectionInactiveBackgroundOpaque=b.color.blend(y.background,y.selectionInactiveBackgroundTransparent),y.selectionForeground=O.selectionForeground?R(O.selectionForeground,b.NULL_COLOR):void 0,y.selectionForeground===b.NULL_COLOR&&(y.selectionForeground=void 0),b.color.isOpaque(y.selectionBackgroundTransparent)&&(y.selectionBackgroundTransparent=b.color.opacity(y.selectionBackgroundTransparent,.3)),b.color.isOpaque(y.selectionInactiveBackgroundTransparent)&&(y.selectionInactiveBackgroundTransparent=b.color.opacity(y.selectionInactiveBackgroundTransparent,.3)),y.ansi=l.DEFAULT_ANSI_COLORS.slice(),y.ansi[0]=R(O.black,l.DEFAULT_ANSI_COLORS[0]),y.ansi[1]=R(O.red,l.DEFAULT_ANSI_COLORS[1]),y.ansi[2]=R(O.green,l.DEFAULT_ANSI_COLORS[2]),y.ansi[3]=R(O.yellow,l.DEFAULT_ANSI_COLORS[3]),y.ansi[4]=R(O.blue,l.DEFAULT_ANSI_COLORS[4]),y.ansi[5]=R(O.magenta,l.DEFAULT_ANSI_COLORS[5]),y.ansi[6]=R(O.cyan,l.DEFAULT_ANSI_COLORS[6]),y.ansi[7]=R(O.white,l.DEFAULT_ANSI_COLORS[7]),y.ansi[8]=R(O.brightBlack,l.DEFAULT_ANSI_COLORS[8]),y.ansi[9]=R(O.brightRed,l.DEFAULT_ANSI_COLORS[9]),y.ansi[10]=R(O.brightGreen,l.DEFAULT_ANSI_COLORS[10]),y.ansi[11]=R(O.brightYellow,l.DEFAULT_ANSI_COLORS[11]),y.ansi[12]=R(O.brightBlue,l.DEFAULT_ANSI_COLORS[12]),y.ansi[13]=R(O.brightMagenta,l.DEFAULT_ANSI_COLORS[13]),y.ansi[14]=R(O.brightCyan,l.DEFAULT_ANSI_COLORS[14]),y.ansi[15]=R(O.brightWhite,l.DEFAULT_ANSI_COLORS[15]),O.extendedAnsi){const N=Math.min(y.ansi.length-16,O.extendedAnsi.length);for(let F=0;F<N;F++)y.ansi[F+16]=R(O.extendedAnsi[F],l.DEFAULT_ANSI_COLORS[F+16])}this._contrastCache.clear(),this._halfContrastCache.clear(),this._updateRestoreColors(),this._onChangeColors.fire(this.colors)}restoreColor(O){this._restoreColor(O),this._onChangeColors.fire(this.colors)}_restoreColor(O){if(O!==void 0)switch(O){case 256:this._colors.foreground=this._restoreColors.foreground;break;case 257:this._colors.background=this._restoreColors.background;break;case 258:this._colors.cursor=this._restoreColors.cursor;break;default:this._colors.ansi[O]=this._restoreColors.ansi[O]}else for(let y=0;y<this._restoreColors.ansi.length;++y)this._colors.ansi[y]=this._restoreColors.ansi[y]}modifyColors(O){O(this._colors),this._onChangeColors.fire(this.colors)}_updateRestoreColors(){this._restoreColors={foreground:this._colors.foreground,background:this._colors.background,cursor:this._colors.cursor,ansi:this._colors.ansi.slice()}}};function R(O,y){if(O!==void 0)try{return b.css.toColor(O)}catch{}return y}l.ThemeService=x=h([p(0,C.IOptionsService)],x)},6349:(B,l,c)=>{Object.defineProperty(l,"__esModule",{value:!0}),l.CircularList=void 0;const h=c(8460),p=c(844);class v extends p.Disposable{constructor(w){super(),this._maxLength=w,this.onDeleteEmitter=this.register(new h.EventEmitter),this.onDelete=this.onDeleteEmitter.event,this.onInsertEmitter=this.register(new h.EventEmitter),this.onInsert=this.onInsertEmitter.event,this.onTrimEmitter=this.register(new h.EventEmitter),this.onTrim=this.onTrimEmitter.event,this._array=new Array(this._maxLength),this._startIndex=0,this._length=0}get maxLength(){return this._maxLength}set maxLength(w){if(this._maxLength===w)return;const k=new Array(w);for(let C=0;C<Math.min(w,this.length);C++)k[C]=this._array[this._getCyclicIndex(C)];this._array=k,this._maxLength=w,this._startIndex=0}get length(){return this._length}set length(w){if(w>this._length)for(let k=this._length;k<w;k++)this._array[k]=void 0;this._length=w}get(w){return this._array[this._getCyclicIndex(w)]}set(w,k){this._array[this._getCyclicIndex(w)]=k}push(w){this._array[this._getCyclicIndex(this._length)]=w,this._length===this._maxLength?(this._startIndex=++this._startIndex%this._maxLength,this.onTrimEmitter.fire(1)):this._length++}recycle(){if(this._length!==this._maxLength)throw new Error("Can only recycle when the buffer is full");return this._startIndex=++this._startIndex%this._maxLength,this.onTrimEmitter.fire(1),this._array[this._getCyclicIndex(this._length-1)]}get isFull(){return this._length===this._maxLength}pop(){return this._array[this._getCyclicIndex(this._length---1)]}splice(w,k,...C){if(k){for(let a=w;a<this._length-k;a++)this._array[this._getCyclicIndex(a)]=this._array[this._getCyclicIndex(a+k)];this._length-=k,this.onDeleteEmitter.fire({index:w,amount:k})}for(let a=this._length-1;a>=w;a--)this._array[this._getCyclicIndex(a+C.length)]=this._array[this._getCyclicIndex(a)];for(let a=0;a<C.length;a++)this._array[this._getCyclicIndex(w+a)]=C[a];if(C.length&&this.onInsertEmitter.fire({index:w,amount:C.length}),this._length+C.length>this._maxLength){const a=this._length+C.length-this._maxLength;this._startIndex+=a,this._length=this._maxLength,this.onTrimEmitter.fire(a)}else this._length+=C.length}trimStart(w){w>this._length&&(w=this._length),this._startIndex+=w,this._length-=w,this.onTrimEmitter.fire(w)}shiftElements(w,k,C){if(!(k<=0)){if(w<0||w>=this._length)throw new Error("start argument out of range");if(w+C<0)throw new Error("Cannot shift elements in list beyond index 0");if(C>0){for(let u=k-1;u>=0;u--)this.set(w+u+C,this.get(w+u));const a=w+k+C-this._length;if(a>0)for(this._length+=a;this._length>this._maxLength;)this._length--,this._startIndex++,this.onTrimEmitter.fire(1)}else for(let a=0;a<k;a++)this.set(w+a+C,this.get(w+a))}}_getCyclicIndex(w){return(this._startIndex+w)%this._maxLength}}l.CircularList=v},1439:(B,l)=>{Object.defineProperty(l,"__esModule",{value:!0}),l.clone=void 0,l.clone=function c(h,p=5){if(typeof h!="object")return h;const v=Array.isArray(h)?[]:{};for(const b in h)v[b]=p<=1?h[b]:h[b]&&c(h[b],p-1);return v}},8055:(B,l,c)=>{Object.defineProperty(l,"__esModule",{value:!0}),l.contrastRatio=l.toPaddedHex=l.rgba=l.rgb=l.css=l.color=l.channels=l.NULL_COLOR=void 0;const h=c(6114);let p=0,v=0,b=0,w=0;var k,C,a,u,d;function f(x){const R=x.toString(16);return R.length<2?"0"+R:R}function S(x,R){return x<R?(R+.05)/(x+.05):(x+.05)/(R+.05)}l.NULL_COLOR={css:"#00000000",rgba:0},function(x){x.toCss=function(R,O,y,N){return N!==void 0?`#${f(R)}${f(O)}${f(y)}${f(N)}`:`#${f(R)}${f(O)}${f(y)}`},x.toRgba=function(R,O,y,N=255){return(R<<24|O<<16|y<<8|N)>>>0}}(k||(l.channels=k={})),function(x){function R(O,y){return w=Math.round(255*y),[p,v,b]=d.toChannels(O.rgba),{css:k.toCss(p,v,b,w),rgba:k.toRgba(p,v,b,w)}}x.blend=function(O,y){if(w=(255&y.rgba)/255,w===1)return{css:y.css,rgba:y.rgba};const N=y.rgba>>24&255,F=y.rgba>>16&255,Y=y.rgba>>8&255,q=O.rgba>>24&255,V=O.rgba>>16&255,ee=O.rgba>>8&255;return p=q+Math.round((N-q)*w),v=V+Math.round((F-V)*w),b=ee+Math.round((Y-ee)*w),{css:k.toCss(p,v,b),rgba:k.toRgba(p,v,b)}},x.isOpaque=function(O){return(255&O.rgba)==255},x.ensureContrastRatio=function(O,y,N){const F=d.ensureContrastRatio(O.rgba,y.rgba,N);if(F)return d.toColor(F>>24&255,F>>16&255,F>>8&255)},x.opaque=function(O){const y=(255|O.rgba)>>>0;return[p,v,b]=d.toChannels(y),{css:k.toCss(p,v,b),rgba:y}},x.opacity=R,x.multiplyOpacity=function(O,y){return w=255&O.rgba,R(O,w*y/255)},x.toColorRGB=function(O){return[O.rgba>>24&255,O.rgba>>16&255,O.rgba>>8&255]}}(C||(l.color=C={})),function(x){let R,O;if(!h.isNode){const y=document.createElement("canvas");y.width=1,y.height=1;const N=y.getContext("2d",{willReadFrequently:!0});N&&(R=N,R.globalCompositeOperation="copy",O=R.createLinearGradient(0,0,1,1))}x.toColor=function(y){if(y.match(/#[\da-f]{3,8}/i))switch(y.length){case 4:return p=parseInt(y.slice(1,2).repeat(2),16),v=parseInt(y.slice(2,3).repeat(2),16),b=parseInt(y.slice(3,4).repeat(2),16),d.toColor(p,v,b);case 5:return p=parseInt(y.slice(1,2).repeat(2),16),v=parseInt(y.slice(2,3).repeat(2),16),b=parseInt(y.slice(3,4).repeat(2),16),w=parseInt(y.slice(4,5).repeat(2),16),d.toColor(p,v,b,w);case 7:return{css:y,rgba:(parseInt(y.slice(1),16)<<8|255)>>>0};case 9:return{css:y,rgba:parseInt(y.slice(1),16)>>>0}}const N=y.match(/rgba?\(\s*(\d{1,3})\s*,\s*(\d{1,3})\s*,\s*(\d{1,3})\s*(,\s*(0|1|\d?\.(\d+))\s*)?\)/);if(N)return p=parseInt(N[1]),v=parseInt(N[2]),b=parseInt(N[3]),w=Math.round(255*(N[5]===void 0?1:parseFloat(N[5]))),d.toColor(p,v,b,w);if(!R||!O)throw new Error("css.toColor: Unsupported css format");if(R.fillStyle=O,R.fillStyle=y,typeof R.fillStyle!="string")throw new Error("css.toColor: Unsupported css format");if(R.fillRect(0,0,1,1),[p,v,b,w]=R.getImageData(0,0,1,1).data,w!==255)throw new Error("css.toColor: Unsupported css format");return{rgba:k.toRgba(p,v,b,w),css:y}}}(a||(l.css=a={})),function(x){function R(O,y,N){const F=O/255,Y=y/255,q=N/255;return .2126*(F<=.03928?F/12.92:Math.pow((F+.055)/1.055,2.4))+.7152*(Y<=.03928?Y/12.92:Math.pow((Y+.055)/1.055,2.4))+.0722*(q<=.03928?q/12.92:Math.pow((q+.055)/1.055,2.4))}x.relativeLuminance=function(O){return R(O>>16&255,O>>8&255,255&O)},x.relativeLuminance2=R}(u||(l.rgb=u={})),function(x){function R(y,N,F){const Y=y>>24&255,q=y>>16&255,V=y>>8&255;let ee=N>>24&255,K=N>>16&255,I=N>>8&255,G=S(u.relativeLuminance2(ee,K,I),u.relativeLuminance2(Y,q,V));for(;G<F&&(ee>0||K>0||I>0);)ee-=Math.max(0,Math.ceil(.1*ee)),K-=Math.max(0,Math.ceil(.1*K)),I-=Math.max(0,Math.ceil(.1*I)),G=S(u.relativeLuminance2(ee,K,I),u.relativeLuminance2(Y,q,V));return(ee<<24|K<<16|I<<8|255)>>>0}function O(y,N,F){const Y=y>>24&255,q=y>>16&255,V=y>>8&255;let ee=N>>24&255,K=N>>16&255,I=N>>8&255,G=S(u.relativeLuminance2(ee,K,I),u.relativeLuminance2(Y,q,V));for(;G<F&&(ee<255||K<255||I<255);)ee=Math.min(255,ee+Math.ceil(.1*(255-ee))),K=Math.min(255,K+Math.ceil(.1*(255-K))),I=Math.min(255,I+Math.ceil(.1*(255-I))),G=S(u.relativeLuminance2(ee,K,I),u.relativeLuminance2(Y,q,V));return(ee<<24|K<<16|I<<8|255)>>>0}x.ensureContrastRatio=function(y,N,F){const Y=u.relativeLuminance(y>>8),q=u.relativeLuminance(N>>8);if(S(Y,q)<F){if(q<Y){const K=R(y,N,F),I=S(Y,u.relativeLuminance(K>>8));if(I<F){const G=O(y,N,F);return I>S(Y,u.relativeLuminance(G>>8))?K:G}return K}const V=O(y,N,F),ee=S(Y,u.relativeLuminance(V>>8));if(ee<F){const K=R(y,N,F);return ee>S(Y,u.relativeLuminance(K>>8))?V:K}return V}},x.reduceLuminance=R,x.increaseLuminance=O,x.toChannels=function(y){return[y>>24&255,y>>16&255,y>>8&255,255&y]},x.toColor=function(y,N,F,Y){return{css:k.toCss(y,N,F,Y),rgba:k.toRgba(y,N,F,Y)}}}(d||(l.rgba=d={})),l.toPaddedHex=f,l.contrastRatio=S},8969:(B,l,c)=>{Object.defineProperty(l,"__esModule",{value:!0}),l.CoreTerminal=void 0;const h=c(844),p=c(2585),v=c(4348),b=c(7866),w=c(744),k=c(7302),C=c(6975),a=c(8460),u=c(1753),d=c(1480),f=c(7994),S=c(9282),x=c(5435),R=c(5981),O=c(2660);let y=!1;class N extends h.Disposable{get onScroll(){return this._onScrollApi||(this._onScrollApi=this.register(new a.EventEmitter),this._onScroll.event(Y=>{var q;(q=this._onScrollApi)===null||q===void 0||q.fire(Y.position)})),this._onScrollApi.event}get cols(){return this._bufferService.cols}get rows(){return this._bufferService.rows}get buffers(){return this._bufferService.buffers}get options(){return this.optionsService.options}set options(Y){for(const q in Y)this.optionsService.options[q]=Y[q]}constructor(Y){super(),this._windowsWrappingHeuristics=this.register(new h.MutableDisposable),this._onBinary=this.register(new a.EventEmitter),this.onBinary=this._onBinary.event,this._onData=this.register(new a.EventEmitter),this.onData=this._onData.event,this._onLineFeed=this.register(new a.EventEmitter),this.onLineFeed=this._onLineFeed.event,this._onResize=this.register(new a.EventEmitter),this.onResize=this._onResize.event,this._onWriteParsed=this.register(new a.EventEmitter),this.onWriteParsed=this._onWriteParsed.event,this._onScroll=this.register(new a.EventEmitter),this._instantiationService=new v.InstantiationService,this.optionsService=this.register(new k.OptionsService(Y)),this._instantiationService.setService(p.IOptionsService,this.optionsService),this._bufferService=this.register(this._instantiationService.createInstance(w.BufferService)),this._instantiationService.setService(p.IBufferService,this._bufferService),this._logService=this.register(this._instantiationService.createInstance(b.LogService)),this._instantiationService.setService(p.ILogService,this._logService),this.coreService=this.register(this._instantiationService.createInstance(C.CoreService)),this._instantiationService.setService(p.ICoreService,this.coreService),this.coreMouseService=this.register(this._instantiationService.createInstance(u.CoreMouseService)),this._instantiationService.setService(p.ICoreMouseService,this.coreMouseService),this.unicodeService=this.register(this._instantiationService.createInstance(d.UnicodeService)),this._instantiationService.setService(p.IUnicodeService,this.unicodeService),this._charsetService=this._instantiationService.createInstance(f.CharsetService),this._instantiationService.setService(p.ICharsetService,this._charsetService),this._oscLinkService=this._instantiationService.createInstance(O.OscLinkService),this._instantiationService.setService(p.IOscLinkService,this._oscLinkService),this._inputHandler=this.register(new x.InputHandler(this._bufferService,this._charsetService,this.coreService,this._logService,this.optionsService,this._oscLinkService,this.coreMouseService,this.unicodeService)),this.register((0,a.forwardEvent)(this._inputHandler.onLineFeed,this._onLineFeed)),this.register(this._inputHandler),this.register((0,a.forwardEvent)(this._bufferService.onResize,this._onResize)),this.register((0,a.forwardEvent)(this.coreService.onData,this._onData)),this.register((0,a.forwardEvent)(this.coreService.onBinary,this._onBinary)),this.register(this.coreService.onRequestScrollToBottom(()=>this.scrollToBottom())),this.register(this.coreService.onUserInput(()=>this._writeBuffer.handleUserInput())),this.register(this.optionsService.onMultipleOptionChange(["windowsMode","windowsPty"],()=>this._handleWindowsPtyOptionChange())),this.register(this._bufferService.onScroll(q=>{this._onScroll.fire({position:this._bufferService.buffer.ydisp,source:0}),this._inputHandler.markRangeDirty(this._bufferService.buffer.scrollTop,this._bufferService.buffer.scrollBottom)})),this.register(this._inputHandler.onScroll(q=>{this._onScroll.fire({position:this._bufferService.buffer.ydisp,source:0}),this._inputHandler.markRangeDirty(this._bufferService.buffer.scrollTop,this._bufferService.buffer.scrollBottom)})),this._writeBuffer=this.register(new R.WriteBuffer((q,V)=>this._inputHandler.parse(q,V))),this.register((0,a.forwardEvent)(this._writeBuffer.onWriteParsed,this._onWriteParsed))}write(Y,q){this._writeBuffer.write(Y,q)}writeSync(Y,q){this._logService.logLevel<=p.LogLevelEnum.WARN&&!y&&(this._logService.warn("writeSync is unreliable and will be removed soon."),y=!0),this._writeBuffer.writeSync(Y,q)}resize(Y,q){isNaN(Y)||isNaN(q)||(Y=Math.max(Y,w.MINIMUM_COLS),q=Math.max(q,w.MINIMUM_ROWS),this._bufferService.resize(Y,q))}scroll(Y,q=!1){this._bufferService.scroll(Y,q)}scrollLines(Y,q,V){this._bufferService.scrollLines(Y,q,V)}scrollPages(Y){this.scrollLines(Y*(this.rows-1))}scrollToTop(){this.scrollLines(-this._bufferService.buffer.ydisp)}scrollToBottom(){this.scrollLines(this._bufferService.buffer.ybase-this._bufferService.buffer.ydisp)}scrollToLine(Y){const q=Y-this._bufferService.buffer.ydisp;q!==0&&this.scrollLines(q)}registerEscHandler(Y,q){return this._inputHandler.registerEscHandler(Y,q)}registerDcsHandler(Y,q){return this._inputHandler.registerDcsHandler(Y,q)}registerCsiHandler(Y,q){return this._inputHandler.registerCsiHandler(Y,q)}registerOscHandler(Y,q){return this._inputHandler.registerOscHandler(Y,q)}_setup(){this._handleWindowsPtyOptionChange()}reset(){this._inputHandler.reset(),this._bufferService.reset(),this._charsetService.reset(),this.coreService.reset(),this.coreMouseService.reset()}_handleWindowsPtyOptionChange(){let Y=!1;const q=this.optionsService.rawOptions.windowsPty;q&&q.buildNumber!==void 0&&q.buildNumber!==void 0?Y=q.backend==="conpty"&&q.buildNumber<21376:this.optionsService.rawOptions.windowsMode&&(Y=!0),Y?this._enableWindowsWrappingHeuristics():this._windowsWrappingHeuristics.clear()}_enableWindowsWrappingHeuristics(){if(!this._windowsWrappingHeuristics.value){const Y=[];Y.push(this.onLineFeed(S.updateWindowsModeWrappedState.bind(null,this._bufferService))),Y.push(this.registerCsiHandler({final:"H"},()=>((0,S.updateWindowsModeWrappedState)(this._bufferService),!1))),this._windowsWrappingHeuristics.value=(0,h.toDisposable)(()=>{for(const q of Y)q.dispose()})}}}l.CoreTerminal=N},8460:(B,l)=>{Object.defineProperty(l,"__esModule",{value:!0}),l.forwardEvent=l.EventEmitter=void 0,l.EventEmitter=class{constructor(){this._listeners=[],this._disposed=!1}get event(){return this._event||(this._event=c=>(this._listeners.push(c),{dispose:()=>{if(!this._disposed){for(let h=0;h<this._listeners.length;h++)if(this._listeners[h]===c)return void this._listeners.splice(h,1)}}})),this._event}fire(c,h){const p=[];for(let v=0;v<this._listeners.length;v++)p.push(this._listeners[v]);for(let v=0;v<p.length;v++)p[v].call(void 0,c,h)}dispose(){this.clearListeners(),this._disposed=!0}clearListeners(){this._listeners&&(this._listeners.length=0)}},l.forwardEvent=function(c,h){return c(p=>h.fire(p))}},5435:function(B,l,c){var h=this&&this.__decorate||function(G,A,z,M){var P,W=arguments.length,J=W<3?A:M===null?M=Object.getOwnPropertyDescriptor(A,z):M;if(typeof Reflect=="object"&&typeof Reflect.decorate=="function")J=Reflect.decorate(G,A,z,M);else for(var le=G.length-1;le>=0;le--)(P=G[le])&&(J=(W<3?P(J):W>3?P(A,z,J):P(A,z))||J);return W>3&&J&&Object.defineProperty(A,z,J),J},p=this&&this.__param||function(G,A){return function(z,M){A(z,M,G)}};Object.defineProperty(l,"__esModule",{value:!0}),l.InputHandler=l.WindowsOptionsReportType=void 0;const v=c(2584),b=c(7116),w=c(2015),k=c(844),C=c(482),a=c(8437),u=c(8460),d=c(643),f=c(511),S=c(3734),x=c(2585),R=c(6242),O=c(6351),y=c(5941),N={"(":0,")":1,"*":2,"+":3,"-":1,".":2},F=131072;function Y(G,A){if(G>24)return A.setWinLines||!1;switch(G){case 1:return!!A.restoreWin;case 2:return!!A.minimizeWin;case 3:return!!A.setWinPosition;case 4:return!!A.setWinSizePixels;case 5:return!!A.raiseWin;case 6:return!!A.lowerWin;case 7:return!!A.refreshWin;case 8:return!!A.setWinSizeChars;case 9:return!!A.maximizeWin;case 10:return!!A.fullscreenWin;case 11:return!!A.getWinState;case 13:return!!A.getWinPosition;case 14:return!!A.getWinSizePixels;case 15:return!!A.getScreenSizePixels;case 16:return!!A.getCellSizePixels;case 18:return!!A.getWinSizeChars;case 19:return!!A.getScreenSizeChars;case 20:return!!A.getIconTitle;case 21:return!!A.getWinTitle;case 22:return!!A.pushTitle;case 23:return!!A.popTitle;case 24:return!!A.setWinLines}return!1}var q;(function(G){G[G.GET_WIN_SIZE_PIXELS=0]="GET_WIN_SIZE_PIXELS",G[G.GET_CELL_SIZE_PIXELS=1]="GET_CELL_SIZE_PIXELS"})(q||(l.WindowsOptionsReportType=q={}));let V=0;class ee extends k.Disposable{getAttrData(){return this._curAttrData}constructor(A,z,M,P,W,J,le,ue,fe=new w.EscapeSequenceParser){super(),this._bufferService=A,this._charsetService=z,this._coreService=M,this._logService=P,this._optionsService=W,this._oscLinkService=J,this._coreMouseService=le,this._unicodeService=ue,this._parser=fe,this._parseBuffer=new Uint32Array(4096),this._stringDecoder=new C.StringToUtf32,this._utf8Decoder=new C.Utf8ToUtf32,this._workCell=new f.CellData,this._windowTitle="",this._iconName="",this._windowTitleStack=[],this._iconNameStack=[],this._curAttrData=a.DEFAULT_ATTR_DATA.clone(),this._eraseAttrDataInternal=a.DEFAULT_ATTR_DATA.clone(),this._onRequestBell=this.register(new u.EventEmitter),this.onRequestBell=this._onRequestBell.event,this._onRequestRefreshRows=this.register(new u.EventEmitter),this.onRequestRefreshRows=this._onRequestRefreshRows.event,this._onRequestReset=this.register(new u.EventEmitter),this.onRequestReset=this._onRequestReset.event,this._onRequestSendFocus=this.register(new u.EventEmitter),this.onRequestSendFocus=this._onRequestSendFocus.event,this._onRequestSyncScrollBar=this.register(new u.EventEmitter),this.onRequestSyncScrollBar=this._onRequestSyncScrollBar.event,this._onRequestWindowsOptionsReport=this.register(new u.EventEmitter),this.onRequestWindowsOptionsReport=this._onRequestWindowsOptionsReport.event,this._onA11yChar=this.register(new u.EventEmitter),this.onA11yChar=this._onA11yChar.event,this._onA11yTab=this.register(new u.EventEmitter),this.onA11yTab=this._onA11yTab.event,this._onCursorMove=this.register(new u.EventEmitter),this.onCursorMove=this._onCursorMove.event,this._onLineFeed=this.register(new u.EventEmitter),this.onLineFeed=this._onLineFeed.event,this._onScroll=this.register(new u.EventEmitter),this.onScroll=this._onScroll.event,this._onTitleChange=this.register(new u.EventEmitter),this.onTitleChange=this._onTitleChange.event,this._onColor=this.register(new u.EventEmitter),this.onColor=this._onColor.event,this._parseStack={paused:!1,cursorStartX:0,cursorStartY:0,decodedLength:0,position:0},this._specialColors=[256,257,258],this.register(this._parser),this._dirtyRowTracker=new K(this._bufferService),this._activeBuffer=this._bufferService.buffer,this.register(this._bufferService.buffers.onBufferActivate(j=>this._activeBuffer=j.activeBuffer)),this._parser.setCsiHandlerFallback((j,Z)=>{this._logService.debug("Unknown CSI code: ",{identifier:this._parser.identToString(j),params:Z.toArray()})}),this._parser.setEscHandlerFallback(j=>{this._logService.debug("Unknown ESC code: ",{identifier:this._parser.identToString(j)})}),this._parser.setExecuteHandlerFallback(j=>{this._logService.debug("Unknown EXECUTE code: ",{code:j})}),this._parser.setOscHandlerFallback((j,Z,ae)=>{this._logService.debug("Unknown OSC code: ",{identifier:j,action:Z,data:ae})}),this._parser.setDcsHandlerFallback((j,Z,ae)=>{Z==="HOOK"&&(ae=ae.toArray()),this._logService.debug("Unknown DCS code: ",{identifier:this._parser.identToString(j),action:Z,payload:ae})}),this._parser.setPrintHandler((j,Z,ae)=>this.print(j,Z,ae)),this._parser.registerCsiHandler({final:"@"},j=>this.insertChars(j)),this._parser.registerCsiHandler({intermediates:" ",final:"@"},j=>this.scrollLeft(j)),this._parser.registerCsiHandler({final:"A"},j=>this.cursorUp(j)),this._parser.registerCsiHandler({intermediates:" ",final:"A"},j=>this.scrollRight(j)),this._parser.registerCsiHandler({final:"B"},j=>this.cursorDown(j)),this._parser.registerCsiHandler({final:"C"},j=>this.cursorForward(j)),this._parser.registerCsiHandler({final:"D"},j=>this.cursorBackward(j)),this._parser.registerCsiHandler({final:"E"},j=>this.cursorNextLine(j)),this._parser.registerCsiHandler({final:"F"},j=>this.cursorPrecedingLine(j)),this._parser.registerCsiHandler({final:"G"},j=>this.cursorCharAbsolute(j)),this._parser.registerCsiHandler({final:"H"},j=>this.cursorPosition(j)),this._parser.registerCsiHandler({final:"I"},j=>this.cursorForwardTab(j)),this._parser.registerCsiHandler({final:"J"},j=>this.eraseInDisplay(j,!1)),this._parser.registerCsiHandler({prefix:"?",final:"J"},j=>this.eraseInDisplay(j,!0)),this._parser.registerCsiHandler({final:"K"},j=>this.eraseInLine(j,!1)),this._parser.registerCsiHandler({prefix:"?",final:"K"},j=>this.eraseInLine(j,!0)),this._parser.registerCsiHandler({final:"L"},j=>this.insertLines(j)),this._parser.registerCsiHandler({final:"M"},j=>this.deleteLines(j)),this._parser.registerCsiHandler({final:"P"},j=>this.deleteChars(j)),this._parser.registerCsiHandler({final:"S"},j=>this.scrollUp(j)),this._parser.registerCsiHandler({final:"T"},j=>this.scrollDown(j)),this._parser.registerCsiHandler({final:"X"},j=>this.eraseChars(j)),this._parser.registerCsiHandler({final:"Z"},j=>this.cursorBackwardTab(j)),this._parser.registerCsiHandler({final:"`"},j=>this.charPosAbsolute(j)),this._parser.registerCsiHandler({final:"a"},j=>this.hPositionRelative(j)),this._parser.registerCsiHandler({final:"b"},j=>this.repeatPrecedingCharacter(j)),this._parser.registerCsiHandler({final:"c"},j=>this.sendDeviceAttributesPrimary(j)),this._parser.registerCsiHandler({prefix:">",final:"c"},j=>this.sendDeviceAttributesSecondary(j)),this._parser.registerCsiHandler({final:"d"},j=>this.linePosAbsolute(j)),this._parser.registerCsiHandler({final:"e"},j=>this.vPositionRelative(j)),this._parser.registerCsiHandler({final:"f"},j=>this.hVPosition(j)),this._parser.registerCsiHandler({final:"g"},j=>this.tabClear(j)),this._parser.registerCsiHandler({final:"h"},j=>this.setMode(j)),this._parser.registerCsiHandler({prefix:"?",final:"h"},j=>this.setModePrivate(j)),this._parser.registerCsiHandler({final:"l"},j=>this.resetMode(j)),this._parser.registerCsiHandler({prefix:"?",final:"l"},j=>this.resetModePrivate(j)),this._parser.registerCsiHandler({final:"m"},j=>this.charAttributes(j)),this._parser.registerCsiHandler({final:"n"},j=>this.deviceStatus(j)),this._parser.registerCsiHandler({prefix:"?",final:"n"},j=>this.deviceStatusPrivate(j)),this._parser.registerCsiHandler({intermediates:"!",final:"p"},j=>this.softReset(j)),this._parser.registerCsiHandler({intermediates:" ",final:"q"},j=>this.setCursorStyle(j)),this._parser.registerCsiHandler({final:"r"},j=>this.setScrollRegion(j)),this._parser.registerCsiHandler({final:"s"},j=>this.saveCursor(j)),this._parser.registerCsiHandler({final:"t"},j=>this.windowOptions(j)),this._parser.registerCsiHandler({final:"u"},j=>this.restoreCursor(j)),this._parser.registerCsiHandler({intermediates:"'",final:"}"},j=>this.insertColumns(j)),this._parser.registerCsiHandler({intermediates:"'",final:"~"},j=>this.deleteColumns(j)),this._parser.registerCsiHandler({intermediates:'"',final:"q"},j=>this.selectProtected(j)),this._parser.registerCsiHandler({intermediates:"$",final:"p"},j=>this.requestMode(j,!0)),this._parser.registerCsiHandler({prefix:"?",intermediates:"$",final:"p"},j=>this.requestMode(j,!1)),this._parser.setExecuteHandler(v.C0.BEL,()=>this.bell()),this._parser.setExecuteHandler(v.C0.LF,()=>this.lineFeed()),this._parser.setExecuteHandler(v.C0.VT,()=>this.lineFeed()),this._parser.setExecuteHandler(v.C0.FF,()=>this.lineFeed()),this._parser.setExecuteHandler(v.C0.CR,()=>this.carriageReturn()),this._parser.setExecuteHandler(v.C0.BS,()=>this.backspace()),this._parser.setExecuteHandler(v.C0.HT,()=>this.tab()),this._parser.setExecuteHandler(v.C0.SO,()=>this.shiftOut()),this._parser.setExecuteHandler(v.C0.SI,()=>this.shiftIn()),this._parser.setExecuteHandler(v.C1.IND,()=>this.index()),this._parser.setExecuteHandler(v.C1.NEL,()=>this.nextLine()),this._parser.setExecuteHandler(v.C1.HTS,()=>this.tabSet()),this._parser.registerOscHandler(0,new R.OscHandler(j=>(this.setTitle(j),this.setIconName(j),!0))),this._parser.registerOscHandler(1,new R.OscHandler(j=>this.setIconName(j))),this._parser.registerOscHandler(2,new R.OscHandler(j=>this.setTitle(j))),this._parser.registerOscHandler(4,new R.OscHandler(j=>this.setOrReportIndexedColor(j))),this._parser.registerOscHandler(8,new R.OscHandler(j=>this.setHyperlink(j))),this._parser.registerOscHandler(10,new R.OscHandler(j=>this.setOrReportFgColor(j))),this._parser.registerOscHandler(11,new R.OscHandler(j=>this.setOrReportBgColor(j))),this._parser.registerOscHandler(12,new R.OscHandler(j=>this.setOrReportCursorColor(j))),this._parser.registerOscHandler(104,new R.OscHandler(j=>this.restoreIndexedColor(j))),this._parser.registerOscHandler(110,new R.OscHandler(j=>this.restoreFgColor(j))),this._parser.registerOscHandler(111,new R.OscHandler(j=>this.restoreBgColor(j))),this._parser.registerOscHandler(112,new R.OscHandler(j=>this.restoreCursorColor(j))),this._parser.registerEscHandler({final:"7"},()=>this.saveCursor()),this._parser.registerEscHandler({final:"8"},()=>this.restoreCursor()),this._parser.registerEscHandler({final:"D"},()=>this.index()),this._parser.registerEscHandler({final:"E"},()=>this.nextLine()),this._parser.registerEscHandler({final:"H"},()=>this.tabSet()),this._parser.registerEscHandler({final:"M"},()=>this.reverseIndex()),this._parser.registerEscHandler({final:"="},()=>this.keypadApplicationMode()),this._parser.registerEscHandler({final:">"},()=>this.keypadNumericMode()),this._parser.registerEscHandler({final:"c"},()=>this.fullReset()),this._parser.registerEscHandler({final:"n"},()=>this.setgLevel(2)),this._parser.registerEscHandler({final:"o"},()=>this.setgLevel(3)),this._parser.registerEscHandler({final:"|"},()=>this.setgLevel(3)),this._parser.registerEscHandler({final:"}"},()=>this.setgLevel(2)),this._parser.registerEscHandler({final:"~"},()=>this.setgLevel(1)),this._parser.registerEscHandler({intermediates:"%",final:"@"},()=>this.selectDefaultCharset()),this._parser.registerEscHandler({intermediates:"%",final:"G"},()=>this.selectDefaultCharset());for(const j in b.CHARSETS)this._parser.registerEscHandler({intermediates:"(",final:j},()=>this.selectCharset("("+j)),this._parser.registerEscHandler({intermediates:")",final:j},()=>this.selectCharset(")"+j)),this._parser.registerEscHandler({intermediates:"*",final:j},()=>this.selectCharset("*"+j)),this._parser.registerEscHandler({intermediates:"+",final:j},()=>this.selectCharset("+"+j)),this._parser.registerEscHandler({intermediates:"-",final:j},()=>this.selectCharset("-"+j)),this._parser.registerEscHandler({intermediates:".",final:j},()=>this.selectCharset("."+j)),this._parser.registerEscHandler({intermediates:"/",final:j},()=>this.selectCharset("/"+j));this._parser.registerEscHandler({intermediates:"#",final:"8"},()=>this.screenAlignmentPattern()),this._parser.setErrorHandler(j=>(this._logService.error("Parsing error: ",j),j)),this._parser.registerDcsHandler({intermediates:"$",final:"q"},new O.DcsHandler((j,Z)=>this.requestStatusString(j,Z)))}_preserveStack(A,z,M,P){this._parseStack.paused=!0,this._parseStack.cursorStartX=A,this._parseStack.cursorStartY=z,this._parseStack.decodedLength=M,this._parseStack.position=P}_logSlowResolvingAsync(A){this._logService.logLevel<=x.LogLevelEnum.WARN&&Promise.race([A,new Promise((z,M)=>setTimeout(()=>M("#SLOW_TIMEOUT"),5e3))]).catch(z=>{if(z!=="#SLOW_TIMEOUT")throw z;console.warn("async parser handler taking longer than 5000 ms")})}_getCurrentLinkId(){return this._curAttrData.extended.urlId}parse(A,z){let M,P=this._activeBuffer.x,W=this._activeBuffer.y,J=0;const le=this._parseStack.paused;if(le){if(M=this._parser.parse(this._parseBuffer,this._parseStack.decodedLength,z))return this._logSlowResolvingAsync(M),M;P=this._parseStack.cursorStartX,W=this._parseStack.cursorStartY,this._parseStack.paused=!1,A.length>F&&(J=this._parseStack.position+F)}if(this._logService.logLevel<=x.LogLevelEnum.DEBUG&&this._logService.debug("parsing data"+(typeof A=="string"?` "${A}"`:` "${Array.prototype.map.call(A,ue=>String.fromCharCode(ue)).join("")}"`),typeof A=="string"?A.split("").map(ue=>ue.charCodeAt(0)):A),this._parseBuffer.length<A.length&&this._parseBuffer.length<F&&(this._parseBuffer=new Uint32Array(Math.min(A.length,F))),le||this._dirtyRowTracker.clearRange(),A.length>F)for(let ue=J;ue<A.length;ue+=F){const fe=ue+F<A.length?ue+F:A.length,j=typeof A=="string"?this._stringDecoder.decode(A.substring(ue,fe),this._parseBuffer):this._utf8Decoder.decode(A.subarray(ue,fe),this._parseBuffer);if(M=this._parser.parse(this._parseBuffer,j))return this._preserveStack(P,W,j,ue),this._logSlowResolvingAsync(M),M}else if(!le){const ue=typeof A=="string"?this._stringDecoder.decode(A,this._parseBuffer):this._utf8Decoder.decode(A,this._parseBuffer);if(M=this._parser.parse(this._parseBuffer,ue))return this._preserveStack(P,W,ue,0),this._logSlowResolvingAsync(M),M}this._activeBuffer.x===P&&this._activeBuffer.y===W||this._onCursorMove.fire(),this._onRequestRefreshRows.fire(this._dirtyRowTracker.start,this._dirtyRowTracker.end)}print(A,z,M){let P,W;const J=this._charsetService.charset,le=this._optionsService.rawOptions.screenReaderMode,ue=this._bufferService.cols,fe=this._coreService.decPrivateModes.wraparound,j=this._coreService.modes.insertMode,Z=this._curAttrData;let ae=this._activeBuffer.lines.get(this._activeBuffer.ybase+this._activeBuffer.y);this._dirtyRowTracker.markDirty(this._activeBuffer.y),this._activeBuffer.x&&M-z>0&&ae.getWidth(this._activeBuffer.x-1)===2&&ae.setCellFromCodePoint(this._activeBuffer.x-1,0,1,Z.fg,Z.bg,Z.extended);for(let re=z;re<M;++re){if(P=A[re],W=this._unicodeService.wcwidth(P),P<127&&J){const pe=J[String.fromCharCode(P)];pe&&(P=pe.charCodeAt(0))}if(le&&this._onA11yChar.fire((0,C.stringFromCodePoint)(P)),this._getCurrentLinkId()&&this._oscLinkService.addLineToLink(this._getCurrentLinkId(),this._activeBuffer.ybase+this._activeBuffer.y),W||!this._activeBuffer.x){if(this._activeBuffer.x+W-1>=ue){if(fe){for(;this._activeBuffer.x<ue;)ae.setCellFromCodePoint(this._activeBuffer.x++,0,1,Z.fg,Z.bg,Z.extended);this._activeBuffer.x=0,this._activeBuffer.y++,this._activeBuffer.y===this._activeBuffer.scrollBottom+1?(this._activeBuffer.y--,this._bufferService.scroll(this._eraseAttrData(),!0)):(this._activeBuffer.y>=this._bufferService.rows&&(this._activeBuffer.y=this._bufferService.rows-1),this._activeBuffer.lines.get(this._activeBuffer.ybase+this._activeBuffer.y).isWrapped=!0),ae=this._activeBuffer.lines.get(this._activeBuffer.ybase+this._activeBuffer.y)}else if(this._activeBuffer.x=ue-1,W===2)continue}if(j&&(ae.insertCells(this._activeBuffer.x,W,this._activeBuffer.getNullCell(Z),Z),ae.getWidth(ue-1)===2&&ae.setCellFromCodePoint(ue-1,d.NULL_CELL_CODE,d.NULL_CELL_WIDTH,Z.fg,Z.bg,Z.extended)),ae.setCellFromCodePoint(this._activeBuffer.x++,P,W,Z.fg,Z.bg,Z.extended),W>0)for(;--W;)ae.setCellFromCodePoint(this._activeBuffer.x++,0,0,Z.fg,Z.bg,Z.extended)}else ae.getWidth(this._activeBuffer.x-1)?ae.addCodepointToCell(this._activeBuffer.x-1,P):ae.addCodepointToCell(this._activeBuffer.x-2,P)}M-z>0&&(ae.loadCell(this._activeBuffer.x-1,this._workCell),this._workCell.getWidth()===2||this._workCell.getCode()>65535?this._parser.precedingCodepoint=0:this._workCell.isCombined()?this._parser.precedingCodepoint=this._workCell.getChars().charCodeAt(0):this._parser.precedingCodepoint=this._workCell.content),this._activeBuffer.x<ue&&M-z>0&&ae.getWidth(this._activeBuffer.x)===0&&!ae.hasContent(this._activeBuffer.x)&&ae.setCellFromCodePoint(this._activeBuffer.x,0,1,Z.fg,Z.bg,Z.extended),this._dirtyRowTracker.markDirty(this._activeBuffer.y)}registerCsiHandler(A,z){return A.final!=="t"||A.prefix||A.intermediates?this._parser.registerCsiHandler(A,z):this._parser.registerCsiHandler(A,M=>!Y(M.params[0],this._optionsService.rawOptions.windowOptions)||z(M))}registerDcsHandler(A,z){return this._parser.registerDcsHandler(A,new O.DcsHandler(z))}registerEscHandler(A,z){return this._parser.registerEscHandler(A,z)}registerOscHandler(A,z){return this._parser.registerOscHandler(A,new R.OscHandler(z))}bell(){return this._onRequestBell.fire(),!0}lineFeed(){return this._dirtyRowTracker.markDirty(this._activeBuffer.y),this._optionsService.rawOptions.convertEol&&(this._activeBuffer.x=0),this._activeBuffer.y++,this._activeBuffer.y===this._activeBuffer.scrollBottom+1?(this._activeBuffer.y--,this._bufferService.scroll(this._eraseAttrData())):this._activeBuffer.y>=this._bufferService.rows?this._activeBuffer.y=this._bufferService.rows-1:this._activeBuffer.lines.get(this._activeBuffer.ybase+this._activeBuffer.y).isWrapped=!1,this._activeBuffer.x>=this._bufferService.cols&&this._activeBuffer.x--,this._dirtyRowTracker.markDirty(this._activeBuffer.y),this._onLineFeed.fire(),!0}carriageReturn(){return this._activeBuffer.x=0,!0}backspace(){var A;if(!this._coreService.decPrivateModes.reverseWraparound)return this._restrictCursor(),this._activeBuffer.x>0&&this._activeBuffer.x--,!0;if(this._restrictCursor(this._bufferService.cols),this._activeBuffer.x>0)this._activeBuffer.x--;else if(this._activeBuffer.x===0&&this._activeBuffer.y>this._activeBuffer.scrollTop&&this._activeBuffer.y<=this._activeBuffer.scrollBottom&&(!((A=this._activeBuffer.lines.get(this._activeBuffer.ybase+this._activeBuffer.y))===null||A===void 0)&&A.isWrapped)){this._activeBuffer.lines.get(this._activeBuffer.ybase+this._activeBuffer.y).isWrapped=!1,this._activeBuffer.y--,this._activeBuffer.x=this._bufferService.cols-1;const z=this._activeBuffer.lines.get(this._activeBuffer.ybase+this._activeBuffer.y);z.hasWidth(this._activeBuffer.x)&&!z.hasContent(this._activeBuffer.x)&&this._activeBuffer.x--}return this._restrictCursor(),!0}tab(){if(this._activeBuffer.x>=this._bufferService.cols)return!0;const A=this._activeBuffer.x;return this._activeBuffer.x=this._activeBuffer.nextStop(),this._optionsService.rawOptions.screenReaderMode&&this._onA11yTab.fire(this._activeBuffer.x-A),!0}shiftOut(){return this._charsetService.setgLevel(1),!0}shiftIn(){return this._charsetService.setgLevel(0),!0}_restrictCursor(A=this._bufferService.cols-1){this._activeBuffer.x=Math.min(A,Math.max(0,this._activeBuffer.x)),this._activeBuffer.y=this._coreService.decPrivateModes.origin?Math.min(this._activeBuffer.scrollBottom,Math.max(this._activeBuffer.scrollTop,this._activeBuffer.y)):Math.min(this._bufferService.rows-1,Math.max(0,this._activeBuffer.y)),this._dirtyRowTracker.markDirty(this._activeBuffer.y)}_setCursor(A,z){this._dirtyRowTracker.markDirty(this._activeBuffer.y),this._coreService.decPrivateModes.origin?(this._activeBuffer.x=A,this._activeBuffer.y=this._activeBuffer.scrollTop+z):(this._activeBuffer.x=A,this._activeBuffer.y=z),this._restrictCursor(),this._dirtyRowTracker.markDirty(this._activeBuffer.y)}_moveCursor(A,z){this._restrictCursor(),this._setCursor(this._activeBuffer.x+A,this._activeBuffer.y+z)}cursorUp(A){const z=this._activeBuffer.y-this._activeBuffer.scrollTop;return z>=0?this._moveCursor(0,-Math.min(z,A.params[0]||1)):this._moveCursor(0,-(A.params[0]||1)),!0}cursorDown(A){const z=this._activeBuffer.scrollBottom-this._activeBuffer.y;return z>=0?this._moveCursor(0,Math.min(z,A.params[0]||1)):this._moveCursor(0,A.params[0]||1),!0}cursorForward(A){return this._moveCursor(A.params[0]||1,0),!0}cursorBackward(A){return this._moveCursor(-(A.params[0]||1),0),!0}cursorNextLine(A){return this.cursorDown(A),this._activeBuffer.x=0,!0}cursorPrecedingLine(A){return this.cursorUp(A),this._activeBuffer.x=0,!0}cursorCharAbsolute(A){return this._setCursor((A.params[0]||1)-1,this._activeBuffer.y),!0}cursorPosition(A){return this._setCursor(A.length>=2?(A.params[1]||1)-1:0,(A.params[0]||1)-1),!0}charPosAbsolute(A){return this._setCursor((A.params[0]||1)-1,this._activeBuffer.y),!0}hPositionRelative(A){return this._moveCursor(A.params[0]||1,0),!0}linePosAbsolute(A){return this._setCursor(this._activeBuffer.x,(A.params[0]||1)-1),!0}vPositionRelative(A){return this._moveCursor(0,A.params[0]||1),!0}hVPosition(A){return this.cursorPosition(A),!0}tabClear(A){const z=A.params[0];return z===0?delete this._activeBuffer.tabs[this._activeBuffer.x]:z===3&&(this._activeBuffer.tabs={}),!0}cursorForwardTab(A){if(this._activeBuffer.x>=this._bufferService.cols)return!0;let z=A.params[0]||1;for(;z--;)this._activeBuffer.x=this._activeBuffer.nextStop();return!0}cursorBackwardTab(A){if(this._activeBuffer.x>=this._bufferService.cols)return!0;let z=A.params[0]||1;for(;z--;)this._activeBuffer.x=this._activeBuffer.prevStop();return!0}selectProtected(A){const z=A.params[0];return z===1&&(this._curAttrData.bg|=536870912),z!==2&&z!==0||(this._curAttrData.bg&=-536870913),!0}_eraseInBufferLine(A,z,M,P=!1,W=!1){const J=this._activeBuffer.lines.get(this._activeBuffer.ybase+A);J.replaceCells(z,M,this._activeBuffer.getNullCell(this._eraseAttrData()),this._eraseAttrData(),W),P&&(J.isWrapped=!1)}_resetBufferLine(A,z=!1){const M=this._activeBuffer.lines.get(this._activeBuffer.ybase+A);M&&(M.fill(this._activeBuffer.getNullCell(this._eraseAttrData()),z),this._bufferService.buffer.clearMarkers(this._activeBuffer.ybase+A),M.isWrapped=!1)}eraseInDisplay(A,z=!1){let M;switch(this._restrictCursor(this._bufferService.cols),A.params[0]){case 0:for(M=this._activeBuffer.y,this._dirtyRowTracker.markDirty(M),this._eraseInBufferLine(M++,this._activeBuffer.x,this._bufferService.cols,this._activeBuffer.x===0,z);M<this._bufferService.rows;M++)this._resetBufferLine(M,z);this._dirtyRowTracker.markDirty(M);break;case 1:for(M=this._activeBuffer.y,this._dirtyRowTracker.markDirty(M),this._eraseInBufferLine(M,0,this._activeBuffer.x+1,!0,z),this._activeBuffer.x+1>=this._bufferService.cols&&(this._activeBuffer.lines.get(M+1).isWrapped=!1);M--;)this._resetBufferLine(M,z);this._dirtyRowTracker.markDirty(0);break;case 2:for(M=this._bufferService.rows,this._dirtyRowTracker.markDirty(M-1);M--;)this._resetBufferLine(M,z);this._dirtyRowTracker.markDirty(0);break;case 3:const P=this._activeBuffer.lines.length-this._bufferService.rows;P>0&&(this._activeBuffer.lines.trimStart(P),this._activeBuffer.ybase=Math.max(this._activeBuffer.ybase-P,0),this._activeBuffer.ydisp=Math.max(this._activeBuffer.ydisp-P,0),this._onScroll.fire(0))}return!0}eraseInLine(A,z=!1){switch(this._restrictCursor(this._bufferService.cols),A.params[0]){case 0:this._eraseInBufferLine(this._activeBuffer.y,this._activeBuffer.x,this._bufferService.cols,this._activeBuffer.x===0,z);break;case 1:this._eraseInBufferLine(this._activeBuffer.y,0,this._activeBuffer.x+1,!1,z);break;case 2:this._eraseInBufferLine(this._activeBuffer.y,0,this._bufferService.cols,!0,z)}return this._dirtyRowTracker.markDirty(this._activeBuffer.y),!0}insertLines(A){this._restrictCursor();let z=A.params[0]||1;if(this._activeBuffer.y>this._activeBuffer.scrollBottom||this._activeBuffer.y<this._activeBuffer.scrollTop)return!0;const M=this._activeBuffer.ybase+this._activeBuffer.y,P=this._bufferService.rows-1-this._activeBuffer.scrollBottom,W=this._bufferService.rows-1+this._activeBuffer.ybase-P+1;for(;z--;)this._activeBuffer.lines.splice(W-1,1),this._activeBuffer.lines.splice(M,0,this._activeBuffer.getBlankLine(this._eraseAttrData()));return this._dirtyRowTracker.markRangeDirty(this._activeBuffer.y,this._activeBuffer.scrollBottom),this._activeBuffer.x=0,!0}deleteLines(A){this._restrictCursor();let z=A.params[0]||1;if(this._activeBuffer.y>this._activeBuffer.scrollBottom||this._activeBuffer.y<this._activeBuffer.scrollTop)return!0;const M=this._activeBuffer.ybase+this._activeBuffer.y;let P;for(P=this._bufferService.rows-1-this._activeBuffer.scrollBottom,P=this._bufferService.rows-1+this._activeBuffer.ybase-P;z--;)this._activeBuffer.lines.splice(M,1),this._activeBuffer.lines.splice(P,0,this._activeBuffer.getBlankLine(this._eraseAttrData()));return this._dirtyRowTracker.markRangeDirty(this._activeBuffer.y,this._activeBuffer.scrollBottom),this._activeBuffer.x=0,!0}insertChars(A){this._restrictCursor();const z=this._activeBuffer.lines.get(this._activeBuffer.ybase+this._activeBuffer.y);return z&&(z.insertCells(this._activeBuffer.x,A.params[0]||1,this._activeBuffer.getNullCell(this._eraseAttrData()),this._eraseAttrData()),this._dirtyRowTracker.markDirty(this._activeBuffer.y)),!0}deleteChars(A){this._restrictCursor();const z=this._activeBuffer.lines.get(this._activeBuffer.ybase+this._activeBuffer.y);return z&&(z.deleteCells(this._activeBuffer.x,A.params[0]||1,this._activeBuffer.getNullCell(this._eraseAttrData()),this._eraseAttrData()),this._dirtyRowTracker.markDirty(this._activeBuffer.y)),!0}scrollUp(A){let z=A.params[0]||1;for(;z--;)this._activeBuffer.lines.splice(this._activeBuffer.ybase+this._activeBuffer.scrollTop,1),this._activeBuffer.lines.splice(this._activeBuffer.ybase+this._activeBuffer.scrollBottom,0,this._activeBuffer.getBlankLine(this._eraseAttrData()));return this._dirtyRowTracker.markRangeDirty(this._activeBuffer.scrollTop,this._activeBuffer.scrollBottom),!0}scrollDown(A){let z=A.params[0]||1;for(;z--;)this._activeBuffer.lines.splice(this._activeBuffer.ybase+this._activeBuffer.scrollBottom,1),this._activeBuffer.lines.splice(this._activeBuffer.ybase+this._activeBuffer.scrollTop,0,this._activeBuffer.getBlankLine(a.DEFAULT_ATTR_DATA));return this._dirtyRowTracker.markRangeDirty(this._activeBuffer.scrollTop,this._activeBuffer.scrollBottom),!0}scrollLeft(A){if(this._activeBuffer.y>this._activeBuffer.scrollBottom||this._activeBuffer.y<this._activeBuffer.scrollTop)return!0;const z=A.params[0]||1;for(let M=this._activeBuffer.scrollTop;M<=this._activeBuffer.scrollBottom;++M){const P=this._activeBuffer.lines.get(this._activeBuffer.ybase+M);P.deleteCells(0,z,this._activeBuffer.getNullCell(this._eraseAttrData()),this._eraseAttrData()),P.isWrapped=!1}return this._dirtyRowTracker.markRangeDirty(this._activeBuffer.scrollTop,this._activeBuffer.scrollBottom),!0}scrollRight(A){if(this._activeBuffer.y>this._activeBuffer.scrollBottom||this._activeBuffer.y<this._activeBuffer.scrollTop)return!0;const z=A.params[0]||1;for(let M=this._activeBuffer.scrollTop;M<=this._activeBuffer.scrollBottom;++M){const P=this._activeBuffer.lines.get(this._activeBuffer.ybase+M);P.insertCells(0,z,this._activeBuffer.getNullCell(this._eraseAttrData()),this._eraseAttrData()),P.isWrapped=!1}return this._dirtyRowTracker.markRangeDirty(this._activeBuffer.scrollTop,this._activeBuffer.scrollBottom),!0}insertColumns(A){if(this._activeBuffer.y>this._activeBuffer.scrollBottom||this._activeBuffer.y<this._activeBuffer.scrollTop)return!0;const z=A.params[0]||1;for(let M=this._activeBuffer.scrollTop;M<=this._activeBuffer.scrollBottom;++M){const P=this._activeBuffer.lines.get(this._activeBuffer.ybase+M);P.insertCells(this._activeBuffer.x,z,this._activeBuffer.getNullCell(this._eraseAttrData()),this._eraseAttrData()),P.isWrapped=!1}return this._dirtyRowTracker.markRangeDirty(this._activeBuffer.scrollTop,this._activeBuffer.scrollBottom),!0}deleteColumns(A){if(this._activeBuffer.y>this._activeBuffer.scrollBottom||this._activeBuffer.y<this._activeBuffer.scrollTop)return!0;const z=A.params[0]||1;for(let M=this._activeBuffer.scrollTop;M<=this._activeBuffer.scrollBottom;++M){const P=this._activeBuffer.lines.get(this._activeBuffer.ybase+M);P.deleteCells(this._activeBuffer.x,z,this._activeBuffer.getNullCell(this._eraseAttrData()),this._eraseAttrData()),P.isWrapped=!1}return this._dirtyRowTracker.markRangeDirty(this._activeBuffer.scrollTop,this._activeBuffer.scrollBottom),!0}eraseChars(A){this._restrictCursor();const z=this._activeBuffer.lines.get(this._activeBuffer.ybase+this._activeBuffer.y);return z&&(z.replaceCells(this._activeBuffer.x,this._activeBuffer.x+(A.params[0]||1),this._activeBuffer.getNullCell(this._eraseAttrData()),this._eraseAttrData()),this._dirtyRowTracker.markDirty(this._activeBuffer.y)),!0}repeatPrecedingCharacter(A){if(!this._parser.precedingCodepoint)return!0;const z=A.params[0]||1,M=new Uint32Array(z);for(let P=0;P<z;++P)M[P]=this._parser.precedingCodepoint;return this.print(M,0,M.length),!0}sendDeviceAttributesPrimary(A){return A.params[0]>0||(this._is("xterm")||this._is("rxvt-unicode")||this._is("screen")?this._coreService.triggerDataEvent(v.C0.ESC+"[?1;2c"):this._is("linux")&&this._coreService.triggerDataEvent(v.C0.ESC+"[?6c")),!0}sendDeviceAttributesSecondary(A){return A.params[0]>0||(this._is("xterm")?this._coreService.triggerDataEvent(v.C0.ESC+"[>0;276;0c"):this._is("rxvt-unicode")?this._coreService.triggerDataEvent(v.C0.ESC+"[>85;95;0c"):this._is("linux")?this._coreService.triggerDataEvent(A.params[0]+"c"):this._is("screen")&&this._coreService.triggerDataEvent(v.C0.ESC+"[>83;40003;0c")),!0}_is(A){return(this._optionsService.rawOptions.termName+"").indexOf(A)===0}setMode(A){for(let z=0;z<A.length;z++)switch(A.params[z]){case 4:this._coreService.modes.insertMode=!0;break;case 20:this._optionsService.options.convertEol=!0}return!0}setModePrivate(A){for(let z=0;z<A.length;z++)switch(A.params[z]){case 1:this._coreService.decPrivateModes.applicationCursorKeys=!0;break;case 2:this._charsetService.setgCharset(0,b.DEFAULT_CHARSET),this._charsetService.setgCharset(1,b.DEFAULT_CHARSET),this._charsetService.setgCharset(2,b.DEFAULT_CHARSET),this._charsetService.setgCharset(3,b.DEFAULT_CHARSET);break;case 3:this._optionsService.rawOptions.windowOptions.setWinLines&&(this._bufferService.resize(132,this._bufferService.rows),this._onRequestReset.fire());break;case 6:this._coreService.decPrivateModes.origin=!0,this._setCursor(0,0);break;case 7:this._coreService.decPrivateModes.wraparound=!0;break;case 12:this._optionsService.options.cursorBlink=!0;break;case 45:this._coreService.decPrivateModes.reverseWraparound=!0;break;case 66:this._logService.debug("Serial port requested application keypad."),this._coreService.decPrivateModes.applicationKeypad=!0,this._onRequestSyncScrollBar.fire();break;case 9:this._coreMouseService.activeProtocol="X10";break;case 1e3:this._coreMouseService.activeProtocol="VT200";break;case 1002:this._coreMouseService.activeProtocol="DRAG";break;case 1003:this._coreMouseService.activeProtocol="ANY";break;case 1004:this._coreService.decPrivateModes.sendFocus=!0,this._onRequestSendFocus.fire();break;case 1005:this._logService.debug("DECSET 1005 not supported (see #2507)");break;case 1006:this._coreMouseService.activeEncoding="SGR";break;case 1015:this._logService.debug("DECSET 1015 not supported (see #2507)");break;case 1016:this._coreMouseService.activeEncoding="SGR_PIXELS";break;case 25:this._coreService.isCursorHidden=!1;break;case 1048:this.saveCursor();break;case 1049:this.saveCursor();case 47:case 1047:this._bufferService.buffers.activateAltBuffer(this._eraseAttrData()),this._coreService.isCursorInitialized=!0,this._onRequestRefreshRows.fire(0,this._bufferService.rows-1),this._onRequestSyncScrollBar.fire();break;case 2004:this._coreService.decPrivateModes.bracketedPasteMode=!0}return!0}resetMode(A){for(let z=0;z<A.length;z++)switch(A.params[z]){case 4:this._coreService.modes.insertMode=!1;break;case 20:this._optionsService.options.convertEol=!1}return!0}resetModePrivate(A){for(let z=0;z<A.length;z++)switch(A.params[z]){case 1:this._coreService.decPrivateModes.applicationCursorKeys=!1;break;case 3:this._optionsService.rawOptions.windowOptions.setWinLines&&(this._bufferService.resize(80,this._bufferService.rows),this._onRequestReset.fire());break;case 6:this._coreService.decPrivateModes.origin=!1,this._setCursor(0,0);break;case 7:this._coreService.decPrivateModes.wraparound=!1;break;case 12:this._optionsService.options.cursorBlink=!1;break;case 45:this._coreService.decPrivateModes.reverseWraparound=!1;break;case 66:this._logService.debug("Switching back to normal keypad."),this._coreService.decPrivateModes.applicationKeypad=!1,this._onRequestSyncScrollBar.fire();break;case 9:case 1e3:case 1002:case 1003:this._coreMouseService.activeProtocol="NONE";break;case 1004:this._coreService.decPrivateModes.sendFocus=!1;break;case 1005:this._logService.debug("DECRST 1005 not supported (see #2507)");break;case 1006:case 1016:this._coreMouseService.activeEncoding="DEFAULT";break;case 1015:this._logService.debug("DECRST 1015 not supported (see #2507)");break;case 25:this._coreService.isCursorHidden=!0;break;case 1048:this.restoreCursor();break;case 1049:case 47:case 1047:this._bufferService.buffers.activateNormalBuffer(),A.params[z]===1049&&this.restoreCursor(),this._coreService.isCursorInitialized=!0,this._onRequestRefreshRows.fire(0,this._bufferService.rows-1),this._onRequestSyncScrollBar.fire();break;case 2004:this._coreService.decPrivateModes.bracketedPasteMode=!1}return!0}requestMode(A,z){const M=this._coreService.decPrivateModes,{activeProtocol:P,activeEncoding:W}=this._coreMouseService,J=this._coreService,{buffers:le,cols:ue}=this._bufferService,{active:fe,alt:j}=le,Z=this._optionsService.rawOptions,ae=Ee=>Ee?1:2,re=A.params[0];return pe=re,de=z?re===2?4:re===4?ae(J.modes.insertMode):re===12?3:re===20?ae(Z.convertEol):0:re===1?ae(M.applicationCursorKeys):re===3?Z.windowOptions.setWinLines?ue===80?2:ue===132?1:0:0:re===6?ae(M.origin):re===7?ae(M.wraparound):re===8?3:re===9?ae(P==="X10"):re===12?ae(Z.cursorBlink):re===25?ae(!J.isCursorHidden):re===45?ae(M.reverseWraparound):re===66?ae(M.applicationKeypad):re===67?4:re===1e3?ae(P==="VT200"):re===1002?ae(P==="DRAG"):re===1003?ae(P==="ANY"):re===1004?ae(M.sendFocus):re===1005?4:re===1006?ae(W==="SGR"):re===1015?4:re===1016?ae(W==="SGR_PIXELS"):re===1048?1:re===47||re===1047||re===1049?ae(fe===j):re===2004?ae(M.bracketedPasteMode):0,J.triggerDataEvent(`${v.C0.ESC}[${z?"":"?"}${pe};${de}$y`),!0;var pe,de}_updateAttrColor(A,z,M,P,W){return z===2?(A|=50331648,A&=-16777216,A|=S.AttributeData.fromColorRGB([M,P,W])):z===5&&(A&=-50331904,A|=33554432|255&M),A}_extractColor(A,z,M){const P=[0,0,-1,0,0,0];let W=0,J=0;do{if(P[J+W]=A.params[z+J],A.hasSubParams(z+J)){const le=A.getSubParams(z+J);let ue=0;do P[1]===5&&(W=1),P[J+ue+1+W]=le[ue];while(++ue<le.length&&ue+J+1+W<P.length);break}if(P[1]===5&&J+W>=2||P[1]===2&&J+W>=5)break;P[1]&&(W=1)}while(++J+z<A.length&&J+W<P.length);for(let le=2;le<P.length;++le)P[le]===-1&&(P[le]=0);switch(P[0]){case 38:M.fg=this._updateAttrColor(M.fg,P[1],P[3],P[4],P[5]);break;case 48:M.bg=this._updateAttrColor(M.bg,P[1],P[3],P[4],P[5]);break;case 58:M.extended=M.extended.clone(),M.extended.underlineColor=this._updateAttrColor(M.extended.underlineColor,P[1],P[3],P[4],P[5])}return J}_processUnderline(A,z){z.extended=z.extended.clone(),(!~A||A>5)&&(A=1),z.extended.underlineStyle=A,z.fg|=268435456,A===0&&(z.fg&=-268435457),z.updateExtended()}_processSGR0(A){A.fg=a.DEFAULT_ATTR_DATA.fg,A.bg=a.DEFAULT_ATTR_DATA.bg,A.extended=A.extended.clone(),A.extended.underlineStyle=0,A.extended.underlineColor&=-67108864,A.updateExtended()}charAttributes(A){if(A.length===1&&A.params[0]===0)return this._processSGR0(this._curAttrData),!0;const z=A.length;let M;const P=this._curAttrData;for(let W=0;W<z;W++)M=A.params[W],M>=30&&M<=37?(P.fg&=-50331904,P.fg|=16777216|M-30):M>=40&&M<=47?(P.bg&=-50331904,P.bg|=16777216|M-40):M>=90&&M<=97?(P.fg&=-50331904,P.fg|=16777224|M-90):M>=100&&M<=107?(P.bg&=-50331904,P.bg|=16777224|M-100):M===0?this._processSGR0(P):M===1?P.fg|=134217728:M===3?P.bg|=67108864:M===4?(P.fg|=268435456,this._processUnderline(A.hasSubParams(W)?A.getSubParams(W)[0]:1,P)):M===5?P.fg|=536870912:M===7?P.fg|=67108864:M===8?P.fg|=1073741824:M===9?P.fg|=2147483648:M===2?P.bg|=134217728:M===21?this._processUnderline(2,P):M===22?(P.fg&=-134217729,P.bg&=-134217729):M===23?P.bg&=-67108865:M===24?(P.fg&=-268435457,this._processUnderline(0,P)):M===25?P.fg&=-536870913:M===27?P.fg&=-67108865:M===28?P.fg&=-1073741825:M===29?P.fg&=2147483647:M===39?(P.fg&=-67108864,P.fg|=16777215&a.DEFAULT_ATTR_DATA.fg):M===49?(P.bg&=-67108864,P.bg|=16777215&a.DEFAULT_ATTR_DATA.bg):M===38||M===48||M===58?W+=this._extractColor(A,W,P):M===53?P.bg|=1073741824:M===55?P.bg&=-1073741825:M===59?(P.extended=P.extended.clone(),P.extended.underlineColor=-1,P.updateExtended()):M===100?(P.fg&=-67108864,P.fg|=16777215&a.DEFAULT_ATTR_DATA.fg,P.bg&=-67108864,P.bg|=16777215&a.DEFAULT_ATTR_DATA.bg):this._logService.debug("Unknown SGR attribute: %d.",M);return!0}deviceStatus(A){switch(A.params[0]){case 5:this._coreService.triggerDataEvent(`${v.C0.ESC}[0n`);break;case 6:const z=this._activeBuffer.y+1,M=this._activeBuffer.x+1;this._coreService.triggerDataEvent(`${v.C0.ESC}[${z};${M}R`)}return!0}deviceStatusPrivate(A){if(A.params[0]===6){const z=this._activeBuffer.y+1,M=this._activeBuffer.x+1;this._coreService.triggerDataEvent(`${v.C0.ESC}[?${z};${M}R`)}return!0}softReset(A){return this._coreService.isCursorHidden=!1,this._onRequestSyncScrollBar.fire(),this._activeBuffer.scrollTop=0,this._activeBuffer.scrollBottom=this._bufferService.rows-1,this._curAttrData=a.DEFAULT_ATTR_DATA.clone(),this._coreService.reset(),this._charsetService.reset(),this._activeBuffer.savedX=0,this._activeBuffer.savedY=this._activeBuffer.ybase,this._activeBuffer.savedCurAttrData.fg=this._curAttrData.fg,this._activeBuffer.savedCurAttrData.bg=this._curAttrData.bg,this._activeBuffer.savedCharset=this._charsetService.charset,this._coreService.decPrivateModes.origin=!1,!0}setCursorStyle(A){const z=A.params[0]||1;switch(z){case 1:case 2:this._optionsService.options.cursorStyle="block";break;case 3:case 4:this._optionsService.options.cursorStyle="underline";break;case 5:case 6:this._optionsService.options.cursorStyle="bar"}const M=z%2==1;return this._optionsService.options.cursorBlink=M,!0}setScrollRegion(A){const z=A.params[0]||1;let M;return(A.length<2||(M=A.params[1])>this._bufferService.rows||M===0)&&(M=this._bufferService.rows),M>z&&(this._activeBuffer.scrollTop=z-1,this._activeBuffer.scrollBottom=M-1,this._setCursor(0,0)),!0}windowOptions(A){if(!Y(A.params[0],this._optionsService.rawOptions.windowOptions))return!0;const z=A.length>1?A.params[1]:0;switch(A.params[0]){case 14:z!==2&&this._onRequestWindowsOptionsReport.fire(q.GET_WIN_SIZE_PIXELS);break;case 16:this._onRequestWindowsOptionsReport.fire(q.GET_CELL_SIZE_PIXELS);break;case 18:this._bufferService&&this._coreService.triggerDataEvent(`${v.C0.ESC}[8;${this._bufferService.rows};${this._bufferService.cols}t`);break;case 22:z!==0&&z!==2||(this._windowTitleStack.push(this._windowTitle),this._windowTitleStack.length>10&&this._windowTitleStack.shift()),z!==0&&z!==1||(this._iconNameStack.push(this._iconName),this._iconNameStack.length>10&&this._iconNameStack.shift());break;case 23:z!==0&&z!==2||this._windowTitleStack.length&&this.setTitle(this._windowTitleStack.pop()),z!==0&&z!==1||this._iconNameStack.length&&this.setIconName(this._iconNameStack.pop())}return!0}saveCursor(A){return this._activeBuffer.savedX=this._activeBuffer.x,this._activeBuffer.savedY=this._activeBuffer.ybase+this._activeBuffer.y,this._activeBuffer.savedCurAttrData.fg=this._curAttrData.fg,this._activeBuffer.savedCurAttrData.bg=this._curAttrData.bg,this._activeBuffer.savedCharset=this._charsetService.charset,!0}restoreCursor(A){return this._activeBuffer.x=this._activeBuffer.savedX||0,this._activeBuffer.y=Math.max(this._activeBuffer.savedY-this._activeBuffer.ybase,0),this._curAttrData.fg=this._activeBuffer.savedCurAttrData.fg,this._curAttrData.bg=this._activeBuffer.savedCurAttrData.bg,this._charsetService.charset=this._savedCharset,this._activeBuffer.savedCharset&&(this._charsetService.charset=this._activeBuffer.savedCharset),this._restrictCursor(),!0}setTitle(A){return this._windowTitle=A,this._onTitleChange.fire(A),!0}setIconName(A){return this._iconName=A,!0}setOrReportIndexedColor(A){const z=[],M=A.split(";");for(;M.length>1;){const P=M.shift(),W=M.shift();if(/^\d+$/.exec(P)){const J=parseInt(P);if(I(J))if(W==="?")z.push({type:0,index:J});else{const le=(0,y.parseColor)(W);le&&z.push({type:1,index:J,color:le})}}}return z.length&&this._onColor.fire(z),!0}setHyperlink(A){const z=A.split(";");return!(z.length<2)&&(z[1]?this._createHyperlink(z[0],z[1]):!z[0]&&this._finishHyperlink())}_createHyperlink(A,z){this._getCurrentLinkId()&&this._finishHyperlink();const M=A.split(":");let P;const W=M.findIndex(J=>J.startsWith("id="));return W!==-1&&(P=M[W].slice(3)||void 0),this._curAttrData.extended=this._curAttrData.extended.clone(),this._curAttrData.extended.urlId=this._oscLinkService.registerLink({id:P,uri:z}),this._curAttrData.updateExtended(),!0}_finishHyperlink(){return this._curAttrData.extended=this._curAttrData.extended.clone(),this._curAttrData.extended.urlId=0,this._curAttrData.updateExtended(),!0}_setOrReportSpecialColor(A,z){const M=A.split(";");for(let P=0;P<M.length&&!(z>=this._specialColors.length);++P,++z)if(M[P]==="?")this._onColor.fire([{type:0,index:this._specialColors[z]}]);else{const W=(0,y.parseColor)(M[P]);W&&this._onColor.fire([{type:1,index:this._specialColors[z],color:W}])}return!0}setOrReportFgColor(A){return this._setOrReportSpecialColor(A,0)}setOrReportBgColor(A){return this._setOrReportSpecialColor(A,1)}setOrReportCursorColor(A){return this._setOrReportSpecialColor(A,2)}restoreIndexedColor(A){if(!A)return this._onColor.fire([{type:2}]),!0;const z=[],M=A.split(";");for(let P=0;P<M.length;++P)if(/^\d+$/.exec(M[P])){const W=parseInt(M[P]);I(W)&&z.push({type:2,index:W})}return z.length&&this._onColor.fire(z),!0}restoreFgColor(A){return this._onColor.fire([{type:2,index:256}]),!0}restoreBgColor(A){return this._onColor.fire([{type:2,index:257}]),!0}restoreCursorColor(A){return this._onColor.fire([{type:2,index:258}]),!0}nextLine(){return this._activeBuffer.x=0,this.index(),!0}keypadApplicationMode(){return this._logService.debug("Serial port requested application keypad."),this._coreService.decPrivateModes.applicationKeypad=!0,this._onRequestSyncScrollBar.fire(),!0}keypadNumericMode(){return this._logService.debug("Switching back to normal keypad."),this._coreService.decPrivateModes.applicationKeypad=!1,this._onRequestSyncScrollBar.fire(),!0}selectDefaultCharset(){return this._charsetService.setgLevel(0),this._charsetService.setgCharset(0,b.DEFAULT_CHARSET),!0}selectCharset(A){return A.length!==2?(this.selectDefaultCharset(),!0):(A[0]==="/"||this._charsetService.setgCharset(N[A[0]],b.CHARSETS[A[1]]||b.DEFAULT_CHARSET),!0)}index(){return this._restrictCursor(),this._activeBuffer.y++,this._activeBuffer.y===this._activeBuffer.scrollBottom+1?(this._activeBuffer.y--,this._bufferService.scroll(this._eraseAttrData())):this._activeBuffer.y>=this._bufferService.rows&&(this._activeBuffer.y=this._bufferService.rows-1),this._restrictCursor(),!0}tabSet(){return this._activeBuffer.tabs[this._activeBuffer.x]=!0,!0}reverseIndex(){if(this._restrictCursor(),this._activeBuffer.y===this._activeBuffer.scrollTop){const A=this._activeBuffer.scrollBottom-this._activeBuffer.scrollTop;this._activeBuffer.lines.shiftElements(this._activeBuffer.ybase+this._activeBuffer.y,A,1),this._activeBuffer.lines.set(this._activeBuffer.ybase+this._activeBuffer.y,this._activeBuffer.getBlankLine(this._eraseAttrData())),this._dirtyRowTracker.markRangeDirty(this._activeBuffer.scrollTop,this._activeBuffer.scrollBottom)}else this._activeBuffer.y--,this._restrictCursor();return!0}fullReset(){return this._parser.reset(),this._onRequestReset.fire(),!0}reset(){this._curAttrData=a.DEFAULT_ATTR_DATA.clone(),this._eraseAttrDataInternal=a.DEFAULT_ATTR_DATA.clone()}_eraseAttrData(){return this._eraseAttrDataInternal.bg&=-67108864,this._eraseAttrDataInternal.bg|=67108863&this._curAttrData.bg,this._eraseAttrDataInternal}setgLevel(A){return this._charsetService.setgLevel(A),!0}screenAlignmentPattern(){const A=new f.CellData;A.content=4194373,A.fg=this._curAttrData.fg,A.bg=this._curAttrData.bg,this._setCursor(0,0);for(let z=0;z<this._bufferService.rows;++z){const M=this._activeBuffer.ybase+this._activeBuffer.y+z,P=this._activeBuffer.lines.get(M);P&&(P.fill(A),P.isWrapped=!1)}return this._dirtyRowTracker.markAllDirty(),this._setCursor(0,0),!0}requestStatusString(A,z){const M=this._bufferService.buffer,P=this._optionsService.rawOptions;return(W=>(this._coreService.triggerDataEvent(`${v.C0.ESC}${W}${v.C0.ESC}\\`),!0))(A==='"q'?`P1$r${this._curAttrData.isProtected()?1:0}"q`:A==='"p'?'P1$r61;1"p':A==="r"?`P1$r${M.scrollTop+1};${M.scrollBottom+1}r`:A==="m"?"P1$r0m":A===" q"?`P1$r${{block:2,underline:4,bar:6}[P.cursorStyle]-(P.cursorBlink?1:0)} q`:"P0$r")}markRangeDirty(A,z){this._dirtyRowTracker.markRangeDirty(A,z)}}l.InputHandler=ee;let K=class{constructor(G){this._bufferService=G,this.clearRange()}clearRange(){this.start=this._bufferService.buffer.y,this.end=this._bufferService.buffer.y}markDirty(G){G<this.start?this.start=G:G>this.end&&(this.end=G)}markRangeDirty(G,A){G>A&&(V=G,G=A,A=V),G<this.start&&(this.start=G),A>this.end&&(this.end=A)}markAllDirty(){this.markRangeDirty(0,this._bufferService.rows-1)}};function I(G){return 0<=G&&G<256}K=h([p(0,x.IBufferService)],K)},844:(B,l)=>{function c(h){for(const p of h)p.dispose();h.length=0}Object.defineProperty(l,"__esModule",{value:!0}),l.getDisposeArrayDisposable=l.disposeArray=l.toDisposable=l.MutableDisposable=l.Disposable=void 0,l.Disposable=class{constructor(){this._disposables=[],this._isDisposed=!1}dispose(){this._isDisposed=!0;for(const h of this._disposables)h.dispose();this._disposables.length=0}register(h){return this._disposables.push(h),h}unregister(h){const p=this._disposables.indexOf(h);p!==-1&&this._disposables.splice(p,1)}},l.MutableDisposable=class{constructor(){this._isDisposed=!1}get value(){return this._isDisposed?void 0:this._value}set value(h){var p;this._isDisposed||h===this._value||((p=this._value)===null||p===void 0||p.dispose(),this._value=h)}clear(){this.value=void 0}dispose(){var h;this._isDisposed=!0,(h=this._value)===null||h===void 0||h.dispose(),this._value=void 0}},l.toDisposable=function(h){return{dispose:h}},l.disposeArray=c,l.getDisposeArrayDisposable=function(h){return{dispose:()=>c(h)}}},1505:(B,l)=>{Object.defineProperty(l,"__esModule",{value:!0}),l.FourKeyMap=l.TwoKeyMap=void 0;class c{constructor(){this._data={}}set(p,v,b){this._data[p]||(this._data[p]={}),this._data[p][v]=b}get(p,v){return this._data[p]?this._data[p][v]:void 0}clear(){this._data={}}}l.TwoKeyMap=c,l.FourKeyMap=class{constructor(){this._data=new c}set(h,p,v,b,w){this._data.get(h,p)||this._data.set(h,p,new c),this._data.get(h,p).set(v,b,w)}get(h,p,v,b){var w;return(w=this._data.get(h,p))===null||w===void 0?void 0:w.get(v,b)}clear(){this._data.clear()}}},6114:(B,l)=>{Object.defineProperty(l,"__esModule",{value:!0}),l.isChromeOS=l.isLinux=l.isWindows=l.isIphone=l.isIpad=l.isMac=l.getSafariVersion=l.isSafari=l.isLegacyEdge=l.isFirefox=l.isNode=void 0,l.isNode=typeof navigator>"u";const c=l.isNode?"node":navigator.userAgent,h=l.isNode?"node":navigator.platform;l.isFirefox=c.includes("Firefox"),l.isLegacyEdge=c.includes("Edge"),l.isSafari=/^((?!chrome|android).)*safari/i.test(c),l.getSafariVersion=function(){if(!l.isSafari)return 0;const p=c.match(/Version\/(\d+)/);return p===null||p.length<2?0:parseInt(p[1])},l.isMac=["Macintosh","MacIntel","MacPPC","Mac68K"].includes(h),l.isIpad=h==="iPad",l.isIphone=h==="iPhone",l.isWindows=["Windows","Win16","Win32","WinCE"].includes(h),l.isLinux=h.indexOf("Linux")>=0,l.isChromeOS=/\bCrOS\b/.test(c)},6106:(B,l)=>{Object.defineProperty(l,"__esModule",{value:!0}),l.SortedList=void 0;let c=0;l.SortedList=class{constructor(h){this._getKey=h,this._array=[]}clear(){this._array.length=0}insert(h){this._array.length!==0?(c=this._search(this._getKey(h)),this._array.splice(c,0,h)):this._array.push(h)}delete(h){if(this._array.length===0)return!1;const p=this._getKey(h);if(p===void 0||(c=this._search(p),c===-1)||this._getKey(this._array[c])!==p)return!1;do if(this._array[c]===h)return this._array.splice(c,1),!0;while(++c<this._array.length&&this._getKey(this._array[c])===p);return!1}*getKeyIterator(h){if(this._array.length!==0&&(c=this._search(h),!(c<0||c>=this._array.length)&&this._getKey(this._array[c])===h))do yield this._array[c];while(++c<this._array.length&&this._getKey(this._array[c])===h)}forEachByKey(h,p){if(this._array.length!==0&&(c=this._search(h),!(c<0||c>=this._array.length)&&this._getKey(this._array[c])===h))do p(this._array[c]);while(++c<this._array.length&&this._getKey(this._array[c])===h)}values(){return[...this._array].values()}_search(h){let p=0,v=this._array.length-1;for(;v>=p;){let b=p+v>>1;const w=this._getKey(this._array[b]);if(w>h)v=b-1;else{if(!(w<h)){for(;b>0&&this._getKey(this._array[b-1])===h;)b--;return b}p=b+1}}return p}}},7226:(B,l,c)=>{Object.defineProperty(l,"__esModule",{value:!0}),l.DebouncedIdleTask=l.IdleTaskQueue=l.PriorityTaskQueue=void 0;const h=c(6114);class p{constructor(){this._tasks=[],this._i=0}enqueue(w){this._tasks.push(w),this._start()}flush(){for(;this._i<this._tasks.length;)this._tasks[this._i]()||this._i++;this.clear()}clear(){this._idleCallback&&(this._cancelCallback(this._idleCallback),this._idleCallback=void 0),this._i=0,this._tasks.length=0}_start(){this._idleCallback||(this._idleCallback=this._requestCallback(this._process.bind(this)))}_process(w){this._idleCallback=void 0;let k=0,C=0,a=w.timeRemaining(),u=0;for(;this._i<this._tasks.length;){if(k=Date.now(),this._tasks[this._i]()||this._i++,k=Math.max(1,Date.now()-k),C=Math.max(k,C),u=w.timeRemaining(),1.5*C>u)return a-k<-20&&console.warn(`task queue exceeded allotted deadline by ${Math.abs(Math.round(a-k))}ms`),void this._start();a=u}this.clear()}}class v extends p{_requestCallback(w){return setTimeout(()=>w(this._createDeadline(16)))}_cancelCallback(w){clearTimeout(w)}_createDeadline(w){const k=Date.now()+w;return{timeRemaining:()=>Math.max(0,k-Date.now())}}}l.PriorityTaskQueue=v,l.IdleTaskQueue=!h.isNode&&"requestIdleCallback"in window?class extends p{_requestCallback(b){return requestIdleCallback(b)}_cancelCallback(b){cancelIdleCallback(b)}}:v,l.DebouncedIdleTask=class{constructor(){this._queue=new l.IdleTaskQueue}set(b){this._queue.clear(),this._queue.enqueue(b)}flush(){this._queue.flush()}}},9282:(B,l,c)=>{Object.defineProperty(l,"__esModule",{value:!0}),l.updateWindowsModeWrappedState=void 0;const h=c(643);l.updateWindowsModeWrappedState=function(p){const v=p.buffer.lines.get(p.buffer.ybase+p.buffer.y-1),b=v?.get(p.cols-1),w=p.buffer.lines.get(p.buffer.ybase+p.buffer.y);w&&b&&(w.isWrapped=b[h.CHAR_DATA_CODE_INDEX]!==h.NULL_CELL_CODE&&b[h.CHAR_DATA_CODE_INDEX]!==h.WHITESPACE_CELL_CODE)}},3734:(B,l)=>{Object.defineProperty(l,"__esModule",{value:!0}),l.ExtendedAttrs=l.AttributeData=void 0;class c{constructor(){this.fg=0,this.bg=0,this.extended=new h}static toColorRGB(v){return[v>>>16&255,v>>>8&255,255&v]}static fromColorRGB(v){return(255&v[0])<<16|(255&v[1])<<8|255&v[2]}clone(){const v=new c;return v.fg=this.fg,v.bg=this.bg,v.extended=this.extended.clone(),v}isInverse(){return 67108864&this.fg}isBold(){return 134217728&this.fg}isUnderline(){return this.hasExtendedAttrs()&&this.extended.underlineStyle!==0?1:268435456&this.fg}isBlink(){return 536870912&this.fg}isInvisible(){return 1073741824&this.fg}isItalic(){return 67108864&this.bg}isDim(){return 134217728&this.bg}isStrikethrough(){return 2147483648&this.fg}isProtected(){return 536870912&this.bg}isOverline(){return 1073741824&this.bg}getFgColorMode(){return 50331648&this.fg}getBgColorMode(){return 50331648&this.bg}isFgRGB(){return(50331648&this.fg)==50331648}isBgRGB(){return(50331648&this.bg)==50331648}isFgPalette(){return(50331648&this.fg)==16777216||(50331648&this.fg)==33554432}isBgPalette(){return(50331648&this.bg)==16777216||(50331648&this.bg)==33554432}isFgDefault(){return(50331648&this.fg)==0}isBgDefault(){return(50331648&this.bg)==0}isAttributeDefault(){return this.fg===0&&this.bg===0}getFgColor(){switch(50331648&this.fg){case 16777216:case 33554432:return 255&this.fg;case 50331648:return 16777215&this.fg;default:return-1}}getBgColor(){switch(50331648&this.bg){case 16777216:case 33554432:return 255&this.bg;case 50331648:return 16777215&this.bg;default:return-1}}hasExtendedAttrs(){return 268435456&this.bg}updateExtended(){this.extended.isEmpty()?this.bg&=-268435457:this.bg|=268435456}getUnderlineColor(){if(268435456&this.bg&&~this.extended.underlineColor)switch(50331648&this.extended.underlineColor){case 16777216:case 33554432:return 255&this.extended.underlineColor;case 50331648:return 16777215&this.extended.underlineColor;default:return this.getFgColor()}return this.getFgColor()}getUnderlineColorMode(){return 268435456&this.bg&&~this.extended.underlineColor?50331648&this.extended.underlineColor:this.getFgColorMode()}isUnderlineColorRGB(){return 268435456&this.bg&&~this.extended.underlineColor?(50331648&this.extended.underlineColor)==50331648:this.isFgRGB()}isUnderlineColorPalette(){return 268435456&this.bg&&~this.extended.underlineColor?(50331648&this.extended.underlineColor)==16777216||(50331648&this.extended.underlineColor)==33554432:this.isFgPalette()}isUnderlineColorDefault(){return 268435456&this.bg&&~this.extended.underlineColor?(50331648&this.extended.underlineColor)==0:this.isFgDefault()}getUnderlineStyle(){return 268435456&this.fg?268435456&this.bg?this.extended.underlineStyle:1:0}}l.AttributeData=c;class h{get ext(){return this._urlId?-469762049&this._ext|this.underlineStyle<<26:this._ext}set ext(v){this._ext=v}get underlineStyle(){return this._urlId?5:(469762048&this._ext)>>26}set underlineStyle(v){this._ext&=-469762049,this._ext|=v<<26&469762048}get underlineColor(){return 67108863&this._ext}set underlineColor(v){this._ext&=-67108864,this._ext|=67108863&v}get urlId(){return this._urlId}set urlId(v){this._urlId=v}constructor(v=0,b=0){this._ext=0,this._urlId=0,this._ext=v,this._urlId=b}clone(){return new h(this._ext,this._urlId)}isEmpty(){return this.underlineStyle===0&&this._urlId===0}}l.ExtendedAttrs=h},9092:(B,l,c)=>{Object.defineProperty(l,"__esModule",{value:!0}),l.Buffer=l.MAX_BUFFER_SIZE=void 0;const h=c(6349),p=c(7226),v=c(3734),b=c(8437),w=c(4634),k=c(511),C=c(643),a=c(4863),u=c(7116);l.MAX_BUFFER_SIZE=4294967295,l.Buffer=class{constructor(d,f,S){this._hasScrollback=d,this._optionsService=f,this._bufferService=S,this.ydisp=0,this.ybase=0,this.y=0,this.x=0,this.tabs={},this.savedY=0,this.savedX=0,this.savedCurAttrData=b.DEFAULT_ATTR_DATA.clone(),this.savedCharset=u.DEFAULT_CHARSET,this.markers=[],this._nullCell=k.CellData.fromCharData([0,C.NULL_CELL_CHAR,C.NULL_CELL_WIDTH,C.NULL_CELL_CODE]),this._whitespaceCell=k.CellData.fromCharData([0,C.WHITESPACE_CELL_CHAR,C.WHITESPACE_CELL_WIDTH,C.WHITESPACE_CELL_CODE]),this._isClearing=!1,this._memoryCleanupQueue=new p.IdleTaskQueue,this._memoryCleanupPosition=0,this._cols=this._bufferService.cols,this._rows=this._bufferService.rows,this.lines=new h.CircularList(this._getCorrectBufferLength(this._rows)),this.scrollTop=0,this.scrollBottom=this._rows-1,this.setupTabStops()}getNullCell(d){return d?(this._nullCell.fg=d.fg,this._nullCell.bg=d.bg,this._nullCell.extended=d.extended):(this._nullCell.fg=0,this._nullCell.bg=0,this._nullCell.extended=new v.ExtendedAttrs),this._nullCell}getWhitespaceCell(d){return d?(this._whitespaceCell.fg=d.fg,this._whitespaceCell.bg=d.bg,this._whitespaceCell.extended=d.extended):(this._whitespaceCell.fg=0,this._whitespaceCell.bg=0,this._whitespaceCell.extended=new v.ExtendedAttrs),this._whitespaceCell}getBlankLine(d,f){return new b.BufferLine(this._bufferService.cols,this.getNullCell(d),f)}get hasScrollback(){return this._hasScrollback&&this.lines.maxLength>this._rows}get isCursorInViewport(){const d=this.ybase+this.y-this.ydisp;return d>=0&&d<this._rows}_getCorrectBufferLength(d){if(!this._hasScrollback)return d;const f=d+this._optionsService.rawOptions.scrollback;return f>l.MAX_BUFFER_SIZE?l.MAX_BUFFER_SIZE:f}fillViewportRows(d){if(this.lines.length===0){d===void 0&&(d=b.DEFAULT_ATTR_DATA);let f=this._rows;for(;f--;)this.lines.push(this.getBlankLine(d))}}clear(){this.ydisp=0,this.ybase=0,this.y=0,this.x=0,this.lines=new h.CircularList(this._getCorrectBufferLength(this._rows)),this.scrollTop=0,this.scrollBottom=this._rows-1,this.setupTabStops()}resize(d,f){const S=this.getNullCell(b.DEFAULT_ATTR_DATA);let x=0;const R=this._getCorrectBufferLength(f);if(R>this.lines.maxLength&&(this.lines.maxLength=R),this.lines.length>0){if(this._cols<d)for(let y=0;y<this.lines.length;y++)x+=+this.lines.get(y).resize(d,S);let O=0;if(this._rows<f)for(let y=this._rows;y<f;y++)this.lines.length<f+this.ybase&&(this._optionsService.rawOptions.windowsMode||this._optionsService.rawOptions.windowsPty.backend!==void 0||this._optionsService.rawOptions.windowsPty.buildNumber!==void 0?this.lines.push(new b.BufferLine(d,S)):this.ybase>0&&this.lines.length<=this.ybase+this.y+O+1?(this.ybase--,O++,this.ydisp>0&&this.ydisp--):this.lines.push(new b.BufferLine(d,S)));else for(let y=this._rows;y>f;y--)this.lines.length>f+this.ybase&&(this.lines.length>this.ybase+this.y+1?this.lines.pop():(this.ybase++,this.ydisp++));if(R<this.lines.maxLength){const y=this.lines.length-R;y>0&&(this.lines.trimStart(y),this.ybase=Math.max(this.ybase-y,0),this.ydisp=Math.max(this.ydisp-y,0),this.savedY=Math.max(this.savedY-y,0)),this.lines.maxLength=R}this.x=Math.min(this.x,d-1),this.y=Math.min(this.y,f-1),O&&(this.y+=O),this.savedX=Math.min(this.savedX,d-1),this.scrollTop=0}if(this.scrollBottom=f-1,this._isReflowEnabled&&(this._reflow(d,f),this._cols>d))for(let O=0;O<this.lines.length;O++)x+=+this.lines.get(O).resize(d,S);this._cols=d,this._rows=f,this._memoryCleanupQueue.clear(),x>.1*this.lines.length&&(this._memoryCleanupPosition=0,this._memoryCleanupQueue.enqueue(()=>this._batchedMemoryCleanup()))}_batchedMemoryCleanup(){let d=!0;this._memoryCleanupPosition>=this.lines.length&&(this._memoryCleanupPosition=0,d=!1);let f=0;for(;this._memoryCleanupPosition<this.lines.length;)if(f+=this.lines.get(this._memoryCleanupPosition++).cleanupMemory(),f>100)return!0;return d}get _isReflowEnabled(){const d=this._optionsService.rawOptions.windowsPty;return d&&d.buildNumber?this._hasScrollback&&d.backend==="conpty"&&d.buildNumber>=21376:this._hasScrollback&&!this._optionsService.rawOptions.windowsMode}_reflow(d,f){this._cols!==d&&(d>this._cols?this._reflowLarger(d,f):this._reflowSmaller(d,f))}_reflowLarger(d,f){const S=(0,w.reflowLargerGetLinesToRemove)(this.lines,this._cols,d,this.ybase+this.y,this.getNullCell(b.DEFAULT_ATTR_DATA));if(S.length>0){const x=(0,w.reflowLargerCreateNewLayout)(this.lines,S);(0,w.reflowLargerApplyNewLayout)(this.lines,x.layout),this._reflowLargerAdjustViewport(d,f,x.countRemoved)}}_reflowLargerAdjustViewport(d,f,S){const x=this.getNullCell(b.DEFAULT_ATTR_DATA);let R=S;for(;R-- >0;)this.ybase===0?(this.y>0&&this.y--,this.lines.length<f&&this.lines.push(new b.BufferLine(d,x))):(this.ydisp===this.ybase&&this.ydisp--,this.ybase--);this.savedY=Math.max(this.savedY-S,0)}_reflowSmaller(d,f){const S=this.getNullCell(b.DEFAULT_ATTR_DATA),x=[];let R=0;for(let O=this.lines.length-1;O>=0;O--){let y=this.lines.get(O);if(!y||!y.isWrapped&&y.getTrimmedLength()<=d)continue;const N=[y];for(;y.isWrapped&&O>0;)y=this.lines.get(--O),N.unshift(y);const F=this.ybase+this.y;if(F>=O&&F<O+N.length)continue;const Y=N[N.length-1].getTrimmedLength(),q=(0,w.reflowSmallerGetNewLineLengths)(N,this._cols,d),V=q.length-N.length;let ee;ee=this.ybase===0&&this.y!==this.lines.length-1?Math.max(0,this.y-this.lines.maxLength+V):Math.max(0,this.lines.length-this.lines.maxLength+V);const K=[];for(let P=0;P<V;P++){const W=this.getBlankLine(b.DEFAULT_ATTR_DATA,!0);K.push(W)}K.length>0&&(x.push({start:O+N.length+R,newLines:K}),R+=K.length),N.push(...K);let I=q.length-1,G=q[I];G===0&&(I--,G=q[I]);let A=N.length-V-1,z=Y;for(;A>=0;){const P=Math.min(z,G);if(N[I]===void 0)break;if(N[I].copyCellsFrom(N[A],z-P,G-P,P,!0),G-=P,G===0&&(I--,G=q[I]),z-=P,z===0){A--;const W=Math.max(A,0);z=(0,w.getWrappedLineTrimmedLength)(N,W,this._cols)}}for(let P=0;P<N.length;P++)q[P]<d&&N[P].setCell(q[P],S);let M=V-ee;for(;M-- >0;)this.ybase===0?this.y<f-1?(this.y++,this.lines.pop()):(this.ybase++,this.ydisp++):this.ybase<Math.min(this.lines.maxLength,this.lines.length+R)-f&&(this.ybase===this.ydisp&&this.ydisp++,this.ybase++);this.savedY=Math.min(this.savedY+V,this.ybase+f-1)}if(x.length>0){const O=[],y=[];for(let I=0;I<this.lines.length;I++)y.push(this.lines.get(I));const N=this.lines.length;let F=N-1,Y=0,q=x[Y];this.lines.length=Math.min(this.lines.maxLength,this.lines.length+R);let V=0;for(let I=Math.min(this.lines.maxLength-1,N+R-1);I>=0;I--)if(q&&q.start>F+V){for(let G=q.newLines.length-1;G>=0;G--)this.lines.set(I--,q.newLines[G]);I++,O.push({index:F+1,amount:q.newLines.length}),V+=q.newLines.length,q=x[++Y]}else this.lines.set(I,y[F--]);let ee=0;for(let I=O.length-1;I>=0;I--)O[I].index+=ee,this.lines.onInsertEmitter.fire(O[I]),ee+=O[I].amount;const K=Math.max(0,N+R-this.lines.maxLength);K>0&&this.lines.onTrimEmitter.fire(K)}}translateBufferLineToString(d,f,S=0,x){const R=this.lines.get(d);return R?R.translateToString(f,S,x):""}getWrappedRangeForLine(d){let f=d,S=d;for(;f>0&&this.lines.get(f).isWrapped;)f--;for(;S+1<this.lines.length&&this.lines.get(S+1).isWrapped;)S++;return{first:f,last:S}}setupTabStops(d){for(d!=null?this.tabs[d]||(d=this.prevStop(d)):(this.tabs={},d=0);d<this._cols;d+=this._optionsService.rawOptions.tabStopWidth)this.tabs[d]=!0}prevStop(d){for(d==null&&(d=this.x);!this.tabs[--d]&&d>0;);return d>=this._cols?this._cols-1:d<0?0:d}nextStop(d){for(d==null&&(d=this.x);!this.tabs[++d]&&d<this._cols;);return d>=this._cols?this._cols-1:d<0?0:d}clearMarkers(d){this._isClearing=!0;for(let f=0;f<this.markers.length;f++)this.markers[f].line===d&&(this.markers[f].dispose(),this.markers.splice(f--,1));this._isClearing=!1}clearAllMarkers(){this._isClearing=!0;for(let d=0;d<this.markers.length;d++)this.markers[d].dispose(),this.markers.splice(d--,1);this._isClearing=!1}addMarker(d){const f=new a.Marker(d);return this.markers.push(f),f.register(this.lines.onTrim(S=>{f.line-=S,f.line<0&&f.dispose()})),f.register(this.lines.onInsert(S=>{f.line>=S.index&&(f.line+=S.amount)})),f.register(this.lines.onDelete(S=>{f.line>=S.index&&f.line<S.index+S.amount&&f.dispose(),f.line>S.index&&(f.line-=S.amount)})),f.register(f.onDispose(()=>this._removeMarker(f))),f}_removeMarker(d){this._isClearing||this.markers.splice(this.markers.indexOf(d),1)}}},8437:(B,l,c)=>{Object.defineProperty(l,"__esModule",{value:!0}),l.BufferLine=l.DEFAULT_ATTR_DATA=void 0;const h=c(3734),p=c(511),v=c(643),b=c(482);l.DEFAULT_ATTR_DATA=Object.freeze(new h.AttributeData);let w=0;class k{constructor(a,u,d=!1){this.isWrapped=d,this._combined={},this._extendedAttrs={},this._data=new Uint32Array(3*a);const f=u||p.CellData.fromCharData([0,v.NULL_CELL_CHAR,v.NULL_CELL_WIDTH,v.NULL_CELL_CODE]);for(let S=0;S<a;++S)this.setCell(S,f);this.length=a}get(a){const u=this._data[3*a+0],d=2097151&u;return[this._data[3*a+1],2097152&u?this._combined[a]:d?(0,b.stringFromCodePoint)(d):"",u>>22,2097152&u?this._combined[a].charCodeAt(this._combined[a].length-1):d]}set(a,u){this._data[3*a+1]=u[v.CHAR_DATA_ATTR_INDEX],u[v.CHAR_DATA_CHAR_INDEX].length>1?(this._combined[a]=u[1],this._data[3*a+0]=2097152|a|u[v.CHAR_DATA_WIDTH_INDEX]<<22):this._data[3*a+0]=u[v.CHAR_DATA_CHAR_INDEX].charCodeAt(0)|u[v.CHAR_DATA_WIDTH_INDEX]<<22}getWidth(a){return this._data[3*a+0]>>22}hasWidth(a){return 12582912&this._data[3*a+0]}getFg(a){return this._data[3*a+1]}getBg(a){return this._data[3*a+2]}hasContent(a){return 4194303&this._data[3*a+0]}getCodePoint(a){const u=this._data[3*a+0];return 2097152&u?this._combined[a].charCodeAt(this._combined[a].length-1):2097151&u}isCombined(a){return 2097152&this._data[3*a+0]}getString(a){const u=this._data[3*a+0];return 2097152&u?this._combined[a]:2097151&u?(0,b.stringFromCodePoint)(2097151&u):""}isProtected(a){return 536870912&this._data[3*a+2]}loadCell(a,u){return w=3*a,u.content=this._data[w+0],u.fg=this._data[w+1],u.bg=this._data[w+2],2097152&u.content&&(u.combinedData=this._combined[a]),268435456&u.bg&&(u.extended=this._extendedAttrs[a]),u}setCell(a,u){2097152&u.content&&(this._combined[a]=u.combinedData),268435456&u.bg&&(this._extendedAttrs[a]=u.extended),this._data[3*a+0]=u.content,this._data[3*a+1]=u.fg,this._data[3*a+2]=u.bg}setCellFromCodePoint(a,u,d,f,S,x){268435456&S&&(this._extendedAttrs[a]=x),this._data[3*a+0]=u|d<<22,this._data[3*a+1]=f,this._data[3*a+2]=S}addCodepointToCell(a,u){let d=this._data[3*a+0];2097152&d?this._combined[a]+=(0,b.stringFromCodePoint)(u):(2097151&d?(this._combined[a]=(0,b.stringFromCodePoint)(2097151&d)+(0,b.stringFromCodePoint)(u),d&=-2097152,d|=2097152):d=u|4194304,this._data[3*a+0]=d)}insertCells(a,u,d,f){if((a%=this.length)&&this.getWidth(a-1)===2&&this.setCellFromCodePoint(a-1,0,1,f?.fg||0,f?.bg||0,f?.extended||new h.ExtendedAttrs),u<this.length-a){const S=new p.CellData;for(let x=this.length-a-u-1;x>=0;--x)this.setCell(a+u+x,this.loadCell(a+x,S));for(let x=0;x<u;++x)this.setCell(a+x,d)}else for(let S=a;S<this.length;++S)this.setCell(S,d);this.getWidth(this.length-1)===2&&this.setCellFromCodePoint(this.length-1,0,1,f?.fg||0,f?.bg||0,f?.extended||new h.ExtendedAttrs)}deleteCells(a,u,d,f){if(a%=this.length,u<this.length-a){const S=new p.CellData;for(let x=0;x<this.length-a-u;++x)this.setCell(a+x,this.loadCell(a+u+x,S));for(let x=this.length-u;x<this.length;++x)this.setCell(x,d)}else for(let S=a;S<this.length;++S)this.setCell(S,d);a&&this.getWidth(a-1)===2&&this.setCellFromCodePoint(a-1,0,1,f?.fg||0,f?.bg||0,f?.extended||new h.ExtendedAttrs),this.getWidth(a)!==0||this.hasContent(a)||this.setCellFromCodePoint(a,0,1,f?.fg||0,f?.bg||0,f?.extended||new h.ExtendedAttrs)}replaceCells(a,u,d,f,S=!1){if(S)for(a&&this.getWidth(a-1)===2&&!this.isProtected(a-1)&&this.setCellFromCodePoint(a-1,0,1,f?.fg||0,f?.bg||0,f?.extended||new h.ExtendedAttrs),u<this.length&&this.getWidth(u-1)===2&&!this.isProtected(u)&&this.setCellFromCodePoint(u,0,1,f?.fg||0,f?.bg||0,f?.extended||new h.ExtendedAttrs);a<u&&a<this.length;)this.isProtected(a)||this.setCell(a,d),a++;else for(a&&this.getWidth(a-1)===2&&this.setCellFromCodePoint(a-1,0,1,f?.fg||0,f?.bg||0,f?.extended||new h.ExtendedAttrs),u<this.length&&this.getWidth(u-1)===2&&this.setCellFromCodePoint(u,0,1,f?.fg||0,f?.bg||0,f?.extended||new h.ExtendedAttrs);a<u&&a<this.length;)this.setCell(a++,d)}resize(a,u){if(a===this.length)return 4*this._data.length*2<this._data.buffer.byteLength;const d=3*a;if(a>this.length){if(this._data.buffer.byteLength>=4*d)this._data=new Uint32Array(this._data.buffer,0,d);else{const f=new Uint32Array(d);f.set(this._data),this._data=f}for(let f=this.length;f<a;++f)this.setCell(f,u)}else{this._data=this._data.subarray(0,d);const f=Object.keys(this._combined);for(let x=0;x<f.length;x++){const R=parseInt(f[x],10);R>=a&&delete this._combined[R]}const S=Object.keys(this._extendedAttrs);for(let x=0;x<S.length;x++){const R=parseInt(S[x],10);R>=a&&delete this._extendedAttrs[R]}}return this.length=a,4*d*2<this._data.buffer.byteLength}cleanupMemory(){if(4*this._data.length*2<this._data.buffer.byteLength){const a=new Uint32Array(this._data.length);return a.set(this._data),this._data=a,1}return 0}fill(a,u=!1){if(u)for(let d=0;d<this.length;++d)this.isProtected(d)||this.setCell(d,a);else{this._combined={},this._extendedAttrs={};for(let d=0;d<this.length;++d)this.setCell(d,a)}}copyFrom(a){this.length!==a.length?this._data=new Uint32Array(a._data):this._data.set(a._data),this.length=a.length,this._combined={};for(const u in a._combined)this._combined[u]=a._combined[u];this._extendedAttrs={};for(const u in a._extendedAttrs)this._extendedAttrs[u]=a._extendedAttrs[u];this.isWrapped=a.isWrapped}clone(){const a=new k(0);a._data=new Uint32Array(this._data),a.length=this.length;for(const u in this._combined)a._combined[u]=this._combined[u];for(const u in this._extendedAttrs)a._extendedAttrs[u]=this._extendedAttrs[u];return a.isWrapped=this.isWrapped,a}getTrimmedLength(){for(let a=this.length-1;a>=0;--a)if(4194303&this._data[3*a+0])return a+(this._data[3*a+0]>>22);return 0}getNoBgTrimmedLength(){for(let a=this.length-1;a>=0;--a)if(4194303&this._data[3*a+0]||50331648&this._data[3*a+2])return a+(this._data[3*a+0]>>22);return 0}copyCellsFrom(a,u,d,f,S){const x=a._data;if(S)for(let O=f-1;O>=0;O--){for(let y=0;y<3;y++)this._data[3*(d+O)+y]=x[3*(u+O)+y];268435456&x[3*(u+O)+2]&&(this._extendedAttrs[d+O]=a._extendedAttrs[u+O])}else for(let O=0;O<f;O++){for(let y=0;y<3;y++)this._data[3*(d+O)+y]=x[3*(u+O)+y];268435456&x[3*(u+O)+2]&&(this._extendedAttrs[d+O]=a._extendedAttrs[u+O])}const R=Object.keys(a._combined);for(let O=0;O<R.length;O++){const y=parseInt(R[O],10);y>=u&&(this._combined[y-u+d]=a._combined[y])}}translateToString(a=!1,u=0,d=this.length){a&&(d=Math.min(d,this.getTrimmedLength()));let f="";for(;u<d;){const S=this._data[3*u+0],x=2097151&S;f+=2097152&S?this._combined[u]:x?(0,b.stringFromCodePoint)(x):v.WHITESPACE_CELL_CHAR,u+=S>>22||1}return f}}l.BufferLine=k},4841:(B,l)=>{Object.defineProperty(l,"__esModule",{value:!0}),l.getRangeLength=void 0,l.getRangeLength=function(c,h){if(c.start.y>c.end.y)throw new Error(`Buffer range end (${c.end.x}, ${c.end.y}) cannot be before start (${c.start.x}, ${c.start.y})`);return h*(c.end.y-c.start.y)+(c.end.x-c.start.x+1)}},4634:(B,l)=>{function c(h,p,v){if(p===h.length-1)return h[p].getTrimmedLength();const b=!h[p].hasContent(v-1)&&h[p].getWidth(v-1)===1,w=h[p+1].getWidth(0)===2;return b&&w?v-1:v}Object.defineProperty(l,"__esModule",{value:!0}),l.getWrappedLineTrimmedLength=l.reflowSmallerGetNewLineLengths=l.reflowLargerApplyNewLayout=l.reflowLargerCreateNewLayout=l.reflowLargerGetLinesToRemove=void 0,l.reflowLargerGetLinesToRemove=function(h,p,v,b,w){const k=[];for(let C=0;C<h.length-1;C++){let a=C,u=h.get(++a);if(!u.isWrapped)continue;const d=[h.get(C)];for(;a<h.length&&u.isWrapped;)d.push(u),u=h.get(++a);if(b>=C&&b<a){C+=d.length-1;continue}let f=0,S=c(d,f,p),x=1,R=0;for(;x<d.length;){const y=c(d,x,p),N=y-R,F=v-S,Y=Math.min(N,F);d[f].copyCellsFrom(d[x],R,S,Y,!1),S+=Y,S===v&&(f++,S=0),R+=Y,R===y&&(x++,R=0),S===0&&f!==0&&d[f-1].getWidth(v-1)===2&&(d[f].copyCellsFrom(d[f-1],v-1,S++,1,!1),d[f-1].setCell(v-1,w))}d[f].replaceCells(S,v,w);let O=0;for(let y=d.length-1;y>0&&(y>f||d[y].getTrimmedLength()===0);y--)O++;O>0&&(k.push(C+d.length-O),k.push(O)),C+=d.length-1}return k},l.reflowLargerCreateNewLayout=function(h,p){const v=[];let b=0,w=p[b],k=0;for(let C=0;C<h.length;C++)if(w===C){const a=p[++b];h.onDeleteEmitter.fire({index:C-k,amount:a}),C+=a-1,k+=a,w=p[++b]}else v.push(C);return{layout:v,countRemoved:k}},l.reflowLargerApplyNewLayout=function(h,p){const v=[];for(let b=0;b<p.length;b++)v.push(h.get(p[b]));for(let b=0;b<v.length;b++)h.set(b,v[b]);h.length=p.length},l.reflowSmallerGetNewLineLengths=function(h,p,v){const b=[],w=h.map((u,d)=>c(h,d,p)).reduce((u,d)=>u+d);let k=0,C=0,a=0;for(;a<w;){if(w-a<v){b.push(w-a);break}k+=v;const u=c(h,C,p);k>u&&(k-=u,C++);const d=h[C].getWidth(k-1)===2;d&&k--;const f=d?v-1:v;b.push(f),a+=f}return b},l.getWrappedLineTrimmedLength=c},5295:(B,l,c)=>{Object.defineProperty(l,"__esModule",{value:!0}),l.BufferSet=void 0;const h=c(8460),p=c(844),v=c(9092);class b extends p.Disposable{constructor(k,C){super(),this._optionsService=k,this._bufferService=C,this._onBufferActivate=this.register(new h.EventEmitter),this.onBufferActivate=this._onBufferActivate.event,this.reset(),this.register(this._optionsService.onSpecificOptionChange("scrollback",()=>this.resize(this._bufferService.cols,this._bufferService.rows))),this.register(this._optionsService.onSpecificOptionChange("tabStopWidth",()=>this.setupTabStops()))}reset(){this._normal=new v.Buffer(!0,this._optionsService,this._bufferService),this._normal.fillViewportRows(),this._alt=new v.Buffer(!1,this._optionsService,this._bufferService),this._activeBuffer=this._normal,this._onBufferActivate.fire({activeBuffer:this._normal,inactiveBuffer:this._alt}),this.setupTabStops()}get alt(){return this._alt}get active(){return this._activeBuffer}get normal(){return this._normal}activateNormalBuffer(){this._activeBuffer!==this._normal&&(this._normal.x=this._alt.x,this._normal.y=this._alt.y,this._alt.clearAllMarkers(),this._alt.clear(),this._activeBuffer=this._normal,this._onBufferActivate.fire({activeBuffer:this._normal,inactiveBuffer:this._alt}))}activateAltBuffer(k){this._activeBuffer!==this._alt&&(this._alt.fillViewportRows(k),this._alt.x=this._normal.x,this._alt.y=this._normal.y,this._activeBuffer=this._alt,this._onBufferActivate.fire({activeBuffer:this._alt,inactiveBuffer:this._normal}))}resize(k,C){this._normal.resize(k,C),this._alt.resize(k,C),this.setupTabStops(k)}setupTabStops(k){this._normal.setupTabStops(k),this._alt.setupTabStops(k)}}l.BufferSet=b},511:(B,l,c)=>{Object.defineProperty(l,"__esModule",{value:!0}),l.CellData=void 0;const h=c(482),p=c(643),v=c(3734);class b extends v.AttributeData{constructor(){super(...arguments),this.content=0,this.fg=0,this.bg=0,this.extended=new v.ExtendedAttrs,this.combinedData=""}static fromCharData(k){const C=new b;return C.setFromCharData(k),C}isCombined(){return 2097152&this.content}getWidth(){return this.content>>22}getChars(){return 2097152&this.content?this.combinedData:2097151&this.content?(0,h.stringFromCodePoint)(2097151&this.content):""}getCode(){return this.isCombined()?this.combinedData.charCodeAt(this.combinedData.length-1):2097151&this.content}setFromCharData(k){this.fg=k[p.CHAR_DATA_ATTR_INDEX],this.bg=0;let C=!1;if(k[p.CHAR_DATA_CHAR_INDEX].length>2)C=!0;else if(k[p.CHAR_DATA_CHAR_INDEX].length===2){const a=k[p.CHAR_DATA_CHAR_INDEX].charCodeAt(0);if(55296<=a&&a<=56319){const u=k[p.CHAR_DATA_CHAR_INDEX].charCodeAt(1);56320<=u&&u<=57343?this.content=1024*(a-55296)+u-56320+65536|k[p.CHAR_DATA_WIDTH_INDEX]<<22:C=!0}else C=!0}else this.content=k[p.CHAR_DATA_CHAR_INDEX].charCodeAt(0)|k[p.CHAR_DATA_WIDTH_INDEX]<<22;C&&(this.combinedData=k[p.CHAR_DATA_CHAR_INDEX],this.content=2097152|k[p.CHAR_DATA_WIDTH_INDEX]<<22)}getAsCharData(){return[this.fg,this.getChars(),this.getWidth(),this.getCode()]}}l.CellData=b},643:(B,l)=>{Object.defineProperty(l,"__esModule",{value:!0}),l.WHITESPACE_CELL_CODE=l.WHITESPACE_CELL_WIDTH=l.WHITESPACE_CELL_CHAR=l.NULL_CELL_CODE=l.NULL_CELL_WIDTH=l.NULL_CELL_CHAR=l.CHAR_DATA_CODE_INDEX=l.CHAR_DATA_WIDTH_INDEX=l.CHAR_DATA_CHAR_INDEX=l.CHAR_DATA_ATTR_INDEX=l.DEFAULT_EXT=l.DEFAULT_ATTR=l.DEFAULT_COLOR=void 0,l.DEFAULT_COLOR=0,l.DEFAULT_ATTR=256|l.DEFAULT_COLOR<<9,l.DEFAULT_EXT=0,l.CHAR_DATA_ATTR_INDEX=0,l.CHAR_DATA_CHAR_INDEX=1,l.CHAR_DATA_WIDTH_INDEX=2,l.CHAR_DATA_CODE_INDEX=3,l.NULL_CELL_CHAR="",l.NULL_CELL_WIDTH=1,l.NULL_CELL_CODE=0,l.WHITESPACE_CELL_CHAR=" ",l.WHITESPACE_CELL_WIDTH=1,l.WHITESPACE_CELL_CODE=32},4863:(B,l,c)=>{Object.defineProperty(l,"__esModule",{value:!0}),l.Marker=void 0;const h=c(8460),p=c(844);class v{get id(){return this._id}constructor(w){this.line=w,this.isDisposed=!1,this._disposables=[],this._id=v._nextId++,this._onDispose=this.register(new h.EventEmitter),this.onDispose=this._onDispose.event}dispose(){this.isDisposed||(this.isDisposed=!0,this.line=-1,this._onDispose.fire(),(0,p.disposeArray)(this._disposables),this._disposables.length=0)}register(w){return this._disposables.push(w),w}}l.Marker=v,v._nextId=1},7116:(B,l)=>{Object.defineProperty(l,"__esModule",{value:!0}),l.DEFAULT_CHARSET=l.CHARSETS=void 0,l.CHARSETS={},l.DEFAULT_CHARSET=l.CHARSETS.B,l.CHARSETS[0]={"`":"◆",a:"▒",b:"␉",c:"␌",d:"␍",e:"␊",f:"°",g:"±",h:"␤",i:"␋",j:"┘",k:"┐",l:"┌",m:"└",n:"┼",o:"⎺",p:"⎻",q:"─",r:"⎼",s:"⎽",t:"├",u:"┤",v:"┴",w:"┬",x:"│",y:"≤",z:"≥","{":"π","|":"≠","}":"£","~":"·"},l.CHARSETS.A={"#":"£"},l.CHARSETS.B=void 0,l.CHARSETS[4]={"#":"£","@":"¾","[":"ij","\\":"½","]":"|","{":"¨","|":"f","}":"¼","~":"´"},l.CHARSETS.C=l.CHARSETS[5]={"[":"Ä","\\":"Ö","]":"Å","^":"Ü","`":"é","{":"ä","|":"ö","}":"å","~":"ü"},l.CHARSETS.R={"#":"£","@":"à","[":"°","\\":"ç","]":"§","{":"é","|":"ù","}":"è","~":"¨"},l.CHARSETS.Q={"@":"à","[":"â","\\":"ç","]":"ê","^":"î","`":"ô","{":"é","|":"ù","}":"è","~":"û"},l.CHARSETS.K={"@":"§","[":"Ä","\\":"Ö","]":"Ü","{":"ä","|":"ö","}":"ü","~":"ß"},l.CHARSETS.Y={"#":"£","@":"§","[":"°","\\":"ç","]":"é","`":"ù","{":"à","|":"ò","}":"è","~":"ì"},l.CHARSETS.E=l.CHARSETS[6]={"@":"Ä","[":"Æ","\\":"Ø","]":"Å","^":"Ü","`":"ä","{":"æ","|":"ø","}":"å","~":"ü"},l.CHARSETS.Z={"#":"£","@":"§","[":"¡","\\":"Ñ","]":"¿","{":"°","|":"ñ","}":"ç"},l.CHARSETS.H=l.CHARSETS[7]={"@":"É","[":"Ä","\\":"Ö","]":"Å","^":"Ü","`":"é","{":"ä","|":"ö","}":"å","~":"ü"},l.CHARSETS["="]={"#":"ù","@":"à","[":"é","\\":"ç","]":"ê","^":"î",_:"è","`":"ô","{":"ä","|":"ö","}":"ü","~":"û"}},2584:(B,l)=>{var c,h,p;Object.defineProperty(l,"__esModule",{value:!0}),l.C1_ESCAPED=l.C1=l.C0=void 0,function(v){v.NUL="\0",v.SOH="",v.STX="",v.ETX="",v.EOT="",v.ENQ="",v.ACK="",v.BEL="\x07",v.BS="\b",v.HT="	",v.LF=`
 `,v.VT="\v",v.FF="\f",v.CR="\r",v.SO="",v.SI="",v.DLE="",v.DC1="",v.DC2="",v.DC3="",v.DC4="",v.NAK="",v.SYN="",v.ETB="",v.CAN="",v.EM="",v.SUB="",v.ESC="\x1B",v.FS="",v.GS="",v.RS="",v.US="",v.SP=" ",v.DEL=""}(c||(l.C0=c={})),function(v){v.PAD="",v.HOP="",v.BPH="",v.NBH="",v.IND="",v.NEL="",v.SSA="",v.ESA="",v.HTS="",v.HTJ="",v.VTS="",v.PLD="",v.PLU="",v.RI="",v.SS2="",v.SS3="",v.DCS="",v.PU1="",v.PU2="",v.STS="",v.CCH="",v.MW="",v.SPA="",v.EPA="",v.SOS="",v.SGCI="",v.SCI="",v.CSI="",v.ST="",v.OSC="",v.PM="",v.APC=""}(h||(l.C1=h={})),function(v){v.ST=`${c.ESC}\\`}(p||(l.C1_ESCAPED=p={}))},7399:(B,l,c)=>{Object.defineProperty(l,"__esModule",{value:!0}),l.evaluateKeyboardEvent=void 0;const h=c(2584),p={48:["0",")"],49:["1","!"],50:["2","@"],51:["3","#"],52:["4","$"],53:["5","%"],54:["6","^"],55:["7","&"],56:["8","*"],57:["9","("],186:[";",":"],187:["=","+"],188:[",","<"],189:["-","_"],190:[".",">"],191:["/","?"],192:["`","~"],219:["[","{"],220:["\\","|"],221:["]","}"],222:["'",'"']};l.evaluateKeyboardEvent=function(v,b,w,k){const C={type:0,cancel:!1,key:void 0},a=(v.shiftKey?1:0)|(v.altKey?2:0)|(v.ctrlKey?4:0)|(v.metaKey?8:0);switch(v.keyCode){case 0:v.key==="UIKeyInputUpArrow"?C.key=b?h.C0.ESC+"OA":h.C0.ESC+"[A":v.key==="UIKeyInputLeftArrow"?C.key=b?h.C0.ESC+"OD":h.C0.ESC+"[D":v.key==="UIKeyInputRightArrow"?C.key=b?h.C0.ESC+"OC":h.C0.ESC+"[C":v.key==="UIKeyInputDownArrow"&&(C.key=b?h.C0.ESC+"OB":h.C0.ESC+"[B");break;case 8:if(v.altKey){C.key=h.C0.ESC+h.C0.DEL;break}C.key=h.C0.DEL;break;case 9:if(v.shiftKey){C.key=h.C0.ESC+"[Z";break}C.key=h.C0.HT,C.cancel=!0;break;case 13:C.key=v.altKey?h.C0.ESC+h.C0.CR:h.C0.CR,C.cancel=!0;break;case 27:C.key=h.C0.ESC,v.altKey&&(C.key=h.C0.ESC+h.C0.ESC),C.cancel=!0;break;case 37:if(v.metaKey)break;a?(C.key=h.C0.ESC+"[1;"+(a+1)+"D",C.key===h.C0.ESC+"[1;3D"&&(C.key=h.C0.ESC+(w?"b":"[1;5D"))):C.key=b?h.C0.ESC+"OD":h.C0.ESC+"[D";break;case 39:if(v.metaKey)break;a?(C.key=h.C0.ESC+"[1;"+(a+1)+"C",C.key===h.C0.ESC+"[1;3C"&&(C.key=h.C0.ESC+(w?"f":"[1;5C"))):C.key=b?h.C0.ESC+"OC":h.C0.ESC+"[C";break;case 38:if(v.metaKey)break;a?(C.key=h.C0.ESC+"[1;"+(a+1)+"A",w||C.key!==h.C0.ESC+"[1;3A"||(C.key=h.C0.ESC+"[1;5A")):C.key=b?h.C0.ESC+"OA":h.C0.ESC+"[A";break;case 40:if(v.metaKey)break;a?(C.key=h.C0.ESC+"[1;"+(a+1)+"B",w||C.key!==h.C0.ESC+"[1;3B"||(C.key=h.C0.ESC+"[1;5B")):C.key=b?h.C0.ESC+"OB":h.C0.ESC+"[B";break;case 45:v.shiftKey||v.ctrlKey||(C.key=h.C0.ESC+"[2~");break;case 46:C.key=a?h.C0.ESC+"[3;"+(a+1)+"~":h.C0.ESC+"[3~";break;case 36:C.key=a?h.C0.ESC+"[1;"+(a+1)+"H":b?h.C0.ESC+"OH":h.C0.ESC+"[H";break;case 35:C.key=a?h.C0.ESC+"[1;"+(a+1)+"F":b?h.C0.ESC+"OF":h.C0.ESC+"[F";break;case 33:v.shiftKey?C.type=2:v.ctrlKey?C.key=h.C0.ESC+"[5;"+(a+1)+"~":C.key=h.C0.ESC+"[5~";break;case 34:v.shiftKey?C.type=3:v.ctrlKey?C.key=h.C0.ESC+"[6;"+(a+1)+"~":C.key=h.C0.ESC+"[6~";break;case 112:C.key=a?h.C0.ESC+"[1;"+(a+1)+"P":h.C0.ESC+"OP";break;case 113:C.key=a?h.C0.ESC+"[1;"+(a+1)+"Q":h.C0.ESC+"OQ";break;case 114:C.key=a?h.C0.ESC+"[1;"+(a+1)+"R":h.C0.ESC+"OR";break;case 115:C.key=a?h.C0.ESC+"[1;"+(a+1)+"S":h.C0.ESC+"OS";break;case 116:C.key=a?h.C0.ESC+"[15;"+(a+1)+"~":h.C0.ESC+"[15~";break;case 117:C.key=a?h.C0.ESC+"[17;"+(a+1)+"~":h.C0.ESC+"[17~";break;case 118:C.key=a?h.C0.ESC+"[18;"+(a+1)+"~":h.C0.ESC+"[18~";break;case 119:C.key=a?h.C0.ESC+"[19;"+(a+1)+"~":h.C0.ESC+"[19~";break;case 120:C.key=a?h.C0.ESC+"[20;"+(a+1)+"~":h.C0.ESC+"[20~";break;case 121:C.key=a?h.C0.ESC+"[21;"+(a+1)+"~":h.C0.ESC+"[21~";break;case 122:C.key=a?h.C0.ESC+"[23;"+(a+1)+"~":h.C0.ESC+"[23~";break;case 123:C.key=a?h.C0.ESC+"[24;"+(a+1)+"~":h.C0.ESC+"[24~";break;default:if(!v.ctrlKey||v.shiftKey||v.altKey||v.metaKey)if(w&&!k||!v.altKey||v.metaKey)!w||v.altKey||v.ctrlKey||v.shiftKey||!v.metaKey?v.key&&!v.ctrlKey&&!v.altKey&&!v.metaKey&&v.keyCode>=48&&v.key.length===1?C.key=v.key:v.key&&v.ctrlKey&&(v.key==="_"&&(C.key=h.C0.US),v.key==="@"&&(C.key=h.C0.NUL)):v.keyCode===65&&(C.type=1);else{const u=p[v.keyCode],d=u?.[v.shiftKey?1:0];if(d)C.key=h.C0.ESC+d;else if(v.keyCode>=65&&v.keyCode<=90){const f=v.ctrlKey?v.keyCode-64:v.keyCode+32;let S=String.fromCharCode(f);v.shiftKey&&(S=S.toUpperCase()),C.key=h.C0.ESC+S}else if(v.keyCode===32)C.key=h.C0.ESC+(v.ctrlKey?h.C0.NUL:" ");else if(v.key==="Dead"&&v.code.startsWith("Key")){let f=v.code.slice(3,4);v.shiftKey||(f=f.toLowerCase()),C.key=h.C0.ESC+f,C.cancel=!0}}else v.keyCode>=65&&v.keyCode<=90?C.key=String.fromCharCode(v.keyCode-64):v.keyCode===32?C.key=h.C0.NUL:v.keyCode>=51&&v.keyCode<=55?C.key=String.fromCharCode(v.keyCode-51+27):v.keyCode===56?C.key=h.C0.DEL:v.keyCode===219?C.key=h.C0.ESC:v.keyCode===220?C.key=h.C0.FS:v.keyCode===221&&(C.key=h.C0.GS)}return C}},482:(B,l)=>{Object.defineProperty(l,"__esModule",{value:!0}),l.Utf8ToUtf32=l.StringToUtf32=l.utf32ToString=l.stringFromCodePoint=void 0,l.stringFromCodePoint=function(c){return c>65535?(c-=65536,String.fromCharCode(55296+(c>>10))+String.fromCharCode(c%1024+56320)):String.fromCharCode(c)},l.utf32ToString=function(c,h=0,p=c.length){let v="";for(let b=h;b<p;++b){let w=c[b];w>65535?(w-=65536,v+=String.fromCharCode(55296+(w>>10))+String.fromCharCode(w%1024+56320)):v+=String.fromCharCode(w)}return v},l.StringToUtf32=class{constructor(){this._interim=0}clear(){this._interim=0}decode(c,h){const p=c.length;if(!p)return 0;let v=0,b=0;if(this._interim){const w=c.charCodeAt(b++);56320<=w&&w<=57343?h[v++]=1024*(this._interim-55296)+w-56320+65536:(h[v++]=this._interim,h[v++]=w),this._interim=0}for(let w=b;w<p;++w){const k=c.charCodeAt(w);if(55296<=k&&k<=56319){if(++w>=p)return this._interim=k,v;const C=c.charCodeAt(w);56320<=C&&C<=57343?h[v++]=1024*(k-55296)+C-56320+65536:(h[v++]=k,h[v++]=C)}else k!==65279&&(h[v++]=k)}return v}},l.Utf8ToUtf32=class{constructor(){this.interim=new Uint8Array(3)}clear(){this.interim.fill(0)}decode(c,h){const p=c.length;if(!p)return 0;let v,b,w,k,C=0,a=0,u=0;if(this.interim[0]){let S=!1,x=this.interim[0];x&=(224&x)==192?31:(240&x)==224?15:7;let R,O=0;for(;(R=63&this.interim[++O])&&O<4;)x<<=6,x|=R;const y=(224&this.interim[0])==192?2:(240&this.interim[0])==224?3:4,N=y-O;for(;u<N;){if(u>=p)return 0;if(R=c[u++],(192&R)!=128){u--,S=!0;break}this.interim[O++]=R,x<<=6,x|=63&R}S||(y===2?x<128?u--:h[C++]=x:y===3?x<2048||x>=55296&&x<=57343||x===65279||(h[C++]=x):x<65536||x>1114111||(h[C++]=x)),this.interim.fill(0)}const d=p-4;let f=u;for(;f<p;){for(;!(!(f<d)||128&(v=c[f])||128&(b=c[f+1])||128&(w=c[f+2])||128&(k=c[f+3]));)h[C++]=v,h[C++]=b,h[C++]=w,h[C++]=k,f+=4;if(v=c[f++],v<128)h[C++]=v;else if((224&v)==192){if(f>=p)return this.interim[0]=v,C;if(b=c[f++],(192&b)!=128){f--;continue}if(a=(31&v)<<6|63&b,a<128){f--;continue}h[C++]=a}else if((240&v)==224){if(f>=p)return this.interim[0]=v,C;if(b=c[f++],(192&b)!=128){f--;continue}if(f>=p)return this.interim[0]=v,this.interim[1]=b,C;if(w=c[f++],(192&w)!=128){f--;continue}if(a=(15&v)<<12|(63&b)<<6|63&w,a<2048||a>=55296&&a<=57343||a===65279)continue;h[C++]=a}else if((248&v)==240){if(f>=p)return this.interim[0]=v,C;if(b=c[f++],(192&b)!=128){f--;continue}if(f>=p)return this.interim[0]=v,this.interim[1]=b,C;if(w=c[f++],(192&w)!=128){f--;continue}if(f>=p)return this.interim[0]=v,this.interim[1]=b,this.interim[2]=w,C;if(k=c[f++],(192&k)!=128){f--;continue}if(a=(7&v)<<18|(63&b)<<12|(63&w)<<6|63&k,a<65536||a>1114111)continue;h[C++]=a}}return C}}},225:(B,l)=>{Object.defineProperty(l,"__esModule",{value:!0}),l.UnicodeV6=void 0;const c=[[768,879],[1155,1158],[1160,1161],[1425,1469],[1471,1471],[1473,1474],[1476,1477],[1479,1479],[1536,1539],[1552,1557],[1611,1630],[1648,1648],[1750,1764],[1767,1768],[1770,1773],[1807,1807],[1809,1809],[1840,1866],[1958,1968],[2027,2035],[2305,2306],[2364,2364],[2369,2376],[2381,2381],[2385,2388],[2402,2403],[2433,2433],[2492,2492],[2497,2500],[2509,2509],[2530,2531],[2561,2562],[2620,2620],[2625,2626],[2631,2632],[2635,2637],[2672,2673],[2689,2690],[2748,2748],[2753,2757],[2759,2760],[2765,2765],[2786,2787],[2817,2817],[2876,2876],[2879,2879],[2881,2883],[2893,2893],[2902,2902],[2946,2946],[3008,3008],[3021,3021],[3134,3136],[3142,3144],[3146,3149],[3157,3158],[3260,3260],[3263,3263],[3270,3270],[3276,3277],[3298,3299],[3393,3395],[3405,3405],[3530,3530],[3538,3540],[3542,3542],[3633,3633],[3636,3642],[3655,3662],[3761,3761],[3764,3769],[3771,3772],[3784,3789],[3864,3865],[3893,3893],[3895,3895],[3897,3897],[3953,3966],[3968,3972],[3974,3975],[3984,3991],[3993,4028],[4038,4038],[4141,4144],[4146,4146],[4150,4151],[4153,4153],[4184,4185],[4448,4607],[4959,4959],[5906,5908],[5938,5940],[5970,5971],[6002,6003],[6068,6069],[6071,6077],[6086,6086],[6089,6099],[6109,6109],[6155,6157],[6313,6313],[6432,6434],[6439,6440],[6450,6450],[6457,6459],[6679,6680],[6912,6915],[6964,6964],[6966,6970],[6972,6972],[6978,6978],[7019,7027],[7616,7626],[7678,7679],[8203,8207],[8234,8238],[8288,8291],[8298,8303],[8400,8431],[12330,12335],[12441,12442],[43014,43014],[43019,43019],[43045,43046],[64286,64286],[65024,65039],[65056,65059],[65279,65279],[65529,65531]],h=[[68097,68099],[68101,68102],[68108,68111],[68152,68154],[68159,68159],[119143,119145],[119155,119170],[119173,119179],[119210,119213],[119362,119364],[917505,917505],[917536,917631],[917760,917999]];let p;l.UnicodeV6=class{constructor(){if(this.version="6",!p){p=new Uint8Array(65536),p.fill(1),p[0]=0,p.fill(0,1,32),p.fill(0,127,160),p.fill(2,4352,4448),p[9001]=2,p[9002]=2,p.fill(2,11904,42192),p[12351]=1,p.fill(2,44032,55204),p.fill(2,63744,64256),p.fill(2,65040,65050),p.fill(2,65072,65136),p.fill(2,65280,65377),p.fill(2,65504,65511);for(let v=0;v<c.length;++v)p.fill(0,c[v][0],c[v][1]+1)}}wcwidth(v){return v<32?0:v<127?1:v<65536?p[v]:function(b,w){let k,C=0,a=w.length-1;if(b<w[0][0]||b>w[a][1])return!1;for(;a>=C;)if(k=C+a>>1,b>w[k][1])C=k+1;else{if(!(b<w[k][0]))return!0;a=k-1}return!1}(v,h)?0:v>=131072&&v<=196605||v>=196608&&v<=262141?2:1}}},5981:(B,l,c)=>{Object.defineProperty(l,"__esModule",{value:!0}),l.WriteBuffer=void 0;const h=c(8460),p=c(844);class v extends p.Disposable{constructor(w){super(),this._action=w,this._writeBuffer=[],this._callbacks=[],this._pendingData=0,this._bufferOffset=0,this._isSyncWriting=!1,this._syncCalls=0,this._didUserInput=!1,this._onWriteParsed=this.register(new h.EventEmitter),this.onWriteParsed=this._onWriteParsed.event}handleUserInput(){this._didUserInput=!0}writeSync(w,k){if(k!==void 0&&this._syncCalls>k)return void(this._syncCalls=0);if(this._pendingData+=w.length,this._writeBuffer.push(w),this._callbacks.push(void 0),this._syncCalls++,this._isSyncWriting)return;let C;for(this._isSyncWriting=!0;C=this._writeBuffer.shift();){this._action(C);const a=this._callbacks.shift();a&&a()}this._pendingData=0,this._bufferOffset=2147483647,this._isSyncWriting=!1,this._syncCalls=0}write(w,k){if(this._pendingData>5e7)throw new Error("write data discarded, use flow control to avoid losing data");if(!this._writeBuffer.length){if(this._bufferOffset=0,this._didUserInput)return this._didUserInput=!1,this._pendingData+=w.length,this._writeBuffer.push(w),this._callbacks.push(k),void this._innerWrite();setTimeout(()=>this._innerWrite())}this._pendingData+=w.length,this._writeBuffer.push(w),this._callbacks.push(k)}_innerWrite(w=0,k=!0){const C=w||Date.now();for(;this._writeBuffer.length>this._bufferOffset;){const a=this._writeBuffer[this._bufferOffset],u=this._action(a,k);if(u){const f=S=>Date.now()-C>=12?setTimeout(()=>this._innerWrite(0,S)):this._innerWrite(C,S);return void u.catch(S=>(queueMicrotask(()=>{throw S}),Promise.resolve(!1))).then(f)}const d=this._callbacks[this._bufferOffset];if(d&&d(),this._bufferOffset++,this._pendingData-=a.length,Date.now()-C>=12)break}this._writeBuffer.length>this._bufferOffset?(this._bufferOffset>50&&(this._writeBuffer=this._writeBuffer.slice(this._bufferOffset),this._callbacks=this._callbacks.slice(this._bufferOffset),this._bufferOffset=0),setTimeout(()=>this._innerWrite())):(this._writeBuffer.length=0,this._callbacks.length=0,this._pendingData=0,this._bufferOffset=0),this._onWriteParsed.fire()}}l.WriteBuffer=v},5941:(B,l)=>{Object.defineProperty(l,"__esModule",{value:!0}),l.toRgbString=l.parseColor=void 0;const c=/^([\da-f])\/([\da-f])\/([\da-f])$|^([\da-f]{2})\/([\da-f]{2})\/([\da-f]{2})$|^([\da-f]{3})\/([\da-f]{3})\/([\da-f]{3})$|^([\da-f]{4})\/([\da-f]{4})\/([\da-f]{4})$/,h=/^[\da-f]+$/;function p(v,b){const w=v.toString(16),k=w.length<2?"0"+w:w;switch(b){case 4:return w[0];case 8:return k;case 12:return(k+k).slice(0,3);default:return k+k}}l.parseColor=function(v){if(!v)return;let b=v.toLowerCase();if(b.indexOf("rgb:")===0){b=b.slice(4);const w=c.exec(b);if(w){const k=w[1]?15:w[4]?255:w[7]?4095:65535;return[Math.round(parseInt(w[1]||w[4]||w[7]||w[10],16)/k*255),Math.round(parseInt(w[2]||w[5]||w[8]||w[11],16)/k*255),Math.round(parseInt(w[3]||w[6]||w[9]||w[12],16)/k*255)]}}else if(b.indexOf("#")===0&&(b=b.slice(1),h.exec(b)&&[3,6,9,12].includes(b.length))){const w=b.length/3,k=[0,0,0];for(let C=0;C<3;++C){const a=parseInt(b.slice(w*C,w*C+w),16);k[C]=w===1?a<<4:w===2?a:w===3?a>>4:a>>8}return k}},l.toRgbString=function(v,b=16){const[w,k,C]=v;return`rgb:${p(w,b)}/${p(k,b)}/${p(C,b)}`}},5770:(B,l)=>{Object.defineProperty(l,"__esModule",{value:!0}),l.PAYLOAD_LIMIT=void 0,l.PAYLOAD_LIMIT=1e7},6351:(B,l,c)=>{Object.defineProperty(l,"__esModule",{value:!0}),l.DcsHandler=l.DcsParser=void 0;const h=c(482),p=c(8742),v=c(5770),b=[];l.DcsParser=class{constructor(){this._handlers=Object.create(null),this._active=b,this._ident=0,this._handlerFb=()=>{},this._stack={paused:!1,loopPosition:0,fallThrough:!1}}dispose(){this._handlers=Object.create(null),this._handlerFb=()=>{},this._active=b}registerHandler(k,C){this._handlers[k]===void 0&&(this._handlers[k]=[]);const a=this._handlers[k];return a.push(C),{dispose:()=>{const u=a.indexOf(C);u!==-1&&a.splice(u,1)}}}clearHandler(k){this._handlers[k]&&delete this._handlers[k]}setHandlerFallback(k){this._handlerFb=k}reset(){if(this._active.length)for(let k=this._stack.paused?this._stack.loopPosition-1:this._active.length-1;k>=0;--k)this._active[k].unhook(!1);this._stack.paused=!1,this._active=b,this._ident=0}hook(k,C){if(this.reset(),this._ident=k,this._active=this._handlers[k]||b,this._active.length)for(let a=this._active.length-1;a>=0;a--)this._active[a].hook(C);else this._handlerFb(this._ident,"HOOK",C)}put(k,C,a){if(this._active.length)for(let u=this._active.length-1;u>=0;u--)this._active[u].put(k,C,a);else this._handlerFb(this._ident,"PUT",(0,h.utf32ToString)(k,C,a))}unhook(k,C=!0){if(this._active.length){let a=!1,u=this._active.length-1,d=!1;if(this._stack.paused&&(u=this._stack.loopPosition-1,a=C,d=this._stack.fallThrough,this._stack.paused=!1),!d&&a===!1){for(;u>=0&&(a=this._active[u].unhook(k),a!==!0);u--)if(a instanceof Promise)return this._stack.paused=!0,this._stack.loopPosition=u,this._stack.fallThrough=!1,a;u--}for(;u>=0;u--)if(a=this._active[u].unhook(!1),a instanceof Promise)return this._stack.paused=!0,this._stack.loopPosition=u,this._stack.fallThrough=!0,a}else this._handlerFb(this._ident,"UNHOOK",k);this._active=b,this._ident=0}};const w=new p.Params;w.addParam(0),l.DcsHandler=class{constructor(k){this._handler=k,this._data="",this._params=w,this._hitLimit=!1}hook(k){this._params=k.length>1||k.params[0]?k.clone():w,this._data="",this._hitLimit=!1}put(k,C,a){this._hitLimit||(this._data+=(0,h.utf32ToString)(k,C,a),this._data.length>v.PAYLOAD_LIMIT&&(this._data="",this._hitLimit=!0))}unhook(k){let C=!1;if(this._hitLimit)C=!1;else if(k&&(C=this._handler(this._data,this._params),C instanceof Promise))return C.then(a=>(this._params=w,this._data="",this._hitLimit=!1,a));return this._params=w,this._data="",this._hitLimit=!1,C}}},2015:(B,l,c)=>{Object.defineProperty(l,"__esModule",{value:!0}),l.EscapeSequenceParser=l.VT500_TRANSITION_TABLE=l.TransitionTable=void 0;const h=c(844),p=c(8742),v=c(6242),b=c(6351);class w{constructor(u){this.table=new Uint8Array(u)}setDefault(u,d){this.table.fill(u<<4|d)}add(u,d,f,S){this.table[d<<8|u]=f<<4|S}addMany(u,d,f,S){for(let x=0;x<u.length;x++)this.table[d<<8|u[x]]=f<<4|S}}l.TransitionTable=w;const k=160;l.VT500_TRANSITION_TABLE=function(){const a=new w(4095),u=Array.apply(null,Array(256)).map((O,y)=>y),d=(O,y)=>u.slice(O,y),f=d(32,127),S=d(0,24);S.push(25),S.push.apply(S,d(28,32));const x=d(0,14);let R;for(R in a.setDefault(1,0),a.addMany(f,0,2,0),x)a.addMany([24,26,153,154],R,3,0),a.addMany(d(128,144),R,3,0),a.addMany(d(144,152),R,3,0),a.add(156,R,0,0),a.add(27,R,11,1),a.add(157,R,4,8),a.addMany([152,158,159],R,0,7),a.add(155,R,11,3),a.add(144,R,11,9);return a.addMany(S,0,3,0),a.addMany(S,1,3,1),a.add(127,1,0,1),a.addMany(S,8,0,8),a.addMany(S,3,3,3),a.add(127,3,0,3),a.addMany(S,4,3,4),a.add(127,4,0,4),a.addMany(S,6,3,6),a.addMany(S,5,3,5),a.add(127,5,0,5),a.addMany(S,2,3,2),a.add(127,2,0,2),a.add(93,1,4,8),a.addMany(f,8,5,8),a.add(127,8,5,8),a.addMany([156,27,24,26,7],8,6,0),a.addMany(d(28,32),8,0,8),a.addMany([88,94,95],1,0,7),a.addMany(f,7,0,7),a.addMany(S,7,0,7),a.add(156,7,0,0),a.add(127,7,0,7),a.add(91,1,11,3),a.addMany(d(64,127),3,7,0),a.addMany(d(48,60),3,8,4),a.addMany([60,61,62,63],3,9,4),a.addMany(d(48,60),4,8,4),a.addMany(d(64,127),4,7,0),a.addMany([60,61,62,63],4,0,6),a.addMany(d(32,64),6,0,6),a.add(127,6,0,6),a.addMany(d(64,127),6,0,0),a.addMany(d(32,48),3,9,5),a.addMany(d(32,48),5,9,5),a.addMany(d(48,64),5,0,6),a.addMany(d(64,127),5,7,0),a.addMany(d(32,48),4,9,5),a.addMany(d(32,48),1,9,2),a.addMany(d(32,48),2,9,2),a.addMany(d(48,127),2,10,0),a.addMany(d(48,80),1,10,0),a.addMany(d(81,88),1,10,0),a.addMany([89,90,92],1,10,0),a.addMany(d(96,127),1,10,0),a.add(80,1,11,9),a.addMany(S,9,0,9),a.add(127,9,0,9),a.addMany(d(28,32),9,0,9),a.addMany(d(32,48),9,9,12),a.addMany(d(48,60),9,8,10),a.addMany([60,61,62,63],9,9,10),a.addMany(S,11,0,11),a.addMany(d(32,128),11,0,11),a.addMany(d(28,32),11,0,11),a.addMany(S,10,0,10),a.add(127,10,0,10),a.addMany(d(28,32),10,0,10),a.addMany(d(48,60),10,8,10),a.addMany([60,61,62,63],10,0,11),a.addMany(d(32,48),10,9,12),a.addMany(S,12,0,12),a.add(127,12,0,12),a.addMany(d(28,32),12,0,12),a.addMany(d(32,48),12,9,12),a.addMany(d(48,64),12,0,11),a.addMany(d(64,127),12,12,13),a.addMany(d(64,127),10,12,13),a.addMany(d(64,127),9,12,13),a.addMany(S,13,13,13),a.addMany(f,13,13,13),a.add(127,13,0,13),a.addMany([27,156,24,26],13,14,0),a.add(k,0,2,0),a.add(k,8,5,8),a.add(k,6,0,6),a.add(k,11,0,11),a.add(k,13,13,13),a}();class C extends h.Disposable{constructor(u=l.VT500_TRANSITION_TABLE){super(),this._transitions=u,this._parseStack={state:0,handlers:[],handlerPos:0,transition:0,chunkPos:0},this.initialState=0,this.currentState=this.initialState,this._params=new p.Params,this._params.addParam(0),this._collect=0,this.precedingCodepoint=0,this._printHandlerFb=(d,f,S)=>{},this._executeHandlerFb=d=>{},this._csiHandlerFb=(d,f)=>{},this._escHandlerFb=d=>{},this._errorHandlerFb=d=>d,this._printHandler=this._printHandlerFb,this._executeHandlers=Object.create(null),this._csiHandlers=Object.create(null),this._escHandlers=Object.create(null),this.register((0,h.toDisposable)(()=>{this._csiHandlers=Object.create(null),this._executeHandlers=Object.create(null),this._escHandlers=Object.create(null)})),this._oscParser=this.register(new v.OscParser),this._dcsParser=this.register(new b.DcsParser),this._errorHandler=this._errorHandlerFb,this.registerEscHandler({final:"\\"},()=>!0)}_identifier(u,d=[64,126]){let f=0;if(u.prefix){if(u.prefix.length>1)throw new Error("only one byte as prefix supported");if(f=u.prefix.charCodeAt(0),f&&60>f||f>63)throw new Error("prefix must be in range 0x3c .. 0x3f")}if(u.intermediates){if(u.intermediates.length>2)throw new Error("only two bytes as intermediates are supported");for(let x=0;x<u.intermediates.length;++x){const R=u.intermediates.charCodeAt(x);if(32>R||R>47)throw new Error("intermediate must be in range 0x20 .. 0x2f");f<<=8,f|=R}}if(u.final.length!==1)throw new Error("final must be a single byte");const S=u.final.charCodeAt(0);if(d[0]>S||S>d[1])throw new Error(`final must be in range ${d[0]} .. ${d[1]}`);return f<<=8,f|=S,f}identToString(u){const d=[];for(;u;)d.push(String.fromCharCode(255&u)),u>>=8;return d.reverse().join("")}setPrintHandler(u){this._printHandler=u}clearPrintHandler(){this._printHandler=this._printHandlerFb}registerEscHandler(u,d){const f=this._identifier(u,[48,126]);this._escHandlers[f]===void 0&&(this._escHandlers[f]=[]);const S=this._escHandlers[f];return S.push(d),{dispose:()=>{const x=S.indexOf(d);x!==-1&&S.splice(x,1)}}}clearEscHandler(u){this._escHandlers[this._identifier(u,[48,126])]&&delete this._escHandlers[this._identifier(u,[48,126])]}setEscHandlerFallback(u){this._escHandlerFb=u}setExecuteHandler(u,d){this._executeHandlers[u.charCodeAt(0)]=d}clearExecuteHandler(u){this._executeHandlers[u.charCodeAt(0)]&&delete this._executeHandlers[u.charCodeAt(0)]}setExecuteHandlerFallback(u){this._executeHandlerFb=u}registerCsiHandler(u,d){const f=this._identifier(u);this._csiHandlers[f]===void 0&&(this._csiHandlers[f]=[]);const S=this._csiHandlers[f];return S.push(d),{dispose:()=>{const x=S.indexOf(d);x!==-1&&S.splice(x,1)}}}clearCsiHandler(u){this._csiHandlers[this._identifier(u)]&&delete this._csiHandlers[this._identifier(u)]}setCsiHandlerFallback(u){this._csiHandlerFb=u}registerDcsHandler(u,d){return this._dcsParser.registerHandler(this._identifier(u),d)}clearDcsHandler(u){this._dcsParser.clearHandler(this._identifier(u))}setDcsHandlerFallback(u){this._dcsParser.setHandlerFallback(u)}registerOscHandler(u,d){return this._oscParser.registerHandler(u,d)}clearOscHandler(u){this._oscParser.clearHandler(u)}setOscHandlerFallback(u){this._oscParser.setHandlerFallback(u)}setErrorHandler(u){this._errorHandler=u}clearErrorHandler(){this._errorHandler=this._errorHandlerFb}reset(){this.currentState=this.initialState,this._oscParser.reset(),this._dcsParser.reset(),this._params.reset(),this._params.addParam(0),this._collect=0,this.precedingCodepoint=0,this._parseStack.state!==0&&(this._parseStack.state=2,this._parseStack.handlers=[])}_preserveStack(u,d,f,S,x){this._parseStack.state=u,this._parseStack.handlers=d,this._parseStack.handlerPos=f,this._parseStack.transition=S,this._parseStack.chunkPos=x}parse(u,d,f){let S,x=0,R=0,O=0;if(this._parseStack.state)if(this._parseStack.state===2)this._parseStack.state=0,O=this._parseStack.chunkPos+1;else{if(f===void 0||this._parseStack.state===1)throw this._parseStack.state=1,new Error("improper continuation due to previous async handler, giving up parsing");const y=this._parseStack.handlers;let N=this._parseStack.handlerPos-1;switch(this._parseStack.state){case 3:if(f===!1&&N>-1){for(;N>=0&&(S=y[N](this._params),S!==!0);N--)if(S instanceof Promise)return this._parseStack.handlerPos=N,S}this._parseStack.handlers=[];break;case 4:if(f===!1&&N>-1){for(;N>=0&&(S=y[N](),S!==!0);N--)if(S instanceof Promise)return this._parseStack.handlerPos=N,S}this._parseStack.handlers=[];break;case 6:if(x=u[this._parseStack.chunkPos],S=this._dcsParser.unhook(x!==24&&x!==26,f),S)return S;x===27&&(this._parseStack.transition|=1),this._params.reset(),this._params.addParam(0),this._collect=0;break;case 5:if(x=u[this._parseStack.chunkPos],S=this._oscParser.end(x!==24&&x!==26,f),S)return S;x===27&&(this._parseStack.transition|=1),this._params.reset(),this._params.addParam(0),this._collect=0}this._parseStack.state=0,O=this._parseStack.chunkPos+1,this.precedingCodepoint=0,this.currentState=15&this._parseStack.transition}for(let y=O;y<d;++y){switch(x=u[y],R=this._transitions.table[this.currentState<<8|(x<160?x:k)],R>>4){case 2:for(let V=y+1;;++V){if(V>=d||(x=u[V])<32||x>126&&x<k){this._printHandler(u,y,V),y=V-1;break}if(++V>=d||(x=u[V])<32||x>126&&x<k){this._printHandler(u,y,V),y=V-1;break}if(++V>=d||(x=u[V])<32||x>126&&x<k){this._printHandler(u,y,V),y=V-1;break}if(++V>=d||(x=u[V])<32||x>126&&x<k){this._printHandler(u,y,V),y=V-1;break}}break;case 3:this._executeHandlers[x]?this._executeHandlers[x]():this._executeHandlerFb(x),this.precedingCodepoint=0;break;case 0:break;case 1:if(this._errorHandler({position:y,code:x,currentState:this.currentState,collect:this._collect,params:this._params,abort:!1}).abort)return;break;case 7:const N=this._csiHandlers[this._collect<<8|x];let F=N?N.length-1:-1;for(;F>=0&&(S=N[F](this._params),S!==!0);F--)if(S instanceof Promise)return this._preserveStack(3,N,F,R,y),S;F<0&&this._csiHandlerFb(this._collect<<8|x,this._params),this.precedingCodepoint=0;break;case 8:do switch(x){case 59:this._params.addParam(0);break;case 58:this._params.addSubParam(-1);break;default:this._params.addDigit(x-48)}while(++y<d&&(x=u[y])>47&&x<60);y--;break;case 9:this._collect<<=8,this._collect|=x;break;case 10:const Y=this._escHandlers[this._collect<<8|x];let q=Y?Y.length-1:-1;for(;q>=0&&(S=Y[q](),S!==!0);q--)if(S instanceof Promise)return this._preserveStack(4,Y,q,R,y),S;q<0&&this._escHandlerFb(this._collect<<8|x),this.precedingCodepoint=0;break;case 11:this._params.reset(),this._params.addParam(0),this._collect=0;break;case 12:this._dcsParser.hook(this._collect<<8|x,this._params);break;case 13:for(let V=y+1;;++V)if(V>=d||(x=u[V])===24||x===26||x===27||x>127&&x<k){this._dcsParser.put(u,y,V),y=V-1;break}break;case 14:if(S=this._dcsParser.unhook(x!==24&&x!==26),S)return this._preserveStack(6,[],0,R,y),S;x===27&&(R|=1),this._params.reset(),this._params.addParam(0),this._collect=0,this.precedingCodepoint=0;break;case 4:this._oscParser.start();break;case 5:for(let V=y+1;;V++)if(V>=d||(x=u[V])<32||x>127&&x<k){this._oscParser.put(u,y,V),y=V-1;break}break;case 6:if(S=this._oscParser.end(x!==24&&x!==26),S)return this._preserveStack(5,[],0,R,y),S;x===27&&(R|=1),this._params.reset(),this._params.addParam(0),this._collect=0,this.precedingCodepoint=0}this.currentState=15&R}}}l.EscapeSequenceParser=C},6242:(B,l,c)=>{Object.defineProperty(l,"__esModule",{value:!0}),l.OscHandler=l.OscParser=void 0;const h=c(5770),p=c(482),v=[];l.OscParser=class{constructor(){this._state=0,this._active=v,this._id=-1,this._handlers=Object.create(null),this._handlerFb=()=>{},this._stack={paused:!1,loopPosition:0,fallThrough:!1}}registerHandler(b,w){this._handlers[b]===void 0&&(this._handlers[b]=[]);const k=this._handlers[b];return k.push(w),{dispose:()=>{const C=k.indexOf(w);C!==-1&&k.splice(C,1)}}}clearHandler(b){this._handlers[b]&&delete this._handlers[b]}setHandlerFallback(b){this._handlerFb=b}dispose(){this._handlers=Object.create(null),this._handlerFb=()=>{},this._active=v}reset(){if(this._state===2)for(let b=this._stack.paused?this._stack.loopPosition-1:this._active.length-1;b>=0;--b)this._active[b].end(!1);this._stack.paused=!1,this._active=v,this._id=-1,this._state=0}_start(){if(this._active=this._handlers[this._id]||v,this._active.length)for(let b=this._active.length-1;b>=0;b--)this._active[b].start();else this._handlerFb(this._id,"START")}_put(b,w,k){if(this._active.length)for(let C=this._active.length-1;C>=0;C--)this._active[C].put(b,w,k);else this._handlerFb(this._id,"PUT",(0,p.utf32ToString)(b,w,k))}start(){this.reset(),this._state=1}put(b,w,k){if(this._state!==3){if(this._state===1)for(;w<k;){const C=b[w++];if(C===59){this._state=2,this._start();break}if(C<48||57<C)return void(this._state=3);this._id===-1&&(this._id=0),this._id=10*this._id+C-48}this._state===2&&k-w>0&&this._put(b,w,k)}}end(b,w=!0){if(this._state!==0){if(this._state!==3)if(this._state===1&&this._start(),this._active.length){let k=!1,C=this._active.length-1,a=!1;if(this._stack.paused&&(C=this._stack.loopPosition-1,k=w,a=this._stack.fallThrough,this._stack.paused=!1),!a&&k===!1){for(;C>=0&&(k=this._active[C].end(b),k!==!0);C--)if(k instanceof Promise)return this._stack.paused=!0,this._stack.loopPosition=C,this._stack.fallThrough=!1,k;C--}for(;C>=0;C--)if(k=this._active[C].end(!1),k instanceof Promise)return this._stack.paused=!0,this._stack.loopPosition=C,this._stack.fallThrough=!0,k}else this._handlerFb(this._id,"END",b);this._active=v,this._id=-1,this._state=0}}},l.OscHandler=class{constructor(b){this._handler=b,this._data="",this._hitLimit=!1}start(){this._data="",this._hitLimit=!1}put(b,w,k){this._hitLimit||(this._data+=(0,p.utf32ToString)(b,w,k),this._data.length>h.PAYLOAD_LIMIT&&(this._data="",this._hitLimit=!0))}end(b){let w=!1;if(this._hitLimit)w=!1;else if(b&&(w=this._handler(this._data),w instanceof Promise))return w.then(k=>(this._data="",this._hitLimit=!1,k));return this._data="",this._hitLimit=!1,w}}},8742:(B,l)=>{Object.defineProperty(l,"__esModule",{value:!0}),l.Params=void 0;const c=2147483647;class h{static fromArray(v){const b=new h;if(!v.length)return b;for(let w=Array.isArray(v[0])?1:0;w<v.length;++w){const k=v[w];if(Array.isArray(k))for(let C=0;C<k.length;++C)b.addSubParam(k[C]);else b.addParam(k)}return b}constructor(v=32,b=32){if(this.maxLength=v,this.maxSubParamsLength=b,b>256)throw new Error("maxSubParamsLength must not be greater than 256");this.params=new Int32Array(v),this.length=0,this._subParams=new Int32Array(b),this._subParamsLength=0,this._subParamsIdx=new Uint16Array(v),this._rejectDigits=!1,this._rejectSubDigits=!1,this._digitIsSub=!1}clone(){const v=new h(this.maxLength,this.maxSubParamsLength);return v.params.set(this.params),v.length=this.length,v._subParams.set(this._subParams),v._subParamsLength=this._subParamsLength,v._subParamsIdx.set(this._subParamsIdx),v._rejectDigits=this._rejectDigits,v._rejectSubDigits=this._rejectSubDigits,v._digitIsSub=this._digitIsSub,v}toArray(){const v=[];for(let b=0;b<this.length;++b){v.push(this.params[b]);const w=this._subParamsIdx[b]>>8,k=255&this._subParamsIdx[b];k-w>0&&v.push(Array.prototype.slice.call(this._subParams,w,k))}return v}reset(){this.length=0,this._subParamsLength=0,this._rejectDigits=!1,this._rejectSubDigits=!1,this._digitIsSub=!1}addParam(v){if(this._digitIsSub=!1,this.length>=this.maxLength)this._rejectDigits=!0;else{if(v<-1)throw new Error("values lesser than -1 are not allowed");this._subParamsIdx[this.length]=this._subParamsLength<<8|this._subParamsLength,this.params[this.length++]=v>c?c:v}}addSubParam(v){if(this._digitIsSub=!0,this.length)if(this._rejectDigits||this._subParamsLength>=this.maxSubParamsLength)this._rejectSubDigits=!0;else{if(v<-1)throw new Error("values lesser than -1 are not allowed");this._subParams[this._subParamsLength++]=v>c?c:v,this._subParamsIdx[this.length-1]++}}hasSubParams(v){return(255&this._subParamsIdx[v])-(this._subParamsIdx[v]>>8)>0}getSubParams(v){const b=this._subParamsIdx[v]>>8,w=255&this._subParamsIdx[v];return w-b>0?this._subParams.subarray(b,w):null}getSubParamsAll(){const v={};for(let b=0;b<this.length;++b){const w=this._subParamsIdx[b]>>8,k=255&this._subParamsIdx[b];k-w>0&&(v[b]=this._subParams.slice(w,k))}return v}addDigit(v){let b;if(this._rejectDigits||!(b=this._digitIsSub?this._subParamsLength:this.length)||this._digitIsSub&&this._rejectSubDigits)return;const w=this._digitIsSub?this._subParams:this.params,k=w[b-1];w[b-1]=~k?Math.min(10*k+v,c):v}}l.Params=h},5741:(B,l)=>{Object.defineProperty(l,"__esModule",{value:!0}),l.AddonManager=void 0,l.AddonManager=class{constructor(){this._addons=[]}dispose(){for(let c=this._addons.length-1;c>=0;c--)this._addons[c].instance.dispose()}loadAddon(c,h){const p={instance:h,dispose:h.dispose,isDisposed:!1};this._addons.push(p),h.dispose=()=>this._wrappedAddonDispose(p),h.activate(c)}_wrappedAddonDispose(c){if(c.isDisposed)return;let h=-1;for(let p=0;p<this._addons.length;p++)if(this._addons[p]===c){h=p;break}if(h===-1)throw new Error("Could not dispose an addon that has not been loaded");c.isDisposed=!0,c.dispose.apply(c.instance),this._addons.splice(h,1)}}},8771:(B,l,c)=>{Object.defineProperty(l,"__esModule",{value:!0}),l.BufferApiView=void 0;const h=c(3785),p=c(511);l.BufferApiView=class{constructor(v,b){this._buffer=v,this.type=b}init(v){return this._buffer=v,this}get cursorY(){return this._buffer.y}get cursorX(){return this._buffer.x}get viewportY(){return this._buffer.ydisp}get baseY(){return this._buffer.ybase}get length(){return this._buffer.lines.length}getLine(v){const b=this._buffer.lines.get(v);if(b)return new h.BufferLineApiView(b)}getNullCell(){return new p.CellData}}},3785:(B,l,c)=>{Object.defineProperty(l,"__esModule",{value:!0}),l.BufferLineApiView=void 0;const h=c(511);l.BufferLineApiView=class{constructor(p){this._line=p}get isWrapped(){return this._line.isWrapped}get length(){return this._line.length}getCell(p,v){if(!(p<0||p>=this._line.length))return v?(this._line.loadCell(p,v),v):this._line.loadCell(p,new h.CellData)}translateToString(p,v,b){return this._line.translateToString(p,v,b)}}},8285:(B,l,c)=>{Object.defineProperty(l,"__esModule",{value:!0}),l.BufferNamespaceApi=void 0;const h=c(8771),p=c(8460),v=c(844);class b extends v.Disposable{constructor(k){super(),this._core=k,this._onBufferChange=this.register(new p.EventEmitter),this.onBufferChange=this._onBufferChange.event,this._normal=new h.BufferApiView(this._core.buffers.normal,"normal"),this._alternate=new h.BufferApiView(this._core.buffers.alt,"alternate"),this._core.buffers.onBufferActivate(()=>this._onBufferChange.fire(this.active))}get active(){if(this._core.buffers.active===this._core.buffers.normal)return this.normal;if(this._core.buffers.active===this._core.buffers.alt)return this.alternate;throw new Error("Active buffer is neither normal nor alternate")}get normal(){return this._normal.init(this._core.buffers.normal)}get alternate(){return this._alternate.init(this._core.buffers.alt)}}l.BufferNamespaceApi=b},7975:(B,l)=>{Object.defineProperty(l,"__esModule",{value:!0}),l.ParserApi=void 0,l.ParserApi=class{constructor(c){this._core=c}registerCsiHandler(c,h){return this._core.registerCsiHandler(c,p=>h(p.toArray()))}addCsiHandler(c,h){return this.registerCsiHandler(c,h)}registerDcsHandler(c,h){return this._core.registerDcsHandler(c,(p,v)=>h(p,v.toArray()))}addDcsHandler(c,h){return this.registerDcsHandler(c,h)}registerEscHandler(c,h){return this._core.registerEscHandler(c,h)}addEscHandler(c,h){return this.registerEscHandler(c,h)}registerOscHandler(c,h){return this._core.registerOscHandler(c,h)}addOscHandler(c,h){return this.registerOscHandler(c,h)}}},7090:(B,l)=>{Object.defineProperty(l,"__esModule",{value:!0}),l.UnicodeApi=void 0,l.UnicodeApi=class{constructor(c){this._core=c}register(c){this._core.unicodeService.register(c)}get versions(){return this._core.unicodeService.versions}get activeVersion(){return this._core.unicodeService.activeVersion}set activeVersion(c){this._core.unicodeService.activeVersion=c}}},744:function(B,l,c){var h=this&&this.__decorate||function(a,u,d,f){var S,x=arguments.length,R=x<3?u:f===null?f=Object.getOwnPropertyDescriptor(u,d):f;if(typeof Reflect=="object"&&typeof Reflect.decorate=="function")R=Reflect.decorate(a,u,d,f);else for(var O=a.length-1;O>=0;O--)(S=a[O])&&(R=(x<3?S(R):x>3?S(u,d,R):S(u,d))||R);return x>3&&R&&Object.defineProperty(u,d,R),R},p=this&&this.__param||function(a,u){return function(d,f){u(d,f,a)}};Object.defineProperty(l,"__esModule",{value:!0}),l.BufferService=l.MINIMUM_ROWS=l.MINIMUM_COLS=void 0;const v=c(8460),b=c(844),w=c(5295),k=c(2585);l.MINIMUM_COLS=2,l.MINIMUM_ROWS=1;let C=l.BufferService=class extends b.Disposable{get buffer(){return this.buffers.active}constructor(a){super(),this.isUserScrolling=!1,this._onResize=this.register(new v.EventEmitter),this.onResize=this._onResize.event,this._onScroll=this.register(new v.EventEmitter),this.onScroll=this._onScroll.event,this.cols=Math.max(a.rawOptions.cols||0,l.MINIMUM_COLS),this.rows=Math.max(a.rawOptions.rows||0,l.MINIMUM_ROWS),this.buffers=this.register(new w.BufferSet(a,this))}resize(a,u){this.cols=a,this.rows=u,this.buffers.resize(a,u),this._onResize.fire({cols:a,rows:u})}reset(){this.buffers.reset(),this.isUserScrolling=!1}scroll(a,u=!1){const d=this.buffer;let f;f=this._cachedBlankLine,f&&f.length===this.cols&&f.getFg(0)===a.fg&&f.getBg(0)===a.bg||(f=d.getBlankLine(a,u),this._cachedBlankLine=f),f.isWrapped=u;const S=d.ybase+d.scrollTop,x=d.ybase+d.scrollBottom;if(d.scrollTop===0){const R=d.lines.isFull;x===d.lines.length-1?R?d.lines.recycle().copyFrom(f):d.lines.push(f.clone()):d.lines.splice(x+1,0,f.clone()),R?this.isUserScrolling&&(d.ydisp=Math.max(d.ydisp-1,0)):(d.ybase++,this.isUserScrolling||d.ydisp++)}else{const R=x-S+1;d.lines.shiftElements(S+1,R-1,-1),d.lines.set(x,f.clone())}this.isUserScrolling||(d.ydisp=d.ybase),this._onScroll.fire(d.ydisp)}scrollLines(a,u,d){const f=this.buffer;if(a<0){if(f.ydisp===0)return;this.isUserScrolling=!0}else a+f.ydisp>=f.ybase&&(this.isUserScrolling=!1);const S=f.ydisp;f.ydisp=Math.max(Math.min(f.ydisp+a,f.ybase),0),S!==f.ydisp&&(u||this._onScroll.fire(f.ydisp))}};l.BufferService=C=h([p(0,k.IOptionsService)],C)},7994:(B,l)=>{Object.defineProperty(l,"__esModule",{value:!0}),l.CharsetService=void 0,l.CharsetService=class{constructor(){this.glevel=0,this._charsets=[]}reset(){this.charset=void 0,this._charsets=[],this.glevel=0}setgLevel(c){this.glevel=c,this.charset=this._charsets[c]}setgCharset(c,h){this._charsets[c]=h,this.glevel===c&&(this.charset=h)}}},1753:function(B,l,c){var h=this&&this.__decorate||function(f,S,x,R){var O,y=arguments.length,N=y<3?S:R===null?R=Object.getOwnPropertyDescriptor(S,x):R;if(typeof Reflect=="object"&&typeof Reflect.decorate=="function")N=Reflect.decorate(f,S,x,R);else for(var F=f.length-1;F>=0;F--)(O=f[F])&&(N=(y<3?O(N):y>3?O(S,x,N):O(S,x))||N);return y>3&&N&&Object.defineProperty(S,x,N),N},p=this&&this.__param||function(f,S){return function(x,R){S(x,R,f)}};Object.defineProperty(l,"__esModule",{value:!0}),l.CoreMouseService=void 0;const v=c(2585),b=c(8460),w=c(844),k={NONE:{events:0,restrict:()=>!1},X10:{events:1,restrict:f=>f.button!==4&&f.action===1&&(f.ctrl=!1,f.alt=!1,f.shift=!1,!0)},VT200:{events:19,restrict:f=>f.action!==32},DRAG:{events:23,restrict:f=>f.action!==32||f.button!==3},ANY:{events:31,restrict:f=>!0}};function C(f,S){let x=(f.ctrl?16:0)|(f.shift?4:0)|(f.alt?8:0);return f.button===4?(x|=64,x|=f.action):(x|=3&f.button,4&f.button&&(x|=64),8&f.button&&(x|=128),f.action===32?x|=32:f.action!==0||S||(x|=3)),x}const a=String.fromCharCode,u={DEFAULT:f=>{const S=[C(f,!1)+32,f.col+32,f.row+32];return S[0]>255||S[1]>255||S[2]>255?"":`\x1B[M${a(S[0])}${a(S[1])}${a(S[2])}`},SGR:f=>{const S=f.action===0&&f.button!==4?"m":"M";return`\x1B[<${C(f,!0)};${f.col};${f.row}${S}`},SGR_PIXELS:f=>{const S=f.action===0&&f.button!==4?"m":"M";return`\x1B[<${C(f,!0)};${f.x};${f.y}${S}`}};let d=l.CoreMouseService=class extends w.Disposable{constructor(f,S){super(),this._bufferService=f,this._coreService=S,this._protocols={},this._encodings={},this._activeProtocol="",this._activeEncoding="",this._lastEvent=null,this._onProtocolChange=this.register(new b.EventEmitter),this.onProtocolChange=this._onProtocolChange.event;for(const x of Object.keys(k))this.addProtocol(x,k[x]);for(const x of Object.keys(u))this.addEncoding(x,u[x]);this.reset()}addProtocol(f,S){this._protocols[f]=S}addEncoding(f,S){this._encodings[f]=S}get activeProtocol(){return this._activeProtocol}get areMouseEventsActive(){return this._protocols[this._activeProtocol].events!==0}set activeProtocol(f){if(!this._protocols[f])throw new Error(`unknown protocol "${f}"`);this._activeProtocol=f,this._onProtocolChange.fire(this._protocols[f].events)}get activeEncoding(){return this._activeEncoding}set activeEncoding(f){if(!this._encodings[f])throw new Error(`unknown encoding "${f}"`);this._activeEncoding=f}reset(){this.activeProtocol="NONE",this.activeEncoding="DEFAULT",this._lastEvent=null}triggerMouseEvent(f){if(f.col<0||f.col>=this._bufferService.cols||f.row<0||f.row>=this._bufferService.rows||f.button===4&&f.action===32||f.button===3&&f.action!==32||f.button!==4&&(f.action===2||f.action===3)||(f.col++,f.row++,f.action===32&&this._lastEvent&&this._equalEvents(this._lastEvent,f,this._activeEncoding==="SGR_PIXELS"))||!this._protocols[this._activeProtocol].restrict(f))return!1;const S=this._encodings[this._activeEncoding](f);return S&&(this._activeEncoding==="DEFAULT"?this._coreService.triggerBinaryEvent(S):this._coreService.triggerDataEvent(S,!0)),this._lastEvent=f,!0}explainEvents(f){return{down:!!(1&f),up:!!(2&f),drag:!!(4&f),move:!!(8&f),wheel:!!(16&f)}}_equalEvents(f,S,x){if(x){if(f.x!==S.x||f.y!==S.y)return!1}else if(f.col!==S.col||f.row!==S.row)return!1;return f.button===S.button&&f.action===S.action&&f.ctrl===S.ctrl&&f.alt===S.alt&&f.shift===S.shift}};l.CoreMouseService=d=h([p(0,v.IBufferService),p(1,v.ICoreService)],d)},6975:function(B,l,c){var h=this&&this.__decorate||function(d,f,S,x){var R,O=arguments.length,y=O<3?f:x===null?x=Object.getOwnPropertyDescriptor(f,S):x;if(typeof Reflect=="object"&&typeof Reflect.decorate=="function")y=Reflect.decorate(d,f,S,x);else for(var N=d.length-1;N>=0;N--)(R=d[N])&&(y=(O<3?R(y):O>3?R(f,S,y):R(f,S))||y);return O>3&&y&&Object.defineProperty(f,S,y),y},p=this&&this.__param||function(d,f){return function(S,x){f(S,x,d)}};Object.defineProperty(l,"__esModule",{value:!0}),l.CoreService=void 0;const v=c(1439),b=c(8460),w=c(844),k=c(2585),C=Object.freeze({insertMode:!1}),a=Object.freeze({applicationCursorKeys:!1,applicationKeypad:!1,bracketedPasteMode:!1,origin:!1,reverseWraparound:!1,sendFocus:!1,wraparound:!0});let u=l.CoreService=class extends w.Disposable{constructor(d,f,S){super(),this._bufferService=d,this._logService=f,this._optionsService=S,this.isCursorInitialized=!1,this.isCursorHidden=!1,this._onData=this.register(new b.EventEmitter),this.onData=this._onData.event,this._onUserInput=this.register(new b.EventEmitter),this.onUserInput=this._onUserInput.event,this._onBinary=this.register(new b.EventEmitter),this.onBinary=this._onBinary.event,this._onRequestScrollToBottom=this.register(new b.EventEmitter),this.onRequestScrollToBottom=this._onRequestScrollToBottom.event,this.modes=(0,v.clone)(C),this.decPrivateModes=(0,v.clone)(a)}reset(){this.modes=(0,v.clone)(C),this.decPrivateModes=(0,v.clone)(a)}triggerDataEvent(d,f=!1){if(this._optionsService.rawOptions.disableStdin)return;const S=this._bufferService.buffer;f&&this._optionsService.rawOptions.scrollOnUserInput&&S.ybase!==S.ydisp&&this._onRequestScrollToBottom.fire(),f&&this._onUserInput.fire(),this._logService.debug(`sending data "${d}"`,()=>d.split("").map(x=>x.charCodeAt(0))),this._onData.fire(d)}triggerBinaryEvent(d){this._optionsService.rawOptions.disableStdin||(this._logService.debug(`sending binary "${d}"`,()=>d.split("").map(f=>f.charCodeAt(0))),this._onBinary.fire(d))}};l.CoreService=u=h([p(0,k.IBufferService),p(1,k.ILogService),p(2,k.IOptionsService)],u)},9074:(B,l,c)=>{Object.defineProperty(l,"__esModule",{value:!0}),l.DecorationService=void 0;const h=c(8055),p=c(8460),v=c(844),b=c(6106);let w=0,k=0;class C extends v.Disposable{get decorations(){return this._decorations.values()}constructor(){super(),this._decorations=new b.SortedList(d=>d?.marker.line),this._onDecorationRegistered=this.register(new p.EventEmitter),this.onDecorationRegistered=this._onDecorationRegistered.event,this._onDecorationRemoved=this.register(new p.EventEmitter),this.onDecorationRemoved=this._onDecorationRemoved.event,this.register((0,v.toDisposable)(()=>this.reset()))}registerDecoration(d){if(d.marker.isDisposed)return;const f=new a(d);if(f){const S=f.marker.onDispose(()=>f.dispose());f.onDispose(()=>{f&&(this._decorations.delete(f)&&this._onDecorationRemoved.fire(f),S.dispose())}),this._decorations.insert(f),this._onDecorationRegistered.fire(f)}return f}reset(){for(const d of this._decorations.values())d.dispose();this._decorations.clear()}*getDecorationsAtCell(d,f,S){var x,R,O;let y=0,N=0;for(const F of this._decorations.getKeyIterator(f))y=(x=F.options.x)!==null&&x!==void 0?x:0,N=y+((R=F.options.width)!==null&&R!==void 0?R:1),d>=y&&d<N&&(!S||((O=F.options.layer)!==null&&O!==void 0?O:"bottom")===S)&&(yield F)}forEachDecorationAtCell(d,f,S,x){this._decorations.forEachByKey(f,R=>{var O,y,N;w=(O=R.options.x)!==null&&O!==void 0?O:0,k=w+((y=R.options.width)!==null&&y!==void 0?y:1),d>=w&&d<k&&(!S||((N=R.options.layer)!==null&&N!==void 0?N:"bottom")===S)&&x(R)})}}l.DecorationService=C;class a extends v.Disposable{get isDisposed(){return this._isDisposed}get backgroundColorRGB(){return this._cachedBg===null&&(this.options.backgroundColor?this._cachedBg=h.css.toColor(this.options.backgroundColor):this._cachedBg=void 0),this._cachedBg}get foregroundColorRGB(){return this._cachedFg===null&&(this.options.foregroundColor?this._cachedFg=h.css.toColor(this.options.foregroundColor):this._cachedFg=void 0),this._cachedFg}constructor(d){super(),this.options=d,this.onRenderEmitter=this.register(new p.EventEmitter),this.onRender=this.onRenderEmitter.event,this._onDispose=this.register(new p.EventEmitter),this.onDispose=this._onDispose.event,this._cachedBg=null,this._cachedFg=null,this.marker=d.marker,this.options.overviewRulerOptions&&!this.options.overviewRulerOptions.position&&(this.options.overviewRulerOptions.position="full")}dispose(){this._onDispose.fire(),super.dispose()}}},4348:(B,l,c)=>{Object.defineProperty(l,"__esModule",{value:!0}),l.InstantiationService=l.ServiceCollection=void 0;const h=c(2585),p=c(8343);class v{constructor(...w){this._entries=new Map;for(const[k,C]of w)this.set(k,C)}set(w,k){const C=this._entries.get(w);return this._entries.set(w,k),C}forEach(w){for(const[k,C]of this._entries.entries())w(k,C)}has(w){return this._entries.has(w)}get(w){return this._entries.get(w)}}l.ServiceCollection=v,l.InstantiationService=class{constructor(){this._services=new v,this._services.set(h.IInstantiationService,this)}setService(b,w){this._services.set(b,w)}getService(b){return this._services.get(b)}createInstance(b,...w){const k=(0,p.getServiceDependencies)(b).sort((u,d)=>u.index-d.index),C=[];for(const u of k){const d=this._services.get(u.id);if(!d)throw new Error(`[createInstance] ${b.name} depends on UNKNOWN service ${u.id}.`);C.push(d)}const a=k.length>0?k[0].index:w.length;if(w.length!==a)throw new Error(`[createInstance] First service dependency of ${b.name} at position ${a+1} conflicts with ${w.length} static arguments`);return new b(...w,...C)}}},7866:function(B,l,c){var h=this&&this.__decorate||function(a,u,d,f){var S,x=arguments.length,R=x<3?u:f===null?f=Object.getOwnPropertyDescriptor(u,d):f;if(typeof Reflect=="object"&&typeof Reflect.decorate=="function")R=Reflect.decorate(a,u,d,f);else for(var O=a.length-1;O>=0;O--)(S=a[O])&&(R=(x<3?S(R):x>3?S(u,d,R):S(u,d))||R);return x>3&&R&&Object.defineProperty(u,d,R),R},p=this&&this.__param||function(a,u){return function(d,f){u(d,f,a)}};Object.defineProperty(l,"__esModule",{value:!0}),l.traceCall=l.setTraceLogger=l.LogService=void 0;const v=c(844),b=c(2585),w={trace:b.LogLevelEnum.TRACE,debug:b.LogLevelEnum.DEBUG,info:b.LogLevelEnum.INFO,warn:b.LogLevelEnum.WARN,error:b.LogLevelEnum.ERROR,off:b.LogLevelEnum.OFF};let k,C=l.LogService=class extends v.Disposable{get logLevel(){return this._logLevel}constructor(a){super(),this._optionsService=a,this._logLevel=b.LogLevelEnum.OFF,this._updateLogLevel(),this.register(this._optionsService.onSpecificOptionChange("logLevel",()=>this._updateLogLevel())),k=this}_updateLogLevel(){this._logLevel=w[this._optionsService.rawOptions.logLevel]}_evalLazyOptionalParams(a){for(let u=0;u<a.length;u++)typeof a[u]=="function"&&(a[u]=a[u]())}_log(a,u,d){this._evalLazyOptionalParams(d),a.call(console,(this._optionsService.options.logger?"":"xterm.js: ")+u,...d)}trace(a,...u){var d,f;this._logLevel<=b.LogLevelEnum.TRACE&&this._log((f=(d=this._optionsService.options.logger)===null||d===void 0?void 0:d.trace.bind(this._optionsService.options.logger))!==null&&f!==void 0?f:console.log,a,u)}debug(a,...u){var d,f;this._logLevel<=b.LogLevelEnum.DEBUG&&this._log((f=(d=this._optionsService.options.logger)===null||d===void 0?void 0:d.debug.bind(this._optionsService.options.logger))!==null&&f!==void 0?f:console.log,a,u)}info(a,...u){var d,f;this._logLevel<=b.LogLevelEnum.INFO&&this._log((f=(d=this._optionsService.options.logger)===null||d===void 0?void 0:d.info.bind(this._optionsService.options.logger))!==null&&f!==void 0?f:console.info,a,u)}warn(a,...u){var d,f;this._logLevel<=b.LogLevelEnum.WARN&&this._log((f=(d=this._optionsService.options.logger)===null||d===void 0?void 0:d.warn.bind(this._optionsService.options.logger))!==null&&f!==void 0?f:console.warn,a,u)}error(a,...u){var d,f;this._logLevel<=b.LogLevelEnum.ERROR&&this._log((f=(d=this._optionsService.options.logger)===null||d===void 0?void 0:d.error.bind(this._optionsService.options.logger))!==null&&f!==void 0?f:console.error,a,u)}};l.LogService=C=h([p(0,b.IOptionsService)],C),l.setTraceLogger=function(a){k=a},l.traceCall=function(a,u,d){if(typeof d.value!="function")throw new Error("not supported");const f=d.value;d.value=function(...S){if(k.logLevel!==b.LogLevelEnum.TRACE)return f.apply(this,S);k.trace(`GlyphRenderer#${f.name}(${S.map(R=>JSON.stringify(R)).join(", ")})`);const x=f.apply(this,S);return k.trace(`GlyphRenderer#${f.name} return`,x),x}}},7302:(B,l,c)=>{Object.defineProperty(l,"__esModule",{value:!0}),l.OptionsService=l.DEFAULT_OPTIONS=void 0;const h=c(8460),p=c(844),v=c(6114);l.DEFAULT_OPTIONS={cols:80,rows:24,cursorBlink:!1,cursorStyle:"block",cursorWidth:1,cursorInactiveStyle:"outline",customGlyphs:!0,drawBoldTextInBrightColors:!0,fastScrollModifier:"alt",fastScrollSensitivity:5,fontFamily:"courier-new, courier, monospace",fontSize:15,fontWeight:"normal",fontWeightBold:"bold",ignoreBracketedPasteMode:!1,lineHeight:1,letterSpacing:0,linkHandler:null,logLevel:"info",logger:null,scrollback:1e3,scrollOnUserInput:!0,scrollSensitivity:1,screenReaderMode:!1,smoothScrollDuration:0,macOptionIsMeta:!1,macOptionClickForcesSelection:!1,minimumContrastRatio:1,disableStdin:!1,allowProposedApi:!1,allowTransparency:!1,tabStopWidth:8,theme:{},rightClickSelectsWord:v.isMac,windowOptions:{},windowsMode:!1,windowsPty:{},wordSeparator:" ()[]{}',\"`",altClickMovesCursor:!0,convertEol:!1,termName:"xterm",cancelEvents:!1,overviewRulerWidth:0};const b=["normal","bold","100","200","300","400","500","600","700","800","900"];class w extends p.Disposable{constructor(C){super(),this._onOptionChange=this.register(new h.EventEmitter),this.onOptionChange=this._onOptionChange.event;const a=Object.assign({},l.DEFAULT_OPTIONS);for(const u in C)if(u in a)try{const d=C[u];a[u]=this._sanitizeAndValidateOption(u,d)}catch(d){console.error(d)}this.rawOptions=a,this.options=Object.assign({},a),this._setupOptions()}onSpecificOptionChange(C,a){return this.onOptionChange(u=>{u===C&&a(this.rawOptions[C])})}onMultipleOptionChange(C,a){return this.onOptionChange(u=>{C.indexOf(u)!==-1&&a()})}_setupOptions(){const C=u=>{if(!(u in l.DEFAULT_OPTIONS))throw new Error(`No option with key "${u}"`);return this.rawOptions[u]},a=(u,d)=>{if(!(u in l.DEFAULT_OPTIONS))throw new Error(`No option with key "${u}"`);d=this._sanitizeAndValidateOption(u,d),this.rawOptions[u]!==d&&(this.rawOptions[u]=d,this._onOptionChange.fire(u))};for(const u in this.rawOptions){const d={get:C.bind(this,u),set:a.bind(this,u)};Object.defineProperty(this.options,u,d)}}_sanitizeAndValidateOption(C,a){switch(C){case"cursorStyle":if(a||(a=l.DEFAULT_OPTIONS[C]),!function(u){return u==="block"||u==="underline"||u==="bar"}(a))throw new Error(`"${a}" is not a valid value for ${C}`);break;case"wordSeparator":a||(a=l.DEFAULT_OPTIONS[C]);break;case"fontWeight":case"fontWeightBold":if(typeof a=="number"&&1<=a&&a<=1e3)break;a=b.includes(a)?a:l.DEFAULT_OPTIONS[C];break;case"cursorWidth":a=Math.floor(a);case"lineHeight":case"tabStopWidth":if(a<1)throw new Error(`${C} cannot be less than 1, value: ${a}`);break;case"minimumContrastRatio":a=Math.max(1,Math.min(21,Math.round(10*a)/10));break;case"scrollback":if((a=Math.min(a,4294967295))<0)throw new Error(`${C} cannot be less than 0, value: ${a}`);break;case"fastScrollSensitivity":case"scrollSensitivity":if(a<=0)throw new Error(`${C} cannot be less than or equal to 0, value: ${a}`);break;case"rows":case"cols":if(!a&&a!==0)throw new Error(`${C} must be numeric, value: ${a}`);break;case"windowsPty":a=a??{}}return a}}l.OptionsService=w},2660:function(B,l,c){var h=this&&this.__decorate||function(w,k,C,a){var u,d=arguments.length,f=d<3?k:a===null?a=Object.getOwnPropertyDescriptor(k,C):a;if(typeof Reflect=="object"&&typeof Reflect.decorate=="function")f=Reflect.decorate(w,k,C,a);else for(var S=w.length-1;S>=0;S--)(u=w[S])&&(f=(d<3?u(f):d>3?u(k,C,f):u(k,C))||f);return d>3&&f&&Object.defineProperty(k,C,f),f},p=this&&this.__param||function(w,k){return function(C,a){k(C,a,w)}};Object.defineProperty(l,"__esModule",{value:!0}),l.OscLinkService=void 0;const v=c(2585);let b=l.OscLinkService=class{constructor(w){this._bufferService=w,this._nextId=1,this._entriesWithId=new Map,this._dataByLinkId=new Map}registerLink(w){const k=this._bufferService.buffer;if(w.id===void 0){const S=k.addMarker(k.ybase+k.y),x={data:w,id:this._nextId++,lines:[S]};return S.onDispose(()=>this._removeMarkerFromLink(x,S)),this._dataByLinkId.set(x.id,x),x.id}const C=w,a=this._getEntryIdKey(C),u=this._entriesWithId.get(a);if(u)return this.addLineToLink(u.id,k.ybase+k.y),u.id;const d=k.addMarker(k.ybase+k.y),f={id:this._nextId++,key:this._getEntryIdKey(C),data:C,lines:[d]};return d.onDispose(()=>this._removeMarkerFromLink(f,d)),this._entriesWithId.set(f.key,f),this._dataByLinkId.set(f.id,f),f.id}addLineToLink(w,k){const C=this._dataByLinkId.get(w);if(C&&C.lines.every(a=>a.line!==k)){const a=this._bufferService.buffer.addMarker(k);C.lines.push(a),a.onDispose(()=>this._removeMarkerFromLink(C,a))}}getLinkData(w){var k;return(k=this._dataByLinkId.get(w))===null||k===void 0?void 0:k.data}_getEntryIdKey(w){return`${w.id};;${w.uri}`}_removeMarkerFromLink(w,k){const C=w.lines.indexOf(k);C!==-1&&(w.lines.splice(C,1),w.lines.length===0&&(w.data.id!==void 0&&this._entriesWithId.delete(w.key),this._dataByLinkId.delete(w.id)))}};l.OscLinkService=b=h([p(0,v.IBufferService)],b)},8343:(B,l)=>{Object.defineProperty(l,"__esModule",{value:!0}),l.createDecorator=l.getServiceDependencies=l.serviceRegistry=void 0;const c="di$target",h="di$dependencies";l.serviceRegistry=new Map,l.getServiceDependencies=function(p){return p[h]||[]},l.createDecorator=function(p){if(l.serviceRegistry.has(p))return l.serviceRegistry.get(p);const v=function(b,w,k){if(arguments.length!==3)throw new Error("@IServiceName-decorator can only be used to decorate a parameter");(function(C,a,u){a[c]===a?a[h].push({id:C,index:u}):(a[h]=[{id:C,index:u}],a[c]=a)})(v,b,k)};return v.toString=()=>p,l.serviceRegistry.set(p,v),v}},2585:(B,l,c)=>{Object.defineProperty(l,"__esModule",{value:!0}),l.IDecorationService=l.IUnicodeService=l.IOscLinkService=l.IOptionsService=l.ILogService=l.LogLevelEnum=l.IInstantiationService=l.ICharsetService=l.ICoreService=l.ICoreMouseService=l.IBufferService=void 0;const h=c(8343);var p;l.IBufferService=(0,h.createDecorator)("BufferService"),l.ICoreMouseService=(0,h.createDecorator)("CoreMouseService"),l.ICoreService=(0,h.createDecorator)("CoreService"),l.ICharsetService=(0,h.createDecorator)("CharsetService"),l.IInstantiationService=(0,h.createDecorator)("InstantiationService"),function(v){v[v.TRACE=0]="TRACE",v[v.DEBUG=1]="DEBUG",v[v.INFO=2]="INFO",v[v.WARN=3]="WARN",v[v.ERROR=4]="ERROR",v[v.OFF=5]="OFF"}(p||(l.LogLevelEnum=p={})),l.ILogService=(0,h.createDecorator)("LogService"),l.IOptionsService=(0,h.createDecorator)("OptionsService"),l.IOscLinkService=(0,h.createDecorator)("OscLinkService"),l.IUnicodeService=(0,h.createDecorator)("UnicodeService"),l.IDecorationService=(0,h.createDecorator)("DecorationService")},1480:(B,l,c)=>{Object.defineProperty(l,"__esModule",{value:!0}),l.UnicodeService=void 0;const h=c(8460),p=c(225);l.UnicodeService=class{constructor(){this._providers=Object.create(null),this._active="",this._onChange=new h.EventEmitter,this.onChange=this._onChange.event;const v=new p.UnicodeV6;this.register(v),this._active=v.version,this._activeProvider=v}dispose(){this._onChange.dispose()}get versions(){return Object.keys(this._providers)}get activeVersion(){return this._active}set activeVersion(v){if(!this._providers[v])throw new Error(`unknown Unicode version "${v}"`);this._active=v,this._activeProvider=this._providers[v],this._onChange.fire(v)}register(v){this._providers[v.version]=v}wcwidth(v){return this._activeProvider.wcwidth(v)}getStringCellWidth(v){let b=0;const w=v.length;for(let k=0;k<w;++k){let C=v.charCodeAt(k);if(55296<=C&&C<=56319){if(++k>=w)return b+this.wcwidth(C);const a=v.charCodeAt(k);56320<=a&&a<=57343?C=1024*(C-55296)+a-56320+65536:b+=this.wcwidth(a)}b+=this.wcwidth(C)}return b}}}},g={};function E(B){var l=g[B];if(l!==void 0)return l.exports;var c=g[B]={exports:{}};return _[B].call(c.exports,c,c.exports,E),c.exports}var T={};return(()=>{var B=T;Object.defineProperty(B,"__esModule",{value:!0}),B.Terminal=void 0;const l=E(9042),c=E(3236),h=E(844),p=E(5741),v=E(8285),b=E(7975),w=E(7090),k=["cols","rows"];class C extends h.Disposable{constructor(u){super(),this._core=this.register(new c.Terminal(u)),this._addonManager=this.register(new p.AddonManager),this._publicOptions=Object.assign({},this._core.options);const d=S=>this._core.options[S],f=(S,x)=>{this._checkReadonlyOptions(S),this._core.options[S]=x};for(const S in this._core.options){const x={get:d.bind(this,S),set:f.bind(this,S)};Object.defineProperty(this._publicOptions,S,x)}}_checkReadonlyOptions(u){if(k.includes(u))throw new Error(`Option "${u}" can only be set in the constructor`)}_checkProposedApi(){if(!this._core.optionsService.rawOptions.allowProposedApi)throw new Error("You must set the allowProposedApi option to true to use proposed API")}get onBell(){return this._core.onBell}get onBinary(){return this._core.onBinary}get onCursorMove(){return this._core.onCursorMove}get onData(){return this._core.onData}get onKey(){return this._core.onKey}get onLineFeed(){return this._core.onLineFeed}get onRender(){return this._core.onRender}get onResize(){return this._core.onResize}get onScroll(){return this._core.onScroll}get onSelectionChange(){return this._core.onSelectionChange}get onTitleChange(){return this._core.onTitleChange}get onWriteParsed(){return this._core.onWriteParsed}get element(){return this._core.element}get parser(){return this._parser||(this._parser=new b.ParserApi(this._core)),this._parser}get unicode(){return this._checkProposedApi(),new w.UnicodeApi(this._core)}get textarea(){return this._core.textarea}get rows(){return this._core.rows}get cols(){return this._core.cols}get buffer(){return this._buffer||(this._buffer=this.register(new v.BufferNamespaceApi(this._core))),this._buffer}get markers(){return this._checkProposedApi(),this._core.markers}get modes(){const u=this._core.coreService.decPrivateModes;let d="none";switch(this._core.coreMouseService.activeProtocol){case"X10":d="x10";break;case"VT200":d="vt200";break;case"DRAG":d="drag";break;case"ANY":d="any"}return{applicationCursorKeysMode:u.applicationCursorKeys,applicationKeypadMode:u.applicationKeypad,bracketedPasteMode:u.bracketedPasteMode,insertMode:this._core.coreService.modes.insertMode,mouseTrackingMode:d,originMode:u.origin,reverseWraparoundMode:u.reverseWraparound,sendFocusMode:u.sendFocus,wraparoundMode:u.wraparound}}get options(){return this._publicOptions}set options(u){for(const d in u)this._publicOptions[d]=u[d]}blur(){this._core.blur()}focus(){this._core.focus()}resize(u,d){this._verifyIntegers(u,d),this._core.resize(u,d)}open(u){this._core.open(u)}attachCustomKeyEventHandler(u){this._core.attachCustomKeyEventHandler(u)}registerLinkProvider(u){return this._core.registerLinkProvider(u)}registerCharacterJoiner(u){return this._checkProposedApi(),this._core.registerCharacterJoiner(u)}deregisterCharacterJoiner(u){this._checkProposedApi(),this._core.deregisterCharacterJoiner(u)}registerMarker(u=0){return this._verifyIntegers(u),this._core.registerMarker(u)}registerDecoration(u){var d,f,S;return this._checkProposedApi(),this._verifyPositiveIntegers((d=u.x)!==null&&d!==void 0?d:0,(f=u.width)!==null&&f!==void 0?f:0,(S=u.height)!==null&&S!==void 0?S:0),this._core.registerDecoration(u)}hasSelection(){return this._core.hasSelection()}select(u,d,f){this._verifyIntegers(u,d,f),this._core.select(u,d,f)}getSelection(){return this._core.getSelection()}getSelectionPosition(){return this._core.getSelectionPosition()}clearSelection(){this._core.clearSelection()}selectAll(){this._core.selectAll()}selectLines(u,d){this._verifyIntegers(u,d),this._core.selectLines(u,d)}dispose(){super.dispose()}scrollLines(u){this._verifyIntegers(u),this._core.scrollLines(u)}scrollPages(u){this._verifyIntegers(u),this._core.scrollPages(u)}scrollToTop(){this._core.scrollToTop()}scrollToBottom(){this._core.scrollToBottom()}scrollToLine(u){this._verifyIntegers(u),this._core.scrollToLine(u)}clear(){this._core.clear()}write(u,d){this._core.write(u,d)}writeln(u,d){this._core.write(u),this._core.write(`\r
-`,d)}paste(u){this._core.paste(u)}refresh(u,d){this._verifyIntegers(u,d),this._core.refresh(u,d)}reset(){this._core.reset()}clearTextureAtlas(){this._core.clearTextureAtlas()}loadAddon(u){this._addonManager.loadAddon(this,u)}static get strings(){return l}_verifyIntegers(...u){for(const d of u)if(d===1/0||isNaN(d)||d%1!=0)throw new Error("This API only accepts integers")}_verifyPositiveIntegers(...u){for(const d of u)if(d&&(d===1/0||isNaN(d)||d%1!=0||d<0))throw new Error("This API only accepts positive integers")}}B.Terminal=C})(),T})())}(_u)),_u.exports}var C_=ix(),vu={exports:{}},w_;function rx(){return w_||(w_=1,function(s,o){(function(_,g){s.exports=g()})(self,()=>(()=>{var _={};return(()=>{var g=_;Object.defineProperty(g,"__esModule",{value:!0}),g.FitAddon=void 0,g.FitAddon=class{activate(E){this._terminal=E}dispose(){}fit(){const E=this.proposeDimensions();if(!E||!this._terminal||isNaN(E.cols)||isNaN(E.rows))return;const T=this._terminal._core;this._terminal.rows===E.rows&&this._terminal.cols===E.cols||(T._renderService.clear(),this._terminal.resize(E.cols,E.rows))}proposeDimensions(){if(!this._terminal||!this._terminal.element||!this._terminal.element.parentElement)return;const E=this._terminal._core,T=E._renderService.dimensions;if(T.css.cell.width===0||T.css.cell.height===0)return;const B=this._terminal.options.scrollback===0?0:E.viewport.scrollBarWidth,l=window.getComputedStyle(this._terminal.element.parentElement),c=parseInt(l.getPropertyValue("height")),h=Math.max(0,parseInt(l.getPropertyValue("width"))),p=window.getComputedStyle(this._terminal.element),v=c-(parseInt(p.getPropertyValue("padding-top"))+parseInt(p.getPropertyValue("padding-bottom"))),b=h-(parseInt(p.getPropertyValue("padding-right"))+parseInt(p.getPropertyValue("padding-left")))-B;return{cols:Math.max(2,Math.floor(b/T.css.cell.width)),rows:Math.max(1,Math.floor(v/T.css.cell.height))}}}})(),_})())}(vu)),vu.exports}var E_=rx();const sx=({command:s,stepId:o,onDone:_,className:g})=>{const E=U.useRef(null),T=U.useRef(new C_.Terminal),B=U.useRef(new WebSocket("ws://localhost:24050/api/shell")),l=U.useRef(new E_.FitAddon),[c,h]=U.useState(!1),[p,v]=U.useState("Connecting..."),[b,w]=U.useState(!1);U.useEffect(()=>{if(!E.current)return;T.current=new C_.Terminal({cursorBlink:!0,fontSize:14,fontFamily:'"JetBrains Mono", "Fira Code", "Monaco", "Menlo", "Ubuntu Mono", monospace',theme:{background:"#0a0a0a",foreground:"#ffffff",cursor:"#3b82f6",cursorAccent:"#1e40af",black:"#000000",red:"#ef4444",green:"#22c55e",yellow:"#eab308",blue:"#3b82f6",magenta:"#a855f7",cyan:"#06b6d4",white:"#ffffff",brightBlack:"#374151",brightRed:"#f87171",brightGreen:"#4ade80",brightYellow:"#facc15",brightBlue:"#60a5fa",brightMagenta:"#c084fc",brightCyan:"#22d3ee",brightWhite:"#f9fafb"},cols:120,rows:30,scrollback:1e3,allowTransparency:!0}),l.current=new E_.FitAddon,T.current.loadAddon(l.current),T.current.open(E.current),l.current&&l.current.fit();const u=new URLSearchParams;o&&u.append("step_id",o.toString());const d=`ws://localhost:24050/api/shell${u.toString()?"?"+u.toString():""}`;console.log("[Terminal] Connecting to:",d),B.current=new WebSocket(d),B.current.onopen=()=>{console.log("[Terminal] WebSocket connected"),h(!0),v("Connected"),T.current?.write(`\x1B[32m✓ Connected to shell\x1B[0m\r
+`,d)}paste(u){this._core.paste(u)}refresh(u,d){this._verifyIntegers(u,d),this._core.refresh(u,d)}reset(){this._core.reset()}clearTextureAtlas(){this._core.clearTextureAtlas()}loadAddon(u){this._addonManager.loadAddon(this,u)}static get strings(){return l}_verifyIntegers(...u){for(const d of u)if(d===1/0||isNaN(d)||d%1!=0)throw new Error("This API only accepts integers")}_verifyPositiveIntegers(...u){for(const d of u)if(d&&(d===1/0||isNaN(d)||d%1!=0||d<0))throw new Error("This API only accepts positive integers")}}B.Terminal=C})(),T})())}(_u)),_u.exports}var C_=ix(),vu={exports:{}},w_;function rx(){return w_||(w_=1,function(s,o){(function(_,g){s.exports=g()})(self,()=>(()=>{var _={};return(()=>{var g=_;Object.defineProperty(g,"__esModule",{value:!0}),g.FitAddon=void 0,g.FitAddon=class{activate(E){this._terminal=E}dispose(){}fit(){const E=this.proposeDimensions();if(!E||!this._terminal||isNaN(E.cols)||isNaN(E.rows))return;const T=this._terminal._core;this._terminal.rows===E.rows&&this._terminal.cols===E.cols||(T._renderService.clear(),this._terminal.resize(E.cols,E.rows))}proposeDimensions(){if(!this._terminal||!this._terminal.element||!this._terminal.element.parentElement)return;const E=this._terminal._core,T=E._renderService.dimensions;if(T.css.cell.width===0||T.css.cell.height===0)return;const B=this._terminal.options.scrollback===0?0:E.viewport.scrollBarWidth,l=window.getComputedStyle(this._terminal.element.parentElement),c=parseInt(l.getPropertyValue("height")),h=Math.max(0,parseInt(l.getPropertyValue("width"))),p=window.getComputedStyle(this._terminal.element),v=c-(parseInt(p.getPropertyValue("padding-top"))+parseInt(p.getPropertyValue("padding-bottom"))),b=h-(parseInt(p.getPropertyValue("padding-right"))+parseInt(p.getPropertyValue("padding-left")))-B;return{cols:Math.max(2,Math.floor(b/T.css.cell.width)),rows:Math.max(1,Math.floor(v/T.css.cell.height))}}}})(),_})())}(vu)),vu.exports}var E_=rx();const sx=({command:s,stepId:o,onDone:_,className:g})=>{const E=U.useRef(null),T=U.useRef(new C_.Terminal),B=U.useRef(null),l=U.useRef(new E_.FitAddon),[c,h]=U.useState(!1),[p,v]=U.useState("Connecting..."),[b,w]=U.useState(!1);U.useEffect(()=>{if(!E.current)return;T.current=new C_.Terminal({cursorBlink:!0,fontSize:14,fontFamily:'"JetBrains Mono", "Fira Code", "Monaco", "Menlo", "Ubuntu Mono", monospace',theme:{background:"#0a0a0a",foreground:"#ffffff",cursor:"#3b82f6",cursorAccent:"#1e40af",black:"#000000",red:"#ef4444",green:"#22c55e",yellow:"#eab308",blue:"#3b82f6",magenta:"#a855f7",cyan:"#06b6d4",white:"#ffffff",brightBlack:"#374151",brightRed:"#f87171",brightGreen:"#4ade80",brightYellow:"#facc15",brightBlue:"#60a5fa",brightMagenta:"#c084fc",brightCyan:"#22d3ee",brightWhite:"#f9fafb"},cols:120,rows:30,scrollback:1e3,allowTransparency:!0}),l.current=new E_.FitAddon,T.current.loadAddon(l.current),T.current.open(E.current),l.current&&l.current.fit();const u=new URLSearchParams;o&&u.append("step_id",o.toString());const d=shellSocketUrl(u.toString());console.log("[Terminal] Connecting to:",d),B.current=new WebSocket(d),B.current.onopen=()=>{console.log("[Terminal] WebSocket connected"),h(!0),v("Connected"),T.current?.write(`\x1B[32m✓ Connected to shell\x1B[0m\r
 `),o&&T.current?.write(`\x1B[36m→ Environment variables loaded from step ${o}\x1B[0m\r
 `),s&&T.current?.write(`\x1B[36m→ Executing: ${s}\x1B[0m\r
 `)},B.current.onmessage=x=>{try{const R=atob(x.data);T.current?.write(R)}catch(R){console.error("[Terminal] Error decoding message:",R)}},B.current.onerror=x=>{console.error("[Terminal] WebSocket error:",x),v("Connection Error"),T.current?.write(`\r
@@ -8,6 +8,7 @@ require (
 	github.com/labstack/echo/v4 v4.13.4
 	github.com/mattn/go-sqlite3 v1.14.24
 	gopkg.in/yaml.v2 v2.4.0
+	mvdan.cc/sh/v3 v3.12.0
 )
 
 require (
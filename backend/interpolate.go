@@ -0,0 +1,167 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// Variable interpolation
+//
+// Commands, tmux session names and notes reference variables with:
+//
+//	${VAR}            the value of VAR; an error if VAR is undefined
+//	${VAR:-default}   the value of VAR, or default if VAR is undefined or empty
+//	${VAR:?message}   the value of VAR; an error with message if VAR is undefined or empty
+//	$$                a literal $, e.g. $${HOME} is passed to the shell as ${HOME}
+//
+// Variables are looked up in the flow (and run) variables. The server's
+// environment is not consulted, so credentials it holds can't end up in
+// commands, audit records or logs; only HOME and USER fall back to the values
+// commands are given. Anything else that starts with $, such as $VAR, $(cmd)
+// or ${VAR%suffix}, is left for the shell; since flow variables are exported
+// to the command environment, $VAR keeps working in commands.
+
+// InterpolationError lists the variable references that could not be resolved
+type InterpolationError struct {
+	Undefined []string // Names of undefined variables referenced as ${VAR}
+	Messages  []string // Messages of failed ${VAR:?message} references
+}
+
+func (e *InterpolationError) Error() string {
+	var parts []string
+	if len(e.Undefined) > 0 {
+		parts = append(parts, "undefined variables: "+strings.Join(e.Undefined, ", "))
+	}
+	parts = append(parts, e.Messages...)
+	return strings.Join(parts, "; ")
+}
+
+// interpolate expands the variable references in input. On error the returned
+// string still has every resolvable reference expanded, with the unresolved
+// ones left as written.
+func interpolate(input string, variables map[string]string) (string, error) {
+	var ierr InterpolationError
+	output := interpolateInto(input, variables, &ierr)
+	if len(ierr.Undefined) == 0 && len(ierr.Messages) == 0 {
+		return output, nil
+	}
+	sort.Strings(ierr.Undefined)
+	return output, &ierr
+}
+
+func interpolateInto(input string, variables map[string]string, ierr *InterpolationError) string {
+	if !strings.Contains(input, "$") {
+		return input
+	}
+
+	var out strings.Builder
+	for i := 0; i < len(input); {
+		if input[i] != '$' || i+1 >= len(input) {
+			out.WriteByte(input[i])
+			i++
+			continue
+		}
+
+		switch input[i+1] {
+		case '$':
+			out.WriteByte('$')
+			i += 2
+			continue
+		case '{':
+			end := matchingBrace(input, i+2)
+			if end < 0 {
+				break
+			}
+			reference := input[i : end+1]
+			out.WriteString(expandReference(reference, input[i+2:end], variables, ierr))
+			i = end + 1
+			continue
+		}
+
+		out.WriteByte('$')
+		i++
+	}
+	return out.String()
+}
+
+// matchingBrace returns the index of the } closing a ${ whose body starts at
+// start, accounting for nested ${...}, or -1 if there is none
+func matchingBrace(input string, start int) int {
+	depth := 0
+	for i := start; i < len(input); i++ {
+		switch {
+		case input[i] == '$' && i+1 < len(input) && input[i+1] == '{':
+			depth++
+			i++
+		case input[i] == '}':
+			if depth == 0 {
+				return i
+			}
+			depth--
+		}
+	}
+	return -1
+}
+
+// expandReference resolves a single ${...} reference with the given body
+func expandReference(reference, body string, variables map[string]string, ierr *InterpolationError) string {
+	name, op, arg := body, "", ""
+	if i := strings.Index(body, ":"); i >= 0 {
+		name = body[:i]
+		if rest := body[i+1:]; len(rest) > 0 && (rest[0] == '-' || rest[0] == '?') {
+			op, arg = rest[:1], rest[1:]
+		} else {
+			// Some other shell expansion, e.g. ${VAR:0:3}
+			return reference
+		}
+	}
+	if !variableNamePattern.MatchString(name) {
+		// Not one of ours, e.g. ${#VAR}, ${VAR%suffix} or ${1}
+		return reference
+	}
+
+	value, defined := lookupVariable(name, variables)
+	switch op {
+	case "-":
+		if !defined || value == "" {
+			return interpolateInto(arg, variables, ierr)
+		}
+	case "?":
+		if !defined || value == "" {
+			message := interpolateInto(arg, variables, ierr)
+			if message == "" {
+				message = "is required"
+			}
+			ierr.Messages = append(ierr.Messages, fmt.Sprintf("%s: %s", name, message))
+			return reference
+		}
+	default:
+		if !defined {
+			for _, undefined := range ierr.Undefined {
+				if undefined == name {
+					return reference
+				}
+			}
+			ierr.Undefined = append(ierr.Undefined, name)
+			return reference
+		}
+	}
+	return value
+}
+
+// lookupVariable resolves a variable from the flow variables, falling back to
+// HOME and USER as set up by setupCommandEnvironment
+func lookupVariable(name string, variables map[string]string) (string, bool) {
+	if value, ok := variables[name]; ok {
+		return value, true
+	}
+	switch name {
+	case "HOME":
+		return getUserHomeDir(), true
+	case "USER":
+		return os.LookupEnv("USER")
+	}
+	return "", false
+}
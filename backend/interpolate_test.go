@@ -0,0 +1,139 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestInterpolate(t *testing.T) {
+	variables := map[string]string{"NAME": "api", "EMPTY": "", "PORT": "8080"}
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"no references", "no references"},
+		{"deploy ${NAME}", "deploy api"},
+		{"${NAME}:${PORT}", "api:8080"},
+		{"${EMPTY}", ""},
+		{"${NAME:-web}", "api"},
+		{"${EMPTY:-web}", "web"},
+		{"${MISSING:-web}", "web"},
+		{"${MISSING:-}", ""},
+		{"${MISSING:-${NAME}-${PORT}}", "api-8080"},
+		{"${MISSING:-${OTHER:-${NAME}}}", "api"},
+		{"${NAME:?name is required}", "api"},
+		{"${EMPTY:-a}${EMPTY:-b}", "ab"},
+		{"$$", "$"},
+		{"$${NAME}", "${NAME}"},
+		{"$$$", "$$"},
+		{"price: 5$", "price: 5$"},
+		{"$NAME $(whoami) $1", "$NAME $(whoami) $1"},
+		{"${NAME%.txt} ${#NAME} ${NAME:0:2} ${1} ${NAME/a/b}", "${NAME%.txt} ${#NAME} ${NAME:0:2} ${1} ${NAME/a/b}"},
+		{"for f in *; do echo $${f}; done", "for f in *; do echo ${f}; done"},
+		// Unterminated references are left as written
+		{"echo ${NAME", "echo ${NAME"},
+		{"echo ${MISSING:-${NAME}", "echo ${MISSING:-api"},
+		{"${", "${"},
+	}
+
+	for _, tt := range tests {
+		got, err := interpolate(tt.input, variables)
+		if err != nil {
+			t.Errorf("interpolate(%q): %v", tt.input, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("interpolate(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestInterpolateErrors(t *testing.T) {
+	variables := map[string]string{"NAME": "api", "EMPTY": ""}
+
+	tests := []struct {
+		input     string
+		output    string
+		undefined []string
+		messages  []string
+		err       string
+	}{
+		{
+			input:     "deploy ${NAME} to ${TARGET}",
+			output:    "deploy api to ${TARGET}",
+			undefined: []string{"TARGET"},
+			err:       "undefined variables: TARGET",
+		},
+		{
+			input:     "${B} ${A} ${B}",
+			output:    "${B} ${A} ${B}",
+			undefined: []string{"A", "B"},
+			err:       "undefined variables: A, B",
+		},
+		{
+			input:    "${EMPTY:?set EMPTY first}",
+			output:   "${EMPTY:?set EMPTY first}",
+			messages: []string{"EMPTY: set EMPTY first"},
+			err:      "EMPTY: set EMPTY first",
+		},
+		{
+			input:    "${MISSING:?}",
+			output:   "${MISSING:?}",
+			messages: []string{"MISSING: is required"},
+			err:      "MISSING: is required",
+		},
+		{
+			input:    "${MISSING:?no ${NAME} target}",
+			output:   "${MISSING:?no ${NAME} target}",
+			messages: []string{"MISSING: no api target"},
+			err:      "MISSING: no api target",
+		},
+		{
+			input:     "${MISSING:-${OTHER}} ${TOKEN:?token needed}",
+			output:    "${OTHER} ${TOKEN:?token needed}",
+			undefined: []string{"OTHER"},
+			messages:  []string{"TOKEN: token needed"},
+			err:       "undefined variables: OTHER; TOKEN: token needed",
+		},
+	}
+
+	for _, tt := range tests {
+		got, err := interpolate(tt.input, variables)
+		ierr, ok := err.(*InterpolationError)
+		if !ok {
+			t.Errorf("interpolate(%q): got error %v, want an *InterpolationError", tt.input, err)
+			continue
+		}
+		if got != tt.output {
+			t.Errorf("interpolate(%q) = %q, want %q", tt.input, got, tt.output)
+		}
+		if !reflect.DeepEqual(ierr.Undefined, tt.undefined) || !reflect.DeepEqual(ierr.Messages, tt.messages) {
+			t.Errorf("interpolate(%q): undefined %q, messages %q, want %q, %q", tt.input, ierr.Undefined, ierr.Messages, tt.undefined, tt.messages)
+		}
+		if ierr.Error() != tt.err {
+			t.Errorf("interpolate(%q): error %q, want %q", tt.input, ierr.Error(), tt.err)
+		}
+	}
+}
+
+func TestInterpolateServerEnvironment(t *testing.T) {
+	t.Setenv("HOME", "/home/deploy")
+	t.Setenv("USER", "deploy")
+	t.Setenv("DEVTOOL_TEST_SECRET", "hunter2")
+
+	got, err := interpolate("${HOME} ${USER}", nil)
+	if err != nil || got != "/home/deploy deploy" {
+		t.Errorf("got %q, %v, want HOME and USER from the environment", got, err)
+	}
+
+	got, err = interpolate("${HOME}", map[string]string{"HOME": "/srv"})
+	if err != nil || got != "/srv" {
+		t.Errorf("got %q, %v, want flow variables to take precedence", got, err)
+	}
+
+	got, err = interpolate("echo ${DEVTOOL_TEST_SECRET}", nil)
+	if err == nil || got != "echo ${DEVTOOL_TEST_SECRET}" {
+		t.Errorf("got %q, %v, want other server variables to be undefined", got, err)
+	}
+}
@@ -0,0 +1,332 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+// Logging
+//
+// Everything is logged through log/slog as configured by the logging
+// section: level, text or JSON format, and stdout, a file under
+// data.logs_dir, or both. The stdlib log package and Echo's logger are
+// bridged into the same handler: Echo's lines keep their level and log.Printf
+// calls are logged at info, so errors and warnings are logged with
+// slog.Error and slog.Warn. Secret values are masked in everything written.
+
+// Logging outputs
+const (
+	LogOutputStdout = "stdout"
+	LogOutputFile   = "file"
+	LogOutputBoth   = "both"
+)
+
+// defaultLogFile is the log file name under data.logs_dir when logging.file_path is empty
+const defaultLogFile = "dev-tool.log"
+
+// backupTimeFormat is the timestamp in the names of rotated log files
+const backupTimeFormat = "2006-01-02T15-04-05.000"
+
+// logger is the configured logger, set up by initLogging
+var logger = slog.Default()
+
+// initLogging sets up the logger and routes the log package and Echo through it
+func initLogging(cfg LoggingConfig, logsDir string) error {
+	level, err := parseLogLevel(cfg.Level)
+	if err != nil {
+		return err
+	}
+
+	var outputs []io.Writer
+	switch cfg.Output {
+	case "", LogOutputStdout:
+		outputs = append(outputs, os.Stdout)
+	case LogOutputFile, LogOutputBoth:
+		file, err := openRotatingFile(logFilePath(cfg.FilePath, logsDir), cfg.MaxSizeMB, cfg.MaxBackups, cfg.MaxAgeDays)
+		if err != nil {
+			return err
+		}
+		outputs = append(outputs, file)
+		if cfg.Output == LogOutputBoth {
+			outputs = append(outputs, os.Stdout)
+		}
+	default:
+		return fmt.Errorf("invalid logging output %q: must be %s, %s or %s", cfg.Output, LogOutputStdout, LogOutputFile, LogOutputBoth)
+	}
+	sink := &maskingWriter{w: io.MultiWriter(outputs...)}
+
+	options := &slog.HandlerOptions{Level: level}
+	var handler slog.Handler
+	switch cfg.Format {
+	case "", "text":
+		handler = slog.NewTextHandler(sink, options)
+	case "json":
+		handler = slog.NewJSONHandler(sink, options)
+	default:
+		return fmt.Errorf("invalid logging format %q: must be text or json", cfg.Format)
+	}
+
+	logger = slog.New(handler)
+	slog.SetDefault(logger)
+
+	// SetDefault sends the log package to the handler at info level; bridge
+	// it instead so its messages get a level of their own
+	log.SetFlags(0)
+	log.SetOutput(&logBridge{logger: logger})
+	return nil
+}
+
+// parseLogLevel parses logging.level
+func parseLogLevel(level string) (slog.Level, error) {
+	switch strings.ToLower(level) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "", "info":
+		return slog.LevelInfo, nil
+	case "warn", "warning":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	}
+	return slog.LevelInfo, fmt.Errorf("invalid logging level %q: must be debug, info, warn or error", level)
+}
+
+// logFilePath returns where the log file goes: logging.file_path, which is
+// relative to data.logs_dir, or dev-tool.log there if it is empty
+func logFilePath(filePath, logsDir string) string {
+	if filePath == "" {
+		return filepath.Join(logsDir, defaultLogFile)
+	}
+	if !filepath.IsAbs(filePath) {
+		return filepath.Join(logsDir, filePath)
+	}
+	return filePath
+}
+
+// logBridge is the output of the log package and Echo's logger. It logs
+// each line through the logger at the level of its Echo prefix, or at info.
+type logBridge struct {
+	logger *slog.Logger
+}
+
+func (b *logBridge) Write(p []byte) (int, error) {
+	message := strings.TrimRight(string(p), "\n")
+	level, message := bridgeLevel(message)
+	b.logger.Log(context.Background(), level, secrets.mask(message))
+	return len(p), nil
+}
+
+// bridgeLevel returns the level of a message written through the log
+// package and the message without its level prefix. Echo's lines start with
+// their level; everything else is info.
+func bridgeLevel(message string) (slog.Level, string) {
+	for prefix, level := range map[string]slog.Level{
+		"DEBUG ": slog.LevelDebug,
+		"INFO ":  slog.LevelInfo,
+		"WARN ":  slog.LevelWarn,
+		"ERROR ": slog.LevelError,
+	} {
+		if strings.HasPrefix(message, prefix) {
+			return level, strings.TrimPrefix(message, prefix)
+		}
+	}
+	return slog.LevelInfo, message
+}
+
+// setupEchoLogging routes Echo's logger and request log through the logger
+func setupEchoLogging(e *echo.Echo) {
+	e.HideBanner = true
+	e.Logger.SetHeader("${level}")
+	e.Logger.SetOutput(&logBridge{logger: logger})
+
+	e.Use(middleware.RequestLoggerWithConfig(middleware.RequestLoggerConfig{
+		LogMethod:   true,
+		LogURI:      true,
+		LogStatus:   true,
+		LogLatency:  true,
+		LogRemoteIP: true,
+		LogError:    true,
+		HandleError: true,
+		LogValuesFunc: func(c echo.Context, v middleware.RequestLoggerValues) error {
+			level := slog.LevelInfo
+			if v.Status >= 500 {
+				level = slog.LevelError
+			}
+			attrs := []slog.Attr{
+				slog.String("method", v.Method),
+				slog.String("uri", v.URI),
+				slog.Int("status", v.Status),
+				slog.Duration("latency", v.Latency),
+				slog.String("remote_ip", v.RemoteIP),
+			}
+			if v.Error != nil {
+				attrs = append(attrs, slog.String("error", v.Error.Error()))
+			}
+			logger.LogAttrs(context.Background(), level, "request", attrs...)
+			return nil
+		},
+	}))
+}
+
+// rotatingFile is a log file that is rotated when it reaches its size limit
+// or was started longer ago than the age limit. Rotated files are
+// renamed with the time of rotation, e.g. dev-tool-2024-01-02T15-04-05.000.log;
+// those older than the age limit are deleted, as are all but the newest
+// maxBackups. A zero limit doesn't apply.
+type rotatingFile struct {
+	path       string
+	maxSize    int64
+	maxBackups int
+	maxAge     time.Duration
+
+	mu        sync.Mutex
+	file      *os.File
+	size      int64
+	startedAt time.Time
+}
+
+// openRotatingFile opens a log file for appending, creating its directory if needed
+func openRotatingFile(path string, maxSizeMB, maxBackups, maxAgeDays int) (*rotatingFile, error) {
+	f := &rotatingFile{
+		path:       path,
+		maxSize:    int64(maxSizeMB) * 1024 * 1024,
+		maxBackups: maxBackups,
+		maxAge:     time.Duration(maxAgeDays) * 24 * time.Hour,
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create log directory: %v", err)
+	}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	f.removeOldBackups()
+	return f, nil
+}
+
+// open opens the log file for appending. The file was started when the newest
+// backup was rotated; without backups, a file left by an earlier run is taken
+// to have been started when it was last written, so restarts don't postpone
+// rotating it by age.
+func (f *rotatingFile) open() error {
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open log file %s: %v", f.path, err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("failed to stat log file %s: %v", f.path, err)
+	}
+	f.file = file
+	f.size = info.Size()
+	f.startedAt = time.Now()
+	if backups := f.backups(); len(backups) > 0 {
+		f.startedAt = backups[0].rotatedAt
+	} else if f.size > 0 {
+		f.startedAt = info.ModTime()
+	}
+	return nil
+}
+
+func (f *rotatingFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.size > 0 && f.due(int64(len(p))) {
+		if err := f.rotate(); err != nil {
+			// Keep writing to the current file rather than losing the message
+			fmt.Fprintf(os.Stderr, "Failed to rotate log file: %v\n", err)
+		}
+	}
+
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+// due reports whether the file must be rotated before writing n more bytes
+func (f *rotatingFile) due(n int64) bool {
+	if f.maxSize > 0 && f.size+n > f.maxSize {
+		return true
+	}
+	return f.maxAge > 0 && time.Since(f.startedAt) > f.maxAge
+}
+
+// rotate renames the log file to a backup and starts a new one
+func (f *rotatingFile) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return err
+	}
+	ext := filepath.Ext(f.path)
+	backup := fmt.Sprintf("%s-%s%s", strings.TrimSuffix(f.path, ext), time.Now().Format(backupTimeFormat), ext)
+	renameErr := os.Rename(f.path, backup)
+	if err := f.open(); err != nil {
+		return err
+	}
+	if renameErr != nil {
+		return renameErr
+	}
+	f.removeOldBackups()
+	return nil
+}
+
+// removeOldBackups deletes the rotated files beyond the backup and age limits
+func (f *rotatingFile) removeOldBackups() {
+	if f.maxBackups <= 0 && f.maxAge <= 0 {
+		return
+	}
+
+	for i, b := range f.backups() {
+		tooMany := f.maxBackups > 0 && i >= f.maxBackups
+		tooOld := f.maxAge > 0 && time.Since(b.rotatedAt) > f.maxAge
+		if tooMany || tooOld {
+			if err := os.Remove(filepath.Join(filepath.Dir(f.path), b.name)); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to remove log backup %s: %v\n", b.name, err)
+			}
+		}
+	}
+}
+
+// logBackup is a rotated log file
+type logBackup struct {
+	name      string
+	rotatedAt time.Time
+}
+
+// backups returns the rotated log files, newest first
+func (f *rotatingFile) backups() []logBackup {
+	ext := filepath.Ext(f.path)
+	prefix := filepath.Base(strings.TrimSuffix(f.path, ext)) + "-"
+	entries, err := os.ReadDir(filepath.Dir(f.path))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to list log backups: %v\n", err)
+		return nil
+	}
+
+	var backups []logBackup
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, ext) {
+			continue
+		}
+		rotatedAt, err := time.ParseInLocation(backupTimeFormat, strings.TrimSuffix(strings.TrimPrefix(name, prefix), ext), time.Local)
+		if err != nil {
+			continue
+		}
+		backups = append(backups, logBackup{name: name, rotatedAt: rotatedAt})
+	}
+	sort.Slice(backups, func(i, j int) bool { return backups[i].rotatedAt.After(backups[j].rotatedAt) })
+	return backups
+}
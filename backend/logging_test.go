@@ -0,0 +1,135 @@
+package main
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBridgeLevel(t *testing.T) {
+	tests := []struct {
+		line    string
+		level   slog.Level
+		message string
+	}{
+		{"ERROR http: TLS handshake error", slog.LevelError, "http: TLS handshake error"},
+		{"WARN slow request", slog.LevelWarn, "slow request"},
+		{"DEBUG route added", slog.LevelDebug, "route added"},
+		{"INFO shutting down", slog.LevelInfo, "shutting down"},
+		{"Error is only a word here", slog.LevelInfo, "Error is only a word here"},
+		{"Run 3: starting 2 steps", slog.LevelInfo, "Run 3: starting 2 steps"},
+	}
+
+	for _, tt := range tests {
+		level, message := bridgeLevel(tt.line)
+		if level != tt.level || message != tt.message {
+			t.Errorf("bridgeLevel(%q) = %v, %q, want %v, %q", tt.line, level, message, tt.level, tt.message)
+		}
+	}
+}
+
+func TestRotatingFileAgeAcrossRestarts(t *testing.T) {
+	old := time.Now().Add(-72 * time.Hour)
+
+	tests := []struct {
+		name    string
+		setup   func(t *testing.T, path string)
+		rotated bool
+	}{
+		{
+			name: "file last written long ago",
+			setup: func(t *testing.T, path string) {
+				writeLogFile(t, path, "old\n")
+				if err := os.Chtimes(path, old, old); err != nil {
+					t.Fatal(err)
+				}
+			},
+			rotated: true,
+		},
+		{
+			// The file keeps being written by restarted servers, but was
+			// started when the backup was rotated
+			name: "old backup",
+			setup: func(t *testing.T, path string) {
+				writeLogFile(t, path, "recent\n")
+				writeLogFile(t, backupPath(path, old), "older\n")
+			},
+			rotated: true,
+		},
+		{
+			name: "recent backup",
+			setup: func(t *testing.T, path string) {
+				writeLogFile(t, path, "recent\n")
+				if err := os.Chtimes(path, old, old); err != nil {
+					t.Fatal(err)
+				}
+				writeLogFile(t, backupPath(path, time.Now().Add(-time.Hour)), "older\n")
+			},
+			rotated: false,
+		},
+	}
+
+	for _, tt := range tests {
+		path := filepath.Join(t.TempDir(), "dev-tool.log")
+		tt.setup(t, path)
+
+		f, err := openRotatingFile(path, 0, 0, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := f.Write([]byte("new\n")); err != nil {
+			t.Fatal(err)
+		}
+		f.file.Close()
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// A rotated file holds only what was written after reopening it
+		rotated := string(data) == "new\n"
+		if rotated != tt.rotated {
+			t.Errorf("%s: rotated = %v, want %v (log holds %q)", tt.name, rotated, tt.rotated, data)
+		}
+	}
+}
+
+func TestRotatingFileRemovesOldBackups(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dev-tool.log")
+	now := time.Now()
+	for _, age := range []time.Duration{time.Hour, 2 * time.Hour, 3 * time.Hour, 72 * time.Hour} {
+		writeLogFile(t, backupPath(path, now.Add(-age)), "backup\n")
+	}
+
+	f, err := openRotatingFile(path, 0, 2, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.file.Close()
+
+	backups := f.backups()
+	if len(backups) != 2 {
+		t.Fatalf("%d backups left, want 2", len(backups))
+	}
+	for i, age := range []time.Duration{time.Hour, 2 * time.Hour} {
+		if want := filepath.Base(backupPath(path, now.Add(-age))); backups[i].name != want {
+			t.Errorf("backup %d is %s, want %s", i, backups[i].name, want)
+		}
+	}
+}
+
+// backupPath returns the name a log file rotated at the given time gets
+func backupPath(path string, rotatedAt time.Time) string {
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + "-" + rotatedAt.Format(backupTimeFormat) + ext
+}
+
+func writeLogFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -2,19 +2,25 @@ package main
 
 import (
 	"bytes"
+	"context"
 	"database/sql"
 	"embed"
 	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"io"
 	"io/fs"
 	"log"
+	"log/slog"
 	"net/http"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 
@@ -22,7 +28,6 @@ import (
 	"github.com/kr/pty"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
-	_ "github.com/mattn/go-sqlite3"
 	"gopkg.in/yaml.v2"
 )
 
@@ -74,7 +79,16 @@ type WebConfig struct {
 }
 
 type SecurityConfig struct {
-	CORS CORSConfig `yaml:"cors"`
+	CORS          CORSConfig          `yaml:"cors"`
+	Secrets       SecretsConfig       `yaml:"secrets"`
+	CommandPolicy CommandPolicyConfig `yaml:"command_policy"`
+	Auth          AuthConfig          `yaml:"auth"`
+}
+
+// SecretsConfig locates the key used to encrypt secret variables
+type SecretsConfig struct {
+	KeyFile string `yaml:"key_file"` // Created with a random key if missing; defaults to <data.base_dir>/secret.key
+	KeyEnv  string `yaml:"key_env"`  // Environment variable holding the key, takes precedence over key_file
 }
 
 type CORSConfig struct {
@@ -83,14 +97,17 @@ type CORSConfig struct {
 	AllowedHeaders []string `yaml:"allowed_headers"`
 }
 
+// LoggingConfig configures the logger, see logging.go
 type LoggingConfig struct {
-	Level      string `yaml:"level"`
-	Format     string `yaml:"format"`
-	Output     string `yaml:"output"`
-	FilePath   string `yaml:"file_path"`
-	MaxSizeMB  int    `yaml:"max_size_mb"`
-	MaxBackups int    `yaml:"max_backups"`
-	MaxAgeDays int    `yaml:"max_age_days"`
+	Level      string `yaml:"level"`        // debug, info, warn or error
+	Format     string `yaml:"format"`       // text or json
+	Output     string `yaml:"output"`       // stdout, file or both
+	FilePath   string `yaml:"file_path"`    // Relative to data.logs_dir; defaults to dev-tool.log there
+	MaxSizeMB  int    `yaml:"max_size_mb"`  // Rotate the file at this size; 0 means no limit
+	MaxBackups int    `yaml:"max_backups"`  // Rotated files to keep; 0 keeps all
+	MaxAgeDays int    `yaml:"max_age_days"` // Rotate the file after this long and delete older rotated files; 0 means no limit
+
+	RunLogs RunLogsConfig `yaml:"run_logs"`
 }
 
 type WebSocketConfig struct {
@@ -108,7 +125,7 @@ type FlowsConfig struct {
 type ValidationConfig struct {
 	MaxSteps         int      `yaml:"max_steps"`
 	MaxCommandLength int      `yaml:"max_command_length"`
-	BlockedCommands  []string `yaml:"blocked_commands"`
+	BlockedCommands  []string `yaml:"blocked_commands"` // Added to security.command_policy as deny rules
 }
 
 type SystemConfig struct {
@@ -145,8 +162,20 @@ type CommandRequest struct {
 
 // StepExecutionRequest represents the request payload for step execution by ID
 type StepExecutionRequest struct {
-	StepID int `json:"step_id" binding:"required"`
-}
+	StepID      int               `json:"step_id" binding:"required"`
+	Environment string            `json:"environment,omitempty"` // Environment whose variables to use
+	Variables   map[string]string `json:"variables,omitempty"`   // Overrides for this execution only
+}
+
+// Command result statuses
+const (
+	CommandStatusSucceeded = "succeeded"
+	CommandStatusFailed    = "failed"
+	CommandStatusTimedOut  = "timed_out"
+	CommandStatusCancelled = "cancelled"
+	CommandStatusStarted   = "started" // A tmux command still running after its tmux_wait
+	CommandStatusSkipped   = "skipped" // The step's when condition did not hold
+)
 
 // CommandResult represents the result of command execution
 type CommandResult struct {
@@ -156,7 +185,11 @@ type CommandResult struct {
 	Stderr     string        `json:"stderr"`
 	Duration   time.Duration `json:"duration"`
 	Success    bool          `json:"success"`
+	Status     string        `json:"status"`
+	Attempt    int           `json:"attempt,omitempty"` // 1-based attempt number for steps with retries
 	ExecutedAt time.Time     `json:"executed_at"`
+
+	rawStdout string // Stdout before secret values were masked, for output captures
 }
 
 // Database models
@@ -169,16 +202,27 @@ type FlowDB struct {
 }
 
 type StepDB struct {
-	ID              int    `json:"id"`
-	FlowID          int    `json:"flow_id"`
-	Name            string `json:"name"`
-	Command         string `json:"command"`
-	Notes           string `json:"notes,omitempty"`
-	SkipPrompt      bool   `json:"skip_prompt"`
-	Terminal        bool   `json:"terminal"`
-	TmuxSessionName string `json:"tmux_session_name"`
-	IsTmuxTerminal  bool   `json:"is_tmux_terminal"` // If terminal is true and this also true then use the session to run the command inside it. Create session if not exists.
-	OrderIndex      int    `json:"order_index"`
+	ID              int               `json:"id"`
+	FlowID          int               `json:"flow_id"`
+	Name            string            `json:"name"`
+	Command         string            `json:"command"`
+	Notes           string            `json:"notes,omitempty"`
+	SkipPrompt      bool              `json:"skip_prompt"`
+	Terminal        bool              `json:"terminal"`
+	TmuxSessionName string            `json:"tmux_session_name"`
+	IsTmuxTerminal  bool              `json:"is_tmux_terminal"` // If terminal is true and this also true then use the session to run the command inside it. Create session if not exists.
+	OrderIndex      int               `json:"order_index"`
+	Timeout         string            `json:"timeout,omitempty"`     // Overrides system.shell.timeout for this step, e.g. "5m"
+	TmuxWait        string            `json:"tmux_wait,omitempty"`   // For tmux steps, report the command as started if still running after this long
+	DependsOn       []string          `json:"depends_on,omitempty"`  // Names of steps that must succeed before this one runs
+	When            string            `json:"when,omitempty"`        // Condition that must hold for the step to run, see when.go
+	Retries         int               `json:"retries,omitempty"`     // Extra attempts after a non-zero exit
+	RetryDelay      string            `json:"retry_delay,omitempty"` // Delay before the first retry, e.g. "5s"
+	Backoff         string            `json:"backoff,omitempty"`     // How the delay grows between retries, see retry.go
+	Captures        []OutputCapture   `json:"captures,omitempty"`    // Run-scoped variables taken from stdout, see capture.go
+	EnvFiles        []string          `json:"env_files,omitempty"`   // Env files loaded after the flow's, see dotenv.go
+	WorkingDir      string            `json:"working_dir,omitempty"` // Directory the command runs in, may reference variables
+	Env             map[string]string `json:"env,omitempty"`         // Environment variables overriding flow variables
 }
 
 type VariableDB struct {
@@ -186,30 +230,51 @@ type VariableDB struct {
 	FlowID int    `json:"flow_id"`
 	Key    string `json:"key"`
 	Value  string `json:"value"`
+	Secret bool   `json:"secret"` // Value is encrypted, see secrets.go
 }
 
 // API models (keeping existing for compatibility)
 type Step struct {
-	ID              int    `yaml:"-" json:"id,omitempty"`
-	Name            string `yaml:"name" json:"name"`
-	Command         string `yaml:"command" json:"command"`
-	Notes           string `yaml:"notes,omitempty" json:"notes,omitempty"`
-	SkipPrompt      bool   `yaml:"skip_prompt,omitempty" json:"skip_prompt,omitempty"`
-	Terminal        bool   `yaml:"terminal" json:"terminal"`
-	TmuxSessionName string `yaml:"tmux_session_name,omitempty" json:"tmux_session_name,omitempty"`
-	IsTmuxTerminal  bool   `yaml:"is_tmux_terminal,omitempty" json:"is_tmux_terminal,omitempty"`
+	ID              int               `yaml:"-" json:"id,omitempty"`
+	Name            string            `yaml:"name" json:"name"`
+	Command         string            `yaml:"command" json:"command"`
+	Notes           string            `yaml:"notes,omitempty" json:"notes,omitempty"`
+	SkipPrompt      bool              `yaml:"skip_prompt,omitempty" json:"skip_prompt,omitempty"`
+	Terminal        bool              `yaml:"terminal" json:"terminal"`
+	TmuxSessionName string            `yaml:"tmux_session_name,omitempty" json:"tmux_session_name,omitempty"`
+	IsTmuxTerminal  bool              `yaml:"is_tmux_terminal,omitempty" json:"is_tmux_terminal,omitempty"`
+	Timeout         string            `yaml:"timeout,omitempty" json:"timeout,omitempty"`
+	TmuxWait        string            `yaml:"tmux_wait,omitempty" json:"tmux_wait,omitempty"`
+	DependsOn       []string          `yaml:"depends_on,omitempty" json:"depends_on,omitempty"`
+	When            string            `yaml:"when,omitempty" json:"when,omitempty"`
+	Retries         int               `yaml:"retries,omitempty" json:"retries,omitempty"`
+	RetryDelay      string            `yaml:"retry_delay,omitempty" json:"retry_delay,omitempty"`
+	Backoff         string            `yaml:"backoff,omitempty" json:"backoff,omitempty"`
+	Captures        []OutputCapture   `yaml:"captures,omitempty" json:"captures,omitempty"`
+	EnvFiles        []string          `yaml:"env_files,omitempty" json:"env_files,omitempty"`
+	WorkingDir      string            `yaml:"working_dir,omitempty" json:"working_dir,omitempty"`
+	Env             map[string]string `yaml:"env,omitempty" json:"env,omitempty"`
+
+	// RenderedNotes is Notes with variable references expanded, set when it differs
+	RenderedNotes string `yaml:"-" json:"rendered_notes,omitempty"`
 }
 
 type Flow struct {
 	ID        int               `json:"id"`
 	Name      string            `yaml:"name" json:"name"`
 	Variables map[string]string `yaml:"variables,omitempty" json:"variables"`
+	Secrets   []string          `yaml:"secrets,omitempty" json:"secrets,omitempty"` // Names of secret variables, whose values are masked
+	Prompts   []VariablePrompt  `yaml:"prompts,omitempty" json:"prompts,omitempty"`
+	EnvFiles  []string          `yaml:"env_files,omitempty" json:"env_files,omitempty"` // Env files loaded by every step, see dotenv.go
 	Steps     []Step            `yaml:"steps" json:"steps"`
 }
 
 type CreateFlowRequest struct {
 	Name      string            `json:"name" binding:"required"`
 	Variables map[string]string `json:"variables,omitempty"`
+	Secrets   []string          `json:"secrets,omitempty"`
+	Prompts   []VariablePrompt  `json:"prompts,omitempty"`
+	EnvFiles  []string          `json:"env_files,omitempty"`
 	Steps     []Step            `json:"steps"`
 }
 
@@ -248,11 +313,21 @@ func loadConfig(configPath string) (*Config, error) {
 				AllowedMethods: []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
 				AllowedHeaders: []string{"Origin", "Content-Type", "Accept", "Authorization"},
 			},
+			Auth: AuthConfig{
+				Enabled: true,
+			},
 		},
 		Logging: LoggingConfig{
-			Level:  "info",
-			Format: "text",
-			Output: "stdout",
+			Level:      "info",
+			Format:     "text",
+			Output:     "stdout",
+			MaxSizeMB:  100,
+			MaxBackups: 5,
+			MaxAgeDays: 30,
+			RunLogs: RunLogsConfig{
+				MaxAgeDays: 30,
+				MaxRuns:    1000,
+			},
 		},
 		WebSocket: WebSocketConfig{
 			AllowedOrigins:  []string{"*"},
@@ -296,27 +371,13 @@ func loadConfig(configPath string) (*Config, error) {
 	dirs := []string{cfg.Data.BaseDir, cfg.Data.FlowsDir, cfg.Data.LogsDir, cfg.Data.TempDir}
 	for _, dir := range dirs {
 		if err := os.MkdirAll(dir, 0755); err != nil {
-			log.Printf("Warning: Could not create directory %s: %v", dir, err)
+			slog.Warn("Could not create directory", "path", dir, "error", err)
 		}
 	}
 
 	return cfg, nil
 }
 
-// isCommandBlocked checks if a command contains blocked patterns
-func isCommandBlocked(command string) bool {
-	if config == nil || len(config.Flows.Validation.BlockedCommands) == 0 {
-		return false
-	}
-
-	for _, blocked := range config.Flows.Validation.BlockedCommands {
-		if strings.Contains(command, blocked) {
-			return true
-		}
-	}
-	return false
-}
-
 // getUserHomeDir returns the user's home directory
 func getUserHomeDir() string {
 	if home := os.Getenv("HOME"); home != "" {
@@ -326,18 +387,24 @@ func getUserHomeDir() string {
 	return "/home/bacancy"
 }
 
-// setupCommandEnvironment sets up proper environment and working directory for commands
-func setupCommandEnvironment(cmd *exec.Cmd, variables map[string]string) {
-	// Get user home directory
-	homeDir := getUserHomeDir()
-
-	// Determine working directory based on configuration
-	workingDir := homeDir // Default to home directory
+// commandWorkingDir returns the directory commands run in: workspace.default_dir,
+// or the user's home directory
+func commandWorkingDir() string {
 	if config != nil && config.System.Workspace.DefaultDir != "" {
-		workingDir = config.System.Workspace.DefaultDir
+		return config.System.Workspace.DefaultDir
 	}
+	return getUserHomeDir()
+}
+
+// setupCommandEnvironment sets up proper environment and working directory for
+// commands. Variables loaded from env files are added first, then the flow
+// variables and the step's env, so later ones take precedence.
+func setupCommandEnvironment(cmd *exec.Cmd, cmdEnv CommandEnvironment) {
+	// Get user home directory
+	homeDir := getUserHomeDir()
 
 	// Set working directory
+	workingDir := cmdEnv.dir()
 	cmd.Dir = workingDir
 
 	// Set up environment variables
@@ -348,9 +415,11 @@ func setupCommandEnvironment(cmd *exec.Cmd, variables map[string]string) {
 	env = append(env, fmt.Sprintf("USER=%s", os.Getenv("USER")))
 	env = append(env, "PATH=/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin")
 
-	// Add flow variables
-	for key, value := range variables {
-		env = append(env, fmt.Sprintf("%s=%s", key, value))
+	// Add env file variables, flow variables and the step's env
+	for _, layer := range cmdEnv.layers() {
+		for key, value := range layer {
+			env = append(env, fmt.Sprintf("%s=%s", key, value))
+		}
 	}
 
 	cmd.Env = env
@@ -358,51 +427,199 @@ func setupCommandEnvironment(cmd *exec.Cmd, variables map[string]string) {
 	log.Printf("Command environment setup - Working Dir: %s, Home: %s", workingDir, homeDir)
 }
 
-// executeCommand executes a shell command and returns the result
-func executeCommand(command string, variables map[string]string) CommandResult {
+// validateDuration checks that an optional step duration setting is empty or positive
+func validateDuration(name, value string) error {
+	if value == "" {
+		return nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return fmt.Errorf("invalid %s %q: %v", name, value, err)
+	}
+	if d <= 0 {
+		return fmt.Errorf("invalid %s %q: must be positive", name, value)
+	}
+	return nil
+}
+
+// validateStepDurations checks the duration settings of a step
+func validateStepDurations(timeout, tmuxWait string) error {
+	if err := validateDuration("timeout", timeout); err != nil {
+		return err
+	}
+	return validateDuration("tmux_wait", tmuxWait)
+}
+
+// parseTmuxWait parses a step's tmux_wait; empty means wait for the command to exit
+func parseTmuxWait(tmuxWait string) time.Duration {
+	if tmuxWait == "" {
+		return 0
+	}
+	d, err := time.ParseDuration(tmuxWait)
+	if err != nil || d <= 0 {
+		slog.Warn("Ignoring invalid tmux_wait", "value", tmuxWait)
+		return 0
+	}
+	return d
+}
+
+// commandTimeout resolves the timeout for a command. A step timeout overrides
+// system.shell.timeout; zero means the command may run forever.
+func commandTimeout(stepTimeout string) time.Duration {
+	timeout := stepTimeout
+	if timeout == "" && config != nil {
+		timeout = config.System.Shell.Timeout
+	}
+	if timeout == "" {
+		return 0
+	}
+
+	d, err := time.ParseDuration(timeout)
+	if err != nil || d <= 0 {
+		slog.Warn("Ignoring invalid command timeout", "value", timeout)
+		return 0
+	}
+	return d
+}
+
+// killGracePeriod is how long a cancelled command gets to exit after SIGTERM before SIGKILL
+const killGracePeriod = 10 * time.Second
+
+// newShellCommand creates a bash command bound to ctx. The command runs in its
+// own process group; when ctx is done the whole group receives SIGTERM, then
+// SIGKILL if it is still running after killGracePeriod.
+func newShellCommand(ctx context.Context, command string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, "/bin/bash", "-c", command)
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		pgid := -cmd.Process.Pid
+		time.AfterFunc(killGracePeriod, func() {
+			syscall.Kill(pgid, syscall.SIGKILL)
+		})
+		return syscall.Kill(pgid, syscall.SIGTERM)
+	}
+	// Don't wait forever on pipes held open by processes that escaped the group
+	cmd.WaitDelay = killGracePeriod + 5*time.Second
+	return cmd
+}
+
+// commandStatus derives the exit code and status of a finished command
+func commandStatus(ctx context.Context, err error) (int, string) {
+	switch ctx.Err() {
+	case context.DeadlineExceeded:
+		return -1, CommandStatusTimedOut
+	case context.Canceled:
+		return -1, CommandStatusCancelled
+	}
+	if err == nil {
+		return 0, CommandStatusSucceeded
+	}
+	if exitError, ok := err.(*exec.ExitError); ok {
+		return exitError.ExitCode(), CommandStatusFailed
+	}
+	return -1, CommandStatusFailed
+}
+
+// withTimeout returns a child of parent that expires after timeout, or only
+// when parent is done if timeout is zero
+func withTimeout(parent context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(parent)
+	}
+	return context.WithTimeout(parent, timeout)
+}
+
+// executeCommand executes a shell command and returns the result. The command
+// stops when ctx is cancelled, and its execution slot is grouped under key.
+// The execution is audited as event, which identifies the caller.
+func executeCommand(ctx context.Context, key, command string, variables map[string]string, event AuditEvent) (result CommandResult) {
 	startTime := time.Now()
 
+	finalCommand, err := interpolate(command, variables)
+	if err == nil {
+		err = checkCommandPolicy(finalCommand)
+	}
+	if err == nil {
+		err = checkWorkingDir(commandWorkingDir())
+	}
+	startErr := err
+	defer func() {
+		auditExecution(event, command, finalCommand, result, startErr)
+		observeExecution(event, result, startErr)
+	}()
+	if err != nil {
+		return CommandResult{
+			Command:    command,
+			ExitCode:   -1,
+			Stderr:     err.Error(),
+			Duration:   time.Since(startTime),
+			Success:    false,
+			Status:     CommandStatusFailed,
+			ExecutedAt: startTime,
+		}
+	}
+
+	slot, err := scheduler.acquire(ctx, key, command)
+	if err != nil {
+		return CommandResult{
+			Command:    command,
+			ExitCode:   -1,
+			Stderr:     err.Error(),
+			Duration:   time.Since(startTime),
+			Success:    false,
+			Status:     CommandStatusFailed,
+			ExecutedAt: startTime,
+		}
+	}
+	defer scheduler.release(slot)
+
+	timeout := commandTimeout("")
+	ctx, cancel := withTimeout(ctx, timeout)
+	defer cancel()
+
 	// Execute the command
-	cmd := exec.Command("bash", "-c", command)
+	cmd := newShellCommand(ctx, finalCommand)
 
 	// Setup environment and working directory
-	setupCommandEnvironment(cmd, variables)
+	setupCommandEnvironment(cmd, CommandEnvironment{Variables: variables})
 
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
 
-	err := cmd.Run()
+	err = cmd.Run()
 
 	log.Printf("Command: %s", command)
-	log.Printf("Working Directory: %s", cmd.Dir)
-	log.Printf("Variables: %+v", variables)
+	slog.Debug("Command environment", "dir", cmd.Dir, "variables", variables)
 
 	duration := time.Since(startTime)
 
-	exitCode := 0
-	success := true
-	if err != nil {
-		success = false
-		if exitError, ok := err.(*exec.ExitError); ok {
-			exitCode = exitError.ExitCode()
-		} else {
-			exitCode = -1
-		}
+	exitCode, status := commandStatus(ctx, err)
+	if status == CommandStatusTimedOut {
+		fmt.Fprintf(&stderr, "\nCommand timed out after %v", timeout)
 	}
 
-	return CommandResult{
+	return maskResult(CommandResult{
 		Command:    command,
 		ExitCode:   exitCode,
 		Stdout:     stdout.String(),
 		Stderr:     stderr.String(),
 		Duration:   duration,
-		Success:    success,
+		Success:    status == CommandStatusSucceeded,
+		Status:     status,
 		ExecutedAt: startTime,
-	}
+	})
+}
+
+// CommandExecutionResponse is the result of /api/execute-command
+type CommandExecutionResponse struct {
+	CommandID int `json:"command_id"` // For POST /api/commands/:id/cancel
+	CommandResult
 }
 
-// HandleCommandExecution executes a command synchronously and returns the result
+// HandleCommandExecution executes a command synchronously and returns the
+// result. The command's ID is sent in the X-Command-ID header before it runs,
+// so that callers can cancel it while waiting.
 func handleCommandExecution(c echo.Context) error {
 	var req CommandRequest
 	if err := c.Bind(&req); err != nil {
@@ -417,17 +634,45 @@ func handleCommandExecution(c echo.Context) error {
 		})
 	}
 
+	commandID := executions.newCommandID()
+	key := commandKey(commandID)
+	ctx, done := executions.start(context.Background(), key)
+	defer done()
+
+	c.Response().Header().Set("X-Command-ID", strconv.Itoa(commandID))
+	c.Response().Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	c.Response().WriteHeader(http.StatusOK)
+	c.Response().Flush()
+
 	// Execute the command with variables
-	result := executeCommand(req.Command, req.Variables)
+	result := executeCommand(ctx, key, req.Command, req.Variables, requestAudit(c, AuditExecution, key))
 
-	return c.JSON(http.StatusOK, result)
+	return json.NewEncoder(c.Response()).Encode(CommandExecutionResponse{CommandID: commandID, CommandResult: result})
 }
 
 // handleShellWebSocket handles WebSocket connections for interactive shell
 func handleShellWebSocket(c echo.Context) error {
+	// Running a step's command needs flows:run on its flow; a free shell can run anything
+	permission, flowID := PermissionRunCommands, 0
+	stepID := 0
+	if _, err := fmt.Sscanf(c.QueryParam("step_id"), "%d", &stepID); err == nil {
+		if id, err := flowOfStep(stepID); err == nil {
+			permission, flowID = PermissionRunFlows, id
+		}
+	}
+	if err := authorize(c, permission, flowID); err != nil {
+		return forbidden(c, err)
+	}
+	// Input typed into a step terminal runs like a free shell would, so without
+	// commands:run the terminal only shows the step's output: the command runs
+	// without an interactive shell around it and tmux sessions are attached
+	// read-only
+	interactive := authorize(c, PermissionRunCommands, 0) == nil
+	event := requestAudit(c, AuditShellOpened, "shell")
+
 	ws, err := upgrader.Upgrade(c.Response(), c.Request(), nil)
 	if err != nil {
-		log.Printf("WebSocket upgrade failed: %v", err)
+		slog.Error("WebSocket upgrade failed", "error", err)
 		return err
 	}
 	defer ws.Close()
@@ -444,22 +689,22 @@ func handleShellWebSocket(c echo.Context) error {
 			// Get step details to get flow ID
 			step, err = getStepByID(stepID)
 			if err != nil {
-				log.Printf("WebSocket: Failed to get step %d: %v", stepID, err)
+				slog.Error("WebSocket: Failed to get step", "step_id", stepID, "error", err)
 			} else {
 				// Get flow variables
-				flowVariables, err := getFlowVariables(step.FlowID)
+				flowVariables, err := getLaunchVariables(step.FlowID, c.QueryParam("environment"), nil)
 				if err != nil {
-					log.Printf("WebSocket: Failed to get variables for flow %d: %v", step.FlowID, err)
+					slog.Error("WebSocket: Failed to get variables", "flow_id", step.FlowID, "error", err)
 				} else {
 					variables = flowVariables
 					log.Printf("WebSocket: Loaded %d variables for step %d (flow %d)", len(variables), stepID, step.FlowID)
 					for key, value := range variables {
-						log.Printf("WebSocket: Setting environment variable %s=%s", key, value)
+						slog.Debug("WebSocket: setting environment variable", "key", key, "value", value)
 					}
 				}
 			}
 		} else {
-			log.Printf("WebSocket: Invalid step_id parameter: %s", stepIDParam)
+			slog.Warn("WebSocket: Invalid step_id parameter", "value", stepIDParam)
 		}
 	}
 
@@ -475,6 +720,51 @@ func handleShellWebSocket(c echo.Context) error {
 		shell = "/bin/bash"
 	}
 
+	// Resolve the step's environment and variable references before starting
+	// anything; errors are shown in the terminal
+	var finalCommand string
+	cmdEnv := CommandEnvironment{Variables: variables}
+	if step == nil {
+		err := checkWorkingDir(cmdEnv.dir())
+		if err == nil && !interactive {
+			err = fmt.Errorf("step not found")
+		}
+		if err != nil {
+			auditPolicyError(event, err)
+			message := fmt.Sprintf("Cannot start shell: %v\r\n", err)
+			if err := ws.WriteMessage(websocket.TextMessage, []byte(base64.StdEncoding.EncodeToString([]byte(message)))); err != nil {
+				slog.Error("Error writing to WebSocket", "error", err)
+			}
+			return nil
+		}
+	} else {
+		event.Target, event.FlowID, event.StepID = stepKey(step.ID), step.FlowID, step.ID
+		flowEnvFiles, stepErr := getFlowEnvFiles(step.FlowID)
+		if stepErr == nil {
+			cmdEnv, stepErr = prepareCommandEnvironment(variables, step.WorkingDir, stepEnvFiles(flowEnvFiles, step.EnvFiles), step.Env)
+		}
+		commandVariables := cmdEnv.lookup()
+		if stepErr == nil {
+			finalCommand, stepErr = interpolate(step.Command, commandVariables)
+		}
+		if stepErr == nil {
+			step.TmuxSessionName, stepErr = interpolate(step.TmuxSessionName, commandVariables)
+		}
+		if stepErr == nil {
+			stepErr = checkCommandPolicy(finalCommand)
+		}
+		if stepErr != nil {
+			slog.Error("WebSocket: Cannot run step", "step_id", step.ID, "error", stepErr)
+			auditExecution(event, step.Command, finalCommand, CommandResult{Status: CommandStatusFailed}, stepErr)
+			observeExecution(event, CommandResult{Status: CommandStatusFailed}, stepErr)
+			message := fmt.Sprintf("Cannot run step: %v\r\n", stepErr)
+			if err := ws.WriteMessage(websocket.TextMessage, []byte(base64.StdEncoding.EncodeToString([]byte(message)))); err != nil {
+				slog.Error("Error writing to WebSocket", "error", err)
+			}
+			return nil
+		}
+	}
+
 	if step != nil && step.IsTmuxTerminal {
 		if step.TmuxSessionName == "" {
 			step.TmuxSessionName = "flows_session"
@@ -482,29 +772,37 @@ func handleShellWebSocket(c echo.Context) error {
 
 		// First, ensure the tmux session exists
 		log.Printf("Setting up tmux session: %s", step.TmuxSessionName)
-		checkCmd := exec.Command("tmux", "has-session", "-t", step.TmuxSessionName)
-		setupCommandEnvironment(checkCmd, variables)
-
-		if err := checkCmd.Run(); err != nil {
-			// Session doesn't exist, create it
-			log.Printf("Creating new tmux session: %s", step.TmuxSessionName)
-			createCmd := exec.Command("tmux", "new-session", "-d", "-s", step.TmuxSessionName)
-			setupCommandEnvironment(createCmd, variables)
-			if err := createCmd.Run(); err != nil {
-				log.Printf("Failed to create tmux session %s: %v", step.TmuxSessionName, err)
-				return fmt.Errorf("failed to create tmux session: %v", err)
-			}
+		if err := ensureTmuxSession(step.TmuxSessionName, cmdEnv); err != nil {
+			slog.Error("Error setting up tmux session", "session", step.TmuxSessionName, "error", err)
+			return err
 		}
 
 		// Attach to the existing session
 		shell = "tmux"
 		shellArgs = append(shellArgs, "attach-session", "-t", step.TmuxSessionName)
+		if !interactive {
+			shellArgs = append(shellArgs, "-r")
+		}
+	} else if step != nil && !interactive {
+		shellArgs = append(shellArgs, "-c", finalCommand)
+	}
+
+	// PTYs count towards system.shell.max_concurrent for as long as the session is open
+	slotKey, slotLabel := "shell", "interactive shell"
+	if step != nil {
+		slotKey, slotLabel = stepKey(step.ID), step.Name
+	}
+	slot, err := scheduler.acquire(c.Request().Context(), slotKey, slotLabel)
+	if err != nil {
+		slog.Warn("Shell session abandoned while queued", "error", err)
+		return nil
 	}
+	defer scheduler.release(slot)
 
 	cmd := exec.Command(shell, shellArgs...)
 
 	// Set environment variables for the shell using the new setup function
-	setupCommandEnvironment(cmd, variables)
+	setupCommandEnvironment(cmd, cmdEnv)
 
 	// Add additional terminal-specific environment variables
 	cmd.Env = append(cmd.Env, "TERM=xterm-256color")
@@ -513,64 +811,100 @@ func handleShellWebSocket(c echo.Context) error {
 
 	ptmx, err := pty.Start(cmd)
 	if err != nil {
-		log.Printf("Failed to start shell with PTY: %v", err)
+		slog.Error("Failed to start shell with PTY", "error", err)
 		return err
 	}
 	defer ptmx.Close()
+	ptySessions.Add(1)
+	defer ptySessions.Add(-1)
 
-	// Execute command if provided
-	command := step.Command
-	if command != "" {
-		if isCommandBlocked(command) {
-			log.Printf("Blocked command attempt: %s", command)
-			return echo.NewHTTPError(http.StatusForbidden, "Command blocked by security policy")
-		}
-
-		// Substitute variables in the command
-		finalCommand := command
-		for key, value := range variables {
-			placeholder := fmt.Sprintf("${%s}", key)
-			finalCommand = strings.ReplaceAll(finalCommand, placeholder, value)
-		}
+	// Interactive sessions have no outcome to record, so they're audited as they start
+	if step == nil {
+		recordAudit(event)
+	} else {
+		auditExecution(event, step.Command, finalCommand, CommandResult{Status: CommandStatusStarted}, nil)
+		observeExecution(event, CommandResult{Status: CommandStatusStarted}, nil)
+	}
 
+	// Execute command if provided
+	if finalCommand != "" {
+		command := step.Command
 		log.Printf("Executing command: %s", finalCommand)
 		if len(variables) > 0 {
-			log.Printf("Original command: %s", command)
-			log.Printf("Variables: %+v", variables)
+			slog.Debug("Original command", "command", command, "variables", variables)
 		}
 
-		if step != nil && step.IsTmuxTerminal {
-			// For tmux sessions, we need to wait a moment for the session to be ready
-			// then send the command
-			time.Sleep(100 * time.Millisecond)
-		}
+		switch {
+		case interactive:
+			if step != nil && step.IsTmuxTerminal {
+				// For tmux sessions, we need to wait a moment for the session to be ready
+				// then send the command
+				time.Sleep(100 * time.Millisecond)
+			}
 
-		_, err := ptmx.Write([]byte(finalCommand + "\n"))
-		if err != nil {
-			log.Printf("Failed to write command to PTY: %v", err)
+			_, err := ptmx.Write([]byte(finalCommand + "\n"))
+			if err != nil {
+				slog.Error("Failed to write command to PTY", "error", err)
+			}
+		case step.IsTmuxTerminal:
+			// A read-only client can't type into the session
+			sendCmd := exec.Command("tmux", "send-keys", "-t", step.TmuxSessionName, "-l", finalCommand,
+				";", "send-keys", "-t", step.TmuxSessionName, "Enter")
+			setupCommandEnvironment(sendCmd, cmdEnv)
+			if err := sendCmd.Run(); err != nil {
+				slog.Error("Failed to send command to tmux session", "session", step.TmuxSessionName, "error", err)
+			}
 		}
 	}
 
-	// Handle PTY output -> WebSocket
+	// Handle PTY output -> WebSocket, with secret values masked. Output held
+	// back as the possible start of a secret is sent if nothing follows it
+	// within terminalMaskHold.
 	go func() {
+		var mu sync.Mutex
+		masker := &outputMasker{}
+		send := func(next func() string) error {
+			mu.Lock()
+			defer mu.Unlock()
+			output := next()
+			if output == "" {
+				return nil
+			}
+			// Encode output as base64 before sending to WebSocket
+			encodedOutput := base64.StdEncoding.EncodeToString([]byte(output))
+			return ws.WriteMessage(websocket.TextMessage, []byte(encodedOutput))
+		}
+		flushTimer := time.AfterFunc(terminalMaskHold, func() {
+			if err := send(masker.flush); err != nil {
+				slog.Error("Error writing to WebSocket", "error", err)
+			}
+		})
+		defer flushTimer.Stop()
+
 		buf := make([]byte, 1024)
 		for {
 			n, err := ptmx.Read(buf)
 			if err != nil {
-				if err == io.EOF {
+				// Reading fails with EIO once the process has exited
+				if err == io.EOF || errors.Is(err, syscall.EIO) {
 					log.Println("PTY closed")
 				} else {
-					log.Printf("Error reading from PTY: %v", err)
+					slog.Error("Error reading from PTY", "error", err)
+				}
+				send(masker.flush)
+				if !interactive {
+					// Nothing more will be shown in an output-only terminal
+					ws.Close()
 				}
 				break
 			}
 
-			// Encode output as base64 before sending to WebSocket
-			encodedOutput := base64.StdEncoding.EncodeToString(buf[:n])
-			if err := ws.WriteMessage(websocket.TextMessage, []byte(encodedOutput)); err != nil {
-				log.Printf("Error writing to WebSocket: %v", err)
+			flushTimer.Stop()
+			if err := send(func() string { return masker.write(string(buf[:n])) }); err != nil {
+				slog.Error("Error writing to WebSocket", "error", err)
 				break
 			}
+			flushTimer.Reset(terminalMaskHold)
 		}
 	}()
 
@@ -578,20 +912,24 @@ func handleShellWebSocket(c echo.Context) error {
 	for {
 		_, message, err := ws.ReadMessage()
 		if err != nil {
-			log.Printf("Error reading from WebSocket: %v", err)
+			slog.Error("Error reading from WebSocket", "error", err)
 			break
 		}
 
+		if !interactive {
+			continue
+		}
+
 		// Decode base64 input from WebSocket
 		decodedInput, err := base64.StdEncoding.DecodeString(string(message))
 		if err != nil {
-			log.Printf("Error decoding base64 input: %v", err)
+			slog.Error("Error decoding base64 input", "error", err)
 			continue
 		}
 
 		// Write decoded input to PTY
 		if _, err := ptmx.Write(decodedInput); err != nil {
-			log.Printf("Error writing to PTY: %v", err)
+			slog.Error("Error writing to PTY", "error", err)
 			break
 		}
 	}
@@ -612,10 +950,11 @@ func initDatabase() error {
 	}
 
 	var err error
-	db, err = sql.Open("sqlite3", dbPath)
-	if err != nil {
-		return fmt.Errorf("failed to open database: %v", err)
-	}
+	// Flow runs write from background goroutines, so wait on locks instead of
+	// failing; the connector counts SQLite errors for /metrics. Foreign keys
+	// are off by default in SQLite and enabled per connection, so the ON DELETE
+	// CASCADE clauses only work with _foreign_keys in the DSN.
+	db = sql.OpenDB(sqliteConnector{dsn: dbPath + "?_busy_timeout=5000&_foreign_keys=on"})
 
 	// Test connection
 	if err = db.Ping(); err != nil {
@@ -637,6 +976,7 @@ func createTables() error {
 			id INTEGER PRIMARY KEY AUTOINCREMENT,
 			name TEXT UNIQUE NOT NULL,
 			description TEXT,
+			env_files TEXT DEFAULT '',
 			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
 			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
 		)`,
@@ -651,6 +991,17 @@ func createTables() error {
 			tmux_session_name TEXT,
 			is_tmux_terminal BOOLEAN DEFAULT FALSE,
 			order_index INTEGER NOT NULL,
+			timeout TEXT DEFAULT '',
+			tmux_wait TEXT DEFAULT '',
+			depends_on TEXT DEFAULT '',
+			when_condition TEXT DEFAULT '',
+			retries INTEGER DEFAULT 0,
+			retry_delay TEXT DEFAULT '',
+			backoff TEXT DEFAULT '',
+			captures TEXT DEFAULT '',
+			env_files TEXT DEFAULT '',
+			working_dir TEXT DEFAULT '',
+			env TEXT DEFAULT '',
 			FOREIGN KEY (flow_id) REFERENCES flows (id) ON DELETE CASCADE
 		)`,
 		`CREATE TABLE IF NOT EXISTS variables (
@@ -658,6 +1009,7 @@ func createTables() error {
 			flow_id INTEGER NOT NULL,
 			key TEXT NOT NULL,
 			value TEXT,
+			secret BOOLEAN DEFAULT FALSE,
 			FOREIGN KEY (flow_id) REFERENCES flows (id) ON DELETE CASCADE,
 			UNIQUE(flow_id, key)
 		)`,
@@ -672,7 +1024,124 @@ func createTables() error {
 		}
 	}
 
-	return nil
+	if err := createRunTables(); err != nil {
+		return err
+	}
+
+	if err := createPromptTables(); err != nil {
+		return err
+	}
+
+	if err := createEnvironmentTables(); err != nil {
+		return err
+	}
+
+	if err := createAuthTables(); err != nil {
+		return err
+	}
+
+	if err := createGrantTables(); err != nil {
+		return err
+	}
+
+	if err := createAuditTables(); err != nil {
+		return err
+	}
+
+	return migrateTables()
+}
+
+// migrateTables adds columns introduced after the original schema to existing
+// databases and deletes rows orphaned before foreign keys were enforced
+func migrateTables() error {
+	columns := []struct {
+		table      string
+		definition string
+	}{
+		{"steps", "timeout TEXT DEFAULT ''"},
+		{"steps", "tmux_wait TEXT DEFAULT ''"},
+		{"steps", "depends_on TEXT DEFAULT ''"},
+		{"steps", "when_condition TEXT DEFAULT ''"},
+		{"steps", "retries INTEGER DEFAULT 0"},
+		{"steps", "retry_delay TEXT DEFAULT ''"},
+		{"steps", "backoff TEXT DEFAULT ''"},
+		{"steps", "captures TEXT DEFAULT ''"},
+		{"steps", "env_files TEXT DEFAULT ''"},
+		{"steps", "working_dir TEXT DEFAULT ''"},
+		{"steps", "env TEXT DEFAULT ''"},
+		{"flows", "env_files TEXT DEFAULT ''"},
+		{"run_steps", "skip_reason TEXT"},
+		{"flow_runs", "overrides TEXT"},
+		{"variables", "secret BOOLEAN DEFAULT FALSE"},
+		{"flow_runs", "environment TEXT"},
+		{"api_tokens", "role TEXT DEFAULT ''"},
+	}
+
+	// Columns that already exist are skipped rather than left to fail, so
+	// startup doesn't show up in the SQLite error metric
+	for _, column := range columns {
+		exists, err := columnExists(column.table, strings.Fields(column.definition)[0])
+		if err != nil {
+			return err
+		}
+		if exists {
+			continue
+		}
+		query := fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s", column.table, column.definition)
+		if _, err := db.Exec(query); err != nil {
+			return fmt.Errorf("failed to execute query %s: %v", query, err)
+		}
+	}
+
+	return deleteOrphans()
+}
+
+// deleteOrphans deletes rows whose parent row was deleted while foreign keys
+// were not enforced. Deleting an orphaned run orphans its steps in turn, so
+// this repeats until no violations are left.
+func deleteOrphans() error {
+	for {
+		rows, err := db.Query("PRAGMA foreign_key_check")
+		if err != nil {
+			return fmt.Errorf("failed to check foreign keys: %v", err)
+		}
+		orphans := make(map[string][]int64)
+		for rows.Next() {
+			var table, parent string
+			var rowID sql.NullInt64
+			var fkID int
+			if err := rows.Scan(&table, &rowID, &parent, &fkID); err != nil {
+				rows.Close()
+				return fmt.Errorf("failed to check foreign keys: %v", err)
+			}
+			if rowID.Valid {
+				orphans[table] = append(orphans[table], rowID.Int64)
+			}
+		}
+		rows.Close()
+		if len(orphans) == 0 {
+			return nil
+		}
+
+		for table, rowIDs := range orphans {
+			for _, rowID := range rowIDs {
+				if _, err := db.Exec(fmt.Sprintf("DELETE FROM %s WHERE rowid = ?", table), rowID); err != nil {
+					return fmt.Errorf("failed to delete orphaned row of %s: %v", table, err)
+				}
+			}
+			slog.Warn("Deleted orphaned rows", "table", table, "rows", len(rowIDs))
+		}
+	}
+}
+
+// columnExists reports whether a table has a column
+func columnExists(table, column string) (bool, error) {
+	var count int
+	err := db.QueryRow("SELECT COUNT(*) FROM pragma_table_info(?) WHERE name = ?", table, column).Scan(&count)
+	if err != nil {
+		return false, fmt.Errorf("failed to get columns of %s: %v", table, err)
+	}
+	return count > 0, nil
 }
 
 // Database operations
@@ -685,8 +1154,8 @@ func createFlow(req CreateFlowRequest) (*FlowDB, error) {
 
 	// Insert flow
 	result, err := tx.Exec(
-		"INSERT INTO flows (name, description) VALUES (?, ?)",
-		req.Name, "",
+		"INSERT INTO flows (name, description, env_files) VALUES (?, ?, ?)",
+		req.Name, "", encodeEnvFiles(req.EnvFiles),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to insert flow: %v", err)
@@ -698,21 +1167,19 @@ func createFlow(req CreateFlowRequest) (*FlowDB, error) {
 	}
 
 	// Insert variables
-	for key, value := range req.Variables {
-		_, err = tx.Exec(
-			"INSERT INTO variables (flow_id, key, value) VALUES (?, ?, ?)",
-			flowID, key, value,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("failed to insert variable %s: %v", key, err)
-		}
+	if err := storeVariables(tx, flowID, req.Variables, req.Secrets, nil); err != nil {
+		return nil, err
+	}
+
+	if err := savePrompts(tx, flowID, req.Prompts); err != nil {
+		return nil, err
 	}
 
 	// Insert steps
 	for i, step := range req.Steps {
 		_, err = tx.Exec(
-			"INSERT INTO steps (flow_id, name, command, notes, skip_prompt, terminal, tmux_session_name, is_tmux_terminal, order_index) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)",
-			flowID, step.Name, step.Command, step.Notes, step.SkipPrompt, step.Terminal, step.TmuxSessionName, step.IsTmuxTerminal, i,
+			"INSERT INTO steps (flow_id, name, command, notes, skip_prompt, terminal, tmux_session_name, is_tmux_terminal, order_index, timeout, tmux_wait, depends_on, when_condition, retries, retry_delay, backoff, captures, env_files, working_dir, env) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+			flowID, step.Name, step.Command, step.Notes, step.SkipPrompt, step.Terminal, step.TmuxSessionName, step.IsTmuxTerminal, i, step.Timeout, step.TmuxWait, encodeDependsOn(step.DependsOn), step.When, step.Retries, step.RetryDelay, step.Backoff, encodeCaptures(step.Captures), encodeEnvFiles(step.EnvFiles), step.WorkingDir, encodeStepEnv(step.Env),
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to insert step %s: %v", step.Name, err)
@@ -742,7 +1209,7 @@ func getFlowByID(id int) (*FlowDB, error) {
 }
 
 func getAllFlows() ([]Flow, error) {
-	rows, err := db.Query("SELECT id, name FROM flows ORDER BY created_at DESC")
+	rows, err := db.Query("SELECT id, name, env_files FROM flows ORDER BY created_at DESC")
 	if err != nil {
 		return nil, fmt.Errorf("failed to query flows: %v", err)
 	}
@@ -751,8 +1218,8 @@ func getAllFlows() ([]Flow, error) {
 	var flows []Flow
 	for rows.Next() {
 		var flowID int
-		var flowName string
-		if err := rows.Scan(&flowID, &flowName); err != nil {
+		var flowName, envFiles string
+		if err := rows.Scan(&flowID, &flowName, &envFiles); err != nil {
 			return nil, fmt.Errorf("failed to scan flow: %v", err)
 		}
 
@@ -762,16 +1229,37 @@ func getAllFlows() ([]Flow, error) {
 			return nil, fmt.Errorf("failed to get variables for flow %d: %v", flowID, err)
 		}
 
+		// Secret values are never returned
+		secretKeys, err := getFlowSecrets(flowID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get secrets for flow %d: %v", flowID, err)
+		}
+
+		// Get prompts
+		prompts, err := getFlowPrompts(flowID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get prompts for flow %d: %v", flowID, err)
+		}
+
 		// Get steps
 		steps, err := getFlowSteps(flowID)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get steps for flow %d: %v", flowID, err)
 		}
+		for i := range steps {
+			// Notes are informational, so unresolved references are left as written
+			if rendered, _ := interpolate(steps[i].Notes, variables); rendered != steps[i].Notes {
+				steps[i].RenderedNotes = secrets.mask(rendered)
+			}
+		}
 
 		flows = append(flows, Flow{
 			ID:        flowID,
 			Name:      flowName,
-			Variables: variables,
+			Variables: maskSecretVariables(variables, secretKeys),
+			Secrets:   secretKeys,
+			Prompts:   prompts,
+			EnvFiles:  decodeEnvFiles(envFiles),
 			Steps:     steps,
 		})
 	}
@@ -780,7 +1268,7 @@ func getAllFlows() ([]Flow, error) {
 }
 
 func getFlowVariables(flowID int) (map[string]string, error) {
-	rows, err := db.Query("SELECT key, value FROM variables WHERE flow_id = ?", flowID)
+	rows, err := db.Query("SELECT key, value, secret FROM variables WHERE flow_id = ?", flowID)
 	if err != nil {
 		return nil, err
 	}
@@ -789,9 +1277,15 @@ func getFlowVariables(flowID int) (map[string]string, error) {
 	variables := make(map[string]string)
 	for rows.Next() {
 		var key, value string
-		if err := rows.Scan(&key, &value); err != nil {
+		var secret bool
+		if err := rows.Scan(&key, &value, &secret); err != nil {
 			return nil, err
 		}
+		if secret {
+			if value, err = secrets.decrypt(value); err != nil {
+				return nil, fmt.Errorf("failed to decrypt variable %s: %v", key, err)
+			}
+		}
 		variables[key] = value
 	}
 
@@ -800,7 +1294,7 @@ func getFlowVariables(flowID int) (map[string]string, error) {
 
 func getFlowSteps(flowID int) ([]Step, error) {
 	rows, err := db.Query(
-		"SELECT id, name, command, notes, skip_prompt, terminal, tmux_session_name, is_tmux_terminal FROM steps WHERE flow_id = ? ORDER BY order_index",
+		"SELECT id, name, command, notes, skip_prompt, terminal, tmux_session_name, is_tmux_terminal, timeout, tmux_wait, depends_on, when_condition, retries, retry_delay, backoff, captures, env_files, working_dir, env FROM steps WHERE flow_id = ? ORDER BY order_index",
 		flowID,
 	)
 	if err != nil {
@@ -811,9 +1305,14 @@ func getFlowSteps(flowID int) ([]Step, error) {
 	var steps []Step
 	for rows.Next() {
 		var step Step
-		if err := rows.Scan(&step.ID, &step.Name, &step.Command, &step.Notes, &step.SkipPrompt, &step.Terminal, &step.TmuxSessionName, &step.IsTmuxTerminal); err != nil {
+		var dependsOn, captures, envFiles, env string
+		if err := rows.Scan(&step.ID, &step.Name, &step.Command, &step.Notes, &step.SkipPrompt, &step.Terminal, &step.TmuxSessionName, &step.IsTmuxTerminal, &step.Timeout, &step.TmuxWait, &dependsOn, &step.When, &step.Retries, &step.RetryDelay, &step.Backoff, &captures, &envFiles, &step.WorkingDir, &env); err != nil {
 			return nil, err
 		}
+		step.DependsOn = decodeDependsOn(dependsOn)
+		step.Captures = decodeCaptures(captures)
+		step.EnvFiles = decodeEnvFiles(envFiles)
+		step.Env = decodeStepEnv(env)
 		steps = append(steps, step)
 	}
 
@@ -823,179 +1322,163 @@ func getFlowSteps(flowID int) ([]Step, error) {
 // New function to get step by ID
 func getStepByID(stepID int) (*StepDB, error) {
 	var step StepDB
+	var dependsOn, captures, envFiles, env string
 	err := db.QueryRow(
-		"SELECT id, flow_id, name, command, notes, skip_prompt, terminal, tmux_session_name, is_tmux_terminal, order_index FROM steps WHERE id = ?",
+		"SELECT id, flow_id, name, command, notes, skip_prompt, terminal, tmux_session_name, is_tmux_terminal, order_index, timeout, tmux_wait, depends_on, when_condition, retries, retry_delay, backoff, captures, env_files, working_dir, env FROM steps WHERE id = ?",
 		stepID,
-	).Scan(&step.ID, &step.FlowID, &step.Name, &step.Command, &step.Notes, &step.SkipPrompt, &step.Terminal, &step.TmuxSessionName, &step.IsTmuxTerminal, &step.OrderIndex)
+	).Scan(&step.ID, &step.FlowID, &step.Name, &step.Command, &step.Notes, &step.SkipPrompt, &step.Terminal, &step.TmuxSessionName, &step.IsTmuxTerminal, &step.OrderIndex, &step.Timeout, &step.TmuxWait, &dependsOn, &step.When, &step.Retries, &step.RetryDelay, &step.Backoff, &captures, &envFiles, &step.WorkingDir, &env)
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to get step: %v", err)
 	}
+	step.DependsOn = decodeDependsOn(dependsOn)
+	step.Captures = decodeCaptures(captures)
+	step.EnvFiles = decodeEnvFiles(envFiles)
+	step.Env = decodeStepEnv(env)
 
 	return &step, nil
 }
 
-// Enhanced executeCommand function with tmux support
-func executeCommandWithTmux(command string, variables map[string]string, tmuxSessionName string, isTmuxTerminal bool) CommandResult {
+// ExecutionOptions controls how a step command is executed
+type ExecutionOptions struct {
+	TmuxSessionName string
+	IsTmuxTerminal  bool
+	Timeout         time.Duration     // Zero means no timeout
+	TmuxWait        time.Duration     // If non-zero, report tmux commands still running after this long as started
+	Output          OutputSink        // If non-nil, receives stdout and stderr chunks as they are produced
+	QueueKey        string            // Identifies the execution in the scheduler queue
+	OnStart         func()            // If non-nil, called once an execution slot has been acquired
+	WorkingDir      string            // Directory to run in, may reference variables
+	EnvFiles        []string          // Env files to load, see dotenv.go
+	Env             map[string]string // Extra environment variables, may reference variables
+	Audit           AuditEvent        // Who and what the execution is for, recorded with its outcome
+}
+
+// Enhanced executeCommand function with tmux support.
+// Cancelling ctx terminates the command and reports it as cancelled.
+func executeCommandWithTmux(ctx context.Context, command string, variables map[string]string, opts ExecutionOptions) (result CommandResult) {
 	start := time.Now()
+	output := opts.Output
 
-	// Substitute variables in the command
-	finalCommand := command
-	for key, value := range variables {
-		placeholder := fmt.Sprintf("${%s}", key)
-		finalCommand = strings.ReplaceAll(finalCommand, placeholder, value)
+	// Audit the execution however it ends
+	var finalCommand string
+	var startErr error
+	defer func() {
+		if opts.Audit.Target == "" {
+			opts.Audit.Target = opts.QueueKey
+		}
+		auditExecution(opts.Audit, command, finalCommand, result, startErr)
+		observeExecution(opts.Audit, result, startErr)
+	}()
+
+	// Resolve the working directory, env files and env; their variables can
+	// also be referenced by the command
+	cmdEnv, err := prepareCommandEnvironment(variables, opts.WorkingDir, opts.EnvFiles, opts.Env)
+	commandVariables := cmdEnv.lookup()
+
+	// Substitute variables in the command and session name
+	if err == nil {
+		finalCommand, err = interpolate(command, commandVariables)
+	}
+	if err == nil {
+		opts.TmuxSessionName, err = interpolate(opts.TmuxSessionName, commandVariables)
 	}
+	if err != nil {
+		slog.Error("Cannot execute command", "command", command, "error", err)
+		startErr = err
+		return CommandResult{
+			Command:    command,
+			ExitCode:   -1,
+			Stdout:     "",
+			Stderr:     err.Error(),
+			Duration:   time.Since(start),
+			Success:    false,
+			Status:     CommandStatusFailed,
+			ExecutedAt: start,
+		}
+	}
+	tmuxSessionName := opts.TmuxSessionName
 
 	log.Printf("Executing command: %s", finalCommand)
 	if len(variables) > 0 {
-		log.Printf("Original command: %s", command)
-		log.Printf("Variables: %+v", variables)
+		slog.Debug("Original command", "command", command, "variables", variables)
 	}
 
-	// Check if command is blocked by security policy
-	if isCommandBlocked(finalCommand) {
-		log.Printf("Command blocked by security policy: %s", finalCommand)
+	// Check the command against the security policy
+	if err := checkCommandPolicy(finalCommand); err != nil {
+		slog.Warn("Command blocked by security policy", "command", finalCommand)
+		startErr = err
 		return CommandResult{
 			Command:    command,
 			ExitCode:   -1,
 			Stdout:     "",
-			Stderr:     "Command blocked by security policy",
+			Stderr:     err.Error(),
 			Duration:   time.Since(start),
 			Success:    false,
+			Status:     CommandStatusFailed,
 			ExecutedAt: start,
 		}
 	}
 
-	var cmd *exec.Cmd
 	var stdout, stderr bytes.Buffer
 
-	if isTmuxTerminal && tmuxSessionName != "" {
-		// Check if tmux session exists
-		checkCmd := exec.Command("tmux", "has-session", "-t", tmuxSessionName)
-		setupCommandEnvironment(checkCmd, variables)
-		if err := checkCmd.Run(); err != nil {
-			// Session doesn't exist, create it
-			log.Printf("Creating tmux session: %s", tmuxSessionName)
-			createCmd := exec.Command("tmux", "new-session", "-d", "-s", tmuxSessionName)
-			setupCommandEnvironment(createCmd, variables)
-			if err := createCmd.Run(); err != nil {
-				log.Printf("Failed to create tmux session %s: %v", tmuxSessionName, err)
-				return CommandResult{
-					Command:    command,
-					ExitCode:   -1,
-					Stdout:     "",
-					Stderr:     fmt.Sprintf("Failed to create tmux session: %v", err),
-					Duration:   time.Since(start),
-					Success:    false,
-					ExecutedAt: start,
-				}
-			}
-		}
-
-		// For tmux execution, we'll use a different approach:
-		// 1. Send the command to the session
-		// 2. Capture the output by running the command in a way that returns results
-
-		// Create a script that will execute the command and capture output
-		tempScript := fmt.Sprintf("/tmp/devtool_tmux_%d.sh", time.Now().UnixNano())
-		scriptContent := fmt.Sprintf(`#!/bin/bash
-set -e
-cd "%s"
-%s
-`, getUserHomeDir(), finalCommand)
-
-		if err := os.WriteFile(tempScript, []byte(scriptContent), 0755); err != nil {
-			log.Printf("Failed to create temp script: %v", err)
-			return CommandResult{
-				Command:    command,
-				ExitCode:   -1,
-				Stdout:     "",
-				Stderr:     fmt.Sprintf("Failed to create temp script: %v", err),
-				Duration:   time.Since(start),
-				Success:    false,
-				ExecutedAt: start,
-			}
-		}
-		defer os.Remove(tempScript)
-
-		// Execute the script in the tmux session and capture output
-		cmd = exec.Command("tmux", "send-keys", "-t", tmuxSessionName, fmt.Sprintf("bash %s", tempScript), "Enter")
-		setupCommandEnvironment(cmd, variables)
-
-		// Send the command to tmux session
-		if err := cmd.Run(); err != nil {
-			log.Printf("Failed to send command to tmux session: %v", err)
-			return CommandResult{
-				Command:    command,
-				ExitCode:   -1,
-				Stdout:     "",
-				Stderr:     fmt.Sprintf("Failed to send command to tmux session: %v", err),
-				Duration:   time.Since(start),
-				Success:    false,
-				ExecutedAt: start,
-			}
-		}
-
-		// Wait a moment for command to execute
-		time.Sleep(500 * time.Millisecond)
-
-		// Capture the session output (this is a simplified approach)
-		captureCmd := exec.Command("tmux", "capture-pane", "-t", tmuxSessionName, "-p")
-		setupCommandEnvironment(captureCmd, variables)
-		output, err := captureCmd.Output()
-
-		if err != nil {
-			log.Printf("Failed to capture tmux output: %v", err)
-			stdout.WriteString(fmt.Sprintf("Command sent to tmux session '%s': %s", tmuxSessionName, finalCommand))
-		} else {
-			stdout.Write(output)
-		}
-
-		log.Printf("Command sent to tmux session %s: %s", tmuxSessionName, finalCommand)
-
-		// For tmux commands, we assume success since we can't easily get exit codes
+	// Wait for an execution slot; time spent queued doesn't count towards the timeout
+	slot, err := scheduler.acquire(ctx, opts.QueueKey, command)
+	if err != nil {
 		return CommandResult{
 			Command:    command,
-			ExitCode:   0,
-			Stdout:     stdout.String(),
-			Stderr:     stderr.String(),
+			ExitCode:   -1,
+			Stdout:     "",
+			Stderr:     "Command cancelled while queued",
 			Duration:   time.Since(start),
-			Success:    true,
+			Success:    false,
+			Status:     CommandStatusCancelled,
 			ExecutedAt: start,
 		}
-	} else {
-		// Regular command execution
-		cmd = exec.Command("/bin/bash", "-c", finalCommand)
-		setupCommandEnvironment(cmd, variables)
-		cmd.Stdout = &stdout
-		cmd.Stderr = &stderr
+	}
+	defer scheduler.release(slot)
+	if opts.OnStart != nil {
+		opts.OnStart()
+	}
+
+	ctx, cancel := withTimeout(ctx, opts.Timeout)
+	defer cancel()
+
+	if opts.IsTmuxTerminal && tmuxSessionName != "" {
+		return executeInTmux(ctx, command, finalCommand, cmdEnv, opts, start)
 	}
 
-	err := cmd.Run()
+	// Regular command execution
+	cmd := newShellCommand(ctx, finalCommand)
+	setupCommandEnvironment(cmd, cmdEnv)
+	cmd.Stdout = newOutputWriter(&stdout, StreamStdout, output)
+	cmd.Stderr = newOutputWriter(&stderr, StreamStderr, output)
+
+	err = cmd.Run()
 	duration := time.Since(start)
 
-	exitCode := 0
-	if err != nil {
-		if exitError, ok := err.(*exec.ExitError); ok {
-			exitCode = exitError.ExitCode()
-		} else {
-			exitCode = -1
-		}
+	exitCode, status := commandStatus(ctx, err)
+	switch status {
+	case CommandStatusTimedOut:
+		fmt.Fprintf(&stderr, "\nCommand timed out after %v", opts.Timeout)
+	case CommandStatusCancelled:
+		stderr.WriteString("\nCommand cancelled")
 	}
 
-	success := exitCode == 0
+	success := status == CommandStatusSucceeded
 
-	result := CommandResult{
+	result = CommandResult{
 		Command:    command,
 		ExitCode:   exitCode,
 		Stdout:     stdout.String(),
 		Stderr:     stderr.String(),
 		Duration:   duration,
 		Success:    success,
+		Status:     status,
 		ExecutedAt: start,
 	}
 
-	log.Printf("Command completed: exit_code=%d, duration=%v, success=%v", exitCode, duration, success)
+	log.Printf("Command completed: exit_code=%d, duration=%v, status=%s", exitCode, duration, status)
 	return result
 }
 
@@ -1011,23 +1494,56 @@ func handleStepExecution(c echo.Context) error {
 	// Get step details
 	step, err := getStepByID(req.StepID)
 	if err != nil {
-		log.Printf("Error getting step %d: %v", req.StepID, err)
+		slog.Error("Error getting step", "step_id", req.StepID, "error", err)
 		return c.JSON(http.StatusNotFound, map[string]string{
 			"error": "Step not found",
 		})
 	}
+	if err := authorize(c, PermissionRunFlows, step.FlowID); err != nil {
+		return forbidden(c, err)
+	}
 
-	// Get flow variables
-	variables, err := getFlowVariables(step.FlowID)
+	// Get flow variables, with any overrides for this execution
+	variables, err := getLaunchVariables(step.FlowID, req.Environment, req.Variables)
 	if err != nil {
-		log.Printf("Error getting variables for flow %d: %v", step.FlowID, err)
+		if launchErr, ok := err.(*LaunchError); ok {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": launchErr.Error(),
+			})
+		}
+		slog.Error("Error getting variables", "flow_id", step.FlowID, "error", err)
 		return c.JSON(http.StatusInternalServerError, map[string]string{
 			"error": "Failed to get flow variables",
 		})
 	}
 
-	// Execute the command
-	result := executeCommandWithTmux(step.Command, variables, step.TmuxSessionName, step.IsTmuxTerminal)
+	if skipped := checkStepCondition(step, variables); skipped != nil {
+		return c.JSON(http.StatusOK, skipped)
+	}
+
+	flowEnvFiles, err := getFlowEnvFiles(step.FlowID)
+	if err != nil {
+		slog.Error("Error getting env files", "flow_id", step.FlowID, "error", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get flow env files",
+		})
+	}
+
+	// Execute the command, registering it so it can be cancelled
+	ctx, done := executions.start(context.Background(), stepKey(step.ID))
+	defer done()
+
+	result := executeWithRetries(ctx, step.Command, variables, ExecutionOptions{
+		TmuxSessionName: step.TmuxSessionName,
+		IsTmuxTerminal:  step.IsTmuxTerminal,
+		Timeout:         commandTimeout(step.Timeout),
+		TmuxWait:        parseTmuxWait(step.TmuxWait),
+		QueueKey:        stepKey(step.ID),
+		WorkingDir:      step.WorkingDir,
+		EnvFiles:        stepEnvFiles(flowEnvFiles, step.EnvFiles),
+		Env:             step.Env,
+		Audit:           stepAudit(c, step.ID, step.FlowID),
+	}, newRetryPolicy(step.Retries, step.RetryDelay, step.Backoff), nil, nil)
 
 	return c.JSON(http.StatusOK, result)
 }
@@ -1047,6 +1563,24 @@ func handleCreateFlow(c echo.Context) error {
 		})
 	}
 
+	if err := validateFlowSteps(req.Steps); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	if err := validatePrompts(req.Prompts); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	if err := validateEnvFiles(req.EnvFiles); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
 	flow, err := createFlow(req)
 	if err != nil {
 		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
@@ -1054,11 +1588,12 @@ func handleCreateFlow(c echo.Context) error {
 				"error": "Flow with this name already exists",
 			})
 		}
-		log.Printf("Error creating flow: %v", err)
+		slog.Error("Error creating flow", "error", err)
 		return c.JSON(http.StatusInternalServerError, map[string]string{
 			"error": "Failed to create flow",
 		})
 	}
+	auditChange(c, AuditFlowCreated, flowKey(flow.ID), flow.ID, 0, flow.Name)
 
 	return c.JSON(http.StatusCreated, flow)
 }
@@ -1066,12 +1601,13 @@ func handleCreateFlow(c echo.Context) error {
 func getFlows(c echo.Context) error {
 	flows, err := getAllFlows()
 	if err != nil {
-		log.Printf("Error getting flows: %v", err)
+		slog.Error("Error getting flows", "error", err)
 		return c.JSON(http.StatusInternalServerError, map[string]string{
 			"error": "Failed to retrieve flows",
 		})
 	}
 
+	flows = visibleFlows(c, flows)
 	if flows == nil {
 		return c.JSON(http.StatusOK, []Flow{})
 	}
@@ -1103,7 +1639,7 @@ func setupStaticFileServer(e *echo.Echo) {
 
 		// Check if static directory exists
 		if _, err := os.Stat(staticDir); os.IsNotExist(err) {
-			log.Printf("Warning: Static directory %s does not exist", staticDir)
+			slog.Warn("Static directory does not exist", "path", staticDir)
 			return
 		}
 
@@ -1237,8 +1773,22 @@ func setupStaticFileServer(e *echo.Echo) {
             <li><span class="api-endpoint">GET /api/flows</span> - List all flows</li>
             <li><span class="api-endpoint">POST /api/flows</span> - Create new flow</li>
             <li><span class="api-endpoint">POST /api/execute-step</span> - Execute flow step</li>
+            <li><span class="api-endpoint">POST /api/execute-step/stream</span> - Execute flow step with live output</li>
+            <li><span class="api-endpoint">GET /api/flows/:id/prompts</span> - Launch form for a flow</li>
+            <li><span class="api-endpoint">GET /api/environments</span> - Variable environments</li>
+            <li><span class="api-endpoint">POST /api/flows/:id/runs</span> - Run an entire flow</li>
+            <li><span class="api-endpoint">GET /api/flows/:id/runs</span> - Run history and statistics of a flow</li>
+            <li><span class="api-endpoint">GET /api/runs/:id</span> - Get flow run results</li>
+            <li><span class="api-endpoint">GET /api/runs/:id/steps/:stepId/log</span> - Output log of a run step</li>
+            <li><span class="api-endpoint">POST /api/runs/:id/cancel</span> - Cancel a flow run</li>
+            <li><span class="api-endpoint">GET /api/executions</span> - Running and queued executions</li>
             <li><span class="api-endpoint">POST /api/execute-command</span> - Execute command</li>
+            <li><span class="api-endpoint">POST /api/commands/:id/cancel</span> - Cancel an executing command</li>
+            <li><span class="api-endpoint">POST /api/policy/check</span> - Explain the command policy decision for a command</li>
             <li><span class="api-endpoint">GET /api/shell</span> - WebSocket shell connection</li>
+            <li><span class="api-endpoint">GET /api/tokens</span> - API tokens</li>
+            <li><span class="api-endpoint">GET /api/audit</span> - Audit log</li>
+            <li><span class="api-endpoint">GET /metrics</span> - Prometheus metrics</li>
         </ul>
     </div>
 
@@ -1319,8 +1869,22 @@ func setupStaticFileServer(e *echo.Echo) {
             <li><span class="api-endpoint">GET /api/flows</span> - List all flows</li>
             <li><span class="api-endpoint">POST /api/flows</span> - Create new flow</li>
             <li><span class="api-endpoint">POST /api/execute-step</span> - Execute flow step</li>
+            <li><span class="api-endpoint">POST /api/execute-step/stream</span> - Execute flow step with live output</li>
+            <li><span class="api-endpoint">GET /api/flows/:id/prompts</span> - Launch form for a flow</li>
+            <li><span class="api-endpoint">GET /api/environments</span> - Variable environments</li>
+            <li><span class="api-endpoint">POST /api/flows/:id/runs</span> - Run an entire flow</li>
+            <li><span class="api-endpoint">GET /api/flows/:id/runs</span> - Run history and statistics of a flow</li>
+            <li><span class="api-endpoint">GET /api/runs/:id</span> - Get flow run results</li>
+            <li><span class="api-endpoint">GET /api/runs/:id/steps/:stepId/log</span> - Output log of a run step</li>
+            <li><span class="api-endpoint">POST /api/runs/:id/cancel</span> - Cancel a flow run</li>
+            <li><span class="api-endpoint">GET /api/executions</span> - Running and queued executions</li>
             <li><span class="api-endpoint">POST /api/execute-command</span> - Execute command</li>
+            <li><span class="api-endpoint">POST /api/commands/:id/cancel</span> - Cancel an executing command</li>
+            <li><span class="api-endpoint">POST /api/policy/check</span> - Explain the command policy decision for a command</li>
             <li><span class="api-endpoint">GET /api/shell</span> - WebSocket shell connection</li>
+            <li><span class="api-endpoint">GET /api/tokens</span> - API tokens</li>
+            <li><span class="api-endpoint">GET /api/audit</span> - Audit log</li>
+            <li><span class="api-endpoint">GET /metrics</span> - Prometheus metrics</li>
         </ul>
     </div>
 
@@ -1349,34 +1913,60 @@ type UpdateFlowRequest struct {
 	Name        string            `json:"name" binding:"required"`
 	Description string            `json:"description,omitempty"`
 	Variables   map[string]string `json:"variables,omitempty"`
+	Secrets     []string          `json:"secrets"`   // Existing secret flags are kept when omitted
+	Prompts     []VariablePrompt  `json:"prompts"`   // Existing prompts are kept when omitted
+	EnvFiles    []string          `json:"env_files"` // Existing env files are kept when omitted
 }
 
 type UpdateStepRequest struct {
-	Name            string `json:"name" binding:"required"`
-	Command         string `json:"command" binding:"required"`
-	Notes           string `json:"notes,omitempty"`
-	SkipPrompt      bool   `json:"skip_prompt"`
-	Terminal        bool   `json:"terminal"`
-	TmuxSessionName string `json:"tmux_session_name,omitempty"`
-	IsTmuxTerminal  bool   `json:"is_tmux_terminal"`
-	OrderIndex      int    `json:"order_index"`
+	Name            string            `json:"name" binding:"required"`
+	Command         string            `json:"command" binding:"required"`
+	Notes           string            `json:"notes,omitempty"`
+	SkipPrompt      bool              `json:"skip_prompt"`
+	Terminal        bool              `json:"terminal"`
+	TmuxSessionName string            `json:"tmux_session_name,omitempty"`
+	IsTmuxTerminal  bool              `json:"is_tmux_terminal"`
+	OrderIndex      int               `json:"order_index"`
+	Timeout         string            `json:"timeout,omitempty"`
+	TmuxWait        string            `json:"tmux_wait,omitempty"`
+	DependsOn       []string          `json:"depends_on,omitempty"`
+	When            string            `json:"when,omitempty"`
+	Retries         int               `json:"retries,omitempty"`
+	RetryDelay      string            `json:"retry_delay,omitempty"`
+	Backoff         string            `json:"backoff,omitempty"`
+	Captures        []OutputCapture   `json:"captures,omitempty"`
+	EnvFiles        []string          `json:"env_files,omitempty"`
+	WorkingDir      string            `json:"working_dir,omitempty"`
+	Env             map[string]string `json:"env,omitempty"`
 }
 
 type CreateStepRequest struct {
-	FlowID          int    `json:"flow_id" binding:"required"`
-	Name            string `json:"name" binding:"required"`
-	Command         string `json:"command" binding:"required"`
-	Notes           string `json:"notes,omitempty"`
-	SkipPrompt      bool   `json:"skip_prompt"`
-	Terminal        bool   `json:"terminal"`
-	TmuxSessionName string `json:"tmux_session_name,omitempty"`
-	IsTmuxTerminal  bool   `json:"is_tmux_terminal"`
-	OrderIndex      int    `json:"order_index"`
+	FlowID          int               `json:"flow_id" binding:"required"`
+	Name            string            `json:"name" binding:"required"`
+	Command         string            `json:"command" binding:"required"`
+	Notes           string            `json:"notes,omitempty"`
+	SkipPrompt      bool              `json:"skip_prompt"`
+	Terminal        bool              `json:"terminal"`
+	TmuxSessionName string            `json:"tmux_session_name,omitempty"`
+	IsTmuxTerminal  bool              `json:"is_tmux_terminal"`
+	OrderIndex      int               `json:"order_index"`
+	Timeout         string            `json:"timeout,omitempty"`
+	TmuxWait        string            `json:"tmux_wait,omitempty"`
+	DependsOn       []string          `json:"depends_on,omitempty"`
+	When            string            `json:"when,omitempty"`
+	Retries         int               `json:"retries,omitempty"`
+	RetryDelay      string            `json:"retry_delay,omitempty"`
+	Backoff         string            `json:"backoff,omitempty"`
+	Captures        []OutputCapture   `json:"captures,omitempty"`
+	EnvFiles        []string          `json:"env_files,omitempty"`
+	WorkingDir      string            `json:"working_dir,omitempty"`
+	Env             map[string]string `json:"env,omitempty"`
 }
 
 type UpdateVariableRequest struct {
-	Key   string `json:"key" binding:"required"`
-	Value string `json:"value"`
+	Key    string `json:"key" binding:"required"`
+	Value  string `json:"value"`
+	Secret bool   `json:"secret"`
 }
 
 // Export/Import types
@@ -1384,26 +1974,43 @@ type ExportFlowResponse struct {
 	Name        string            `json:"name"`
 	Description string            `json:"description,omitempty"`
 	Variables   map[string]string `json:"variables"`
+	Secrets     []string          `json:"secrets,omitempty"` // Names of secret variables; their values are not exported
+	Prompts     []VariablePrompt  `json:"prompts,omitempty"`
+	EnvFiles    []string          `json:"env_files,omitempty"`
 	Steps       []ExportStep      `json:"steps"`
 	ExportedAt  time.Time         `json:"exported_at"`
 	Version     string            `json:"version"`
 }
 
 type ExportStep struct {
-	Name            string `json:"name"`
-	Command         string `json:"command"`
-	Notes           string `json:"notes,omitempty"`
-	SkipPrompt      bool   `json:"skip_prompt"`
-	Terminal        bool   `json:"terminal"`
-	TmuxSessionName string `json:"tmux_session_name,omitempty"`
-	IsTmuxTerminal  bool   `json:"is_tmux_terminal"`
-	OrderIndex      int    `json:"order_index"`
+	Name            string            `json:"name"`
+	Command         string            `json:"command"`
+	Notes           string            `json:"notes,omitempty"`
+	SkipPrompt      bool              `json:"skip_prompt"`
+	Terminal        bool              `json:"terminal"`
+	TmuxSessionName string            `json:"tmux_session_name,omitempty"`
+	IsTmuxTerminal  bool              `json:"is_tmux_terminal"`
+	OrderIndex      int               `json:"order_index"`
+	Timeout         string            `json:"timeout,omitempty"`
+	TmuxWait        string            `json:"tmux_wait,omitempty"`
+	DependsOn       []string          `json:"depends_on,omitempty"`
+	When            string            `json:"when,omitempty"`
+	Retries         int               `json:"retries,omitempty"`
+	RetryDelay      string            `json:"retry_delay,omitempty"`
+	Backoff         string            `json:"backoff,omitempty"`
+	Captures        []OutputCapture   `json:"captures,omitempty"`
+	EnvFiles        []string          `json:"env_files,omitempty"`
+	WorkingDir      string            `json:"working_dir,omitempty"`
+	Env             map[string]string `json:"env,omitempty"`
 }
 
 type ImportFlowRequest struct {
 	Name        string            `json:"name"`
 	Description string            `json:"description,omitempty"`
 	Variables   map[string]string `json:"variables"`
+	Secrets     []string          `json:"secrets,omitempty"`
+	Prompts     []VariablePrompt  `json:"prompts,omitempty"`
+	EnvFiles    []string          `json:"env_files,omitempty"`
 	Steps       []ExportStep      `json:"steps"`
 	// Optional fields for validation
 	ExportedAt time.Time `json:"exported_at,omitempty"`
@@ -1412,6 +2019,18 @@ type ImportFlowRequest struct {
 
 // Database operations for editing
 func updateFlow(flowID int, req UpdateFlowRequest) (*FlowDB, error) {
+	// Secrets are shown masked, so unchanged secrets come back as the mask
+	previous, err := getFlowVariables(flowID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get existing variables: %v", err)
+	}
+	secretKeys := req.Secrets
+	if secretKeys == nil {
+		if secretKeys, err = getFlowSecrets(flowID); err != nil {
+			return nil, fmt.Errorf("failed to get existing secrets: %v", err)
+		}
+	}
+
 	tx, err := db.Begin()
 	if err != nil {
 		return nil, fmt.Errorf("failed to begin transaction: %v", err)
@@ -1434,13 +2053,19 @@ func updateFlow(flowID int, req UpdateFlowRequest) (*FlowDB, error) {
 	}
 
 	// Insert new variables
-	for key, value := range req.Variables {
-		_, err = tx.Exec(
-			"INSERT INTO variables (flow_id, key, value) VALUES (?, ?, ?)",
-			flowID, key, value,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("failed to insert variable %s: %v", key, err)
+	if err := storeVariables(tx, int64(flowID), req.Variables, secretKeys, previous); err != nil {
+		return nil, err
+	}
+
+	if req.Prompts != nil {
+		if err := savePrompts(tx, int64(flowID), req.Prompts); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.EnvFiles != nil {
+		if _, err := tx.Exec("UPDATE flows SET env_files = ? WHERE id = ?", encodeEnvFiles(req.EnvFiles), flowID); err != nil {
+			return nil, fmt.Errorf("failed to update env files: %v", err)
 		}
 	}
 
@@ -1453,8 +2078,8 @@ func updateFlow(flowID int, req UpdateFlowRequest) (*FlowDB, error) {
 
 func updateStep(stepID int, req UpdateStepRequest) (*StepDB, error) {
 	_, err := db.Exec(
-		"UPDATE steps SET name = ?, command = ?, notes = ?, skip_prompt = ?, terminal = ?, tmux_session_name = ?, is_tmux_terminal = ?, order_index = ? WHERE id = ?",
-		req.Name, req.Command, req.Notes, req.SkipPrompt, req.Terminal, req.TmuxSessionName, req.IsTmuxTerminal, req.OrderIndex, stepID,
+		"UPDATE steps SET name = ?, command = ?, notes = ?, skip_prompt = ?, terminal = ?, tmux_session_name = ?, is_tmux_terminal = ?, order_index = ?, timeout = ?, tmux_wait = ?, depends_on = ?, when_condition = ?, retries = ?, retry_delay = ?, backoff = ?, captures = ?, env_files = ?, working_dir = ?, env = ? WHERE id = ?",
+		req.Name, req.Command, req.Notes, req.SkipPrompt, req.Terminal, req.TmuxSessionName, req.IsTmuxTerminal, req.OrderIndex, req.Timeout, req.TmuxWait, encodeDependsOn(req.DependsOn), req.When, req.Retries, req.RetryDelay, req.Backoff, encodeCaptures(req.Captures), encodeEnvFiles(req.EnvFiles), req.WorkingDir, encodeStepEnv(req.Env), stepID,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update step: %v", err)
@@ -1465,8 +2090,8 @@ func updateStep(stepID int, req UpdateStepRequest) (*StepDB, error) {
 
 func createStep(req CreateStepRequest) (*StepDB, error) {
 	result, err := db.Exec(
-		"INSERT INTO steps (flow_id, name, command, notes, skip_prompt, terminal, tmux_session_name, is_tmux_terminal, order_index) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)",
-		req.FlowID, req.Name, req.Command, req.Notes, req.SkipPrompt, req.Terminal, req.TmuxSessionName, req.IsTmuxTerminal, req.OrderIndex,
+		"INSERT INTO steps (flow_id, name, command, notes, skip_prompt, terminal, tmux_session_name, is_tmux_terminal, order_index, timeout, tmux_wait, depends_on, when_condition, retries, retry_delay, backoff, captures, env_files, working_dir, env) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		req.FlowID, req.Name, req.Command, req.Notes, req.SkipPrompt, req.Terminal, req.TmuxSessionName, req.IsTmuxTerminal, req.OrderIndex, req.Timeout, req.TmuxWait, encodeDependsOn(req.DependsOn), req.When, req.Retries, req.RetryDelay, req.Backoff, encodeCaptures(req.Captures), encodeEnvFiles(req.EnvFiles), req.WorkingDir, encodeStepEnv(req.Env),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create step: %v", err)
@@ -1489,9 +2114,20 @@ func deleteStep(stepID int) error {
 }
 
 func updateVariable(flowID int, key string, req UpdateVariableRequest) error {
+	value := req.Value
+	if req.Secret {
+		previous, err := getFlowVariables(flowID)
+		if err != nil {
+			return fmt.Errorf("failed to get existing variables: %v", err)
+		}
+		if value, err = sealSecret(req.Key, value, previous); err != nil {
+			return err
+		}
+	}
+
 	_, err := db.Exec(
-		"INSERT OR REPLACE INTO variables (flow_id, key, value) VALUES (?, ?, ?)",
-		flowID, req.Key, req.Value,
+		"INSERT OR REPLACE INTO variables (flow_id, key, value, secret) VALUES (?, ?, ?, ?)",
+		flowID, req.Key, value, req.Secret,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to update variable: %v", err)
@@ -1529,6 +2165,26 @@ func exportFlow(flowID int) (*ExportFlowResponse, error) {
 		return nil, fmt.Errorf("failed to get flow variables: %v", err)
 	}
 
+	// Secret values are left out of exports
+	secretKeys, err := getFlowSecrets(flowID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get flow secrets: %v", err)
+	}
+	for _, key := range secretKeys {
+		delete(variables, key)
+	}
+
+	// Get flow prompts
+	prompts, err := getFlowPrompts(flowID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get flow prompts: %v", err)
+	}
+
+	envFiles, err := getFlowEnvFiles(flowID)
+	if err != nil {
+		return nil, err
+	}
+
 	// Get flow steps
 	steps, err := getFlowSteps(flowID)
 	if err != nil {
@@ -1547,6 +2203,17 @@ func exportFlow(flowID int) (*ExportFlowResponse, error) {
 			TmuxSessionName: step.TmuxSessionName,
 			IsTmuxTerminal:  step.IsTmuxTerminal,
 			OrderIndex:      i, // Use array index for consistent ordering
+			Timeout:         step.Timeout,
+			TmuxWait:        step.TmuxWait,
+			DependsOn:       step.DependsOn,
+			When:            step.When,
+			Retries:         step.Retries,
+			RetryDelay:      step.RetryDelay,
+			Backoff:         step.Backoff,
+			Captures:        step.Captures,
+			EnvFiles:        step.EnvFiles,
+			WorkingDir:      step.WorkingDir,
+			Env:             step.Env,
 		}
 	}
 
@@ -1554,6 +2221,9 @@ func exportFlow(flowID int) (*ExportFlowResponse, error) {
 		Name:        flow.Name,
 		Description: flow.Description,
 		Variables:   variables,
+		Secrets:     secretKeys,
+		Prompts:     prompts,
+		EnvFiles:    envFiles,
 		Steps:       exportSteps,
 		ExportedAt:  time.Now(),
 		Version:     version,
@@ -1566,16 +2236,36 @@ func importFlow(req ImportFlowRequest) (*FlowDB, error) {
 		return nil, fmt.Errorf("flow name is required")
 	}
 
+	// Secrets are exported without values; they start out empty unless given
+	variables := make(map[string]string, len(req.Variables)+len(req.Secrets))
+	for key, value := range req.Variables {
+		variables[key] = value
+	}
+	for _, key := range req.Secrets {
+		if _, ok := variables[key]; !ok {
+			variables[key] = ""
+		}
+	}
+
 	// Convert ImportFlowRequest to CreateFlowRequest
 	createReq := CreateFlowRequest{
 		Name:      req.Name,
-		Variables: req.Variables,
-		Steps:     make([]Step, len(req.Steps)),
+		Variables: variables,
+		Secrets:   req.Secrets,
+		Prompts:   req.Prompts,
+		EnvFiles:  req.EnvFiles,
+		Steps:     importSteps(req.Steps),
 	}
 
-	// Convert steps from import format
-	for i, importStep := range req.Steps {
-		createReq.Steps[i] = Step{
+	// Create the flow
+	return createFlow(createReq)
+}
+
+// importSteps converts steps from import format
+func importSteps(exportSteps []ExportStep) []Step {
+	steps := make([]Step, len(exportSteps))
+	for i, importStep := range exportSteps {
+		steps[i] = Step{
 			Name:            importStep.Name,
 			Command:         importStep.Command,
 			Notes:           importStep.Notes,
@@ -1583,11 +2273,120 @@ func importFlow(req ImportFlowRequest) (*FlowDB, error) {
 			Terminal:        importStep.Terminal,
 			TmuxSessionName: importStep.TmuxSessionName,
 			IsTmuxTerminal:  importStep.IsTmuxTerminal,
+			Timeout:         importStep.Timeout,
+			TmuxWait:        importStep.TmuxWait,
+			DependsOn:       importStep.DependsOn,
+			When:            importStep.When,
+			Retries:         importStep.Retries,
+			RetryDelay:      importStep.RetryDelay,
+			Backoff:         importStep.Backoff,
+			Captures:        importStep.Captures,
+			EnvFiles:        importStep.EnvFiles,
+			WorkingDir:      importStep.WorkingDir,
+			Env:             importStep.Env,
 		}
 	}
+	return steps
+}
 
-	// Create the flow
-	return createFlow(createReq)
+// validateFlowSteps checks step definitions before they are stored
+func validateFlowSteps(steps []Step) error {
+	for _, step := range steps {
+		if err := validateStepDurations(step.Timeout, step.TmuxWait); err != nil {
+			return fmt.Errorf("step %q: %v", step.Name, err)
+		}
+		if err := validateRetryPolicy(step.Retries, step.RetryDelay, step.Backoff); err != nil {
+			return fmt.Errorf("step %q: %v", step.Name, err)
+		}
+		if err := validateCaptures(step.Captures); err != nil {
+			return fmt.Errorf("step %q: %v", step.Name, err)
+		}
+		if err := validateEnvFiles(step.EnvFiles); err != nil {
+			return fmt.Errorf("step %q: %v", step.Name, err)
+		}
+		if err := validateStepEnv(step.Env); err != nil {
+			return fmt.Errorf("step %q: %v", step.Name, err)
+		}
+	}
+
+	_, err := stepDependencies(steps)
+	return err
+}
+
+// validateStepChange validates a flow's steps as they would be after a step is
+// created (stepID is zero) or updated, so that dependency errors are caught
+func validateStepChange(flowID, stepID int, changed Step) error {
+	steps, err := getFlowSteps(flowID)
+	if err != nil {
+		return fmt.Errorf("failed to get flow steps: %v", err)
+	}
+
+	replaced := false
+	for i, step := range steps {
+		if stepID != 0 && step.ID == stepID {
+			steps[i] = changed
+			replaced = true
+		}
+	}
+	if !replaced {
+		steps = append(steps, changed)
+	}
+
+	return validateFlowSteps(steps)
+}
+
+// validateStepDeletion validates a flow's steps as they would be after a step
+// is deleted, returning an error if other steps still refer to it
+func validateStepDeletion(flowID, stepID int) error {
+	steps, err := getFlowSteps(flowID)
+	if err != nil {
+		return fmt.Errorf("failed to get flow steps: %v", err)
+	}
+
+	var deleted *Step
+	var remaining []Step
+	for i := range steps {
+		if steps[i].ID == stepID {
+			deleted = &steps[i]
+		} else {
+			remaining = append(remaining, steps[i])
+		}
+	}
+	if deleted == nil {
+		return nil
+	}
+
+	var dependents []string
+	for _, step := range remaining {
+		refs := step.DependsOn
+		if when, err := parseWhen(step.When); err == nil && when != nil {
+			refs = append(append([]string(nil), refs...), when.stepRefs...)
+		}
+		for _, ref := range refs {
+			if ref == deleted.Name {
+				dependents = append(dependents, fmt.Sprintf("%q", step.Name))
+				break
+			}
+		}
+	}
+	if len(dependents) > 0 {
+		return &StepInUseError{Message: fmt.Sprintf("step %q is referenced by %s; remove those references first", deleted.Name, strings.Join(dependents, ", "))}
+	}
+
+	if err := validateFlowSteps(remaining); err != nil {
+		return &StepInUseError{Message: err.Error()}
+	}
+	return nil
+}
+
+// StepInUseError reports a step that can't be deleted without invalidating
+// its flow; handlers return it as a 409
+type StepInUseError struct {
+	Message string
+}
+
+func (e *StepInUseError) Error() string {
+	return e.Message
 }
 
 // New handlers for editing
@@ -1613,6 +2412,18 @@ func handleUpdateFlow(c echo.Context) error {
 		})
 	}
 
+	if err := validatePrompts(req.Prompts); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	if err := validateEnvFiles(req.EnvFiles); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
 	flow, err := updateFlow(id, req)
 	if err != nil {
 		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
@@ -1620,11 +2431,12 @@ func handleUpdateFlow(c echo.Context) error {
 				"error": "Flow with this name already exists",
 			})
 		}
-		log.Printf("Error updating flow: %v", err)
+		slog.Error("Error updating flow", "error", err)
 		return c.JSON(http.StatusInternalServerError, map[string]string{
 			"error": "Failed to update flow",
 		})
 	}
+	auditChange(c, AuditFlowUpdated, flowKey(id), id, 0, flow.Name)
 
 	return c.JSON(http.StatusOK, flow)
 }
@@ -1645,11 +2457,12 @@ func handleDeleteFlow(c echo.Context) error {
 	}
 
 	if err := deleteFlow(id); err != nil {
-		log.Printf("Error deleting flow: %v", err)
+		slog.Error("Error deleting flow", "error", err)
 		return c.JSON(http.StatusInternalServerError, map[string]string{
 			"error": "Failed to delete flow",
 		})
 	}
+	auditChange(c, AuditFlowDeleted, flowKey(id), id, 0, "")
 
 	return c.JSON(http.StatusOK, map[string]string{
 		"message": "Flow deleted successfully",
@@ -1678,13 +2491,46 @@ func handleUpdateStep(c echo.Context) error {
 		})
 	}
 
+	existing, err := getStepByID(id)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "Step not found",
+		})
+	}
+
+	if err := validateStepChange(existing.FlowID, id, Step{
+		Name:            req.Name,
+		Command:         req.Command,
+		Notes:           req.Notes,
+		SkipPrompt:      req.SkipPrompt,
+		Terminal:        req.Terminal,
+		TmuxSessionName: req.TmuxSessionName,
+		IsTmuxTerminal:  req.IsTmuxTerminal,
+		Timeout:         req.Timeout,
+		TmuxWait:        req.TmuxWait,
+		DependsOn:       req.DependsOn,
+		When:            req.When,
+		Retries:         req.Retries,
+		RetryDelay:      req.RetryDelay,
+		Backoff:         req.Backoff,
+		Captures:        req.Captures,
+		EnvFiles:        req.EnvFiles,
+		WorkingDir:      req.WorkingDir,
+		Env:             req.Env,
+	}); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
 	step, err := updateStep(id, req)
 	if err != nil {
-		log.Printf("Error updating step: %v", err)
+		slog.Error("Error updating step", "error", err)
 		return c.JSON(http.StatusInternalServerError, map[string]string{
 			"error": "Failed to update step",
 		})
 	}
+	auditChange(c, AuditStepUpdated, stepKey(id), existing.FlowID, id, step.Name)
 
 	return c.JSON(http.StatusOK, step)
 }
@@ -1696,14 +2542,43 @@ func handleCreateStep(c echo.Context) error {
 			"error": "Invalid request payload",
 		})
 	}
+	if err := authorize(c, PermissionEditFlows, req.FlowID); err != nil {
+		return forbidden(c, err)
+	}
+
+	if err := validateStepChange(req.FlowID, 0, Step{
+		Name:            req.Name,
+		Command:         req.Command,
+		Notes:           req.Notes,
+		SkipPrompt:      req.SkipPrompt,
+		Terminal:        req.Terminal,
+		TmuxSessionName: req.TmuxSessionName,
+		IsTmuxTerminal:  req.IsTmuxTerminal,
+		Timeout:         req.Timeout,
+		TmuxWait:        req.TmuxWait,
+		DependsOn:       req.DependsOn,
+		When:            req.When,
+		Retries:         req.Retries,
+		RetryDelay:      req.RetryDelay,
+		Backoff:         req.Backoff,
+		Captures:        req.Captures,
+		EnvFiles:        req.EnvFiles,
+		WorkingDir:      req.WorkingDir,
+		Env:             req.Env,
+	}); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
 
 	step, err := createStep(req)
 	if err != nil {
-		log.Printf("Error creating step: %v", err)
+		slog.Error("Error creating step", "error", err)
 		return c.JSON(http.StatusInternalServerError, map[string]string{
 			"error": "Failed to create step",
 		})
 	}
+	auditChange(c, AuditStepCreated, stepKey(step.ID), step.FlowID, step.ID, step.Name)
 
 	return c.JSON(http.StatusCreated, step)
 }
@@ -1723,12 +2598,26 @@ func handleDeleteStep(c echo.Context) error {
 		})
 	}
 
+	flowID, _ := flowOfStep(id)
+	if err := validateStepDeletion(flowID, id); err != nil {
+		if _, ok := err.(*StepInUseError); ok {
+			return c.JSON(http.StatusConflict, map[string]string{
+				"error": err.Error(),
+			})
+		}
+		slog.Error("Error validating step deletion", "error", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to delete step",
+		})
+	}
+
 	if err := deleteStep(id); err != nil {
-		log.Printf("Error deleting step: %v", err)
+		slog.Error("Error deleting step", "error", err)
 		return c.JSON(http.StatusInternalServerError, map[string]string{
 			"error": "Failed to delete step",
 		})
 	}
+	auditChange(c, AuditStepDeleted, stepKey(id), flowID, id, "")
 
 	return c.JSON(http.StatusOK, map[string]string{
 		"message": "Step deleted successfully",
@@ -1760,11 +2649,12 @@ func handleUpdateVariable(c echo.Context) error {
 	}
 
 	if err := updateVariable(id, key, req); err != nil {
-		log.Printf("Error updating variable: %v", err)
+		slog.Error("Error updating variable", "error", err)
 		return c.JSON(http.StatusInternalServerError, map[string]string{
 			"error": "Failed to update variable",
 		})
 	}
+	auditChange(c, AuditVariableUpdated, flowKey(id), id, 0, "variable "+key)
 
 	return c.JSON(http.StatusOK, map[string]string{
 		"message": "Variable updated successfully",
@@ -1789,11 +2679,12 @@ func handleDeleteVariable(c echo.Context) error {
 	}
 
 	if err := deleteVariable(id, key); err != nil {
-		log.Printf("Error deleting variable: %v", err)
+		slog.Error("Error deleting variable", "error", err)
 		return c.JSON(http.StatusInternalServerError, map[string]string{
 			"error": "Failed to delete variable",
 		})
 	}
+	auditChange(c, AuditVariableDeleted, flowKey(id), id, 0, "variable "+key)
 
 	return c.JSON(http.StatusOK, map[string]string{
 		"message": "Variable deleted successfully",
@@ -1818,7 +2709,7 @@ func handleExportFlow(c echo.Context) error {
 
 	exportData, err := exportFlow(id)
 	if err != nil {
-		log.Printf("Error exporting flow: %v", err)
+		slog.Error("Error exporting flow", "error", err)
 		return c.JSON(http.StatusInternalServerError, map[string]string{
 			"error": "Failed to export flow",
 		})
@@ -1846,10 +2737,28 @@ func handleImportFlow(c echo.Context) error {
 		})
 	}
 
+	if err := validateFlowSteps(importSteps(req.Steps)); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	if err := validatePrompts(req.Prompts); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	if err := validateEnvFiles(req.EnvFiles); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
 	// Check if flow already exists
 	flows, err := getAllFlows()
 	if err != nil {
-		log.Printf("Error checking existing flows: %v", err)
+		slog.Error("Error checking existing flows", "error", err)
 		return c.JSON(http.StatusInternalServerError, map[string]string{
 			"error": "Failed to check existing flows",
 		})
@@ -1870,11 +2779,12 @@ func handleImportFlow(c echo.Context) error {
 				"error": "Flow with this name already exists",
 			})
 		}
-		log.Printf("Error importing flow: %v", err)
+		slog.Error("Error importing flow", "error", err)
 		return c.JSON(http.StatusInternalServerError, map[string]string{
 			"error": "Failed to import flow",
 		})
 	}
+	auditChange(c, AuditFlowImported, flowKey(flow.ID), flow.ID, 0, flow.Name)
 
 	return c.JSON(http.StatusCreated, map[string]interface{}{
 		"message": "Flow imported successfully",
@@ -1967,9 +2877,10 @@ func handleDiagnostics(c echo.Context) error {
 func main() {
 	// Command line flags
 	var (
-		configPath  = flag.String("config", "", "Path to configuration file")
-		showVersion = flag.Bool("version", false, "Show version information")
-		showHelp    = flag.Bool("help", false, "Show help information")
+		configPath      = flag.String("config", "", "Path to configuration file")
+		showVersion     = flag.Bool("version", false, "Show version information")
+		showHelp        = flag.Bool("help", false, "Show help information")
+		resetAdminToken = flag.Bool("reset-admin-token", false, "Replace the admin API token with a new one")
 	)
 	flag.Parse()
 
@@ -1988,6 +2899,9 @@ func main() {
 		return
 	}
 
+	// Secret variable values never reach the log
+	log.SetOutput(&maskingWriter{w: os.Stderr})
+
 	// Load configuration
 	var err error
 	config, err = loadConfig(*configPath)
@@ -1995,6 +2909,22 @@ func main() {
 		log.Fatalf("Failed to load configuration: %v", err)
 	}
 
+	if err := initLogging(config.Logging, config.Data.LogsDir); err != nil {
+		log.Fatalf("Failed to initialize logging: %v", err)
+	}
+	if err := initRunLogs(config.Logging.RunLogs, config.Data.LogsDir); err != nil {
+		log.Fatalf("Failed to initialize run logs: %v", err)
+	}
+
+	if err := initSecrets(config.Security.Secrets, config.Data.BaseDir); err != nil {
+		log.Fatalf("Failed to initialize secrets: %v", err)
+	}
+	if err := initCommandPolicy(config.Security.CommandPolicy, config.Flows.Validation.BlockedCommands); err != nil {
+		log.Fatalf("Failed to initialize command policy: %v", err)
+	}
+
+	scheduler = newExecutionScheduler(config.System.Shell.MaxConcurrent)
+
 	// Update version from config if available
 	if config.Service.Version != "" {
 		version = config.Service.Version
@@ -2007,11 +2937,15 @@ func main() {
 		log.Fatalf("Failed to initialize database: %v", err)
 	}
 
+	if err := initAuth(config.Security.Auth, config.Data.BaseDir, *resetAdminToken); err != nil {
+		log.Fatalf("Failed to initialize authentication: %v", err)
+	}
+
 	// Ensure database is closed on exit
 	defer func() {
 		if db != nil {
 			if err := db.Close(); err != nil {
-				log.Printf("Error closing database: %v", err)
+				slog.Error("Error closing database", "error", err)
 			}
 		}
 	}()
@@ -2019,7 +2953,8 @@ func main() {
 	e := echo.New()
 
 	// Middleware
-	e.Use(middleware.Logger())
+	e.Use(metricsMiddleware)
+	setupEchoLogging(e)
 	e.Use(middleware.Recover())
 
 	// CORS middleware with configuration
@@ -2032,38 +2967,80 @@ func main() {
 	// Setup static file serving
 	setupStaticFileServer(e)
 
-	// API routes
-	api := e.Group("/api")
+	// Sign-in page for the web interface
+	e.GET("/login", handleLoginPage)
+	e.POST("/login", handleLogin)
+	e.POST("/logout", handleLogout)
+
+	// Health check endpoint, open so that liveness probes need no token
+	e.GET("/api/health", handleHealthCheck)
+
+	// Prometheus metrics, outside /api but behind a token like it
+	e.GET("/metrics", handleMetrics, requireToken, requirePermission(PermissionViewFlows))
+
+	// API routes, all of which except /api/health require a token
+	api := e.Group("/api", requireToken)
+
+	// Permissions are checked per route; handlers that take the step or flow
+	// from the request body or query check them themselves (see rbac.go)
 
 	// Flow routes
-	api.POST("/flows", handleCreateFlow)
+	api.POST("/flows", handleCreateFlow, requirePermission(PermissionEditFlows))
 	api.GET("/flows", getFlows)
 
 	// Step execution routes
 	api.POST("/execute-step", handleStepExecution)
+	api.POST("/execute-step/stream", handleStreamStepExecution)
+
+	// Flow run routes
+	api.GET("/flows/:id/prompts", handleGetFlowPrompts, requireFlowPermission(PermissionViewFlows, "id", flowOfFlow))
+	api.POST("/flows/:id/runs", handleStartFlowRun, requireFlowPermission(PermissionRunFlows, "id", flowOfFlow))
+	api.GET("/flows/:id/runs", handleGetFlowRuns, requireFlowPermission(PermissionViewFlows, "id", flowOfFlow))
+	api.GET("/runs/:id", handleGetRun, requireFlowPermission(PermissionViewFlows, "id", flowOfRun))
+	api.GET("/runs/:id/steps/:stepId/log", handleGetRunStepLog, requireFlowPermission(PermissionViewFlows, "id", flowOfRun))
+	api.POST("/runs/:id/cancel", handleCancelRun, requireFlowPermission(PermissionRunFlows, "id", flowOfRun))
+	api.POST("/steps/:id/cancel", handleCancelStep, requireFlowPermission(PermissionRunFlows, "id", flowOfStep))
+	api.POST("/commands/:id/cancel", handleCancelCommand, requirePermission(PermissionRunCommands))
+	api.GET("/executions", handleGetExecutions)
 
 	// Shell routes
 	api.GET("/shell", handleShellWebSocket)
-	api.POST("/execute-command", handleCommandExecution)
-
-	// Health check endpoint
-	api.GET("/health", handleHealthCheck)
+	api.POST("/execute-command", handleCommandExecution, requirePermission(PermissionRunCommands))
+	api.POST("/policy/check", handlePolicyCheck, requirePermission(PermissionViewFlows))
 
 	// Diagnostic endpoint for troubleshooting permissions
-	api.GET("/diagnostics", handleDiagnostics)
+	api.GET("/diagnostics", handleDiagnostics, requirePermission(PermissionAdmin))
 
 	// New handlers for editing
-	api.PUT("/flows/:id", handleUpdateFlow)
-	api.DELETE("/flows/:id", handleDeleteFlow)
-	api.PUT("/steps/:id", handleUpdateStep)
+	api.PUT("/flows/:id", handleUpdateFlow, requireFlowPermission(PermissionEditFlows, "id", flowOfFlow))
+	api.DELETE("/flows/:id", handleDeleteFlow, requireFlowPermission(PermissionEditFlows, "id", flowOfFlow))
+	api.PUT("/steps/:id", handleUpdateStep, requireFlowPermission(PermissionEditFlows, "id", flowOfStep))
 	api.POST("/steps", handleCreateStep)
-	api.DELETE("/steps/:id", handleDeleteStep)
-	api.PUT("/variables/:flowId/:key", handleUpdateVariable)
-	api.DELETE("/variables/:flowId/:key", handleDeleteVariable)
+	api.DELETE("/steps/:id", handleDeleteStep, requireFlowPermission(PermissionEditFlows, "id", flowOfStep))
+	api.PUT("/variables/:flowId/:key", handleUpdateVariable, requireFlowPermission(PermissionEditFlows, "flowId", flowOfFlow))
+	api.DELETE("/variables/:flowId/:key", handleDeleteVariable, requireFlowPermission(PermissionEditFlows, "flowId", flowOfFlow))
+
+	// Global variables and environments
+	api.GET("/global-variables", handleGetGlobalVariables, requirePermission(PermissionViewFlows))
+	api.PUT("/global-variables", handleUpdateGlobalVariables, requirePermission(PermissionEditFlows))
+	api.GET("/environments", handleGetEnvironments, requirePermission(PermissionViewFlows))
+	api.POST("/environments", handleCreateEnvironment, requirePermission(PermissionEditFlows))
+	api.GET("/environments/:name", handleGetEnvironment, requirePermission(PermissionViewFlows))
+	api.PUT("/environments/:name", handleUpdateEnvironment, requirePermission(PermissionEditFlows))
+	api.DELETE("/environments/:name", handleDeleteEnvironment, requirePermission(PermissionEditFlows))
 
 	// Export/Import routes
-	api.GET("/flows/:id/export", handleExportFlow)
-	api.POST("/flows/import", handleImportFlow)
+	api.GET("/flows/:id/export", handleExportFlow, requireFlowPermission(PermissionViewFlows, "id", flowOfFlow))
+	api.POST("/flows/import", handleImportFlow, requirePermission(PermissionEditFlows))
+
+	// API token management
+	api.GET("/tokens", handleGetTokens, requirePermission(PermissionAdmin))
+	api.POST("/tokens", handleCreateToken, requirePermission(PermissionAdmin))
+	api.PUT("/tokens/:id", handleUpdateToken, requirePermission(PermissionAdmin))
+	api.DELETE("/tokens/:id", handleDeleteToken, requirePermission(PermissionAdmin))
+
+	// Audit log
+	api.GET("/audit", handleGetAuditEvents, requirePermission(PermissionAdmin))
 
 	// Start server
 	address := fmt.Sprintf("%s:%d", config.Service.Host, config.Service.Port)
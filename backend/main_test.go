@@ -0,0 +1,40 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+// withTestDatabase points the global database at a new one in a temporary
+// directory for the duration of a test
+func withTestDatabase(t *testing.T) {
+	t.Helper()
+	savedDB, savedConfig := db, config
+	config = &Config{Database: DatabaseConfig{Path: filepath.Join(t.TempDir(), "flows.db")}}
+	if err := initDatabase(); err != nil {
+		t.Fatalf("initializing database: %v", err)
+	}
+	t.Cleanup(func() {
+		db.Close()
+		db, config = savedDB, savedConfig
+	})
+}
+
+// createTestRun creates a flow with the given steps and a pending run of it,
+// returning the run ID and the steps as stored
+func createTestRun(t *testing.T, name string, steps []Step) (int, []Step) {
+	t.Helper()
+	flow, err := createFlow(CreateFlowRequest{Name: name, Steps: steps})
+	if err != nil {
+		t.Fatalf("creating flow: %v", err)
+	}
+	stored, err := getFlowSteps(flow.ID)
+	if err != nil {
+		t.Fatalf("getting flow steps: %v", err)
+	}
+	runID, err := createRun(flow, stored, "", nil)
+	if err != nil {
+		t.Fatalf("creating run: %v", err)
+	}
+	return runID, stored
+}
@@ -0,0 +1,488 @@
+package main
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	sqlite3 "github.com/mattn/go-sqlite3"
+)
+
+// Metrics
+//
+// GET /metrics serves the metrics below in the Prometheus text format. The
+// format is simple enough to write directly, so there is no client library:
+// counters and histograms are kept in the registry as they are updated, and
+// gauges are read when scraped.
+
+// metricsContentType is the Prometheus text exposition format
+const metricsContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// Histogram buckets, in seconds
+var (
+	executionDurationBuckets = []float64{0.1, 0.5, 1, 5, 10, 30, 60, 300, 900, 3600}
+	httpDurationBuckets      = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+)
+
+// metric is a metric family that writes itself in the text format
+type metric interface {
+	write(w io.Writer)
+}
+
+// metricsRegistry holds the metrics by name
+type metricsRegistry struct {
+	metrics map[string]metric
+}
+
+func (r *metricsRegistry) register(name string, m metric) {
+	r.metrics[name] = m
+}
+
+// write writes every metric, in order of name
+func (r *metricsRegistry) write(w io.Writer) {
+	for _, name := range sortedKeys(r.metrics) {
+		r.metrics[name].write(w)
+	}
+}
+
+var registry = &metricsRegistry{metrics: make(map[string]metric)}
+
+// counterVec is a counter with labels
+type counterVec struct {
+	name, help string
+	labels     []string
+
+	mu     sync.Mutex
+	series map[string]*counterSeries
+}
+
+type counterSeries struct {
+	labelValues []string
+	value       float64
+}
+
+func newCounterVec(name, help string, labels ...string) *counterVec {
+	c := &counterVec{name: name, help: help, labels: labels, series: make(map[string]*counterSeries)}
+	if len(labels) == 0 {
+		// Without labels there is a single series, served from zero
+		c.series[""] = &counterSeries{}
+	}
+	registry.register(name, c)
+	return c
+}
+
+// inc adds one to the series with the given label values
+func (c *counterVec) inc(labelValues ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	key := strings.Join(labelValues, "\xff")
+	s, ok := c.series[key]
+	if !ok {
+		s = &counterSeries{labelValues: labelValues}
+		c.series[key] = s
+	}
+	s.value++
+}
+
+func (c *counterVec) write(w io.Writer) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	writeHeader(w, c.name, c.help, "counter")
+	for _, key := range sortedKeys(c.series) {
+		s := c.series[key]
+		fmt.Fprintf(w, "%s%s %s\n", c.name, formatLabels(c.labels, s.labelValues), formatValue(s.value))
+	}
+}
+
+// histogramVec is a histogram with labels
+type histogramVec struct {
+	name, help string
+	labels     []string
+	buckets    []float64
+
+	mu     sync.Mutex
+	series map[string]*histogramSeries
+}
+
+type histogramSeries struct {
+	labelValues []string
+	counts      []uint64 // Per bucket, not cumulative
+	sum         float64
+	count       uint64
+}
+
+func newHistogramVec(name, help string, buckets []float64, labels ...string) *histogramVec {
+	h := &histogramVec{name: name, help: help, labels: labels, buckets: buckets, series: make(map[string]*histogramSeries)}
+	registry.register(name, h)
+	return h
+}
+
+// observe records a value in the series with the given label values
+func (h *histogramVec) observe(value float64, labelValues ...string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	key := strings.Join(labelValues, "\xff")
+	s, ok := h.series[key]
+	if !ok {
+		s = &histogramSeries{labelValues: labelValues, counts: make([]uint64, len(h.buckets))}
+		h.series[key] = s
+	}
+	if i := sort.SearchFloat64s(h.buckets, value); i < len(h.buckets) {
+		s.counts[i]++
+	}
+	s.sum += value
+	s.count++
+}
+
+func (h *histogramVec) write(w io.Writer) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	writeHeader(w, h.name, h.help, "histogram")
+	bucketLabels := append(append([]string{}, h.labels...), "le")
+	for _, key := range sortedKeys(h.series) {
+		s := h.series[key]
+		var cumulative uint64
+		for i, upper := range h.buckets {
+			cumulative += s.counts[i]
+			fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, formatLabels(bucketLabels, append(append([]string{}, s.labelValues...), formatValue(upper))), cumulative)
+		}
+		fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, formatLabels(bucketLabels, append(append([]string{}, s.labelValues...), "+Inf")), s.count)
+		fmt.Fprintf(w, "%s_sum%s %s\n", h.name, formatLabels(h.labels, s.labelValues), formatValue(s.sum))
+		fmt.Fprintf(w, "%s_count%s %d\n", h.name, formatLabels(h.labels, s.labelValues), s.count)
+	}
+}
+
+// gaugeFunc is a gauge read when scraped
+type gaugeFunc struct {
+	name, help string
+	value      func() float64
+}
+
+func newGaugeFunc(name, help string, value func() float64) *gaugeFunc {
+	g := &gaugeFunc{name: name, help: help, value: value}
+	registry.register(name, g)
+	return g
+}
+
+func (g *gaugeFunc) write(w io.Writer) {
+	writeHeader(w, g.name, g.help, "gauge")
+	fmt.Fprintf(w, "%s %s\n", g.name, formatValue(g.value()))
+}
+
+func writeHeader(w io.Writer, name, help, kind string) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+}
+
+// sortedKeys returns the keys of a map in order, so scrapes are stable
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// labelEscaper escapes label values as the text format requires
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func formatLabels(names, values []string) string {
+	if len(names) == 0 {
+		return ""
+	}
+	pairs := make([]string, len(names))
+	for i, name := range names {
+		pairs[i] = fmt.Sprintf(`%s="%s"`, name, labelEscaper.Replace(values[i]))
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func formatValue(value float64) string {
+	if math.IsInf(value, 1) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+// The metrics served
+var (
+	stepExecutions = newCounterVec("devtool_step_executions_total",
+		"Step executions by flow ID and outcome: a command status, denied by the command policy, or error if the step could not be prepared.",
+		"flow_id", "outcome")
+	stepExecutionDuration = newHistogramVec("devtool_step_execution_duration_seconds",
+		"Duration of step executions that ran to completion, including retries.",
+		executionDurationBuckets, "flow_id")
+	ptySessions atomic.Int64
+	_           = newGaugeFunc("devtool_pty_sessions_active",
+		"Interactive shell and step terminals open over WebSocket.",
+		func() float64 { return float64(ptySessions.Load()) })
+	_ = newGaugeFunc("devtool_execution_queue_depth",
+		"Executions waiting for one of the system.shell.max_concurrent slots.",
+		func() float64 { return float64(len(scheduler.status().Queued)) })
+	_ = newGaugeFunc("devtool_executions_running",
+		"Executions holding a slot, including open terminals.",
+		func() float64 { return float64(len(scheduler.status().Running)) })
+	tmuxSessionsCreated = newCounterVec("devtool_tmux_sessions_created_total",
+		"Tmux sessions created for steps.")
+	_ = newGaugeFunc("devtool_tmux_sessions",
+		"Tmux sessions used by steps since the service started that are still open.",
+		func() float64 { return float64(tmuxSessions.open()) })
+	httpRequestDuration = newHistogramVec("devtool_http_request_duration_seconds",
+		"HTTP request latency by route, method and status code.",
+		httpDurationBuckets, "route", "method", "code")
+	sqliteErrors = newCounterVec("devtool_sqlite_errors_total",
+		"Errors returned by SQLite, by operation and SQLite error.",
+		"operation", "error")
+)
+
+// observeExecution counts a step execution and, if it ran to completion, its
+// duration. err is why the step could not be started, if it wasn't. Series
+// are labelled with the flow ID, which unlike the name doesn't change and
+// doesn't tell anyone who can scrape the metrics what the flows are. Ad hoc
+// commands aren't counted.
+func observeExecution(event AuditEvent, result CommandResult, err error) {
+	if event.StepID == 0 {
+		return
+	}
+
+	flow := strconv.Itoa(event.FlowID)
+
+	var policyErr *PolicyError
+	switch {
+	case errors.As(err, &policyErr):
+		stepExecutions.inc(flow, "denied")
+	case err != nil:
+		stepExecutions.inc(flow, "error")
+	default:
+		stepExecutions.inc(flow, result.Status)
+		if result.Status != CommandStatusStarted {
+			stepExecutionDuration.observe(result.Duration.Seconds(), flow)
+		}
+	}
+}
+
+// tmuxSessionSet is the tmux sessions used by steps that may still be open.
+// Each name maps to when it was last added, in the order of adds.
+type tmuxSessionSet struct {
+	mu    sync.Mutex
+	names map[string]uint64
+	adds  uint64
+}
+
+var tmuxSessions = &tmuxSessionSet{names: make(map[string]uint64)}
+
+// add records that a step uses the session, which exists
+func (s *tmuxSessionSet) add(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.adds++
+	s.names[name] = s.adds
+}
+
+// open returns how many of the sessions still exist, and forgets the others
+func (s *tmuxSessionSet) open() int {
+	s.mu.Lock()
+	known := make(map[string]uint64, len(s.names))
+	for name, added := range s.names {
+		known[name] = added
+	}
+	s.mu.Unlock()
+	if len(known) == 0 {
+		return 0
+	}
+
+	// Fails when no tmux server is running, so no session is open
+	listed := make(map[string]bool)
+	output, err := exec.Command("tmux", "list-sessions", "-F", "#{session_name}").Output()
+	if err == nil {
+		for _, name := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+			listed[name] = true
+		}
+	}
+	return s.prune(known, listed)
+}
+
+// prune forgets the known sessions that weren't listed, unless they were
+// added again since, and returns how many of them were listed
+func (s *tmuxSessionSet) prune(known map[string]uint64, listed map[string]bool) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	open := 0
+	for name, added := range known {
+		if listed[name] {
+			open++
+		} else if s.names[name] == added {
+			delete(s.names, name)
+		}
+	}
+	return open
+}
+
+// metricsMiddleware records the latency of every request by route. It runs
+// outside the request logger, which has already written any error response.
+func metricsMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		start := time.Now()
+		err := next(c)
+
+		code := c.Response().Status
+		if !c.Response().Committed && err != nil {
+			code = http.StatusInternalServerError
+			var httpErr *echo.HTTPError
+			if errors.As(err, &httpErr) {
+				code = httpErr.Code
+			}
+		}
+		route := c.Path()
+		if route == "" {
+			route = "unmatched"
+		}
+		httpRequestDuration.observe(time.Since(start).Seconds(), route, c.Request().Method, strconv.Itoa(code))
+		return err
+	}
+}
+
+// handleMetrics serves the metrics in the Prometheus text format
+func handleMetrics(c echo.Context) error {
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, metricsContentType)
+	res.WriteHeader(http.StatusOK)
+	registry.write(res)
+	return nil
+}
+
+// SQLite errors are counted by wrapping the driver, so every query is covered
+
+// sqliteConnector opens instrumented SQLite connections for sql.OpenDB
+type sqliteConnector struct {
+	dsn string
+}
+
+func (c sqliteConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.Driver().Open(c.dsn)
+	if err != nil {
+		countSQLiteError("open", err)
+		return nil, err
+	}
+	return &metricsConn{conn.(*sqlite3.SQLiteConn)}, nil
+}
+
+func (c sqliteConnector) Driver() driver.Driver {
+	return &sqlite3.SQLiteDriver{}
+}
+
+// countSQLiteError counts err unless it only signals the end of rows or a
+// cancelled request
+func countSQLiteError(operation string, err error) {
+	if err == nil || err == io.EOF || err == driver.ErrSkip || errors.Is(err, context.Canceled) {
+		return
+	}
+	code := "other"
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) {
+		code = sqliteErr.Code.Error()
+	}
+	sqliteErrors.inc(operation, code)
+}
+
+type metricsConn struct {
+	*sqlite3.SQLiteConn
+}
+
+func (c *metricsConn) Prepare(query string) (driver.Stmt, error) {
+	return c.PrepareContext(context.Background(), query)
+}
+
+func (c *metricsConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	stmt, err := c.SQLiteConn.PrepareContext(ctx, query)
+	if err != nil {
+		countSQLiteError("prepare", err)
+		return nil, err
+	}
+	return &metricsStmt{stmt.(*sqlite3.SQLiteStmt)}, nil
+}
+
+func (c *metricsConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	result, err := c.SQLiteConn.ExecContext(ctx, query, args)
+	countSQLiteError("exec", err)
+	return result, err
+}
+
+func (c *metricsConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	rows, err := c.SQLiteConn.QueryContext(ctx, query, args)
+	if err != nil {
+		countSQLiteError("query", err)
+		return nil, err
+	}
+	return &metricsRows{rows}, nil
+}
+
+func (c *metricsConn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+func (c *metricsConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	tx, err := c.SQLiteConn.BeginTx(ctx, opts)
+	if err != nil {
+		countSQLiteError("begin", err)
+		return nil, err
+	}
+	return &metricsTx{tx}, nil
+}
+
+type metricsStmt struct {
+	*sqlite3.SQLiteStmt
+}
+
+func (s *metricsStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	result, err := s.SQLiteStmt.ExecContext(ctx, args)
+	countSQLiteError("exec", err)
+	return result, err
+}
+
+func (s *metricsStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	rows, err := s.SQLiteStmt.QueryContext(ctx, args)
+	if err != nil {
+		countSQLiteError("query", err)
+		return nil, err
+	}
+	return &metricsRows{rows}, nil
+}
+
+type metricsRows struct {
+	driver.Rows
+}
+
+func (r *metricsRows) Next(dest []driver.Value) error {
+	err := r.Rows.Next(dest)
+	countSQLiteError("query", err)
+	return err
+}
+
+type metricsTx struct {
+	driver.Tx
+}
+
+func (t *metricsTx) Commit() error {
+	err := t.Tx.Commit()
+	countSQLiteError("commit", err)
+	return err
+}
+
+func (t *metricsTx) Rollback() error {
+	err := t.Tx.Rollback()
+	countSQLiteError("rollback", err)
+	return err
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestObserveExecution(t *testing.T) {
+	event := AuditEvent{FlowID: 9001, StepID: 1}
+	observeExecution(event, CommandResult{Status: CommandStatusSucceeded}, nil)
+	observeExecution(event, CommandResult{}, &PolicyError{Message: "denied"})
+	observeExecution(AuditEvent{FlowID: 9001}, CommandResult{Status: CommandStatusFailed}, nil)
+
+	var out strings.Builder
+	stepExecutions.write(&out)
+	for _, want := range []string{
+		`devtool_step_executions_total{flow_id="9001",outcome="succeeded"} 1`,
+		`devtool_step_executions_total{flow_id="9001",outcome="denied"} 1`,
+	} {
+		if !strings.Contains(out.String(), want+"\n") {
+			t.Errorf("metrics lack %s:\n%s", want, out.String())
+		}
+	}
+	if strings.Contains(out.String(), `outcome="failed"`) {
+		t.Errorf("ad hoc command was counted:\n%s", out.String())
+	}
+}
+
+func TestTmuxSessionSetPrune(t *testing.T) {
+	s := &tmuxSessionSet{names: make(map[string]uint64)}
+	s.add("build")
+	s.add("deploy")
+	s.add("old")
+	known := map[string]uint64{"build": s.names["build"], "deploy": s.names["deploy"], "old": s.names["old"]}
+
+	// deploy was used again while the sessions were being listed
+	s.add("deploy")
+
+	if open := s.prune(known, map[string]bool{"build": true, "other": true}); open != 1 {
+		t.Errorf("open = %d, want 1", open)
+	}
+	for name, kept := range map[string]bool{"build": true, "deploy": true, "old": false} {
+		if _, ok := s.names[name]; ok != kept {
+			t.Errorf("%s: kept = %v, want %v", name, ok, kept)
+		}
+	}
+}
@@ -0,0 +1,354 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Command policy
+//
+// Every command is parsed into the simple commands it runs (see
+// shellparse.go) and each of them is checked against security.command_policy:
+// the first rule that matches it decides, and if none does the default action
+// applies. A command line runs only if all of its simple commands are allowed.
+// A rule matches when all of its criteria do:
+//
+//	executable: glob matched against the executable's name, or its path if the pattern contains a /
+//	args:       globs that must each match one of the arguments; "a|b" matches either
+//	regex:      matched against the simple command's words joined by spaces, or
+//	            against the whole command line if the rule has no executable or args
+//
+// Combined short flags are split for matching, so -rf is also seen as -r and
+// -f, and absolute paths are also matched in their cleaned form (// is /).
+// Entries of the deprecated flows.validation.blocked_commands are added as
+// deny rules after the configured ones.
+
+// Command policy actions
+const (
+	PolicyAllow = "allow"
+	PolicyDeny  = "deny"
+)
+
+// CommandPolicyConfig lists the rules commands are checked against
+type CommandPolicyConfig struct {
+	DefaultAction string              `yaml:"default_action"` // allow (default) or deny
+	Rules         []CommandPolicyRule `yaml:"rules"`
+}
+
+// CommandPolicyRule allows or denies the simple commands it matches
+type CommandPolicyRule struct {
+	Name        string   `yaml:"name" json:"name"`
+	Description string   `yaml:"description,omitempty" json:"description,omitempty"`
+	Action      string   `yaml:"action" json:"action"`
+	Executable  string   `yaml:"executable,omitempty" json:"executable,omitempty"`
+	Args        []string `yaml:"args,omitempty" json:"args,omitempty"`
+	Regex       string   `yaml:"regex,omitempty" json:"regex,omitempty"`
+}
+
+// policyRule is a rule ready to be matched
+type policyRule struct {
+	CommandPolicyRule
+	regex *regexp.Regexp
+}
+
+// commandPolicy is the compiled command policy
+type commandPolicy struct {
+	defaultAction string
+	rules         []policyRule
+}
+
+// policy is the command policy in effect, set up by initCommandPolicy
+var policy = &commandPolicy{defaultAction: PolicyAllow}
+
+// PolicyDecision explains whether a command may run
+type PolicyDecision struct {
+	Command  string               `json:"command"`
+	Allowed  bool                 `json:"allowed"`
+	Reason   string               `json:"reason"`
+	Rule     string               `json:"rule,omitempty"` // The rule that denied the command, if any
+	Commands []PolicyCommandMatch `json:"commands"`
+}
+
+// PolicyCommandMatch is the verdict on one simple command of a command line
+type PolicyCommandMatch struct {
+	Command    string `json:"command"`
+	Executable string `json:"executable"`
+	Action     string `json:"action"`
+	Rule       string `json:"rule,omitempty"` // Empty if the default action applied
+}
+
+// initCommandPolicy compiles the configured command policy
+func initCommandPolicy(cfg CommandPolicyConfig, blockedCommands []string) error {
+	compiled := &commandPolicy{defaultAction: cfg.DefaultAction}
+	switch compiled.defaultAction {
+	case "":
+		compiled.defaultAction = PolicyAllow
+	case PolicyAllow, PolicyDeny:
+	default:
+		return fmt.Errorf("invalid default_action %q: must be %s or %s", cfg.DefaultAction, PolicyAllow, PolicyDeny)
+	}
+
+	rules := append([]CommandPolicyRule{}, cfg.Rules...)
+	for i, blocked := range blockedCommands {
+		rules = append(rules, blockedCommandRule(fmt.Sprintf("blocked_commands[%d]", i), blocked))
+	}
+
+	for i, rule := range rules {
+		if rule.Name == "" {
+			rule.Name = fmt.Sprintf("rule %d", i+1)
+		}
+		compiledRule, err := compilePolicyRule(rule)
+		if err != nil {
+			return fmt.Errorf("command policy rule %q: %v", rule.Name, err)
+		}
+		compiled.rules = append(compiled.rules, compiledRule)
+	}
+
+	policy = compiled
+	log.Printf("Command policy: %d rules, default action %s", len(compiled.rules), compiled.defaultAction)
+	return nil
+}
+
+// compilePolicyRule validates a rule and compiles its regex
+func compilePolicyRule(rule CommandPolicyRule) (policyRule, error) {
+	compiled := policyRule{CommandPolicyRule: rule}
+	if rule.Action != PolicyAllow && rule.Action != PolicyDeny {
+		return compiled, fmt.Errorf("invalid action %q: must be %s or %s", rule.Action, PolicyAllow, PolicyDeny)
+	}
+	if rule.Executable == "" && len(rule.Args) == 0 && rule.Regex == "" {
+		return compiled, fmt.Errorf("an executable, args or regex is required")
+	}
+	if _, err := path.Match(rule.Executable, ""); err != nil {
+		return compiled, fmt.Errorf("invalid executable pattern %q", rule.Executable)
+	}
+	for _, arg := range rule.Args {
+		for _, alternative := range splitAlternatives(arg) {
+			if _, err := path.Match(alternative, ""); err != nil {
+				return compiled, fmt.Errorf("invalid args pattern %q", arg)
+			}
+		}
+	}
+	if rule.Regex != "" {
+		regex, err := regexp.Compile(rule.Regex)
+		if err != nil {
+			return compiled, fmt.Errorf("invalid regex: %v", err)
+		}
+		compiled.regex = regex
+	}
+	return compiled, nil
+}
+
+// blockedCommandRule converts a blocked_commands entry into a deny rule. An
+// entry naming a single command matches that executable with those
+// arguments; anything else matches the command line ignoring whitespace.
+func blockedCommandRule(name, blocked string) CommandPolicyRule {
+	rule := CommandPolicyRule{Name: name, Action: PolicyDeny}
+	commands, err := parseShellCommands(blocked)
+	if err == nil && len(commands) == 1 && strings.TrimSpace(blocked) != "" && !strings.ContainsAny(blocked, "$`(){}|&;") {
+		rule.Executable = escapeGlob(commands[0].Args[0])
+		for _, arg := range commands[0].Args[1:] {
+			for _, expanded := range splitShortFlags(arg) {
+				rule.Args = append(rule.Args, escapeGlob(expanded))
+			}
+		}
+		return rule
+	}
+
+	var pattern strings.Builder
+	for _, c := range strings.Join(strings.Fields(blocked), "") {
+		if pattern.Len() > 0 {
+			pattern.WriteString(`\s*`)
+		}
+		pattern.WriteString(regexp.QuoteMeta(string(c)))
+	}
+	rule.Regex = pattern.String()
+	return rule
+}
+
+// check decides whether a command line may run
+func (p *commandPolicy) check(command string) PolicyDecision {
+	decision := PolicyDecision{Command: command, Allowed: true, Commands: []PolicyCommandMatch{}}
+	commands, err := parseShellCommands(command)
+	if err != nil {
+		decision.Allowed = false
+		decision.Reason = fmt.Sprintf("cannot parse command: %v", err)
+		return decision
+	}
+
+	for _, cmd := range commands {
+		match := PolicyCommandMatch{Command: cmd.line(), Executable: cmd.Args[0], Action: p.defaultAction}
+		for _, rule := range p.rules {
+			if rule.matches(cmd, command) {
+				match.Action = rule.Action
+				match.Rule = rule.Name
+				break
+			}
+		}
+		decision.Commands = append(decision.Commands, match)
+
+		if match.Action == PolicyDeny && decision.Allowed {
+			decision.Allowed = false
+			decision.Rule = match.Rule
+			decision.Reason = p.denyReason(match)
+		}
+	}
+
+	if decision.Allowed {
+		decision.Reason = "allowed"
+		if len(commands) == 0 {
+			decision.Reason = "nothing to run"
+		}
+	}
+	return decision
+}
+
+// denyReason describes why a simple command was denied
+func (p *commandPolicy) denyReason(match PolicyCommandMatch) string {
+	if match.Rule == "" {
+		return fmt.Sprintf("%q is not allowed by any rule and the default action is %s", match.Command, PolicyDeny)
+	}
+	reason := fmt.Sprintf("%q is denied by rule %q", match.Command, match.Rule)
+	for _, rule := range p.rules {
+		if rule.Name == match.Rule && rule.Description != "" {
+			reason += ": " + rule.Description
+		}
+	}
+	return reason
+}
+
+// matches reports whether the rule applies to a simple command of a command line
+func (r policyRule) matches(cmd shellCommand, commandLine string) bool {
+	if r.Executable == "" && len(r.Args) == 0 {
+		return r.regex.MatchString(commandLine)
+	}
+	if r.Executable != "" && !matchExecutable(r.Executable, cmd.Args[0]) {
+		return false
+	}
+	if len(r.Args) > 0 {
+		args := policyArgs(cmd.Args[1:])
+		for _, pattern := range r.Args {
+			if !matchAnyArg(pattern, args) {
+				return false
+			}
+		}
+	}
+	return r.regex == nil || r.regex.MatchString(cmd.line())
+}
+
+// matchExecutable matches an executable against a rule's pattern
+func matchExecutable(pattern, executable string) bool {
+	if !strings.Contains(pattern, "/") {
+		executable = path.Base(executable)
+	}
+	matched, _ := path.Match(pattern, executable)
+	return matched
+}
+
+// matchAnyArg reports whether any argument matches one of the pattern's alternatives
+func matchAnyArg(pattern string, args []string) bool {
+	for _, alternative := range splitAlternatives(pattern) {
+		for _, arg := range args {
+			if matched, _ := path.Match(alternative, arg); matched {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// splitAlternatives splits an args pattern on the | characters not escaped with \
+func splitAlternatives(pattern string) []string {
+	var alternatives []string
+	var current strings.Builder
+	for i := 0; i < len(pattern); i++ {
+		switch {
+		case pattern[i] == '\\' && i+1 < len(pattern) && pattern[i+1] == '|':
+			current.WriteByte('|')
+			i++
+		case pattern[i] == '|':
+			alternatives = append(alternatives, current.String())
+			current.Reset()
+		default:
+			current.WriteByte(pattern[i])
+		}
+	}
+	return append(alternatives, current.String())
+}
+
+// policyArgs returns the arguments to match rules against: each argument,
+// combined short flags split up and absolute paths cleaned
+func policyArgs(args []string) []string {
+	var out []string
+	for _, arg := range args {
+		out = append(out, splitShortFlags(arg)...)
+		if strings.HasPrefix(arg, "/") {
+			if cleaned := path.Clean(arg); cleaned != arg {
+				out = append(out, cleaned)
+			}
+		}
+	}
+	return out
+}
+
+// splitShortFlags returns an argument and, for combined short flags like -rf, each flag
+func splitShortFlags(arg string) []string {
+	out := []string{arg}
+	if len(arg) > 2 && arg[0] == '-' && arg[1] != '-' && !strings.ContainsAny(arg, "=/") {
+		for _, flag := range arg[1:] {
+			out = append(out, "-"+string(flag))
+		}
+	}
+	return out
+}
+
+// escapeGlob quotes the glob characters of a literal
+func escapeGlob(literal string) string {
+	var out strings.Builder
+	for _, c := range literal {
+		if strings.ContainsRune(`*?[]\`, c) {
+			out.WriteRune('\\')
+		} else if c == '|' {
+			out.WriteRune('\\')
+		}
+		out.WriteRune(c)
+	}
+	return out.String()
+}
+
+// checkCommandPolicy returns a *PolicyError if the command may not run
+func checkCommandPolicy(command string) error {
+	decision := policy.check(command)
+	if decision.Allowed {
+		return nil
+	}
+	return &PolicyError{Message: "command blocked: " + decision.Reason}
+}
+
+// PolicyCheckRequest asks whether a command would be allowed
+type PolicyCheckRequest struct {
+	Command string `json:"command"`
+}
+
+// handlePolicyCheck explains how the command policy treats a command
+func handlePolicyCheck(c echo.Context) error {
+	var req PolicyCheckRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request payload",
+		})
+	}
+
+	if req.Command == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Command is required",
+		})
+	}
+
+	return c.JSON(http.StatusOK, policy.check(req.Command))
+}
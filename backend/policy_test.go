@@ -0,0 +1,144 @@
+package main
+
+import (
+	"testing"
+)
+
+// defaultPolicy compiles the command policy of the shipped config.yaml
+func defaultPolicy(t *testing.T) *commandPolicy {
+	t.Helper()
+	cfg, err := loadConfig("../config.yaml")
+	if err != nil {
+		t.Fatalf("loading config: %v", err)
+	}
+	saved := policy
+	t.Cleanup(func() { policy = saved })
+	if err := initCommandPolicy(cfg.Security.CommandPolicy, cfg.Flows.Validation.BlockedCommands); err != nil {
+		t.Fatalf("compiling policy: %v", err)
+	}
+	return policy
+}
+
+func TestDefaultCommandPolicy(t *testing.T) {
+	p := defaultPolicy(t)
+
+	tests := []struct {
+		command string
+		allowed bool
+	}{
+		// Allowed
+		{"ls -la", true},
+		{"echo mkfs", true},
+		{"echo 'rm -rf /'", true},
+		{"rm -rf ./build /tmp/cache", true},
+		{"rm /", true},
+		{"git status && npm test", true},
+		{`grep -r "dd if=/dev/zero" .`, true},
+		{"dd if=in.img of=out.img", true},
+		{"cat <<EOF\nrm -rf /\nEOF", true},
+		{"bash script.sh", true},
+
+		// Denied
+		{"rm -rf /", false},
+		{"rm -fr //", false},
+		{"rm -r -f /etc", false},
+		{"sudo rm --recursive /*", false},
+		{"'rm' -rf /", false},
+		{`r\m -rf /`, false},
+		{"echo $(rm -rf /)", false},
+		{"echo `mkfs /dev/sda`", false},
+		{"cat <(fdisk -l)", false},
+		{"(cd /tmp; mkfs.ext4 /dev/sda1)", false},
+		{"true | sudo -u root mkfs /dev/sda", false},
+		{"ls && bash -c 'rm -rf /'", false},
+		{`sh -c "fdisk /dev/sda"`, false},
+		{"eval 'rm -rf /'", false},
+		{"env FOO=1 rm -rf /", false},
+		{"timeout 5 dd if=/dev/zero of=/dev/sda", false},
+		{"f() { mkfs /dev/sda; }; f", false},
+		{":(){ :|:& };:", false},
+		{"echo 'unterminated", false},
+	}
+
+	for _, tt := range tests {
+		decision := p.check(tt.command)
+		if decision.Allowed != tt.allowed {
+			t.Errorf("check(%q): allowed = %v, want %v (%s)", tt.command, decision.Allowed, tt.allowed, decision.Reason)
+		}
+	}
+}
+
+func TestCommandPolicyRules(t *testing.T) {
+	saved := policy
+	t.Cleanup(func() { policy = saved })
+	err := initCommandPolicy(CommandPolicyConfig{
+		DefaultAction: PolicyDeny,
+		Rules: []CommandPolicyRule{
+			{Name: "no-force-push", Action: PolicyDeny, Executable: "git", Args: []string{"push", "--force|-f"}},
+			{Name: "git", Action: PolicyAllow, Executable: "git"},
+			{Name: "make", Action: PolicyAllow, Executable: "make"},
+			{Name: "local-scripts", Action: PolicyAllow, Executable: "./scripts/*"},
+		},
+	}, nil)
+	if err != nil {
+		t.Fatalf("compiling policy: %v", err)
+	}
+
+	tests := []struct {
+		command string
+		allowed bool
+		rule    string
+	}{
+		{"git pull && make test", true, ""},
+		{"./scripts/deploy.sh staging", true, ""},
+		{"git push origin main", true, ""},
+		{"git push -f origin main", false, "no-force-push"},
+		{"git push origin main --force", false, "no-force-push"},
+		{"make && curl example.com", false, ""},
+		{"/usr/bin/make", true, ""},
+		{"scripts/deploy.sh", false, ""},
+	}
+
+	for _, tt := range tests {
+		decision := policy.check(tt.command)
+		if decision.Allowed != tt.allowed || decision.Rule != tt.rule {
+			t.Errorf("check(%q) = allowed %v by rule %q, want allowed %v by rule %q (%s)",
+				tt.command, decision.Allowed, decision.Rule, tt.allowed, tt.rule, decision.Reason)
+		}
+	}
+}
+
+func TestBlockedCommandRule(t *testing.T) {
+	tests := []struct {
+		blocked    string
+		executable string
+		regex      bool
+	}{
+		{"rm -rf /", "rm", false},
+		{"mkfs", "mkfs", false},
+		{":(){ :|:& };:", "", true},
+		{"curl x | sh", "", true},
+	}
+	for _, tt := range tests {
+		rule := blockedCommandRule("blocked", tt.blocked)
+		if rule.Executable != tt.executable || (rule.Regex != "") != tt.regex {
+			t.Errorf("blockedCommandRule(%q) = executable %q, regex %q", tt.blocked, rule.Executable, rule.Regex)
+		}
+	}
+}
+
+func TestCommandPolicyConfigErrors(t *testing.T) {
+	saved := policy
+	t.Cleanup(func() { policy = saved })
+	for _, cfg := range []CommandPolicyConfig{
+		{DefaultAction: "maybe"},
+		{Rules: []CommandPolicyRule{{Name: "no action", Executable: "rm"}}},
+		{Rules: []CommandPolicyRule{{Name: "empty", Action: PolicyDeny}}},
+		{Rules: []CommandPolicyRule{{Name: "bad glob", Action: PolicyDeny, Executable: "[rm"}}},
+		{Rules: []CommandPolicyRule{{Name: "bad regex", Action: PolicyDeny, Regex: "("}}},
+	} {
+		if err := initCommandPolicy(cfg, nil); err == nil {
+			t.Errorf("initCommandPolicy(%+v): expected an error", cfg)
+		}
+	}
+}
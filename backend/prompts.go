@@ -0,0 +1,323 @@
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Variable prompt types
+const (
+	PromptTypeString  = "string"
+	PromptTypeNumber  = "number"
+	PromptTypeBoolean = "boolean"
+)
+
+// VariablePrompt declares a flow variable whose value can be chosen each time
+// the flow is launched. If Default is empty, the flow variable of the same
+// name (if any) is the default.
+type VariablePrompt struct {
+	Name          string   `yaml:"name" json:"name"`
+	Type          string   `yaml:"type,omitempty" json:"type,omitempty"` // string (default), number or boolean
+	Description   string   `yaml:"description,omitempty" json:"description,omitempty"`
+	Default       string   `yaml:"default,omitempty" json:"default,omitempty"`
+	AllowedValues []string `yaml:"allowed_values,omitempty" json:"allowed_values,omitempty"`
+	Required      bool     `yaml:"required,omitempty" json:"required,omitempty"` // A run can't start without a value
+}
+
+// createPromptTables creates the table holding variable prompts
+func createPromptTables() error {
+	query := `CREATE TABLE IF NOT EXISTS variable_prompts (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		flow_id INTEGER NOT NULL,
+		name TEXT NOT NULL,
+		type TEXT DEFAULT '',
+		description TEXT DEFAULT '',
+		default_value TEXT DEFAULT '',
+		allowed_values TEXT DEFAULT '',
+		required BOOLEAN DEFAULT FALSE,
+		order_index INTEGER NOT NULL,
+		FOREIGN KEY (flow_id) REFERENCES flows (id) ON DELETE CASCADE,
+		UNIQUE(flow_id, name)
+	)`
+	if _, err := db.Exec(query); err != nil {
+		return fmt.Errorf("failed to execute query %s: %v", query, err)
+	}
+	return nil
+}
+
+// validatePrompts checks the prompt declarations of a flow
+func validatePrompts(prompts []VariablePrompt) error {
+	seen := make(map[string]bool, len(prompts))
+	for _, prompt := range prompts {
+		if !variableNamePattern.MatchString(prompt.Name) {
+			return fmt.Errorf("invalid prompt variable name %q", prompt.Name)
+		}
+		if seen[prompt.Name] {
+			return fmt.Errorf("variable %s is prompted for more than once", prompt.Name)
+		}
+		seen[prompt.Name] = true
+
+		switch prompt.Type {
+		case "", PromptTypeString, PromptTypeNumber, PromptTypeBoolean:
+		default:
+			return fmt.Errorf("prompt %s: invalid type %q: must be %s, %s or %s", prompt.Name, prompt.Type, PromptTypeString, PromptTypeNumber, PromptTypeBoolean)
+		}
+		for _, allowed := range prompt.AllowedValues {
+			if _, err := prompt.normalize(allowed); err != nil {
+				return fmt.Errorf("prompt %s: invalid allowed value %q: %v", prompt.Name, allowed, err)
+			}
+		}
+		if prompt.Default != "" {
+			if _, err := prompt.check(prompt.Default); err != nil {
+				return fmt.Errorf("prompt %s: invalid default %q: %v", prompt.Name, prompt.Default, err)
+			}
+		}
+	}
+	return nil
+}
+
+// normalize checks a value against the prompt type and returns its canonical form
+func (p VariablePrompt) normalize(value string) (string, error) {
+	switch p.Type {
+	case PromptTypeNumber:
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return "", fmt.Errorf("not a number")
+		}
+	case PromptTypeBoolean:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return "", fmt.Errorf("not a boolean")
+		}
+		return strconv.FormatBool(b), nil
+	}
+	return value, nil
+}
+
+// check validates a value for the prompt, including its allowed values
+func (p VariablePrompt) check(value string) (string, error) {
+	normalized, err := p.normalize(value)
+	if err != nil {
+		return "", err
+	}
+	if len(p.AllowedValues) == 0 {
+		return normalized, nil
+	}
+	for _, allowed := range p.AllowedValues {
+		if a, _ := p.normalize(allowed); a == normalized {
+			return normalized, nil
+		}
+	}
+	return "", fmt.Errorf("must be one of %s", strings.Join(p.AllowedValues, ", "))
+}
+
+// savePrompts replaces the prompts of a flow within a transaction
+func savePrompts(tx *sql.Tx, flowID int64, prompts []VariablePrompt) error {
+	if _, err := tx.Exec("DELETE FROM variable_prompts WHERE flow_id = ?", flowID); err != nil {
+		return fmt.Errorf("failed to delete existing prompts: %v", err)
+	}
+
+	for i, prompt := range prompts {
+		allowedValues := ""
+		if len(prompt.AllowedValues) > 0 {
+			data, err := json.Marshal(prompt.AllowedValues)
+			if err != nil {
+				return fmt.Errorf("failed to encode allowed values of prompt %s: %v", prompt.Name, err)
+			}
+			allowedValues = string(data)
+		}
+		_, err := tx.Exec(
+			"INSERT INTO variable_prompts (flow_id, name, type, description, default_value, allowed_values, required, order_index) VALUES (?, ?, ?, ?, ?, ?, ?, ?)",
+			flowID, prompt.Name, prompt.Type, prompt.Description, prompt.Default, allowedValues, prompt.Required, i,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to insert prompt %s: %v", prompt.Name, err)
+		}
+	}
+	return nil
+}
+
+// getFlowPrompts returns the prompts of a flow in declaration order
+func getFlowPrompts(flowID int) ([]VariablePrompt, error) {
+	rows, err := db.Query(
+		"SELECT name, type, description, default_value, allowed_values, required FROM variable_prompts WHERE flow_id = ? ORDER BY order_index",
+		flowID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var prompts []VariablePrompt
+	for rows.Next() {
+		var prompt VariablePrompt
+		var allowedValues string
+		if err := rows.Scan(&prompt.Name, &prompt.Type, &prompt.Description, &prompt.Default, &allowedValues, &prompt.Required); err != nil {
+			return nil, err
+		}
+		if allowedValues != "" {
+			if err := json.Unmarshal([]byte(allowedValues), &prompt.AllowedValues); err != nil {
+				slog.Warn("Ignoring invalid allowed values of prompt", "prompt", prompt.Name, "error", err)
+			}
+		}
+		prompts = append(prompts, prompt)
+	}
+
+	return prompts, rows.Err()
+}
+
+// applyPrompts layers prompt defaults and per-run overrides on top of the flow
+// variables. Overrides must name a flow variable or a prompt and satisfy the
+// prompt's type and allowed values; required prompts must end up with a value.
+func applyPrompts(variables map[string]string, prompts []VariablePrompt, overrides map[string]string) (map[string]string, error) {
+	resolved := make(map[string]string, len(variables)+len(prompts))
+	for key, value := range variables {
+		resolved[key] = value
+	}
+
+	byName := make(map[string]VariablePrompt, len(prompts))
+	for _, prompt := range prompts {
+		byName[prompt.Name] = prompt
+		if prompt.Default != "" {
+			resolved[prompt.Name], _ = prompt.normalize(prompt.Default)
+		}
+	}
+
+	var problems []string
+	rejected := make(map[string]bool)
+	names := make([]string, 0, len(overrides))
+	for name := range overrides {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		value := overrides[name]
+		prompt, prompted := byName[name]
+		if !prompted {
+			if _, ok := variables[name]; !ok {
+				problems = append(problems, fmt.Sprintf("%s: unknown variable", name))
+				continue
+			}
+			resolved[name] = value
+			continue
+		}
+		checked, err := prompt.check(value)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", name, err))
+			rejected[name] = true
+			continue
+		}
+		resolved[name] = checked
+	}
+
+	for _, prompt := range prompts {
+		if prompt.Required && resolved[prompt.Name] == "" && !rejected[prompt.Name] {
+			problems = append(problems, fmt.Sprintf("%s: a value is required", prompt.Name))
+		}
+	}
+
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("invalid variables: %s", strings.Join(problems, "; "))
+	}
+	return resolved, nil
+}
+
+// getLaunchVariables returns the variables a flow runs with: the global
+// variables, the selected environment's variables (if any), its own variables,
+// prompt defaults and the given per-run overrides. An unknown environment and
+// invalid overrides are reported as a *LaunchError.
+func getLaunchVariables(flowID int, environment string, overrides map[string]string) (map[string]string, error) {
+	variables, secretKeys, err := resolveVariables(flowID, environment)
+	if err != nil {
+		return nil, err
+	}
+	prompts, err := getFlowPrompts(flowID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get flow prompts: %v", err)
+	}
+	for _, key := range secretKeys {
+		// Overridden secrets must be masked like stored ones
+		if value, ok := overrides[key]; ok {
+			secrets.register(value)
+		}
+	}
+	resolved, err := applyPrompts(variables, prompts, overrides)
+	if err != nil {
+		return nil, &LaunchError{Message: err.Error()}
+	}
+	return resolved, nil
+}
+
+// LaunchError reports launch variables that were rejected; handlers return it as a 400
+type LaunchError struct {
+	Message string
+}
+
+func (e *LaunchError) Error() string {
+	return e.Message
+}
+
+// PromptSchema describes the launch form of a flow
+type PromptSchema struct {
+	FlowID  int              `json:"flow_id"`
+	Prompts []VariablePrompt `json:"prompts"`
+}
+
+// handleGetFlowPrompts returns the prompts of a flow with their effective
+// defaults, resolved for the environment given by the environment query parameter
+func handleGetFlowPrompts(c echo.Context) error {
+	flowID := c.Param("id")
+	id := 0
+	if _, err := fmt.Sscanf(flowID, "%d", &id); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid flow ID",
+		})
+	}
+
+	if _, err := getFlowByID(id); err != nil {
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "Flow not found",
+		})
+	}
+
+	prompts, err := getFlowPrompts(id)
+	if err != nil {
+		slog.Error("Error getting prompts", "flow_id", id, "error", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get flow prompts",
+		})
+	}
+	variables, secretKeys, err := resolveVariables(id, c.QueryParam("environment"))
+	if err != nil {
+		if launchErr, ok := err.(*LaunchError); ok {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": launchErr.Error(),
+			})
+		}
+		slog.Error("Error getting variables", "flow_id", id, "error", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get flow variables",
+		})
+	}
+	variables = maskSecretVariables(variables, secretKeys)
+
+	schema := PromptSchema{FlowID: id, Prompts: []VariablePrompt{}}
+	for _, prompt := range prompts {
+		if prompt.Type == "" {
+			prompt.Type = PromptTypeString
+		}
+		if prompt.Default == "" {
+			prompt.Default = variables[prompt.Name]
+		}
+		schema.Prompts = append(schema.Prompts, prompt)
+	}
+
+	return c.JSON(http.StatusOK, schema)
+}
@@ -0,0 +1,255 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Role-based access control
+//
+// Each API token has a role, which applies to every flow, and may have
+// per-flow grants giving it a higher role on particular flows. A token without
+// a role can only use the flows it has grants for. Roles build on each other:
+//
+//	viewer  view flows, runs, variables and environments
+//	runner  also run flows and their steps, and cancel them; step terminals are output-only
+//	editor  also create, change and delete flows, variables and environments
+//	admin   also run arbitrary commands, open free shells, type into step terminals,
+//	        manage tokens and read the audit log
+//
+// Creating or importing flows and changing global variables and environments
+// need the role itself, not a grant, as they aren't tied to one flow.
+
+// Roles, in increasing order of power
+const (
+	RoleViewer = "viewer"
+	RoleRunner = "runner"
+	RoleEditor = "editor"
+	RoleAdmin  = "admin"
+)
+
+var roleRanks = map[string]int{RoleViewer: 1, RoleRunner: 2, RoleEditor: 3, RoleAdmin: 4}
+
+// Permissions checked by the API handlers
+const (
+	PermissionViewFlows   = "flows:view"
+	PermissionRunFlows    = "flows:run"
+	PermissionEditFlows   = "flows:edit"
+	PermissionRunCommands = "commands:run" // Arbitrary commands and free shells
+	PermissionAdmin       = "admin"        // Token management, diagnostics and the audit log
+)
+
+// permissionRoles maps each permission to the least role that has it
+var permissionRoles = map[string]string{
+	PermissionViewFlows:   RoleViewer,
+	PermissionRunFlows:    RoleRunner,
+	PermissionEditFlows:   RoleEditor,
+	PermissionRunCommands: RoleAdmin,
+	PermissionAdmin:       RoleAdmin,
+}
+
+// TokenGrant gives a token a role on one flow
+type TokenGrant struct {
+	FlowID int    `json:"flow_id"`
+	Role   string `json:"role"`
+}
+
+// PermissionError reports a request lacking a permission
+type PermissionError struct {
+	Permission string
+	FlowID     int // Zero for permissions that aren't about one flow
+	Required   string
+	Token      *APIToken
+}
+
+func (e *PermissionError) Error() string {
+	role := e.Token.Role
+	if role == "" {
+		role = "none"
+	}
+	if e.FlowID != 0 {
+		return fmt.Sprintf("missing permission %s on flow %d: requires role %s, token %q has role %s", e.Permission, e.FlowID, e.Required, e.Token.Name, role)
+	}
+	return fmt.Sprintf("missing permission %s: requires role %s, token %q has role %s", e.Permission, e.Required, e.Token.Name, role)
+}
+
+// createGrantTables creates the table holding per-flow grants
+func createGrantTables() error {
+	query := `CREATE TABLE IF NOT EXISTS token_grants (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		token_id INTEGER NOT NULL,
+		flow_id INTEGER NOT NULL,
+		role TEXT NOT NULL,
+		FOREIGN KEY (token_id) REFERENCES api_tokens (id) ON DELETE CASCADE,
+		FOREIGN KEY (flow_id) REFERENCES flows (id) ON DELETE CASCADE,
+		UNIQUE(token_id, flow_id)
+	)`
+	if _, err := db.Exec(query); err != nil {
+		return fmt.Errorf("failed to execute query %s: %v", query, err)
+	}
+	return nil
+}
+
+// validateRole checks a role name; empty is allowed where the token only has grants
+func validateRole(role string, allowEmpty bool) error {
+	if role == "" && allowEmpty {
+		return nil
+	}
+	if _, ok := roleRanks[role]; !ok {
+		return fmt.Errorf("invalid role %q: must be %s, %s, %s or %s", role, RoleViewer, RoleRunner, RoleEditor, RoleAdmin)
+	}
+	return nil
+}
+
+// validateGrants checks the per-flow grants of a token
+func validateGrants(grants []TokenGrant) error {
+	seen := make(map[int]bool, len(grants))
+	for _, grant := range grants {
+		if _, err := getFlowByID(grant.FlowID); err != nil {
+			return fmt.Errorf("grant for unknown flow %d", grant.FlowID)
+		}
+		if seen[grant.FlowID] {
+			return fmt.Errorf("flow %d is granted more than once", grant.FlowID)
+		}
+		seen[grant.FlowID] = true
+		if err := validateRole(grant.Role, false); err != nil {
+			return fmt.Errorf("grant for flow %d: %v", grant.FlowID, err)
+		}
+	}
+	return nil
+}
+
+// saveGrants replaces the grants of a token within a transaction
+func saveGrants(tx *sql.Tx, tokenID int64, grants []TokenGrant) error {
+	if _, err := tx.Exec("DELETE FROM token_grants WHERE token_id = ?", tokenID); err != nil {
+		return fmt.Errorf("failed to delete existing grants: %v", err)
+	}
+	for _, grant := range grants {
+		if _, err := tx.Exec("INSERT INTO token_grants (token_id, flow_id, role) VALUES (?, ?, ?)", tokenID, grant.FlowID, grant.Role); err != nil {
+			return fmt.Errorf("failed to insert grant for flow %d: %v", grant.FlowID, err)
+		}
+	}
+	return nil
+}
+
+// getTokenGrants returns the grants of a token
+func getTokenGrants(tokenID int) ([]TokenGrant, error) {
+	rows, err := db.Query("SELECT flow_id, role FROM token_grants WHERE token_id = ? ORDER BY flow_id", tokenID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var grants []TokenGrant
+	for rows.Next() {
+		var grant TokenGrant
+		if err := rows.Scan(&grant.FlowID, &grant.Role); err != nil {
+			return nil, err
+		}
+		grants = append(grants, grant)
+	}
+	return grants, rows.Err()
+}
+
+// roleOn returns the token's role on a flow, or its own role if flowID is zero
+func (t *APIToken) roleOn(flowID int) string {
+	role := t.Role
+	if flowID == 0 {
+		return role
+	}
+	for _, grant := range t.Grants {
+		if grant.FlowID == flowID && roleRanks[grant.Role] > roleRanks[role] {
+			role = grant.Role
+		}
+	}
+	return role
+}
+
+// authorize checks that the request's token has a permission, on the given
+// flow if flowID is not zero. Requests are always allowed when authentication
+// is disabled.
+func authorize(c echo.Context, permission string, flowID int) error {
+	token := currentToken(c)
+	if token == nil {
+		return nil
+	}
+	required := permissionRoles[permission]
+	if roleRanks[token.roleOn(flowID)] >= roleRanks[required] {
+		return nil
+	}
+	return &PermissionError{Permission: permission, FlowID: flowID, Required: required, Token: token}
+}
+
+// forbidden responds to a request that failed authorization
+func forbidden(c echo.Context, err error) error {
+	response := map[string]string{"error": err.Error()}
+	if permErr, ok := err.(*PermissionError); ok {
+		response["missing_permission"] = permErr.Permission
+	}
+	return c.JSON(http.StatusForbidden, response)
+}
+
+// requirePermission is route middleware checking a permission that isn't about one flow
+func requirePermission(permission string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if err := authorize(c, permission, 0); err != nil {
+				return forbidden(c, err)
+			}
+			return next(c)
+		}
+	}
+}
+
+// requireFlowPermission is route middleware checking a permission on the flow
+// that the route parameter param identifies, mapped to a flow ID by lookup.
+// Unknown IDs are passed on so the handler can report them.
+func requireFlowPermission(permission, param string, lookup func(id int) (int, error)) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			id := 0
+			if _, err := fmt.Sscanf(c.Param(param), "%d", &id); err != nil {
+				return next(c)
+			}
+			flowID, err := lookup(id)
+			if err != nil {
+				return next(c)
+			}
+			if err := authorize(c, permission, flowID); err != nil {
+				return forbidden(c, err)
+			}
+			return next(c)
+		}
+	}
+}
+
+// flowOfFlow, flowOfStep and flowOfRun map route IDs to the flow they belong to
+func flowOfFlow(id int) (int, error) {
+	return id, nil
+}
+
+func flowOfStep(id int) (int, error) {
+	var flowID int
+	err := db.QueryRow("SELECT flow_id FROM steps WHERE id = ?", id).Scan(&flowID)
+	return flowID, err
+}
+
+func flowOfRun(id int) (int, error) {
+	var flowID int
+	err := db.QueryRow("SELECT flow_id FROM flow_runs WHERE id = ?", id).Scan(&flowID)
+	return flowID, err
+}
+
+// visibleFlows filters flows down to those the request's token can view
+func visibleFlows(c echo.Context, flows []Flow) []Flow {
+	var visible []Flow
+	for _, flow := range flows {
+		if authorize(c, PermissionViewFlows, flow.ID) == nil {
+			visible = append(visible, flow)
+		}
+	}
+	return visible
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/labstack/echo/v4"
+)
+
+func TestAuthorize(t *testing.T) {
+	tests := []struct {
+		token      APIToken
+		permission string
+		flowID     int
+		allowed    bool
+	}{
+		{APIToken{Role: RoleViewer}, PermissionViewFlows, 1, true},
+		{APIToken{Role: RoleViewer}, PermissionRunFlows, 1, false},
+		{APIToken{Role: RoleRunner}, PermissionRunFlows, 1, true},
+		{APIToken{Role: RoleRunner}, PermissionEditFlows, 1, false},
+		{APIToken{Role: RoleEditor}, PermissionEditFlows, 0, true},
+		{APIToken{Role: RoleEditor}, PermissionRunCommands, 0, false},
+		{APIToken{Role: RoleAdmin}, PermissionRunCommands, 0, true},
+		{APIToken{Role: RoleAdmin}, PermissionAdmin, 0, true},
+		// Grants raise the role on their flow only
+		{APIToken{Grants: []TokenGrant{{FlowID: 1, Role: RoleRunner}}}, PermissionRunFlows, 1, true},
+		{APIToken{Grants: []TokenGrant{{FlowID: 1, Role: RoleRunner}}}, PermissionViewFlows, 2, false},
+		{APIToken{Grants: []TokenGrant{{FlowID: 1, Role: RoleRunner}}}, PermissionEditFlows, 0, false},
+		{APIToken{Role: RoleEditor, Grants: []TokenGrant{{FlowID: 1, Role: RoleViewer}}}, PermissionEditFlows, 1, true},
+		// Even an admin grant doesn't allow what isn't about one flow
+		{APIToken{Grants: []TokenGrant{{FlowID: 1, Role: RoleAdmin}}}, PermissionRunCommands, 0, false},
+	}
+
+	for _, tt := range tests {
+		c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/", nil), httptest.NewRecorder())
+		token := tt.token
+		c.Set("token", &token)
+		err := authorize(c, tt.permission, tt.flowID)
+		if (err == nil) != tt.allowed {
+			t.Errorf("role %q, grants %v: %s on flow %d: got %v, want allowed = %v", tt.token.Role, tt.token.Grants, tt.permission, tt.flowID, err, tt.allowed)
+		}
+	}
+}
+
+// serveShell serves the shell WebSocket to requests made with token,
+// returning its URL
+func serveShell(t *testing.T, token *APIToken) string {
+	t.Helper()
+	e := echo.New()
+	e.GET("/api/shell", handleShellWebSocket, func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Set("token", token)
+			return next(c)
+		}
+	})
+	server := httptest.NewServer(e)
+	t.Cleanup(server.Close)
+	return "ws" + strings.TrimPrefix(server.URL, "http") + "/api/shell"
+}
+
+// readTerminal reads what the terminal shows until it contains want, the
+// connection closes or the timeout passes
+func readTerminal(ws *websocket.Conn, want string, timeout time.Duration) string {
+	var output strings.Builder
+	ws.SetReadDeadline(time.Now().Add(timeout))
+	for !strings.Contains(output.String(), want) {
+		_, message, err := ws.ReadMessage()
+		if err != nil {
+			break
+		}
+		decoded, _ := base64.StdEncoding.DecodeString(string(message))
+		output.Write(decoded)
+	}
+	return output.String()
+}
+
+func TestShellWebSocketPermissions(t *testing.T) {
+	withTestDatabase(t)
+	defaultPolicy(t)
+	config.System.Workspace.DefaultDir = t.TempDir()
+
+	_, granted := createTestRun(t, "granted", []Step{{Name: "greet", Command: "echo step-$((40+2))"}})
+	_, other := createTestRun(t, "other", []Step{{Name: "greet", Command: "echo other"}})
+	flowID, err := flowOfStep(granted[0].ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	runner := &APIToken{Name: "ci", Grants: []TokenGrant{{FlowID: flowID, Role: RoleRunner}}}
+	admin := &APIToken{Name: "ops", Role: RoleAdmin}
+	typed := base64.StdEncoding.EncodeToString([]byte("echo typed-$((6*7))\n"))
+
+	// A runner can't open a free shell, nor a terminal of a flow it can't run
+	for _, query := range []string{"", "?step_id=" + strconv.Itoa(other[0].ID), "?step_id=x"} {
+		_, resp, err := websocket.DefaultDialer.Dial(serveShell(t, runner)+query, nil)
+		if err == nil || resp == nil || resp.StatusCode != http.StatusForbidden {
+			t.Errorf("runner opening %q: got %v, want %d", query, err, http.StatusForbidden)
+		}
+	}
+
+	// The runner's step terminal shows the step's output but ignores input
+	ws, _, err := websocket.DefaultDialer.Dial(serveShell(t, runner)+"?step_id="+strconv.Itoa(granted[0].ID), nil)
+	if err != nil {
+		t.Fatalf("runner opening the step terminal: %v", err)
+	}
+	if err := ws.WriteMessage(websocket.TextMessage, []byte(typed)); err != nil {
+		t.Fatal(err)
+	}
+	output := readTerminal(ws, "typed-42", 3*time.Second)
+	ws.Close()
+	if !strings.Contains(output, "step-42") {
+		t.Errorf("runner's step terminal shows %q, want the step's output", output)
+	}
+	if strings.Contains(output, "typed-42") {
+		t.Errorf("runner's input was run: %q", output)
+	}
+
+	// An admin can type into the step terminal
+	ws, _, err = websocket.DefaultDialer.Dial(serveShell(t, admin)+"?step_id="+strconv.Itoa(granted[0].ID), nil)
+	if err != nil {
+		t.Fatalf("admin opening the step terminal: %v", err)
+	}
+	defer ws.Close()
+	if err := ws.WriteMessage(websocket.TextMessage, []byte(typed)); err != nil {
+		t.Fatal(err)
+	}
+	if output := readTerminal(ws, "typed-42", 10*time.Second); !strings.Contains(output, "typed-42") {
+		t.Errorf("admin's input wasn't run: %q", output)
+	}
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// Retry backoff strategies. With a retry_delay of d, the delay before retry n is
+// d for fixed, n*d for linear and d*2^(n-1) for exponential backoff.
+const (
+	BackoffFixed       = "fixed"
+	BackoffLinear      = "linear"
+	BackoffExponential = "exponential"
+)
+
+const (
+	maxRetries        = 100
+	defaultRetryDelay = time.Second
+	maxRetryDelay     = 10 * time.Minute
+)
+
+// retryPolicy controls how often a step is retried after a non-zero exit
+type retryPolicy struct {
+	retries int
+	delay   time.Duration
+	backoff string
+}
+
+// validateRetryPolicy checks the retry settings of a step
+func validateRetryPolicy(retries int, retryDelay, backoff string) error {
+	if retries < 0 || retries > maxRetries {
+		return fmt.Errorf("invalid retries %d: must be between 0 and %d", retries, maxRetries)
+	}
+	if err := validateDuration("retry_delay", retryDelay); err != nil {
+		return err
+	}
+	switch backoff {
+	case "", BackoffFixed, BackoffLinear, BackoffExponential:
+		return nil
+	}
+	return fmt.Errorf("invalid backoff %q: must be %s, %s or %s", backoff, BackoffFixed, BackoffLinear, BackoffExponential)
+}
+
+// newRetryPolicy builds the retry policy of a step, ignoring invalid settings
+func newRetryPolicy(retries int, retryDelay, backoff string) retryPolicy {
+	policy := retryPolicy{retries: retries, delay: defaultRetryDelay, backoff: backoff}
+	if retries < 0 || retries > maxRetries {
+		slog.Warn("Ignoring invalid retries", "value", retries)
+		policy.retries = 0
+	}
+	if retryDelay != "" {
+		d, err := time.ParseDuration(retryDelay)
+		if err != nil || d <= 0 {
+			slog.Warn("Ignoring invalid retry_delay", "value", retryDelay)
+		} else {
+			policy.delay = d
+		}
+	}
+	return policy
+}
+
+// delayBefore returns how long to wait before the given retry (1 for the first retry)
+func (p retryPolicy) delayBefore(retry int) time.Duration {
+	delay := p.delay
+	switch p.backoff {
+	case BackoffLinear:
+		delay = p.delay * time.Duration(retry)
+	case BackoffExponential:
+		for i := 1; i < retry && delay < maxRetryDelay; i++ {
+			delay *= 2
+		}
+	}
+	if delay > maxRetryDelay {
+		delay = maxRetryDelay
+	}
+	return delay
+}
+
+// executeWithRetries runs a command, retrying it according to policy while it
+// exits with a non-zero code. onAttempt, if set, is called with the result of
+// every attempt; onRetry, if set, is called with the failed attempt before
+// waiting for the next one. The result of the last attempt is returned.
+func executeWithRetries(ctx context.Context, command string, variables map[string]string, opts ExecutionOptions, policy retryPolicy, onAttempt func(CommandResult), onRetry func(failed CommandResult, delay time.Duration)) CommandResult {
+	// Secret values are masked in streamed output as well as in results
+	flushOutput := func() {}
+	if opts.Output != nil {
+		opts.Output, flushOutput = maskedOutput(opts.Output)
+	}
+
+	for attempt := 1; ; attempt++ {
+		result := maskResult(executeCommandWithTmux(ctx, command, variables, opts))
+		flushOutput()
+		result.Attempt = attempt
+		if onAttempt != nil {
+			onAttempt(result)
+		}
+
+		// Only retry commands that ran and exited with an error; timeouts,
+		// cancellations and commands that could not be started are final
+		if result.Status != CommandStatusFailed || result.ExitCode <= 0 || attempt > policy.retries {
+			return result
+		}
+
+		delay := policy.delayBefore(attempt)
+		slog.Warn("Command failed, retrying", "exit_code", result.ExitCode, "attempt", attempt,
+			"attempts", policy.retries+1, "delay", delay, "command", command)
+		if onRetry != nil {
+			onRetry(result, delay)
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			result.Status = CommandStatusCancelled
+			result.Stderr += "\nRetry cancelled"
+			return result
+		}
+	}
+}
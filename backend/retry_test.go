@@ -0,0 +1,153 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRetryDelay(t *testing.T) {
+	tests := []struct {
+		backoff string
+		delay   time.Duration
+		retry   int
+		want    time.Duration
+	}{
+		{"", time.Second, 1, time.Second},
+		{"", time.Second, 5, time.Second},
+		{BackoffFixed, 3 * time.Second, 4, 3 * time.Second},
+		{BackoffLinear, 2 * time.Second, 1, 2 * time.Second},
+		{BackoffLinear, 2 * time.Second, 3, 6 * time.Second},
+		{BackoffExponential, time.Second, 1, time.Second},
+		{BackoffExponential, time.Second, 2, 2 * time.Second},
+		{BackoffExponential, time.Second, 4, 8 * time.Second},
+
+		// Delays are capped
+		{BackoffFixed, time.Hour, 1, maxRetryDelay},
+		{BackoffLinear, time.Minute, 11, maxRetryDelay},
+		{BackoffLinear, 5 * time.Minute, maxRetries, maxRetryDelay},
+		{BackoffExponential, time.Second, 10, 512 * time.Second},
+		{BackoffExponential, time.Second, 11, maxRetryDelay},
+		{BackoffExponential, time.Second, maxRetries, maxRetryDelay},
+		{BackoffExponential, 7 * time.Minute, 2, maxRetryDelay},
+	}
+
+	for _, tt := range tests {
+		policy := retryPolicy{retries: maxRetries, delay: tt.delay, backoff: tt.backoff}
+		if got := policy.delayBefore(tt.retry); got != tt.want {
+			t.Errorf("%q backoff of %v, retry %d: delay %v, want %v", tt.backoff, tt.delay, tt.retry, got, tt.want)
+		}
+	}
+}
+
+func TestNewRetryPolicy(t *testing.T) {
+	tests := []struct {
+		retries    int
+		retryDelay string
+		want       retryPolicy
+	}{
+		{0, "", retryPolicy{retries: 0, delay: defaultRetryDelay}},
+		{3, "5s", retryPolicy{retries: 3, delay: 5 * time.Second}},
+		// Invalid settings saved before they were validated are ignored
+		{-1, "soon", retryPolicy{retries: 0, delay: defaultRetryDelay}},
+		{maxRetries + 1, "-5s", retryPolicy{retries: 0, delay: defaultRetryDelay}},
+		{2, "0s", retryPolicy{retries: 2, delay: defaultRetryDelay}},
+	}
+
+	for _, tt := range tests {
+		if got := newRetryPolicy(tt.retries, tt.retryDelay, ""); got != tt.want {
+			t.Errorf("newRetryPolicy(%d, %q) = %+v, want %+v", tt.retries, tt.retryDelay, got, tt.want)
+		}
+	}
+}
+
+func TestValidateRetryPolicy(t *testing.T) {
+	tests := []struct {
+		retries    int
+		retryDelay string
+		backoff    string
+		err        string
+	}{
+		{0, "", "", ""},
+		{maxRetries, "30s", BackoffExponential, ""},
+		{-1, "", "", "invalid retries -1"},
+		{maxRetries + 1, "", "", "invalid retries 101"},
+		{1, "soon", "", "retry_delay"},
+		{1, "1s", "random", `invalid backoff "random"`},
+	}
+
+	for _, tt := range tests {
+		err := validateRetryPolicy(tt.retries, tt.retryDelay, tt.backoff)
+		if tt.err == "" && err != nil || tt.err != "" && (err == nil || !strings.Contains(err.Error(), tt.err)) {
+			t.Errorf("validateRetryPolicy(%d, %q, %q): got error %v, want %q", tt.retries, tt.retryDelay, tt.backoff, err, tt.err)
+		}
+	}
+}
+
+func TestExecuteWithRetries(t *testing.T) {
+	withTestDatabase(t)
+	defaultPolicy(t)
+	config.System.Workspace.DefaultDir = t.TempDir()
+	policy := retryPolicy{retries: 3, delay: time.Millisecond}
+
+	tests := []struct {
+		name     string
+		command  string
+		policy   retryPolicy
+		attempts int
+		status   string
+	}{
+		{"success", "true", policy, 1, CommandStatusSucceeded},
+		{"non-zero exit", "exit 3", policy, 4, CommandStatusFailed},
+		{"no retries", "exit 3", retryPolicy{delay: time.Millisecond}, 1, CommandStatusFailed},
+		{"succeeds on a retry", `n=$(cat count 2>/dev/null || echo 0); echo $((n+1)) > count; [ "$n" -ge 1 ]`, policy, 2, CommandStatusSucceeded},
+		// Commands that could not be started aren't retried
+		{"undefined variable", "echo ${UNDEFINED}", policy, 1, CommandStatusFailed},
+		{"denied by the policy", "rm -rf /", policy, 1, CommandStatusFailed},
+	}
+
+	for _, tt := range tests {
+		var attempts []CommandResult
+		retried := 0
+		result := executeWithRetries(context.Background(), tt.command, nil, ExecutionOptions{}, tt.policy,
+			func(attempt CommandResult) { attempts = append(attempts, attempt) },
+			func(failed CommandResult, delay time.Duration) { retried++ })
+
+		if len(attempts) != tt.attempts || retried != tt.attempts-1 || result.Attempt != tt.attempts {
+			t.Errorf("%s: %d attempts, %d retries, last attempt %d, want %d attempts", tt.name, len(attempts), retried, result.Attempt, tt.attempts)
+		}
+		if result.Status != tt.status {
+			t.Errorf("%s: status %s, want %s: %s", tt.name, result.Status, tt.status, result.Stderr)
+		}
+	}
+}
+
+func TestExecuteWithRetriesTimeout(t *testing.T) {
+	withTestDatabase(t)
+	defaultPolicy(t)
+	config.System.Workspace.DefaultDir = t.TempDir()
+
+	// A command that times out isn't retried
+	attempts := 0
+	result := executeWithRetries(context.Background(), "sleep 5", nil, ExecutionOptions{Timeout: 100 * time.Millisecond},
+		retryPolicy{retries: 3, delay: time.Millisecond}, func(CommandResult) { attempts++ }, nil)
+	if attempts != 1 || result.Status != CommandStatusTimedOut {
+		t.Errorf("%d attempts with status %s, want 1 timed out", attempts, result.Status)
+	}
+}
+
+func TestExecuteWithRetriesCancelled(t *testing.T) {
+	withTestDatabase(t)
+	defaultPolicy(t)
+	config.System.Workspace.DefaultDir = t.TempDir()
+
+	// Cancelling while waiting to retry stops the retries
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	result := executeWithRetries(ctx, "exit 1", nil, ExecutionOptions{}, retryPolicy{retries: 3, delay: time.Minute},
+		nil, func(CommandResult, time.Duration) { cancel() })
+	if result.Attempt != 1 || result.Status != CommandStatusCancelled || !strings.Contains(result.Stderr, "Retry cancelled") {
+		t.Errorf("got attempt %d with status %s, want the first attempt cancelled: %s", result.Attempt, result.Status, result.Stderr)
+	}
+}
@@ -0,0 +1,447 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Run history
+//
+// GET /api/flows/:id/runs pages through the runs of a flow, most recent
+// first, and summarizes them: per flow and per step, how often they succeed,
+// how long they take and when they last failed. Statistics cover every run
+// in the requested date range, whatever the status filter and page; without
+// a start date, only the runs of the last 30 days, as they are computed from
+// every run and step row in the range on each request.
+
+// Page sizes of the run history
+const (
+	defaultRunsPerPage = 20
+	maxRunsPerPage     = 100
+)
+
+// defaultStatsWindow is how far back the statistics go without a start date
+const defaultStatsWindow = 30 * 24 * time.Hour
+
+// RunSummary is a run as listed in the run history
+type RunSummary struct {
+	ID          int           `json:"id"`
+	Status      string        `json:"status"`
+	Error       string        `json:"error,omitempty"`
+	Environment string        `json:"environment,omitempty"`
+	StartedAt   time.Time     `json:"started_at"`
+	FinishedAt  *time.Time    `json:"finished_at,omitempty"`
+	Duration    time.Duration `json:"duration,omitempty"` // Zero until the run has finished
+}
+
+// RunFailure describes the most recent failure of a flow or step
+type RunFailure struct {
+	RunID    int       `json:"run_id"`
+	At       time.Time `json:"at"`
+	Status   string    `json:"status"`
+	ExitCode *int      `json:"exit_code,omitempty"` // Steps only
+	Error    string    `json:"error,omitempty"`     // Flows only
+}
+
+// FlowRunStats summarizes the runs of a flow
+type FlowRunStats struct {
+	Runs        int            `json:"runs"`
+	Statuses    map[string]int `json:"statuses"`     // Number of runs by status
+	SuccessRate *float64       `json:"success_rate"` // Of the runs that succeeded, failed or timed out; null if there are none
+	P50Duration time.Duration  `json:"p50_duration"` // Of finished runs
+	P95Duration time.Duration  `json:"p95_duration"`
+	LastFailure *RunFailure    `json:"last_failure,omitempty"`
+}
+
+// StepRunStats summarizes the executions of a step across runs
+type StepRunStats struct {
+	StepID      int            `json:"step_id"`
+	StepName    string         `json:"step_name"` // As of the most recent run
+	Runs        int            `json:"runs"`      // Runs the step was part of
+	Statuses    map[string]int `json:"statuses"`
+	SuccessRate *float64       `json:"success_rate"`
+	Retried     int            `json:"retried"` // Runs in which the step needed more than one attempt
+	P50Duration time.Duration  `json:"p50_duration"`
+	P95Duration time.Duration  `json:"p95_duration"`
+	LastFailure *RunFailure    `json:"last_failure,omitempty"`
+}
+
+// RunStats summarizes the runs of a flow and of each of its steps
+type RunStats struct {
+	Since time.Time      `json:"since"` // Start of the runs covered
+	Flow  FlowRunStats   `json:"flow"`
+	Steps []StepRunStats `json:"steps"`
+}
+
+// RunHistory is a page of the runs of a flow and statistics about them
+type RunHistory struct {
+	Runs    []RunSummary `json:"runs"`
+	Total   int          `json:"total"` // Runs matching the filters
+	Page    int          `json:"page"`
+	PerPage int          `json:"per_page"`
+	Stats   RunStats     `json:"stats"`
+}
+
+// RunHistoryQuery selects runs of a flow; zero fields don't filter
+type RunHistoryQuery struct {
+	FlowID   int
+	Statuses []string
+	Since    time.Time
+	Until    time.Time
+	Page     int
+	PerPage  int
+}
+
+// runStatuses are the statuses a run can end up with, for validating filters
+var runStatuses = map[string]bool{
+	RunStatusPending:   true,
+	RunStatusRunning:   true,
+	RunStatusSucceeded: true,
+	RunStatusFailed:    true,
+	RunStatusTimedOut:  true,
+	RunStatusCancelled: true,
+}
+
+// dateRange returns the SQL conditions and arguments selecting the runs of
+// the query's flow in its date range. Run times are stored in local time.
+func (q RunHistoryQuery) dateRange() ([]string, []interface{}) {
+	conditions := []string{"r.flow_id = ?"}
+	args := []interface{}{q.FlowID}
+	if !q.Since.IsZero() {
+		conditions = append(conditions, "r.started_at >= ?")
+		args = append(args, q.Since.Local())
+	}
+	if !q.Until.IsZero() {
+		conditions = append(conditions, "r.started_at < ?")
+		args = append(args, q.Until.Local())
+	}
+	return conditions, args
+}
+
+// getRunHistory returns a page of the runs matching a query, with statistics
+func getRunHistory(query RunHistoryQuery) (*RunHistory, error) {
+	history := &RunHistory{Runs: []RunSummary{}, Page: query.Page, PerPage: query.PerPage}
+
+	conditions, args := query.dateRange()
+	if len(query.Statuses) > 0 {
+		conditions = append(conditions, "r.status IN (?"+strings.Repeat(", ?", len(query.Statuses)-1)+")")
+		for _, status := range query.Statuses {
+			args = append(args, status)
+		}
+	}
+	where := " WHERE " + strings.Join(conditions, " AND ")
+
+	if err := db.QueryRow("SELECT COUNT(*) FROM flow_runs r"+where, args...).Scan(&history.Total); err != nil {
+		return nil, fmt.Errorf("failed to count runs: %v", err)
+	}
+
+	rows, err := db.Query(
+		"SELECT r.id, r.status, r.error, r.environment, r.started_at, r.finished_at FROM flow_runs r"+where+" ORDER BY r.id DESC LIMIT ? OFFSET ?",
+		append(args, query.PerPage, (query.Page-1)*query.PerPage)...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query runs: %v", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var run RunSummary
+		var runErr, environment sql.NullString
+		var finishedAt sql.NullTime
+		if err := rows.Scan(&run.ID, &run.Status, &runErr, &environment, &run.StartedAt, &finishedAt); err != nil {
+			return nil, err
+		}
+		run.Error = runErr.String
+		run.Environment = environment.String
+		if finishedAt.Valid {
+			run.FinishedAt = &finishedAt.Time
+			run.Duration = finishedAt.Time.Sub(run.StartedAt)
+		}
+		history.Runs = append(history.Runs, run)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	statsQuery := query.statsRange()
+	history.Stats.Since = statsQuery.Since
+	if history.Stats.Flow, err = getFlowRunStats(statsQuery); err != nil {
+		return nil, err
+	}
+	if history.Stats.Steps, err = getStepRunStats(statsQuery); err != nil {
+		return nil, err
+	}
+	return history, nil
+}
+
+// statsRange returns the query with its start date defaulted to
+// defaultStatsWindow before its end, or before now
+func (q RunHistoryQuery) statsRange() RunHistoryQuery {
+	if q.Since.IsZero() {
+		end := q.Until
+		if end.IsZero() {
+			end = time.Now()
+		}
+		q.Since = end.Add(-defaultStatsWindow)
+	}
+	return q
+}
+
+// getFlowRunStats summarizes the runs in the query's date range
+func getFlowRunStats(query RunHistoryQuery) (FlowRunStats, error) {
+	stats := FlowRunStats{Statuses: map[string]int{}}
+	conditions, args := query.dateRange()
+	rows, err := db.Query(
+		"SELECT r.id, r.status, r.error, r.started_at, r.finished_at FROM flow_runs r WHERE "+strings.Join(conditions, " AND ")+" ORDER BY r.id",
+		args...,
+	)
+	if err != nil {
+		return stats, fmt.Errorf("failed to query runs: %v", err)
+	}
+	defer rows.Close()
+
+	var outcomes outcomeCounter
+	var durations []time.Duration
+	for rows.Next() {
+		var id int
+		var status string
+		var runErr sql.NullString
+		var startedAt time.Time
+		var finishedAt sql.NullTime
+		if err := rows.Scan(&id, &status, &runErr, &startedAt, &finishedAt); err != nil {
+			return stats, err
+		}
+
+		stats.Runs++
+		stats.Statuses[status]++
+		outcomes.add(status)
+		if finishedAt.Valid {
+			durations = append(durations, finishedAt.Time.Sub(startedAt))
+		}
+		if status == RunStatusFailed || status == RunStatusTimedOut {
+			at := startedAt
+			if finishedAt.Valid {
+				at = finishedAt.Time
+			}
+			stats.LastFailure = &RunFailure{RunID: id, At: at, Status: status, Error: runErr.String}
+		}
+	}
+
+	stats.SuccessRate = outcomes.rate()
+	stats.P50Duration, stats.P95Duration = percentile(durations, 50), percentile(durations, 95)
+	return stats, rows.Err()
+}
+
+// getStepRunStats summarizes the executions of each step in the runs in the
+// query's date range, in the order the steps had in the most recent run
+func getStepRunStats(query RunHistoryQuery) ([]StepRunStats, error) {
+	conditions, args := query.dateRange()
+	rows, err := db.Query(
+		`SELECT rs.run_id, rs.step_id, rs.step_name, rs.order_index, rs.status, rs.exit_code, rs.duration, rs.executed_at,
+			(SELECT COUNT(*) FROM run_step_attempts a WHERE a.run_id = rs.run_id AND a.order_index = rs.order_index)
+		FROM run_steps rs JOIN flow_runs r ON r.id = rs.run_id
+		WHERE `+strings.Join(conditions, " AND ")+" ORDER BY rs.run_id, rs.order_index",
+		args...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query run steps: %v", err)
+	}
+	defer rows.Close()
+
+	type stepAccumulator struct {
+		stats      StepRunStats
+		outcomes   outcomeCounter
+		durations  []time.Duration
+		lastRun    int
+		orderIndex int
+	}
+	steps := make(map[int]*stepAccumulator)
+	for rows.Next() {
+		var runID, stepID, orderIndex, attempts int
+		var stepName, status string
+		var exitCode, duration sql.NullInt64
+		var executedAt sql.NullTime
+		if err := rows.Scan(&runID, &stepID, &stepName, &orderIndex, &status, &exitCode, &duration, &executedAt, &attempts); err != nil {
+			return nil, err
+		}
+
+		acc := steps[stepID]
+		if acc == nil {
+			acc = &stepAccumulator{stats: StepRunStats{StepID: stepID, Statuses: map[string]int{}}}
+			steps[stepID] = acc
+		}
+		acc.stats.StepName, acc.lastRun, acc.orderIndex = stepName, runID, orderIndex
+		acc.stats.Runs++
+		acc.stats.Statuses[status]++
+		acc.outcomes.add(status)
+		if attempts > 1 {
+			acc.stats.Retried++
+		}
+		if duration.Valid && executedAt.Valid {
+			acc.durations = append(acc.durations, time.Duration(duration.Int64))
+		}
+		if status == RunStatusFailed || status == RunStatusTimedOut {
+			failure := &RunFailure{RunID: runID, Status: status, At: executedAt.Time}
+			if exitCode.Valid {
+				code := int(exitCode.Int64)
+				failure.ExitCode = &code
+			}
+			acc.stats.LastFailure = failure
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	accumulators := make([]*stepAccumulator, 0, len(steps))
+	for _, acc := range steps {
+		accumulators = append(accumulators, acc)
+	}
+	sort.Slice(accumulators, func(i, j int) bool {
+		a, b := accumulators[i], accumulators[j]
+		if a.lastRun != b.lastRun {
+			return a.lastRun > b.lastRun
+		}
+		return a.orderIndex < b.orderIndex
+	})
+
+	stats := make([]StepRunStats, 0, len(accumulators))
+	for _, acc := range accumulators {
+		acc.stats.SuccessRate = acc.outcomes.rate()
+		acc.stats.P50Duration, acc.stats.P95Duration = percentile(acc.durations, 50), percentile(acc.durations, 95)
+		stats = append(stats, acc.stats)
+	}
+	return stats, nil
+}
+
+// outcomeCounter counts the runs or steps that succeeded or failed; those
+// that were cancelled, skipped or haven't finished don't count either way
+type outcomeCounter struct {
+	succeeded int
+	failed    int
+}
+
+func (o *outcomeCounter) add(status string) {
+	switch status {
+	case RunStatusSucceeded, CommandStatusStarted:
+		o.succeeded++
+	case RunStatusFailed, RunStatusTimedOut:
+		o.failed++
+	}
+}
+
+// rate returns the fraction that succeeded, or nil if none finished either way
+func (o outcomeCounter) rate() *float64 {
+	total := o.succeeded + o.failed
+	if total == 0 {
+		return nil
+	}
+	rate := math.Round(float64(o.succeeded)/float64(total)*1000) / 1000
+	return &rate
+}
+
+// percentile returns the p-th percentile of durations by the nearest-rank
+// method, or zero if there are none
+func percentile(durations []time.Duration, p int) time.Duration {
+	if len(durations) == 0 {
+		return 0
+	}
+	sorted := append([]time.Duration(nil), durations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	rank := int(math.Ceil(float64(p) / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// parseTimeParam parses a query parameter holding an RFC 3339 time or a
+// date, which means midnight UTC; an empty parameter is the zero time
+func parseTimeParam(c echo.Context, param string) (time.Time, error) {
+	value := c.QueryParam(param)
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid %s: must be an RFC 3339 time such as 2024-01-02T15:04:05Z or a date such as 2024-01-02", param)
+}
+
+// handleGetFlowRuns lists the runs of a flow, most recent first, with
+// statistics. Query parameters: status (comma-separated), since and until
+// (RFC 3339 times or dates), page (from 1) and per_page (default 20, at
+// most 100).
+func handleGetFlowRuns(c echo.Context) error {
+	query := RunHistoryQuery{Page: 1, PerPage: defaultRunsPerPage}
+	if _, err := fmt.Sscanf(c.Param("id"), "%d", &query.FlowID); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid flow ID",
+		})
+	}
+	if _, err := getFlowByID(query.FlowID); err != nil {
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "Flow not found",
+		})
+	}
+
+	if value := c.QueryParam("status"); value != "" {
+		for _, status := range strings.Split(value, ",") {
+			status = strings.TrimSpace(status)
+			if !runStatuses[status] {
+				return c.JSON(http.StatusBadRequest, map[string]string{
+					"error": fmt.Sprintf("Invalid status %q", status),
+				})
+			}
+			query.Statuses = append(query.Statuses, status)
+		}
+	}
+
+	var err error
+	if query.Since, err = parseTimeParam(c, "since"); err == nil {
+		query.Until, err = parseTimeParam(c, "until")
+	}
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	for param, dest := range map[string]*int{"page": &query.Page, "per_page": &query.PerPage} {
+		value := c.QueryParam(param)
+		if value == "" {
+			continue
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": fmt.Sprintf("Invalid %s: must be a positive number", param),
+			})
+		}
+		*dest = n
+	}
+	if query.PerPage > maxRunsPerPage {
+		query.PerPage = maxRunsPerPage
+	}
+
+	history, err := getRunHistory(query)
+	if err != nil {
+		slog.Error("Error getting runs", "flow_id", query.FlowID, "error", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get flow runs",
+		})
+	}
+	return c.JSON(http.StatusOK, history)
+}
@@ -0,0 +1,282 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+func TestPercentile(t *testing.T) {
+	tests := []struct {
+		durations []time.Duration
+		p         int
+		want      time.Duration
+	}{
+		{nil, 50, 0},
+		{[]time.Duration{7}, 50, 7},
+		{[]time.Duration{7}, 95, 7},
+		{[]time.Duration{4, 1, 3, 2}, 50, 2},
+		{[]time.Duration{4, 1, 3, 2}, 95, 4},
+		{[]time.Duration{4, 1, 3, 2}, 0, 1},
+		{[]time.Duration{4, 1, 3, 2}, 100, 4},
+		{[]time.Duration{10, 20, 30, 40, 50, 60, 70, 80, 90, 100}, 50, 50},
+		{[]time.Duration{10, 20, 30, 40, 50, 60, 70, 80, 90, 100}, 95, 100},
+		{[]time.Duration{10, 20, 30, 40, 50, 60, 70, 80, 90, 100}, 90, 90},
+	}
+
+	for _, tt := range tests {
+		durations := append([]time.Duration(nil), tt.durations...)
+		if got := percentile(tt.durations, tt.p); got != tt.want {
+			t.Errorf("percentile(%v, %d) = %v, want %v", tt.durations, tt.p, got, tt.want)
+		}
+		if !reflect.DeepEqual(tt.durations, durations) {
+			t.Errorf("percentile(%v, %d) reordered its argument", durations, tt.p)
+		}
+	}
+}
+
+// historyRun is a run of the test flow, which started ago
+type historyRun struct {
+	status   string
+	ago      time.Duration
+	duration time.Duration
+	steps    []CommandResult // By order index; the zero result leaves a step pending
+	attempts []int           // Attempts recorded per step
+}
+
+// createTestHistory creates a flow with the given steps and the runs, returning
+// the flow ID and the run IDs
+func createTestHistory(t *testing.T, steps []Step, runs []historyRun) (int, []int) {
+	t.Helper()
+	runID, stored := createTestRun(t, "history", steps)
+	run, err := getRunByID(runID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	flow, err := getFlowByID(run.FlowID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec("DELETE FROM flow_runs WHERE id = ?", runID); err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	var ids []int
+	for _, r := range runs {
+		id, err := createRun(flow, stored, "", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		startedAt := now.Add(-r.ago)
+		if _, err := db.Exec("UPDATE flow_runs SET status = ?, started_at = ?, finished_at = ? WHERE id = ?",
+			r.status, startedAt, startedAt.Add(r.duration), id); err != nil {
+			t.Fatal(err)
+		}
+		for i, result := range r.steps {
+			if result.Status == "" {
+				continue
+			}
+			result.ExecutedAt = startedAt
+			if err := saveRunStepResult(id, i, result.Status, result); err != nil {
+				t.Fatal(err)
+			}
+		}
+		for i, attempts := range r.attempts {
+			for attempt := 1; attempt <= attempts; attempt++ {
+				if err := saveRunStepAttempt(id, i, CommandResult{Attempt: attempt}); err != nil {
+					t.Fatal(err)
+				}
+			}
+		}
+		ids = append(ids, id)
+	}
+	return flow.ID, ids
+}
+
+func runIDs(history *RunHistory) []int {
+	ids := []int{}
+	for _, run := range history.Runs {
+		ids = append(ids, run.ID)
+	}
+	return ids
+}
+
+func TestGetRunHistory(t *testing.T) {
+	withTestDatabase(t)
+	day := 24 * time.Hour
+	succeeded := CommandResult{Status: RunStatusSucceeded, Duration: time.Second}
+	failed := CommandResult{Status: RunStatusFailed, ExitCode: 1, Duration: 2 * time.Second}
+	timedOut := CommandResult{Status: RunStatusTimedOut, ExitCode: -1, Duration: 3 * time.Second}
+
+	flowID, ids := createTestHistory(t, []Step{{Name: "build", Command: "make"}, {Name: "test", Command: "make test"}}, []historyRun{
+		{status: RunStatusSucceeded, ago: 35 * day, duration: 10 * time.Second, steps: []CommandResult{succeeded, succeeded}},
+		{status: RunStatusFailed, ago: 10 * day, duration: 20 * time.Second, steps: []CommandResult{succeeded, failed}, attempts: []int{0, 2}},
+		{status: RunStatusSucceeded, ago: 5 * day, duration: 30 * time.Second, steps: []CommandResult{succeeded, succeeded}},
+		{status: RunStatusTimedOut, ago: day, duration: 40 * time.Second, steps: []CommandResult{succeeded, timedOut}},
+		{status: RunStatusSucceeded, ago: time.Hour, duration: 50 * time.Second, steps: []CommandResult{succeeded, succeeded}},
+	})
+	newest := func(n ...int) []int {
+		var want []int
+		for _, i := range n {
+			want = append(want, ids[i])
+		}
+		return want
+	}
+
+	tests := []struct {
+		name  string
+		query RunHistoryQuery
+		runs  []int
+		total int
+		stats int // Runs the statistics cover
+	}{
+		{"everything", RunHistoryQuery{}, newest(4, 3, 2, 1, 0), 5, 4},
+		{"status", RunHistoryQuery{Statuses: []string{RunStatusFailed, RunStatusTimedOut}}, newest(3, 1), 2, 4},
+		{"no matching status", RunHistoryQuery{Statuses: []string{RunStatusCancelled}}, []int{}, 0, 4},
+		{"since", RunHistoryQuery{Since: time.Now().Add(-7 * day)}, newest(4, 3, 2), 3, 3},
+		{"until", RunHistoryQuery{Until: time.Now().Add(-7 * day)}, newest(1, 0), 2, 2},
+		{"since and until", RunHistoryQuery{Since: time.Now().Add(-40 * day), Until: time.Now().Add(-2 * day)}, newest(2, 1, 0), 3, 3},
+		{"first page", RunHistoryQuery{PerPage: 2}, newest(4, 3), 5, 4},
+		{"second page", RunHistoryQuery{Page: 2, PerPage: 2}, newest(2, 1), 5, 4},
+		{"last page", RunHistoryQuery{Page: 3, PerPage: 2}, newest(0), 5, 4},
+		{"past the last page", RunHistoryQuery{Page: 4, PerPage: 2}, []int{}, 5, 4},
+	}
+
+	for _, tt := range tests {
+		query := tt.query
+		query.FlowID = flowID
+		if query.Page == 0 {
+			query.Page = 1
+		}
+		if query.PerPage == 0 {
+			query.PerPage = defaultRunsPerPage
+		}
+		history, err := getRunHistory(query)
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if got := runIDs(history); !reflect.DeepEqual(got, tt.runs) {
+			t.Errorf("%s: runs %v, want %v", tt.name, got, tt.runs)
+		}
+		if history.Total != tt.total {
+			t.Errorf("%s: total %d, want %d", tt.name, history.Total, tt.total)
+		}
+		if history.Stats.Flow.Runs != tt.stats {
+			t.Errorf("%s: stats cover %d runs, want %d", tt.name, history.Stats.Flow.Runs, tt.stats)
+		}
+	}
+
+	// Without a start date, the statistics cover the last 30 days
+	history, err := getRunHistory(RunHistoryQuery{FlowID: flowID, Page: 1, PerPage: defaultRunsPerPage})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if since := time.Since(history.Stats.Since); since < 30*day || since > 30*day+time.Minute {
+		t.Errorf("stats since %v, want 30 days ago", history.Stats.Since)
+	}
+
+	flow := history.Stats.Flow
+	if want := map[string]int{RunStatusSucceeded: 2, RunStatusFailed: 1, RunStatusTimedOut: 1}; !reflect.DeepEqual(flow.Statuses, want) {
+		t.Errorf("flow statuses %v, want %v", flow.Statuses, want)
+	}
+	if flow.SuccessRate == nil || *flow.SuccessRate != 0.5 {
+		t.Errorf("flow success rate %v, want 0.5", flow.SuccessRate)
+	}
+	if flow.P50Duration != 30*time.Second || flow.P95Duration != 50*time.Second {
+		t.Errorf("flow p50 %v and p95 %v, want 30s and 50s", flow.P50Duration, flow.P95Duration)
+	}
+	if flow.LastFailure == nil || flow.LastFailure.RunID != ids[3] || flow.LastFailure.Status != RunStatusTimedOut {
+		t.Errorf("flow last failure %+v, want run %d timed out", flow.LastFailure, ids[3])
+	}
+
+	steps := history.Stats.Steps
+	if len(steps) != 2 || steps[0].StepName != "build" || steps[1].StepName != "test" {
+		t.Fatalf("step stats %+v, want build and test", steps)
+	}
+	if steps[0].Runs != 4 || *steps[0].SuccessRate != 1 || steps[0].Retried != 0 || steps[0].LastFailure != nil {
+		t.Errorf("build stats %+v", steps[0])
+	}
+	test := steps[1]
+	if test.Runs != 4 || *test.SuccessRate != 0.5 || test.Retried != 1 {
+		t.Errorf("test stats %+v, want 4 runs, success rate 0.5 and 1 retried", test)
+	}
+	if test.P50Duration != time.Second || test.P95Duration != 3*time.Second {
+		t.Errorf("test p50 %v and p95 %v, want 1s and 3s", test.P50Duration, test.P95Duration)
+	}
+	if f := test.LastFailure; f == nil || f.RunID != ids[3] || f.ExitCode == nil || *f.ExitCode != -1 {
+		t.Errorf("test last failure %+v, want run %d with exit code -1", f, ids[3])
+	}
+}
+
+// getFlowRuns requests the run history of a flow
+func getFlowRuns(t *testing.T, flowID int, query string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/?"+query, nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(strconv.Itoa(flowID))
+	if err := handleGetFlowRuns(c); err != nil {
+		t.Fatalf("handleGetFlowRuns(%q): %v", query, err)
+	}
+	return rec
+}
+
+func TestHandleGetFlowRuns(t *testing.T) {
+	withTestDatabase(t)
+	var runs []historyRun
+	for i := 0; i < maxRunsPerPage+5; i++ {
+		runs = append(runs, historyRun{status: RunStatusSucceeded, ago: time.Duration(i) * time.Minute})
+	}
+	flowID, _ := createTestHistory(t, []Step{{Name: "build", Command: "make"}}, runs)
+
+	tests := []struct {
+		query   string
+		status  int
+		runs    int
+		page    int
+		perPage int
+	}{
+		{"", http.StatusOK, defaultRunsPerPage, 1, defaultRunsPerPage},
+		{"page=2&per_page=50", http.StatusOK, 50, 2, 50},
+		{"page=3&per_page=50", http.StatusOK, 5, 3, 50},
+		{"per_page=1000", http.StatusOK, maxRunsPerPage, 1, maxRunsPerPage},
+		{"status=succeeded,%20failed&since=2000-01-01&until=2100-01-01T00:00:00Z", http.StatusOK, defaultRunsPerPage, 1, defaultRunsPerPage},
+		{"status=done", http.StatusBadRequest, 0, 0, 0},
+		{"since=yesterday", http.StatusBadRequest, 0, 0, 0},
+		{"until=2024-13-01", http.StatusBadRequest, 0, 0, 0},
+		{"page=0", http.StatusBadRequest, 0, 0, 0},
+		{"per_page=x", http.StatusBadRequest, 0, 0, 0},
+	}
+
+	for _, tt := range tests {
+		rec := getFlowRuns(t, flowID, tt.query)
+		if rec.Code != tt.status {
+			t.Errorf("%q: status %d, want %d: %s", tt.query, rec.Code, tt.status, rec.Body.String())
+			continue
+		}
+		if tt.status != http.StatusOK {
+			continue
+		}
+		var history RunHistory
+		if err := json.Unmarshal(rec.Body.Bytes(), &history); err != nil {
+			t.Fatal(err)
+		}
+		if len(history.Runs) != tt.runs || history.Page != tt.page || history.PerPage != tt.perPage || history.Total != len(runs) {
+			t.Errorf("%q: %d runs, page %d of %d, total %d, want %d runs, page %d of %d, total %d",
+				tt.query, len(history.Runs), history.Page, history.PerPage, history.Total, tt.runs, tt.page, tt.perPage, len(runs))
+		}
+	}
+
+	if rec := getFlowRuns(t, flowID+1, ""); rec.Code != http.StatusNotFound {
+		t.Errorf("unknown flow: status %d, want %d", rec.Code, http.StatusNotFound)
+	}
+}
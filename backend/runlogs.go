@@ -0,0 +1,363 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Run step logs
+//
+// The combined output of every step of a run is written, as it is produced,
+// to data.logs_dir/runs/<run ID>/<step ID>.log, so it outlives the request
+// that started the run. Retries are appended to the same log. Step output may
+// contain anything, so only the service user can read the logs. Logs are
+// deleted according to logging.run_logs once their run has finished, checked
+// at startup, after each run and every logPruneInterval.
+
+// RunLogsConfig sets how long run step logs are kept
+type RunLogsConfig struct {
+	MaxAgeDays int `yaml:"max_age_days"` // Delete the logs of runs last written to longer ago; 0 means no limit
+	MaxRuns    int `yaml:"max_runs"`     // Keep the logs of at most this many runs, the most recent; 0 means no limit
+}
+
+const (
+	// logFollowInterval is how often a followed step log is checked for new output
+	logFollowInterval = 500 * time.Millisecond
+
+	// logPruneInterval is how often the retention limits are applied, so that
+	// logs expire even while no runs finish
+	logPruneInterval = time.Hour
+)
+
+// runLogStore is where run step logs are kept
+type runLogStore struct {
+	dir     string
+	maxAge  time.Duration
+	maxRuns int
+}
+
+// runLogs holds the run step logs, set up by initRunLogs
+var runLogs = &runLogStore{dir: filepath.Join("data", "logs", "runs")}
+
+// initRunLogs sets up the run step logs under logsDir and applies the retention limits
+func initRunLogs(cfg RunLogsConfig, logsDir string) error {
+	store := &runLogStore{
+		dir:     filepath.Join(logsDir, "runs"),
+		maxAge:  time.Duration(cfg.MaxAgeDays) * 24 * time.Hour,
+		maxRuns: cfg.MaxRuns,
+	}
+	if err := os.MkdirAll(store.dir, 0700); err != nil {
+		return fmt.Errorf("failed to create run log directory: %v", err)
+	}
+	// Directories created by earlier versions were readable by everyone
+	if err := os.Chmod(store.dir, 0700); err != nil {
+		return fmt.Errorf("failed to restrict run log directory: %v", err)
+	}
+	runLogs = store
+	runLogs.prune()
+
+	if store.maxAge > 0 || store.maxRuns > 0 {
+		go func() {
+			for range time.Tick(logPruneInterval) {
+				store.prune()
+			}
+		}()
+	}
+	return nil
+}
+
+// path returns the log file of a run step
+func (s *runLogStore) path(runID, stepID int) string {
+	return filepath.Join(s.dir, strconv.Itoa(runID), strconv.Itoa(stepID)+".log")
+}
+
+// stepLog is the log file of a run step being executed
+type stepLog struct {
+	mu      sync.Mutex
+	file    *os.File
+	written int64
+}
+
+// open creates or appends to the log of a run step
+func (s *runLogStore) open(runID, stepID int) (*stepLog, error) {
+	path := s.path(runID, stepID)
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, fmt.Errorf("failed to create run log directory: %v", err)
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open step log: %v", err)
+	}
+	return &stepLog{file: file}, nil
+}
+
+// write appends text to the log
+func (l *stepLog) write(text string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.writeLocked(text)
+}
+
+// writeLocked appends text to the log. Callers must hold l.mu.
+func (l *stepLog) writeLocked(text string) {
+	n, err := l.file.WriteString(text)
+	l.written += int64(n)
+	if err != nil {
+		slog.Error("Error writing step log", "path", l.file.Name(), "error", err)
+	}
+}
+
+// sink returns an OutputSink appending stdout and stderr to the log
+func (l *stepLog) sink() OutputSink {
+	return func(chunk OutputChunk) {
+		l.write(chunk.Data)
+	}
+}
+
+// retrying marks the start of a retry in the log
+func (l *stepLog) retrying(failed CommandResult, delay time.Duration) {
+	l.write(fmt.Sprintf("\n--- attempt %d failed with exit code %d, retrying in %v ---\n", failed.Attempt, failed.ExitCode, delay))
+}
+
+// finish records why a command that produced no output failed, then closes the log
+func (l *stepLog) finish(result CommandResult) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.written == 0 && result.Stderr != "" {
+		l.writeLocked(result.Stderr)
+	}
+	if err := l.file.Close(); err != nil {
+		slog.Error("Error closing step log", "error", err)
+	}
+}
+
+// prune deletes the logs of the runs beyond the retention limits
+func (s *runLogStore) prune() {
+	if s.maxAge <= 0 && s.maxRuns <= 0 {
+		return
+	}
+
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		slog.Error("Error listing run logs", "error", err)
+		return
+	}
+
+	var runIDs []int
+	for _, entry := range entries {
+		if id, err := strconv.Atoi(entry.Name()); err == nil && entry.IsDir() {
+			runIDs = append(runIDs, id)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(runIDs)))
+
+	for i, runID := range runIDs {
+		if executions.running(runKey(runID)) {
+			continue
+		}
+		dir := filepath.Join(s.dir, strconv.Itoa(runID))
+		tooMany := s.maxRuns > 0 && i >= s.maxRuns
+		tooOld := s.maxAge > 0 && time.Since(lastModified(dir)) > s.maxAge
+		if tooMany || tooOld {
+			if err := os.RemoveAll(dir); err != nil {
+				slog.Error("Error deleting run logs", "run_id", runID, "error", err)
+			}
+		}
+	}
+}
+
+// lastModified returns when a file in dir, or dir itself, was last changed
+func lastModified(dir string) time.Time {
+	var latest time.Time
+	filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err == nil && info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+		return nil
+	})
+	return latest
+}
+
+// tailOffset returns the offset of the last n lines of a file of the given size
+func tailOffset(file *os.File, size int64, n int) (int64, error) {
+	if n == 0 {
+		return size, nil
+	}
+	const blockSize = 4096
+	offset := size
+	lines := 0
+	buf := make([]byte, blockSize)
+	for offset > 0 {
+		readSize := int64(blockSize)
+		if offset < readSize {
+			readSize = offset
+		}
+		offset -= readSize
+		if _, err := file.ReadAt(buf[:readSize], offset); err != nil && err != io.EOF {
+			return 0, err
+		}
+		for i := readSize - 1; i >= 0; i-- {
+			// A newline ending the file doesn't start another line
+			if buf[i] == '\n' && offset+i != size-1 {
+				lines++
+				if lines == n {
+					return offset + i + 1, nil
+				}
+			}
+		}
+	}
+	return 0, nil
+}
+
+// runStepStatus returns the status of a step in a run, or sql.ErrNoRows if the run has no such step
+func runStepStatus(runID, stepID int) (string, error) {
+	var status string
+	err := db.QueryRow(
+		"SELECT status FROM run_steps WHERE run_id = ? AND step_id = ? ORDER BY order_index LIMIT 1",
+		runID, stepID,
+	).Scan(&status)
+	return status, err
+}
+
+// stepFinished reports whether a run step with this status will produce no more output
+func stepFinished(status string) bool {
+	switch status {
+	case RunStatusPending, RunStatusQueued, RunStatusRunning, RunStatusRetrying:
+		return false
+	}
+	return true
+}
+
+// handleGetRunStepLog serves the log of a run step as plain text. Range
+// requests are supported for paging. tail=N starts at the last N lines,
+// offset=B at byte B, and follow=true keeps the response open, streaming new
+// output until the step finishes. X-Log-Complete tells whether the step has
+// finished, and so whether more output may follow.
+func handleGetRunStepLog(c echo.Context) error {
+	runID, stepID := 0, 0
+	if _, err := fmt.Sscanf(c.Param("id"), "%d", &runID); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid run ID",
+		})
+	}
+	if _, err := fmt.Sscanf(c.Param("stepId"), "%d", &stepID); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid step ID",
+		})
+	}
+
+	status, err := runStepStatus(runID, stepID)
+	if err == sql.ErrNoRows {
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "Run step not found",
+		})
+	}
+	if err != nil {
+		slog.Error("Error getting run step", "run_id", runID, "step_id", stepID, "error", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get run step",
+		})
+	}
+
+	file, err := os.Open(runLogs.path(runID, stepID))
+	if os.IsNotExist(err) {
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": fmt.Sprintf("No log for this step (status %s)", status),
+		})
+	}
+	if err != nil {
+		slog.Error("Error opening step log", "run_id", runID, "step_id", stepID, "error", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to open step log",
+		})
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		slog.Error("Error reading step log", "run_id", runID, "step_id", stepID, "error", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to read step log",
+		})
+	}
+
+	tail, offset, follow := c.QueryParam("tail"), c.QueryParam("offset"), c.QueryParam("follow") == "true"
+	res := c.Response()
+	res.Header().Set("X-Log-Complete", strconv.FormatBool(stepFinished(status)))
+	if tail == "" && offset == "" && !follow {
+		res.Header().Set(echo.HeaderContentType, echo.MIMETextPlainCharsetUTF8)
+		http.ServeContent(res, c.Request(), filepath.Base(file.Name()), info.ModTime(), file)
+		return nil
+	}
+
+	var start int64
+	if tail != "" {
+		lines, err := strconv.Atoi(tail)
+		if err != nil || lines < 0 {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid tail: must be a number of lines",
+			})
+		}
+		if start, err = tailOffset(file, info.Size(), lines); err != nil {
+			slog.Error("Error reading step log", "run_id", runID, "step_id", stepID, "error", err)
+			return c.JSON(http.StatusInternalServerError, map[string]string{
+				"error": "Failed to read step log",
+			})
+		}
+	} else if offset != "" {
+		if start, err = strconv.ParseInt(offset, 10, 64); err != nil || start < 0 {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid offset: must be a number of bytes",
+			})
+		}
+	}
+
+	if _, err := file.Seek(start, io.SeekStart); err != nil {
+		slog.Error("Error reading step log", "run_id", runID, "step_id", stepID, "error", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to read step log",
+		})
+	}
+	res.Header().Set(echo.HeaderContentType, echo.MIMETextPlainCharsetUTF8)
+	res.Header().Set("X-Log-Offset", strconv.FormatInt(start, 10))
+	res.WriteHeader(http.StatusOK)
+
+	if !follow {
+		_, err := io.Copy(res, file)
+		return err
+	}
+
+	// Stream new output until the step has finished and all of it has been sent
+	ctx := c.Request().Context()
+	for {
+		finished := stepFinished(status)
+		if _, err := io.Copy(res, file); err != nil {
+			return nil
+		}
+		res.Flush()
+		if finished {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(logFollowInterval):
+		}
+		if status, err = runStepStatus(runID, stepID); err != nil {
+			slog.Error("Error getting run step", "run_id", runID, "step_id", stepID, "error", err)
+			return nil
+		}
+	}
+}
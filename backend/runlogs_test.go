@@ -0,0 +1,262 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// withRunLogs points the run step logs at a temporary directory for the duration of a test
+func withRunLogs(t *testing.T, maxAge time.Duration, maxRuns int) *runLogStore {
+	t.Helper()
+	saved := runLogs
+	runLogs = &runLogStore{dir: t.TempDir(), maxAge: maxAge, maxRuns: maxRuns}
+	t.Cleanup(func() { runLogs = saved })
+	return runLogs
+}
+
+func TestTailOffset(t *testing.T) {
+	long := strings.Repeat("x", 5000)
+	tests := []struct {
+		content string
+		lines   int
+		want    int64
+	}{
+		{"a\nb\nc\n", 0, 6},
+		{"a\nb\nc\n", 1, 4},
+		{"a\nb\nc\n", 2, 2},
+		{"a\nb\nc\n", 3, 0},
+		{"a\nb\nc\n", 10, 0},
+		{"a\nb\nc", 1, 4},
+		{"a\n\n\nb\n", 2, 3},
+		{"", 5, 0},
+		// Lines spanning the blocks the file is read in
+		{long + "\n" + long + "\nend\n", 2, 5001},
+		{"first\n" + long + long, 1, 6},
+	}
+
+	for _, tt := range tests {
+		path := filepath.Join(t.TempDir(), "step.log")
+		if err := os.WriteFile(path, []byte(tt.content), 0600); err != nil {
+			t.Fatal(err)
+		}
+		file, err := os.Open(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := tailOffset(file, int64(len(tt.content)), tt.lines)
+		file.Close()
+		if err != nil || got != tt.want {
+			t.Errorf("tailOffset(%.20q, %d) = %d, %v, want %d", tt.content, tt.lines, got, err, tt.want)
+		}
+	}
+}
+
+func TestStepLogPermissions(t *testing.T) {
+	store := withRunLogs(t, 0, 0)
+	stepLog, err := store.open(7, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stepLog.finish(CommandResult{})
+
+	for path, want := range map[string]os.FileMode{
+		filepath.Dir(store.path(7, 3)): 0700,
+		store.path(7, 3):               0600,
+	} {
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info.Mode().Perm() != want {
+			t.Errorf("%s has mode %v, want %v", path, info.Mode().Perm(), want)
+		}
+	}
+}
+
+func TestStepLogFinish(t *testing.T) {
+	store := withRunLogs(t, 0, 0)
+
+	// Without output, the reason the command failed ends up in the log
+	stepLog, err := store.open(1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stepLog.finish(CommandResult{Stderr: "policy violation: rm -rf /"})
+
+	// Otherwise the log holds just the output
+	stepLog, err = store.open(1, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stepLog.sink()(OutputChunk{Stream: StreamStdout, Data: "building\n"})
+	stepLog.retrying(CommandResult{Attempt: 1, ExitCode: 2}, time.Second)
+	stepLog.finish(CommandResult{Stderr: "building\n"})
+
+	for stepID, want := range map[int]string{
+		1: "policy violation: rm -rf /",
+		2: "building\n\n--- attempt 1 failed with exit code 2, retrying in 1s ---\n",
+	} {
+		data, err := os.ReadFile(store.path(1, stepID))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != want {
+			t.Errorf("log of step %d = %q, want %q", stepID, data, want)
+		}
+	}
+}
+
+func TestRunLogPrune(t *testing.T) {
+	store := withRunLogs(t, 48*time.Hour, 3)
+	for runID := 1; runID <= 5; runID++ {
+		stepLog, err := store.open(runID, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		stepLog.finish(CommandResult{Stderr: "output"})
+	}
+	old := time.Now().Add(-72 * time.Hour)
+	for _, path := range []string{store.path(4, 1), filepath.Dir(store.path(4, 1))} {
+		if err := os.Chtimes(path, old, old); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Runs still executing are kept whatever their age
+	_, done := executions.start(context.Background(), runKey(1))
+	defer done()
+
+	store.prune()
+
+	for runID, kept := range map[int]bool{5: true, 4: false, 3: true, 2: false, 1: true} {
+		_, err := os.Stat(filepath.Dir(store.path(runID, 1)))
+		if kept != (err == nil) {
+			t.Errorf("run %d: kept = %v, want %v", runID, err == nil, kept)
+		}
+	}
+}
+
+// getRunStepLog requests the log of a run step
+func getRunStepLog(t *testing.T, runID, stepID int, query string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/?"+query, nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("id", "stepId")
+	c.SetParamValues(strconv.Itoa(runID), strconv.Itoa(stepID))
+	if err := handleGetRunStepLog(c); err != nil {
+		t.Fatalf("handleGetRunStepLog(%q): %v", query, err)
+	}
+	return rec
+}
+
+func TestHandleGetRunStepLog(t *testing.T) {
+	withTestDatabase(t)
+	store := withRunLogs(t, 0, 0)
+	runID, steps := createTestRun(t, "logs", []Step{{Name: "build", Command: "make"}, {Name: "test", Command: "make test"}})
+	stepID := steps[0].ID
+
+	stepLog, err := store.open(runID, stepID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stepLog.write("one\ntwo\nthree\n")
+	stepLog.finish(CommandResult{})
+	if err := updateRunStepStatus(runID, 0, RunStatusSucceeded); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		query    string
+		status   int
+		body     string
+		offset   string
+		complete string
+	}{
+		{"", http.StatusOK, "one\ntwo\nthree\n", "", "true"},
+		{"tail=1", http.StatusOK, "three\n", "8", "true"},
+		{"tail=2", http.StatusOK, "two\nthree\n", "4", "true"},
+		{"tail=0", http.StatusOK, "", "14", "true"},
+		{"tail=100", http.StatusOK, "one\ntwo\nthree\n", "0", "true"},
+		{"offset=4", http.StatusOK, "two\nthree\n", "4", "true"},
+		{"offset=100", http.StatusOK, "", "100", "true"},
+		{"follow=true", http.StatusOK, "one\ntwo\nthree\n", "0", "true"},
+		{"tail=-1", http.StatusBadRequest, "", "", ""},
+		{"tail=x", http.StatusBadRequest, "", "", ""},
+		{"offset=-5", http.StatusBadRequest, "", "", ""},
+	}
+
+	for _, tt := range tests {
+		rec := getRunStepLog(t, runID, stepID, tt.query)
+		if rec.Code != tt.status {
+			t.Errorf("%q: status %d, want %d: %s", tt.query, rec.Code, tt.status, rec.Body.String())
+			continue
+		}
+		if tt.status != http.StatusOK {
+			continue
+		}
+		if rec.Body.String() != tt.body {
+			t.Errorf("%q: body %q, want %q", tt.query, rec.Body.String(), tt.body)
+		}
+		if got := rec.Header().Get("X-Log-Offset"); got != tt.offset {
+			t.Errorf("%q: X-Log-Offset %q, want %q", tt.query, got, tt.offset)
+		}
+		if got := rec.Header().Get("X-Log-Complete"); got != tt.complete {
+			t.Errorf("%q: X-Log-Complete %q, want %q", tt.query, got, tt.complete)
+		}
+	}
+
+	// A step that hasn't written a log yet, and one that isn't part of the run
+	if rec := getRunStepLog(t, runID, steps[1].ID, ""); rec.Code != http.StatusNotFound {
+		t.Errorf("step without a log: status %d, want %d", rec.Code, http.StatusNotFound)
+	}
+	if rec := getRunStepLog(t, runID, stepID+100, ""); rec.Code != http.StatusNotFound {
+		t.Errorf("unknown step: status %d, want %d", rec.Code, http.StatusNotFound)
+	}
+}
+
+func TestHandleGetRunStepLogFollow(t *testing.T) {
+	withTestDatabase(t)
+	store := withRunLogs(t, 0, 0)
+	runID, steps := createTestRun(t, "follow", []Step{{Name: "build", Command: "make"}})
+	stepID := steps[0].ID
+
+	stepLog, err := store.open(runID, stepID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stepLog.write("line 1\n")
+	if err := updateRunStepStatus(runID, 0, RunStatusRunning); err != nil {
+		t.Fatal(err)
+	}
+
+	// Output written while the request is being served is streamed, until the step finishes
+	go func() {
+		for i := 2; i <= 3; i++ {
+			time.Sleep(logFollowInterval / 2)
+			stepLog.write(fmt.Sprintf("line %d\n", i))
+		}
+		stepLog.finish(CommandResult{})
+		if err := updateRunStepStatus(runID, 0, RunStatusSucceeded); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	rec := getRunStepLog(t, runID, stepID, "follow=true&tail=1")
+	if want := "line 1\nline 2\nline 3\n"; rec.Body.String() != want {
+		t.Errorf("body %q, want %q", rec.Body.String(), want)
+	}
+	if got := rec.Header().Get("X-Log-Complete"); got != "false" {
+		t.Errorf("X-Log-Complete %q, want false while the step was running", got)
+	}
+}
@@ -0,0 +1,790 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Run and run step statuses
+const (
+	RunStatusPending   = "pending"
+	RunStatusQueued    = "queued"
+	RunStatusRunning   = "running"
+	RunStatusRetrying  = "retrying" // Waiting to retry after a failed attempt
+	RunStatusSucceeded = "succeeded"
+	RunStatusFailed    = "failed"
+	RunStatusTimedOut  = "timed_out"
+	RunStatusCancelled = "cancelled"
+	RunStatusSkipped   = "skipped"
+)
+
+// FlowRun represents a server-side execution of every step of a flow
+type FlowRun struct {
+	ID         int        `json:"id"`
+	FlowID     int        `json:"flow_id"`
+	FlowName   string     `json:"flow_name"`
+	Status     string     `json:"status"`
+	Error      string     `json:"error,omitempty"`
+	StartedAt  time.Time  `json:"started_at"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+	Steps      []RunStep  `json:"steps"`
+
+	// Environment is the environment whose variables the run uses, if any
+	Environment string `json:"environment,omitempty"`
+
+	// Overrides holds the variable values given when the run was started
+	Overrides map[string]string `json:"overrides,omitempty"`
+
+	// Captured holds the run-scoped variables captured from step output
+	Captured map[string]string `json:"captured,omitempty"`
+
+	// QueuePosition is the 1-based position of the run's next step in the
+	// execution queue, or zero when it is not waiting for a slot
+	QueuePosition int `json:"queue_position,omitempty"`
+}
+
+// RunStep represents the execution record of a single step within a run
+type RunStep struct {
+	ID         int             `json:"id"`
+	RunID      int             `json:"run_id"`
+	StepID     int             `json:"step_id"`
+	StepName   string          `json:"step_name"`
+	OrderIndex int             `json:"order_index"`
+	Status     string          `json:"status"`
+	SkipReason string          `json:"skip_reason,omitempty"`
+	Result     *CommandResult  `json:"result,omitempty"`
+	Attempts   []CommandResult `json:"attempts,omitempty"` // Every attempt of a step with retries, oldest first
+}
+
+// createRunTables creates the tables that hold run history
+func createRunTables() error {
+	queries := []string{
+		`CREATE TABLE IF NOT EXISTS flow_runs (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			flow_id INTEGER NOT NULL,
+			flow_name TEXT NOT NULL,
+			status TEXT NOT NULL,
+			error TEXT,
+			started_at DATETIME NOT NULL,
+			finished_at DATETIME,
+			overrides TEXT,
+			environment TEXT,
+			FOREIGN KEY (flow_id) REFERENCES flows (id) ON DELETE CASCADE
+		)`,
+		`CREATE TABLE IF NOT EXISTS run_steps (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			run_id INTEGER NOT NULL,
+			step_id INTEGER NOT NULL,
+			step_name TEXT NOT NULL,
+			order_index INTEGER NOT NULL,
+			status TEXT NOT NULL,
+			command TEXT,
+			exit_code INTEGER,
+			stdout TEXT,
+			stderr TEXT,
+			duration INTEGER,
+			success BOOLEAN,
+			executed_at DATETIME,
+			skip_reason TEXT,
+			FOREIGN KEY (run_id) REFERENCES flow_runs (id) ON DELETE CASCADE
+		)`,
+		`CREATE TABLE IF NOT EXISTS run_step_attempts (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			run_id INTEGER NOT NULL,
+			order_index INTEGER NOT NULL,
+			attempt INTEGER NOT NULL,
+			status TEXT NOT NULL,
+			command TEXT,
+			exit_code INTEGER,
+			stdout TEXT,
+			stderr TEXT,
+			duration INTEGER,
+			success BOOLEAN,
+			executed_at DATETIME,
+			FOREIGN KEY (run_id) REFERENCES flow_runs (id) ON DELETE CASCADE
+		)`,
+		`CREATE TABLE IF NOT EXISTS run_variables (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			run_id INTEGER NOT NULL,
+			order_index INTEGER NOT NULL,
+			key TEXT NOT NULL,
+			value TEXT NOT NULL,
+			FOREIGN KEY (run_id) REFERENCES flow_runs (id) ON DELETE CASCADE
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_flow_runs_flow_id ON flow_runs(flow_id)`,
+		`CREATE INDEX IF NOT EXISTS idx_flow_runs_started_at ON flow_runs(flow_id, started_at)`,
+		`CREATE INDEX IF NOT EXISTS idx_run_steps_run_id ON run_steps(run_id, order_index)`,
+		`CREATE INDEX IF NOT EXISTS idx_run_step_attempts_run_id ON run_step_attempts(run_id, order_index, attempt)`,
+		`CREATE INDEX IF NOT EXISTS idx_run_variables_run_id ON run_variables(run_id)`,
+	}
+
+	for _, query := range queries {
+		if _, err := db.Exec(query); err != nil {
+			return fmt.Errorf("failed to execute query %s: %v", query, err)
+		}
+	}
+
+	return nil
+}
+
+// createRun persists a new run together with a pending record for each step
+func createRun(flow *FlowDB, steps []Step, environment string, overrides map[string]string) (int, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(
+		"INSERT INTO flow_runs (flow_id, flow_name, status, started_at, overrides, environment) VALUES (?, ?, ?, ?, ?, ?)",
+		flow.ID, flow.Name, RunStatusPending, time.Now(), encodeOverrides(overrides), environment,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert run: %v", err)
+	}
+
+	runID, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get run ID: %v", err)
+	}
+
+	for i, step := range steps {
+		_, err = tx.Exec(
+			"INSERT INTO run_steps (run_id, step_id, step_name, order_index, status) VALUES (?, ?, ?, ?, ?)",
+			runID, step.ID, step.Name, i, RunStatusPending,
+		)
+		if err != nil {
+			return 0, fmt.Errorf("failed to insert run step %s: %v", step.Name, err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %v", err)
+	}
+
+	return int(runID), nil
+}
+
+// encodeOverrides serializes run variable overrides for the flow_runs.overrides
+// column, masking secret values
+func encodeOverrides(overrides map[string]string) string {
+	if len(overrides) == 0 {
+		return ""
+	}
+	masked := make(map[string]string, len(overrides))
+	for key, value := range overrides {
+		masked[key] = secrets.mask(value)
+	}
+	data, err := json.Marshal(masked)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// decodeOverrides parses the flow_runs.overrides column
+func decodeOverrides(value string) map[string]string {
+	if value == "" {
+		return nil
+	}
+	var overrides map[string]string
+	if err := json.Unmarshal([]byte(value), &overrides); err != nil {
+		slog.Warn("Ignoring invalid run overrides", "value", value, "error", err)
+		return nil
+	}
+	return overrides
+}
+
+// updateRunStatus sets the status of a run, stamping finished_at for final states
+func updateRunStatus(runID int, status string, runErr string) error {
+	var finishedAt interface{}
+	if status != RunStatusPending && status != RunStatusRunning {
+		finishedAt = time.Now()
+	}
+
+	_, err := db.Exec(
+		"UPDATE flow_runs SET status = ?, error = ?, finished_at = ? WHERE id = ?",
+		status, runErr, finishedAt, runID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update run: %v", err)
+	}
+	return nil
+}
+
+// updateRunStepStatus changes the status of a run step without touching its result
+func updateRunStepStatus(runID, orderIndex int, status string) error {
+	_, err := db.Exec(
+		"UPDATE run_steps SET status = ? WHERE run_id = ? AND order_index = ?",
+		status, runID, orderIndex,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update run step: %v", err)
+	}
+	return nil
+}
+
+// skipRunStep marks a run step as skipped and records why
+func skipRunStep(runID, orderIndex int, reason string) error {
+	_, err := db.Exec(
+		"UPDATE run_steps SET status = ?, skip_reason = ? WHERE run_id = ? AND order_index = ?",
+		RunStatusSkipped, reason, runID, orderIndex,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update run step: %v", err)
+	}
+	return nil
+}
+
+// saveRunStepResult stores the command result of a run step
+func saveRunStepResult(runID, orderIndex int, status string, result CommandResult) error {
+	_, err := db.Exec(
+		"UPDATE run_steps SET status = ?, command = ?, exit_code = ?, stdout = ?, stderr = ?, duration = ?, success = ?, executed_at = ? WHERE run_id = ? AND order_index = ?",
+		status, result.Command, result.ExitCode, result.Stdout, result.Stderr, int64(result.Duration), result.Success, result.ExecutedAt, runID, orderIndex,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save run step result: %v", err)
+	}
+	return nil
+}
+
+// saveRunStepAttempt records the result of one attempt of a run step
+func saveRunStepAttempt(runID, orderIndex int, result CommandResult) error {
+	_, err := db.Exec(
+		"INSERT INTO run_step_attempts (run_id, order_index, attempt, status, command, exit_code, stdout, stderr, duration, success, executed_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		runID, orderIndex, result.Attempt, result.Status, result.Command, result.ExitCode, result.Stdout, result.Stderr, int64(result.Duration), result.Success, result.ExecutedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save run step attempt: %v", err)
+	}
+	return nil
+}
+
+// saveRunVariables records the variables captured by a run step, with secret
+// values masked
+func saveRunVariables(runID, orderIndex int, values map[string]string) error {
+	for key, value := range values {
+		_, err := db.Exec(
+			"INSERT INTO run_variables (run_id, order_index, key, value) VALUES (?, ?, ?, ?)",
+			runID, orderIndex, key, secrets.mask(value),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to save run variable %s: %v", key, err)
+		}
+	}
+	return nil
+}
+
+// getRunVariables returns the variables captured during a run, with secret
+// values masked. If a variable was captured more than once, the latest value
+// wins.
+func getRunVariables(runID int) (map[string]string, error) {
+	rows, err := db.Query("SELECT key, value FROM run_variables WHERE run_id = ? ORDER BY id", runID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	variables := make(map[string]string)
+	for rows.Next() {
+		var key, value string
+		if err := rows.Scan(&key, &value); err != nil {
+			return nil, err
+		}
+		// Values saved before a secret was registered are masked too
+		variables[key] = secrets.mask(value)
+	}
+
+	return variables, rows.Err()
+}
+
+// getRunByID loads a run and all of its step records
+func getRunByID(runID int) (*FlowRun, error) {
+	var run FlowRun
+	var runErr, overrides, environment sql.NullString
+	var finishedAt sql.NullTime
+	err := db.QueryRow(
+		"SELECT id, flow_id, flow_name, status, error, started_at, finished_at, overrides, environment FROM flow_runs WHERE id = ?",
+		runID,
+	).Scan(&run.ID, &run.FlowID, &run.FlowName, &run.Status, &runErr, &run.StartedAt, &finishedAt, &overrides, &environment)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get run: %v", err)
+	}
+	run.Error = runErr.String
+	run.Overrides = decodeOverrides(overrides.String)
+	run.Environment = environment.String
+	if finishedAt.Valid {
+		run.FinishedAt = &finishedAt.Time
+	}
+
+	steps, err := getRunSteps(runID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get run steps: %v", err)
+	}
+	run.Steps = steps
+
+	captured, err := getRunVariables(runID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get run variables: %v", err)
+	}
+	if len(captured) > 0 {
+		run.Captured = captured
+	}
+
+	return &run, nil
+}
+
+func getRunSteps(runID int) ([]RunStep, error) {
+	rows, err := db.Query(
+		"SELECT id, run_id, step_id, step_name, order_index, status, command, exit_code, stdout, stderr, duration, success, executed_at, skip_reason FROM run_steps WHERE run_id = ? ORDER BY order_index, id",
+		runID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	steps := []RunStep{}
+	for rows.Next() {
+		var step RunStep
+		var command, stdout, stderr, skipReason sql.NullString
+		var exitCode, duration sql.NullInt64
+		var success sql.NullBool
+		var executedAt sql.NullTime
+		if err := rows.Scan(&step.ID, &step.RunID, &step.StepID, &step.StepName, &step.OrderIndex, &step.Status,
+			&command, &exitCode, &stdout, &stderr, &duration, &success, &executedAt, &skipReason); err != nil {
+			return nil, err
+		}
+		step.SkipReason = skipReason.String
+
+		// Steps that have not been executed yet have no result
+		if executedAt.Valid {
+			step.Result = &CommandResult{
+				Command:    command.String,
+				ExitCode:   int(exitCode.Int64),
+				Stdout:     stdout.String,
+				Stderr:     stderr.String,
+				Duration:   time.Duration(duration.Int64),
+				Success:    success.Bool,
+				Status:     step.Status,
+				ExecutedAt: executedAt.Time,
+			}
+		}
+		steps = append(steps, step)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	attempts, err := getRunStepAttempts(runID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range steps {
+		step := &steps[i]
+		step.Attempts = attempts[step.OrderIndex]
+		// The final result is the last attempt
+		if step.Result != nil && len(step.Attempts) > 0 {
+			step.Result.Attempt = step.Attempts[len(step.Attempts)-1].Attempt
+		}
+	}
+
+	return steps, nil
+}
+
+// getRunStepAttempts loads the attempts of every step of a run, keyed by
+// order index. Only steps with retries record attempts.
+func getRunStepAttempts(runID int) (map[int][]CommandResult, error) {
+	rows, err := db.Query(
+		"SELECT order_index, attempt, status, command, exit_code, stdout, stderr, duration, success, executed_at FROM run_step_attempts WHERE run_id = ? ORDER BY order_index, attempt",
+		runID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	attempts := make(map[int][]CommandResult)
+	for rows.Next() {
+		var orderIndex int
+		var result CommandResult
+		var duration int64
+		if err := rows.Scan(&orderIndex, &result.Attempt, &result.Status, &result.Command, &result.ExitCode,
+			&result.Stdout, &result.Stderr, &duration, &result.Success, &result.ExecutedAt); err != nil {
+			return nil, err
+		}
+		result.Duration = time.Duration(duration)
+		attempts[orderIndex] = append(attempts[orderIndex], result)
+	}
+
+	return attempts, rows.Err()
+}
+
+// startFlowRun records a new run for the flow and executes it in the background.
+// The run uses the variables of the given environment, if any, and overrides
+// replace variable values for this run only. The executions of its steps are
+// audited on behalf of caller.
+func startFlowRun(flowID int, environment string, overrides map[string]string, caller AuditEvent) (*FlowRun, error) {
+	flow, err := getFlowByID(flowID)
+	if err != nil {
+		return nil, err
+	}
+
+	steps, err := getFlowSteps(flowID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get flow steps: %v", err)
+	}
+
+	variables, err := getLaunchVariables(flowID, environment, overrides)
+	if err != nil {
+		return nil, err
+	}
+
+	// Steps load the flow's env files before their own
+	flowEnvFiles, err := getFlowEnvFiles(flowID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range steps {
+		steps[i].EnvFiles = stepEnvFiles(flowEnvFiles, steps[i].EnvFiles)
+	}
+
+	runID, err := createRun(flow, steps, environment, overrides)
+	if err != nil {
+		return nil, err
+	}
+
+	caller.Target, caller.FlowID, caller.RunID = runKey(runID), flowID, runID
+
+	// Register before returning so the run can be cancelled right away
+	ctx, done := executions.start(context.Background(), runKey(runID))
+	go func() {
+		defer done()
+		executeFlowRun(ctx, runID, steps, variables, caller)
+		runLogs.prune()
+	}()
+
+	return getRunByID(runID)
+}
+
+// flowRunner executes the steps of a single run. Steps start as soon as all
+// of their dependencies have succeeded, so independent steps run concurrently
+// (subject to the execution scheduler).
+type flowRunner struct {
+	runID  int
+	steps  []Step
+	caller AuditEvent // Who started the run
+
+	mu        sync.Mutex
+	variables map[string]string // Flow variables plus variables captured so far
+	status    string
+	err       string
+}
+
+// executeFlowRun executes the steps of a run following their dependencies.
+// Steps whose dependencies did not succeed, steps whose condition is false and
+// steps that have not started when ctx is cancelled are skipped.
+func executeFlowRun(ctx context.Context, runID int, steps []Step, variables map[string]string, caller AuditEvent) {
+	log.Printf("Run %d: starting %d steps", runID, len(steps))
+
+	graph, err := stepDependencies(steps)
+	if err != nil {
+		// Flows are validated when their steps are saved or deleted, so this only
+		// happens if the database was edited directly
+		for i := range steps {
+			if err := skipRunStep(runID, i, "invalid flow"); err != nil {
+				slog.Error("Error skipping run step", "run_id", runID, "error", err)
+			}
+		}
+		if err := updateRunStatus(runID, RunStatusFailed, err.Error()); err != nil {
+			slog.Error("Error updating run status", "run_id", runID, "error", err)
+		}
+		return
+	}
+
+	if err := updateRunStatus(runID, RunStatusRunning, ""); err != nil {
+		slog.Error("Error updating run status", "run_id", runID, "error", err)
+	}
+
+	runner := &flowRunner{
+		runID:     runID,
+		steps:     steps,
+		caller:    caller,
+		variables: variables,
+		status:    RunStatusSucceeded,
+	}
+
+	statuses := make([]string, len(steps))
+	done := make([]chan struct{}, len(steps))
+	for i := range done {
+		done[i] = make(chan struct{})
+	}
+
+	var wg sync.WaitGroup
+	for i := range steps {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer close(done[i])
+
+			for _, dep := range graph.after[i] {
+				<-done[dep]
+			}
+			statuses[i] = runner.executeStep(ctx, i, graph, statuses)
+		}(i)
+	}
+	wg.Wait()
+
+	if err := updateRunStatus(runID, runner.status, runner.err); err != nil {
+		slog.Error("Error updating run status", "run_id", runID, "error", err)
+	}
+	log.Printf("Run %d: finished with status %s", runID, runner.status)
+}
+
+// fail records the first failure of the run, which determines its final status
+func (r *flowRunner) fail(status, msg string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.status == RunStatusSucceeded {
+		r.status = status
+		r.err = msg
+	}
+}
+
+// snapshot returns a copy of the run's current variables
+func (r *flowRunner) snapshot() map[string]string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	variables := make(map[string]string, len(r.variables))
+	for key, value := range r.variables {
+		variables[key] = value
+	}
+	return variables
+}
+
+// capture stores variables captured from a step's output for later steps
+func (r *flowRunner) capture(values map[string]string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for key, value := range values {
+		r.variables[key] = value
+	}
+}
+
+// stepSucceeded reports whether dependents of a step with this status may run
+func stepSucceeded(status string) bool {
+	return status == RunStatusSucceeded || status == CommandStatusStarted
+}
+
+// stepAudit returns the audit event for executing one of the run's steps
+func (r *flowRunner) stepAudit(step Step) AuditEvent {
+	event := r.caller
+	event.StepID = step.ID
+	return event
+}
+
+// skip marks a step as skipped and returns the skipped status
+func (r *flowRunner) skip(i int, reason string) string {
+	log.Printf("Run %d: skipping step %q: %s", r.runID, r.steps[i].Name, reason)
+	if err := skipRunStep(r.runID, i, reason); err != nil {
+		slog.Error("Error skipping run step", "run_id", r.runID, "error", err)
+	}
+	return RunStatusSkipped
+}
+
+// executeStep runs a single step once the steps it waits for have finished and returns its final status
+func (r *flowRunner) executeStep(ctx context.Context, i int, graph *stepGraph, statuses []string) string {
+	runID := r.runID
+	step := r.steps[i]
+
+	for _, dep := range graph.requires[i] {
+		if !stepSucceeded(statuses[dep]) {
+			return r.skip(i, fmt.Sprintf("dependency %q did not succeed", r.steps[dep].Name))
+		}
+	}
+
+	if ctx.Err() != nil {
+		r.fail(RunStatusCancelled, "run cancelled")
+		return r.skip(i, "run cancelled")
+	}
+
+	// Variables captured by steps that finished before this one are visible to it
+	variables := r.snapshot()
+
+	if when := graph.when[i]; when != nil {
+		// Only steps this one waited for are guaranteed to have a final status
+		finished := make(map[string]string, len(graph.after[i]))
+		for _, dep := range graph.after[i] {
+			finished[r.steps[dep].Name] = statuses[dep]
+		}
+		if !when.evaluate(variables, finished) {
+			return r.skip(i, fmt.Sprintf("condition %q is false", step.When))
+		}
+	}
+
+	if err := updateRunStepStatus(runID, i, RunStatusQueued); err != nil {
+		slog.Error("Error updating run step status", "run_id", runID, "error", err)
+	}
+
+	policy := newRetryPolicy(step.Retries, step.RetryDelay, step.Backoff)
+	var onAttempt func(CommandResult)
+	if policy.retries > 0 {
+		onAttempt = func(attempt CommandResult) {
+			if err := saveRunStepAttempt(runID, i, attempt); err != nil {
+				slog.Error("Error saving run step attempt", "run_id", runID, "error", err)
+			}
+		}
+	}
+
+	// Output goes to the step's log as it is produced
+	var output OutputSink
+	stepLog, err := runLogs.open(runID, step.ID)
+	if err != nil {
+		slog.Error("Error opening step log", "run_id", runID, "error", err)
+	} else {
+		output = stepLog.sink()
+	}
+
+	result := executeWithRetries(ctx, step.Command, variables, ExecutionOptions{
+		TmuxSessionName: step.TmuxSessionName,
+		IsTmuxTerminal:  step.IsTmuxTerminal,
+		Timeout:         commandTimeout(step.Timeout),
+		TmuxWait:        parseTmuxWait(step.TmuxWait),
+		Output:          output,
+		QueueKey:        runKey(runID),
+		WorkingDir:      step.WorkingDir,
+		EnvFiles:        step.EnvFiles,
+		Env:             step.Env,
+		Audit:           r.stepAudit(step),
+		OnStart: func() {
+			if err := updateRunStepStatus(runID, i, RunStatusRunning); err != nil {
+				slog.Error("Error updating run step status", "run_id", runID, "error", err)
+			}
+		},
+	}, policy, onAttempt, func(failed CommandResult, delay time.Duration) {
+		slog.Warn("Run step failed, retrying", "run_id", runID, "step", step.Name, "attempt", failed.Attempt, "delay", delay)
+		if stepLog != nil {
+			stepLog.retrying(failed, delay)
+		}
+		if err := updateRunStepStatus(runID, i, RunStatusRetrying); err != nil {
+			slog.Error("Error updating run step status", "run_id", runID, "error", err)
+		}
+	})
+	if stepLog != nil {
+		stepLog.finish(result)
+	}
+
+	if len(step.Captures) > 0 && stepSucceeded(result.Status) {
+		// Later steps get the real values, captured before secrets were masked
+		values, err := applyCaptures(step.Captures, result.rawStdout)
+		if err != nil {
+			// A step whose output can't be captured fails, as later steps rely on it
+			result.Success = false
+			result.Status = CommandStatusFailed
+			result.Stderr += "\n" + err.Error()
+			r.fail(result.Status, fmt.Sprintf("step %q: %v", step.Name, err))
+		} else {
+			r.capture(values)
+			if err := saveRunVariables(runID, i, values); err != nil {
+				slog.Error("Error saving captured variables", "run_id", runID, "error", err)
+			}
+		}
+	}
+
+	// Command result statuses double as run step statuses
+	if !result.Success {
+		switch result.Status {
+		case CommandStatusTimedOut:
+			r.fail(result.Status, fmt.Sprintf("step %q timed out", step.Name))
+		case CommandStatusCancelled:
+			r.fail(result.Status, fmt.Sprintf("run cancelled during step %q", step.Name))
+		default:
+			msg := fmt.Sprintf("step %q failed with exit code %d", step.Name, result.ExitCode)
+			if result.Attempt > 1 {
+				msg = fmt.Sprintf("%s after %d attempts", msg, result.Attempt)
+			}
+			r.fail(result.Status, msg)
+		}
+	}
+
+	if err := saveRunStepResult(runID, i, result.Status, result); err != nil {
+		slog.Error("Error saving run step result", "run_id", runID, "error", err)
+	}
+	return result.Status
+}
+
+// StartRunRequest is the optional body of a run request
+type StartRunRequest struct {
+	Environment string            `json:"environment,omitempty"` // Environment whose variables to use
+	Variables   map[string]string `json:"variables,omitempty"`   // Overrides for this run only
+}
+
+// handleStartFlowRun starts a server-side run of every step of a flow
+func handleStartFlowRun(c echo.Context) error {
+	flowID := c.Param("id")
+	id := 0
+	if _, err := fmt.Sscanf(flowID, "%d", &id); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid flow ID",
+		})
+	}
+
+	if _, err := getFlowByID(id); err != nil {
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "Flow not found",
+		})
+	}
+
+	var req StartRunRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request payload",
+		})
+	}
+
+	run, err := startFlowRun(id, req.Environment, req.Variables, requestAudit(c, AuditExecution, ""))
+	if err != nil {
+		if launchErr, ok := err.(*LaunchError); ok {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": launchErr.Error(),
+			})
+		}
+		slog.Error("Error starting run", "flow_id", id, "error", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to start flow run",
+		})
+	}
+
+	return c.JSON(http.StatusAccepted, run)
+}
+
+// handleGetRun returns a run together with its per-step results
+func handleGetRun(c echo.Context) error {
+	runID := c.Param("id")
+	id := 0
+	if _, err := fmt.Sscanf(runID, "%d", &id); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid run ID",
+		})
+	}
+
+	run, err := getRunByID(id)
+	if err != nil {
+		slog.Error("Error getting run", "run_id", id, "error", err)
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "Run not found",
+		})
+	}
+	run.QueuePosition = scheduler.position(runKey(id))
+
+	return c.JSON(http.StatusOK, run)
+}
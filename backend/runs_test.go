@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExecuteFlowRunCapturesSecrets(t *testing.T) {
+	withTestDatabase(t)
+	defaultPolicy(t)
+	withRunLogs(t, time.Hour, 10)
+	withSecrets(t, "hunter22")
+	config.System.Workspace.DefaultDir = t.TempDir()
+
+	runID, steps := createTestRun(t, "login", []Step{
+		{Name: "login", Command: "echo token=hunter22-abc", Captures: []OutputCapture{{Variable: "TOKEN", Regex: `token=(\S+)`}}},
+		{Name: "use", Command: `[ "$TOKEN" = hunter22-abc ] && echo token ok`, DependsOn: []string{"login"}},
+	})
+	executeFlowRun(context.Background(), runID, steps, map[string]string{}, AuditEvent{})
+
+	run, err := getRunByID(runID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The next step gets the real value
+	if run.Status != RunStatusSucceeded {
+		t.Fatalf("run %s: %s", run.Status, run.Error)
+	}
+	if got := run.Steps[1].Result.Stdout; got != "token ok\n" {
+		t.Errorf("second step printed %q, want the captured value to match", got)
+	}
+	// but it is masked wherever it is shown
+	if got := run.Steps[0].Result.Stdout; strings.Contains(got, "hunter22") {
+		t.Errorf("step output isn't masked: %q", got)
+	}
+	if got := run.Captured["TOKEN"]; got != "********-abc" {
+		t.Errorf("captured TOKEN = %q, want it masked", got)
+	}
+	var stored string
+	if err := db.QueryRow("SELECT value FROM run_variables WHERE run_id = ?", runID).Scan(&stored); err != nil || strings.Contains(stored, "hunter22") {
+		t.Errorf("stored value %q, %v: want it masked", stored, err)
+	}
+}
@@ -0,0 +1,203 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Execution slot states
+const (
+	SlotStateQueued  = "queued"
+	SlotStateRunning = "running"
+)
+
+// ExecutionSlot is an entry in the execution scheduler, either waiting for or
+// holding one of the system.shell.max_concurrent execution slots
+type ExecutionSlot struct {
+	ID        int        `json:"id"`
+	Key       string     `json:"key"`
+	Label     string     `json:"label"`
+	State     string     `json:"state"`
+	Position  int        `json:"position,omitempty"` // 1-based queue position while queued
+	QueuedAt  time.Time  `json:"queued_at"`
+	StartedAt *time.Time `json:"started_at,omitempty"`
+
+	ready chan struct{}
+}
+
+// executionScheduler caps how many commands and PTYs run at once and queues
+// the rest in FIFO order
+type executionScheduler struct {
+	mu      sync.Mutex
+	limit   int // Zero or less means unlimited
+	nextID  int
+	running map[int]*ExecutionSlot
+	queue   []*ExecutionSlot
+}
+
+// Global execution scheduler, sized from system.shell.max_concurrent at startup
+var scheduler = newExecutionScheduler(0)
+
+func newExecutionScheduler(limit int) *executionScheduler {
+	return &executionScheduler{
+		limit:   limit,
+		running: make(map[int]*ExecutionSlot),
+	}
+}
+
+// acquire blocks until an execution slot is free or ctx is done. Key groups
+// slots for lookups (see runKey and stepKey) and label describes the work.
+// The returned slot must be passed to release once the execution finishes.
+func (s *executionScheduler) acquire(ctx context.Context, key, label string) (*ExecutionSlot, error) {
+	s.mu.Lock()
+	s.nextID++
+	slot := &ExecutionSlot{
+		ID:       s.nextID,
+		Key:      key,
+		Label:    label,
+		State:    SlotStateQueued,
+		QueuedAt: time.Now(),
+		ready:    make(chan struct{}),
+	}
+	s.queue = append(s.queue, slot)
+	s.dispatch()
+	s.mu.Unlock()
+
+	select {
+	case <-slot.ready:
+		return slot, nil
+	case <-ctx.Done():
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		// The slot may have been granted while we were giving up
+		if slot.State == SlotStateRunning {
+			delete(s.running, slot.ID)
+			s.dispatch()
+		} else {
+			s.remove(slot)
+		}
+		return nil, ctx.Err()
+	}
+}
+
+// release frees a slot and starts the next queued execution, if any
+func (s *executionScheduler) release(slot *ExecutionSlot) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.running, slot.ID)
+	s.dispatch()
+}
+
+// dispatch moves queued slots to running while capacity allows. Callers must hold s.mu.
+func (s *executionScheduler) dispatch() {
+	for len(s.queue) > 0 && (s.limit <= 0 || len(s.running) < s.limit) {
+		slot := s.queue[0]
+		s.queue = s.queue[1:]
+
+		now := time.Now()
+		slot.State = SlotStateRunning
+		slot.StartedAt = &now
+		s.running[slot.ID] = slot
+		close(slot.ready)
+	}
+}
+
+// remove drops a slot from the queue. Callers must hold s.mu.
+func (s *executionScheduler) remove(slot *ExecutionSlot) {
+	for i, queued := range s.queue {
+		if queued == slot {
+			s.queue = append(s.queue[:i], s.queue[i+1:]...)
+			return
+		}
+	}
+}
+
+// position returns the 1-based queue position of the first queued slot with
+// the given key, or zero if none is queued
+func (s *executionScheduler) position(key string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, slot := range s.queue {
+		if slot.Key == key {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// SchedulerStatus is a snapshot of the execution scheduler
+type SchedulerStatus struct {
+	MaxConcurrent int             `json:"max_concurrent"`
+	Running       []ExecutionSlot `json:"running"`
+	Queued        []ExecutionSlot `json:"queued"`
+}
+
+func (s *executionScheduler) status() SchedulerStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	status := SchedulerStatus{
+		MaxConcurrent: s.limit,
+		Running:       []ExecutionSlot{},
+		Queued:        []ExecutionSlot{},
+	}
+	for _, slot := range s.running {
+		status.Running = append(status.Running, *slot)
+	}
+	sort.Slice(status.Running, func(i, j int) bool {
+		return status.Running[i].ID < status.Running[j].ID
+	})
+	for i, slot := range s.queue {
+		queued := *slot
+		queued.Position = i + 1
+		status.Queued = append(status.Queued, queued)
+	}
+	return status
+}
+
+// handleGetExecutions returns the running and queued executions the request's
+// token may see: those of flows it can view, and free shells and commands
+// only if it may run them itself
+func handleGetExecutions(c echo.Context) error {
+	status := scheduler.status()
+	status.Running = visibleSlots(c, status.Running)
+	status.Queued = visibleSlots(c, status.Queued)
+	return c.JSON(http.StatusOK, status)
+}
+
+// visibleSlots filters execution slots down to those the request's token can see
+func visibleSlots(c echo.Context, slots []ExecutionSlot) []ExecutionSlot {
+	visible := []ExecutionSlot{}
+	for _, slot := range slots {
+		if slotVisible(c, slot.Key) {
+			visible = append(visible, slot)
+		}
+	}
+	return visible
+}
+
+// slotVisible reports whether the request's token can see slots with the given key
+func slotVisible(c echo.Context, key string) bool {
+	var id int
+	var flowOf func(int) (int, error)
+	if _, err := fmt.Sscanf(key, "run:%d", &id); err == nil {
+		flowOf = flowOfRun
+	} else if _, err := fmt.Sscanf(key, "step:%d", &id); err == nil {
+		flowOf = flowOfStep
+	} else {
+		return authorize(c, PermissionRunCommands, 0) == nil
+	}
+
+	flowID, err := flowOf(id)
+	if err != nil {
+		// The run or step was deleted while executing
+		return authorize(c, PermissionAdmin, 0) == nil
+	}
+	return authorize(c, PermissionViewFlows, flowID) == nil
+}
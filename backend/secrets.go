@@ -0,0 +1,357 @@
+package main
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Secret variables are stored encrypted with AES-256-GCM. The key comes from
+// the environment variable named by security.secrets.key_env or, if that is
+// unset, from security.secrets.key_file, which is created with a random key
+// on first start. Every decrypted secret value is masked in logs, command
+// results and streamed output, including values split across chunks.
+
+const (
+	secretPrefix = "enc:v1:"
+	secretMask   = "********"
+
+	// Shorter secret values are not masked, as they would match too much output
+	minMaskedSecretLength = 3
+
+	defaultSecretKeyEnv = "DEVTOOL_SECRET_KEY"
+)
+
+// secretStore encrypts secret variables and remembers the plaintext values it
+// has seen so they can be masked
+type secretStore struct {
+	mu     sync.RWMutex
+	aead   cipher.AEAD
+	values map[string]bool
+}
+
+// Global secret store, initialized from the configuration at startup
+var secrets = &secretStore{values: make(map[string]bool)}
+
+// initSecrets loads or creates the encryption key for secret variables
+func initSecrets(cfg SecretsConfig, baseDir string) error {
+	key, err := loadSecretKey(cfg, baseDir)
+	if err != nil {
+		return err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return fmt.Errorf("failed to create cipher: %v", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return fmt.Errorf("failed to create cipher: %v", err)
+	}
+
+	secrets.mu.Lock()
+	secrets.aead = aead
+	secrets.mu.Unlock()
+	return nil
+}
+
+// loadSecretKey returns the 32-byte key for secret variables. Keys that are
+// not base64 encoded 32-byte values are hashed into one.
+func loadSecretKey(cfg SecretsConfig, baseDir string) ([]byte, error) {
+	keyEnv := cfg.KeyEnv
+	if keyEnv == "" {
+		keyEnv = defaultSecretKeyEnv
+	}
+	material := os.Getenv(keyEnv)
+
+	if material == "" {
+		keyFile := cfg.KeyFile
+		if keyFile == "" {
+			keyFile = filepath.Join(baseDir, "secret.key")
+		}
+
+		data, err := os.ReadFile(keyFile)
+		if os.IsNotExist(err) {
+			key := make([]byte, 32)
+			if _, err := io.ReadFull(rand.Reader, key); err != nil {
+				return nil, fmt.Errorf("failed to generate secret key: %v", err)
+			}
+			if err := os.MkdirAll(filepath.Dir(keyFile), 0700); err != nil {
+				return nil, fmt.Errorf("failed to create secret key directory: %v", err)
+			}
+			encoded := base64.StdEncoding.EncodeToString(key)
+			if err := os.WriteFile(keyFile, []byte(encoded+"\n"), 0600); err != nil {
+				return nil, fmt.Errorf("failed to write secret key file: %v", err)
+			}
+			log.Printf("Generated secret key file %s", keyFile)
+			return key, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read secret key file: %v", err)
+		}
+		material = strings.TrimSpace(string(data))
+		if material == "" {
+			return nil, fmt.Errorf("secret key file %s is empty", keyFile)
+		}
+	}
+
+	if key, err := base64.StdEncoding.DecodeString(material); err == nil && len(key) == 32 {
+		return key, nil
+	}
+	sum := sha256.Sum256([]byte(material))
+	return sum[:], nil
+}
+
+// encrypt returns the stored form of a secret value
+func (s *secretStore) encrypt(plaintext string) (string, error) {
+	s.mu.RLock()
+	aead := s.aead
+	s.mu.RUnlock()
+	if aead == nil {
+		return "", fmt.Errorf("secret encryption is not initialized")
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %v", err)
+	}
+	sealed := aead.Seal(nonce, nonce, []byte(plaintext), nil)
+	s.register(plaintext)
+	return secretPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// decrypt returns the plaintext of a stored secret value and registers it for masking
+func (s *secretStore) decrypt(stored string) (string, error) {
+	s.mu.RLock()
+	aead := s.aead
+	s.mu.RUnlock()
+	if aead == nil {
+		return "", fmt.Errorf("secret encryption is not initialized")
+	}
+
+	if !strings.HasPrefix(stored, secretPrefix) {
+		return "", fmt.Errorf("value is not encrypted")
+	}
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(stored, secretPrefix))
+	if err != nil || len(sealed) < aead.NonceSize() {
+		return "", fmt.Errorf("malformed encrypted value")
+	}
+	plaintext, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt value (wrong key?)")
+	}
+
+	s.register(string(plaintext))
+	return string(plaintext), nil
+}
+
+// register adds a secret value to the set of masked values
+func (s *secretStore) register(value string) {
+	if len(value) < minMaskedSecretLength {
+		return
+	}
+	s.mu.Lock()
+	s.values[value] = true
+	s.mu.Unlock()
+}
+
+// mask replaces every known secret value in text
+func (s *secretStore) mask(text string) string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if len(s.values) == 0 || text == "" {
+		return text
+	}
+
+	// Replace longer values first so a secret containing another is fully masked
+	values := make([]string, 0, len(s.values))
+	for value := range s.values {
+		if strings.Contains(text, value) {
+			values = append(values, value)
+		}
+	}
+	sort.Slice(values, func(i, j int) bool { return len(values[i]) > len(values[j]) })
+	for _, value := range values {
+		text = strings.ReplaceAll(text, value, secretMask)
+	}
+	return text
+}
+
+// partialSuffix returns the length of the longest end of text that is the
+// start of a secret value, and so may be completed by text that follows
+func (s *secretStore) partialSuffix(text string) int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	longest := 0
+	for value := range s.values {
+		for n := min(len(value)-1, len(text)); n > longest; n-- {
+			if strings.HasSuffix(text, value[:n]) {
+				longest = n
+				break
+			}
+		}
+	}
+	return longest
+}
+
+// terminalMaskHold is how long terminal output that may be the start of a
+// secret is held back waiting for the rest
+const terminalMaskHold = 100 * time.Millisecond
+
+// outputMasker masks secret values in output that arrives in chunks. The end
+// of a chunk that may be the start of a secret is held back until the next
+// chunk shows whether it is, so secrets split across chunks are masked too.
+type outputMasker struct {
+	mu      sync.Mutex
+	pending string
+}
+
+// write returns the masked output that can be passed on after chunk
+func (m *outputMasker) write(chunk string) string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	text := secrets.mask(m.pending + chunk)
+	held := secrets.partialSuffix(text)
+	m.pending = text[len(text)-held:]
+	return text[:len(text)-held]
+}
+
+// flush returns the output held back, once no more will follow
+func (m *outputMasker) flush() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	text := m.pending
+	m.pending = ""
+	return text
+}
+
+// maskedOutput wraps an OutputSink to mask secret values in each stream.
+// flush passes on what is held back and must be called when the command ends.
+func maskedOutput(output OutputSink) (sink OutputSink, flush func()) {
+	maskers := map[string]*outputMasker{StreamStdout: {}, StreamStderr: {}}
+	sink = func(chunk OutputChunk) {
+		if chunk.Data = maskers[chunk.Stream].write(chunk.Data); chunk.Data != "" {
+			output(chunk)
+		}
+	}
+	flush = func() {
+		for _, stream := range []string{StreamStdout, StreamStderr} {
+			if data := maskers[stream].flush(); data != "" {
+				output(OutputChunk{Stream: stream, Data: data})
+			}
+		}
+	}
+	return sink, flush
+}
+
+// maskResult masks secret values in the output of a command result, keeping
+// the unmasked stdout for output captures
+func maskResult(result CommandResult) CommandResult {
+	if result.rawStdout == "" {
+		result.rawStdout = result.Stdout
+	}
+	result.Stdout = secrets.mask(result.Stdout)
+	result.Stderr = secrets.mask(result.Stderr)
+	return result
+}
+
+// maskingWriter masks secret values in everything written through it
+type maskingWriter struct {
+	w io.Writer
+}
+
+func (m *maskingWriter) Write(p []byte) (int, error) {
+	if _, err := m.w.Write([]byte(secrets.mask(string(p)))); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// storeVariables inserts the variables of a flow, encrypting the secret ones.
+// A secret whose value is the mask shown by the API keeps its previous value.
+func storeVariables(tx *sql.Tx, flowID int64, variables map[string]string, secretKeys []string, previous map[string]string) error {
+	secret := make(map[string]bool, len(secretKeys))
+	for _, key := range secretKeys {
+		secret[key] = true
+	}
+
+	for key, value := range variables {
+		if secret[key] {
+			sealed, err := sealSecret(key, value, previous)
+			if err != nil {
+				return err
+			}
+			value = sealed
+		}
+
+		_, err := tx.Exec(
+			"INSERT INTO variables (flow_id, key, value, secret) VALUES (?, ?, ?, ?)",
+			flowID, key, value, secret[key],
+		)
+		if err != nil {
+			return fmt.Errorf("failed to insert variable %s: %v", key, err)
+		}
+	}
+	return nil
+}
+
+// sealSecret returns the stored form of a secret variable, keeping its
+// previous value if the given value is the mask
+func sealSecret(key, value string, previous map[string]string) (string, error) {
+	if value == secretMask {
+		if old, ok := previous[key]; ok {
+			value = old
+		}
+	}
+	encrypted, err := secrets.encrypt(value)
+	if err != nil {
+		return "", fmt.Errorf("failed to encrypt variable %s: %v", key, err)
+	}
+	return encrypted, nil
+}
+
+// getFlowSecrets returns the names of the secret variables of a flow
+func getFlowSecrets(flowID int) ([]string, error) {
+	rows, err := db.Query("SELECT key FROM variables WHERE flow_id = ? AND secret ORDER BY key", flowID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var keys []string
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	return keys, rows.Err()
+}
+
+// maskSecretVariables returns a copy of variables with the secret values replaced by the mask
+func maskSecretVariables(variables map[string]string, secretKeys []string) map[string]string {
+	masked := make(map[string]string, len(variables))
+	for key, value := range variables {
+		masked[key] = value
+	}
+	for _, key := range secretKeys {
+		if _, ok := masked[key]; ok {
+			masked[key] = secretMask
+		}
+	}
+	return masked
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+// withSecrets registers secret values for the duration of a test
+func withSecrets(t *testing.T, values ...string) {
+	t.Helper()
+	saved := secrets.values
+	secrets.values = make(map[string]bool)
+	t.Cleanup(func() { secrets.values = saved })
+	for _, value := range values {
+		secrets.register(value)
+	}
+}
+
+func TestOutputMaskerSplitsSecrets(t *testing.T) {
+	withSecrets(t, "hunter22", "s3cr3t-token")
+
+	tests := []struct {
+		name   string
+		chunks []string
+		want   string
+	}{
+		{"whole", []string{"pass=hunter22\n"}, "pass=" + secretMask + "\n"},
+		{"split in two", []string{"pass=hun", "ter22\n"}, "pass=" + secretMask + "\n"},
+		{"split byte by byte", strings.Split("token s3cr3t-token end", ""), "token " + secretMask + " end"},
+		{"split at the end", []string{"pass=hunter2", "2"}, "pass=" + secretMask},
+		{"prefix only", []string{"hunt", "ing season"}, "hunting season"},
+		{"prefix at the end", []string{"echo hunt"}, "echo hunt"},
+		{"two secrets", []string{"hunter22s3cr", "3t-token"}, secretMask + secretMask},
+		{"no secrets", []string{"plain ", "output"}, "plain output"},
+	}
+
+	for _, tt := range tests {
+		masker := &outputMasker{}
+		var out strings.Builder
+		for _, chunk := range tt.chunks {
+			emitted := masker.write(chunk)
+			if strings.Contains(emitted, "hunter22") || strings.Contains(emitted, "s3cr3t-token") {
+				t.Errorf("%s: chunk %q emitted a secret: %q", tt.name, chunk, emitted)
+			}
+			out.WriteString(emitted)
+		}
+		out.WriteString(masker.flush())
+		if out.String() != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, out.String(), tt.want)
+		}
+	}
+}
+
+func TestOutputMaskerHoldsOnlyPossibleSecrets(t *testing.T) {
+	withSecrets(t, "hunter22")
+
+	masker := &outputMasker{}
+	if got := masker.write("line one\n"); got != "line one\n" {
+		t.Errorf("output without a secret prefix was held back: got %q", got)
+	}
+	if got := masker.write("user hun"); got != "user " {
+		t.Errorf("got %q, want the possible start of the secret held back", got)
+	}
+	if got := masker.flush(); got != "hun" {
+		t.Errorf("flush returned %q, want %q", got, "hun")
+	}
+}
+
+func TestMaskedOutputStreams(t *testing.T) {
+	withSecrets(t, "hunter22")
+
+	var got []OutputChunk
+	sink, flush := maskedOutput(func(chunk OutputChunk) { got = append(got, chunk) })
+	sink(OutputChunk{Stream: StreamStdout, Data: "out hun"})
+	sink(OutputChunk{Stream: StreamStderr, Data: "err hunter"})
+	sink(OutputChunk{Stream: StreamStdout, Data: "ter22\n"})
+	flush()
+
+	var stdout, stderr strings.Builder
+	for _, chunk := range got {
+		if chunk.Stream == StreamStdout {
+			stdout.WriteString(chunk.Data)
+		} else {
+			stderr.WriteString(chunk.Data)
+		}
+	}
+	if stdout.String() != "out "+secretMask+"\n" {
+		t.Errorf("stdout = %q", stdout.String())
+	}
+	if stderr.String() != "err hunter" {
+		t.Errorf("stderr = %q", stderr.String())
+	}
+}
@@ -0,0 +1,239 @@
+package main
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"mvdan.cc/sh/v3/syntax"
+)
+
+// Shell parsing for the command policy
+//
+// parseShellCommands parses a bash command line with mvdan.cc/sh and returns
+// the simple commands it runs, so that the policy can look at each executable
+// and its arguments the way the shell will see them: quotes are removed,
+// redirections and variable assignments are dropped, and the commands inside
+// pipelines, subshells, $(...), `...`, <(...), bash -c '...', eval and
+// wrappers such as sudo, env or xargs are included as well. Variable
+// references and globs are left unexpanded.
+
+// maxShellDepth limits how deeply nested commands are parsed
+const maxShellDepth = 8
+
+// shellCommand is a simple command: an executable and its arguments
+type shellCommand struct {
+	Args []string // Args[0] is the executable as written
+}
+
+// line returns the command's words joined by single spaces
+func (c shellCommand) line() string {
+	return strings.Join(c.Args, " ")
+}
+
+// parseShellCommands returns the simple commands a command line runs
+func parseShellCommands(script string) ([]shellCommand, error) {
+	return parseShellScript(script, 0)
+}
+
+func parseShellScript(script string, depth int) ([]shellCommand, error) {
+	if depth > maxShellDepth {
+		return nil, fmt.Errorf("commands are nested too deeply")
+	}
+
+	file, err := syntax.NewParser(syntax.Variant(syntax.LangBash)).Parse(strings.NewReader(script), "")
+	if err != nil {
+		return nil, err
+	}
+
+	// Walk reaches every simple command, including those in pipelines,
+	// subshells, function bodies and command or process substitutions
+	var commands []shellCommand
+	syntax.Walk(file, func(node syntax.Node) bool {
+		if err != nil {
+			return false
+		}
+		call, ok := node.(*syntax.CallExpr)
+		if !ok || len(call.Args) == 0 {
+			return true
+		}
+		args := make([]string, len(call.Args))
+		for i, word := range call.Args {
+			args[i] = shellWord(word)
+		}
+		var expanded []shellCommand
+		expanded, err = expandShellCommand(args, depth)
+		commands = append(commands, expanded...)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return commands, nil
+}
+
+// shellWord returns a word as the command will see it, with quotes removed.
+// Expansions are kept as written.
+func shellWord(word *syntax.Word) string {
+	var out strings.Builder
+	writeWordParts(&out, word.Parts, false)
+	return out.String()
+}
+
+func writeWordParts(out *strings.Builder, parts []syntax.WordPart, quoted bool) {
+	for _, part := range parts {
+		switch part := part.(type) {
+		case *syntax.Lit:
+			out.WriteString(unescapeShell(part.Value, quoted))
+		case *syntax.SglQuoted:
+			out.WriteString(part.Value)
+		case *syntax.DblQuoted:
+			writeWordParts(out, part.Parts, true)
+		default:
+			syntax.NewPrinter().Print(out, part)
+		}
+	}
+}
+
+// unescapeShell removes the backslashes the shell removes from a literal:
+// all of them outside double quotes, and those before $ ` " \ or a newline inside
+func unescapeShell(value string, quoted bool) string {
+	if !strings.Contains(value, `\`) {
+		return value
+	}
+	var out strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] == '\\' && i+1 < len(value) && (!quoted || strings.IndexByte("$`\"\\\n", value[i+1]) >= 0) {
+			i++
+			if value[i] == '\n' {
+				continue
+			}
+		}
+		out.WriteByte(value[i])
+	}
+	return out.String()
+}
+
+// shellAssignmentPattern matches a variable assignment given to env
+var shellAssignmentPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*=`)
+
+// shellWrappers are commands that run another command given as their
+// arguments, with the options that take a value and the number of
+// positional arguments before the command
+var shellWrappers = map[string]struct {
+	valueOptions string
+	positional   int
+}{
+	"sudo":    {valueOptions: "-u -g -U -C -h -p -r -t -D", positional: 0},
+	"doas":    {valueOptions: "-u -C", positional: 0},
+	"env":     {valueOptions: "-u -C -S --unset --chdir", positional: 0},
+	"nice":    {valueOptions: "-n --adjustment", positional: 0},
+	"nohup":   {},
+	"time":    {valueOptions: "-f -o --format --output", positional: 0},
+	"timeout": {valueOptions: "-s -k --signal --kill-after", positional: 1},
+	"exec":    {valueOptions: "-a", positional: 0},
+	"command": {},
+	"builtin": {},
+	"xargs":   {valueOptions: "-I -n -P -L -s -d -E -a --max-args --max-procs --delimiter --arg-file", positional: 0},
+	"stdbuf":  {valueOptions: "-i -o -e", positional: 0},
+	"ionice":  {valueOptions: "-c -n -p", positional: 0},
+	"setsid":  {},
+	"chroot":  {valueOptions: "--userspec --groups", positional: 1},
+}
+
+// shellInterpreters run their -c argument as a command line
+var shellInterpreters = map[string]bool{
+	"sh": true, "bash": true, "dash": true, "zsh": true, "ksh": true,
+}
+
+// expandShellCommand returns a simple command followed by the commands it runs
+func expandShellCommand(args []string, depth int) ([]shellCommand, error) {
+	if len(args) == 0 {
+		return nil, nil
+	}
+	commands := []shellCommand{{Args: args}}
+	name := path.Base(args[0])
+
+	var nested string
+	if shellInterpreters[name] {
+		script, ok := shellScriptArgument(args[1:])
+		if !ok {
+			return commands, nil
+		}
+		nested = script
+	} else if name == "eval" || name == "watch" {
+		nested = strings.Join(wrappedCommand(args[1:], "-n --interval", 0), " ")
+	} else if wrapper, ok := shellWrappers[name]; ok {
+		inner := wrappedCommand(args[1:], wrapper.valueOptions, wrapper.positional)
+		if name == "env" {
+			for len(inner) > 0 && shellAssignmentPattern.MatchString(inner[0]) {
+				inner = inner[1:]
+			}
+		}
+		expanded, err := expandShellCommand(inner, depth)
+		if err != nil {
+			return nil, err
+		}
+		return append(commands, expanded...), nil
+	}
+	if nested == "" {
+		return commands, nil
+	}
+
+	parsed, err := parseShellScript(nested, depth+1)
+	if err != nil {
+		return nil, err
+	}
+	return append(commands, parsed...), nil
+}
+
+// shellScriptArgument returns the command line given to a shell with -c
+func shellScriptArgument(args []string) (string, bool) {
+	hasC := false
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			continue
+		}
+		if (strings.HasPrefix(arg, "-") || strings.HasPrefix(arg, "+")) && !strings.HasPrefix(arg, "--") && len(arg) > 1 {
+			hasC = hasC || (arg[0] == '-' && strings.Contains(arg[1:], "c"))
+			if strings.ContainsAny(arg[1:], "oO") {
+				// Shell options like -o pipefail or -euo pipefail take a value
+				i++
+			}
+			continue
+		}
+		if strings.HasPrefix(arg, "--") {
+			continue
+		}
+		return arg, hasC
+	}
+	return "", false
+}
+
+// wrappedCommand skips a wrapper's options and leading positional arguments
+func wrappedCommand(args []string, valueOptions string, positional int) []string {
+	takesValue := make(map[string]bool)
+	for _, option := range strings.Fields(valueOptions) {
+		takesValue[option] = true
+	}
+	for len(args) > 0 {
+		arg := args[0]
+		if arg == "--" {
+			args = args[1:]
+			break
+		}
+		if !strings.HasPrefix(arg, "-") || arg == "-" {
+			break
+		}
+		args = args[1:]
+		if takesValue[arg] && len(args) > 0 {
+			args = args[1:]
+		}
+	}
+	for ; positional > 0 && len(args) > 0; positional-- {
+		args = args[1:]
+	}
+	return args
+}
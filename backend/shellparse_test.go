@@ -0,0 +1,78 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseShellCommands(t *testing.T) {
+	tests := []struct {
+		script string
+		want   [][]string
+	}{
+		{"ls -la", [][]string{{"ls", "-la"}}},
+		{"", nil},
+		{"# just a comment", nil},
+		{"FOO=1 make build > out.log 2>&1", [][]string{{"make", "build"}}},
+		{"git status && npm test || echo failed", [][]string{{"git", "status"}, {"npm", "test"}, {"echo", "failed"}}},
+		{"cat file | grep x | wc -l", [][]string{{"cat", "file"}, {"grep", "x"}, {"wc", "-l"}}},
+		{"(cd /tmp; rm -rf x)", [][]string{{"cd", "/tmp"}, {"rm", "-rf", "x"}}},
+		{"{ echo a; echo b; }", [][]string{{"echo", "a"}, {"echo", "b"}}},
+		{`echo "it's $(whoami)"`, [][]string{{"echo", "it's $(whoami)"}, {"whoami"}}},
+		{"echo `date`", [][]string{{"echo", "$(date)"}, {"date"}}},
+		{"diff <(ls a) <(ls b)", [][]string{{"diff", "<(ls a)", "<(ls b)"}, {"ls", "a"}, {"ls", "b"}}},
+		{`'rm' "-rf" r\m`, [][]string{{"rm", "-rf", "rm"}}},
+		{`echo "a\$b" 'c\d'`, [][]string{{"echo", "a$b", `c\d`}}},
+		{"echo ${HOME} $USER", [][]string{{"echo", "${HOME}", "$USER"}}},
+		{"bash -c 'rm -rf /tmp/x; ls'", [][]string{{"bash", "-c", "rm -rf /tmp/x; ls"}, {"rm", "-rf", "/tmp/x"}, {"ls"}}},
+		{"bash -euo pipefail -c 'make'", [][]string{{"bash", "-euo", "pipefail", "-c", "make"}, {"make"}}},
+		{"bash script.sh", [][]string{{"bash", "script.sh"}}},
+		{"sudo -u root rm -rf /", [][]string{{"sudo", "-u", "root", "rm", "-rf", "/"}, {"rm", "-rf", "/"}}},
+		{"env -u X FOO=1 make", [][]string{{"env", "-u", "X", "FOO=1", "make"}, {"make"}}},
+		{"timeout 10 sleep 20", [][]string{{"timeout", "10", "sleep", "20"}, {"sleep", "20"}}},
+		{"eval 'mkfs /dev/sda'", [][]string{{"eval", "mkfs /dev/sda"}, {"mkfs", "/dev/sda"}}},
+		{"if test -f x; then cat x; fi", [][]string{{"test", "-f", "x"}, {"cat", "x"}}},
+		{"for f in a b; do rm $f; done", [][]string{{"rm", "$f"}}},
+		{"f() { rm -rf /; }; f", [][]string{{"rm", "-rf", "/"}, {"f"}}},
+		{"cat <<EOF\nrm -rf /\nEOF\necho done", [][]string{{"cat"}, {"echo", "done"}}},
+		{"[[ $(id -u) == 0 ]] && echo root", [][]string{{"id", "-u"}, {"echo", "root"}}},
+	}
+
+	for _, tt := range tests {
+		commands, err := parseShellCommands(tt.script)
+		if err != nil {
+			t.Errorf("parseShellCommands(%q): %v", tt.script, err)
+			continue
+		}
+		var got [][]string
+		for _, command := range commands {
+			got = append(got, command.Args)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parseShellCommands(%q) = %q, want %q", tt.script, got, tt.want)
+		}
+	}
+}
+
+func TestParseShellCommandsErrors(t *testing.T) {
+	for _, script := range []string{
+		"echo 'unterminated",
+		"echo $(ls",
+		"if true; then echo",
+		"bash -c 'echo \"unterminated'",
+	} {
+		if _, err := parseShellCommands(script); err == nil {
+			t.Errorf("parseShellCommands(%q): expected an error", script)
+		}
+	}
+}
+
+func TestParseShellCommandsDepth(t *testing.T) {
+	script := "ls"
+	for i := 0; i <= maxShellDepth+1; i++ {
+		script = "bash -c " + shellQuote(script)
+	}
+	if _, err := parseShellCommands(script); err == nil {
+		t.Errorf("expected commands nested %d deep to be rejected", maxShellDepth+2)
+	}
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Output stream names used to tag chunks
+const (
+	StreamStdout = "stdout"
+	StreamStderr = "stderr"
+)
+
+// OutputChunk is a piece of command output tagged with the stream it came from
+type OutputChunk struct {
+	Stream string `json:"stream"`
+	Data   string `json:"data"`
+}
+
+// OutputSink receives command output as it is produced. It may be called
+// concurrently from the stdout and stderr copy goroutines.
+type OutputSink func(chunk OutputChunk)
+
+// outputWriter buffers output for the final CommandResult and forwards
+// every write to an optional sink
+type outputWriter struct {
+	buf    *bytes.Buffer
+	stream string
+	sink   OutputSink
+}
+
+func newOutputWriter(buf *bytes.Buffer, stream string, sink OutputSink) *outputWriter {
+	return &outputWriter{buf: buf, stream: stream, sink: sink}
+}
+
+func (w *outputWriter) Write(p []byte) (int, error) {
+	n, err := w.buf.Write(p)
+	if w.sink != nil && n > 0 {
+		w.sink(OutputChunk{Stream: w.stream, Data: string(p[:n])})
+	}
+	return n, err
+}
+
+// RetryEvent announces that a failed attempt of a step will be retried
+type RetryEvent struct {
+	Attempt  int           `json:"attempt"`
+	ExitCode int           `json:"exit_code"`
+	Delay    time.Duration `json:"delay"`
+}
+
+// sseWriter writes Server-Sent Events to an Echo response. Once a write
+// fails, typically because the client went away, later events are dropped.
+type sseWriter struct {
+	mu  sync.Mutex
+	res *echo.Response
+	err error // First write error
+}
+
+func newSSEWriter(res *echo.Response) *sseWriter {
+	res.Header().Set(echo.HeaderContentType, "text/event-stream")
+	res.Header().Set("Cache-Control", "no-cache")
+	res.Header().Set("Connection", "keep-alive")
+	res.Header().Set("X-Accel-Buffering", "no")
+	res.WriteHeader(http.StatusOK)
+	res.Flush()
+	return &sseWriter{res: res}
+}
+
+// event sends a single named event with a JSON payload. Write errors are
+// logged here, so callers may ignore them.
+func (s *sseWriter) event(name string, payload interface{}) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.err != nil {
+		return nil
+	}
+	if _, err := fmt.Fprintf(s.res, "event: %s\ndata: %s\n\n", name, data); err != nil {
+		s.err = err
+		slog.Error("Error writing to stream, dropping further events", "error", err)
+		return err
+	}
+	s.res.Flush()
+	return nil
+}
+
+// sink returns an OutputSink that emits each chunk as an event named after its stream
+func (s *sseWriter) sink() OutputSink {
+	return func(chunk OutputChunk) {
+		s.event(chunk.Stream, chunk)
+	}
+}
+
+// handleStreamStepExecution executes a step and streams its output as
+// Server-Sent Events: "stdout" and "stderr" events carry OutputChunk payloads
+// while the command runs, a "retry" event carries a RetryEvent before each
+// retry, and a final "result" event carries the CommandResult. The request
+// body is the same as for /api/execute-step, and the command is cancelled if
+// the client disconnects.
+func handleStreamStepExecution(c echo.Context) error {
+	var req StepExecutionRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request payload",
+		})
+	}
+
+	step, err := getStepByID(req.StepID)
+	if err != nil {
+		slog.Error("Error getting step", "step_id", req.StepID, "error", err)
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "Step not found",
+		})
+	}
+	if err := authorize(c, PermissionRunFlows, step.FlowID); err != nil {
+		return forbidden(c, err)
+	}
+
+	variables, err := getLaunchVariables(step.FlowID, req.Environment, req.Variables)
+	if err != nil {
+		if launchErr, ok := err.(*LaunchError); ok {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": launchErr.Error(),
+			})
+		}
+		slog.Error("Error getting variables", "flow_id", step.FlowID, "error", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get flow variables",
+		})
+	}
+
+	flowEnvFiles, err := getFlowEnvFiles(step.FlowID)
+	if err != nil {
+		slog.Error("Error getting env files", "flow_id", step.FlowID, "error", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get flow env files",
+		})
+	}
+
+	sse := newSSEWriter(c.Response())
+	if skipped := checkStepCondition(step, variables); skipped != nil {
+		sse.event("result", skipped)
+		return nil
+	}
+
+	ctx, done := executions.start(c.Request().Context(), stepKey(step.ID))
+	defer done()
+
+	result := executeWithRetries(ctx, step.Command, variables, ExecutionOptions{
+		TmuxSessionName: step.TmuxSessionName,
+		IsTmuxTerminal:  step.IsTmuxTerminal,
+		Timeout:         commandTimeout(step.Timeout),
+		TmuxWait:        parseTmuxWait(step.TmuxWait),
+		Output:          sse.sink(),
+		QueueKey:        stepKey(step.ID),
+		WorkingDir:      step.WorkingDir,
+		EnvFiles:        stepEnvFiles(flowEnvFiles, step.EnvFiles),
+		Env:             step.Env,
+		Audit:           stepAudit(c, step.ID, step.FlowID),
+	}, newRetryPolicy(step.Retries, step.RetryDelay, step.Backoff), nil, func(failed CommandResult, delay time.Duration) {
+		sse.event("retry", RetryEvent{Attempt: failed.Attempt, ExitCode: failed.ExitCode, Delay: delay})
+	})
+
+	sse.event("result", result)
+	return nil
+}
@@ -0,0 +1,388 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// tmuxPollInterval is how often a tmux step's exit status is checked and its
+// output passed on
+const tmuxPollInterval = 250 * time.Millisecond
+
+// tmuxOutputGracePeriod is how long the rest of a finished tmux step's output
+// is waited for
+const tmuxOutputGracePeriod = time.Second
+
+// ensureTmuxSession creates the tmux session if it doesn't exist yet
+func ensureTmuxSession(sessionName string, cmdEnv CommandEnvironment) error {
+	checkCmd := exec.Command("tmux", "has-session", "-t", sessionName)
+	setupCommandEnvironment(checkCmd, cmdEnv)
+	if err := checkCmd.Run(); err == nil {
+		tmuxSessions.add(sessionName)
+		return nil
+	}
+
+	// Session doesn't exist, create it
+	log.Printf("Creating tmux session: %s", sessionName)
+	createCmd := exec.Command("tmux", "new-session", "-d", "-s", sessionName)
+	setupCommandEnvironment(createCmd, cmdEnv)
+	if err := createCmd.Run(); err != nil {
+		return fmt.Errorf("failed to create tmux session %s: %v", sessionName, err)
+	}
+	tmuxSessions.add(sessionName)
+	tmuxSessionsCreated.inc()
+	return nil
+}
+
+// tmuxWrapperScript runs the step script inside the tmux pane. It runs in a
+// process group of its own, whose ID it writes to pgid so the command can be
+// stopped, then runs command.sh, a FIFO the server writes the script to.
+// Output is shown in the pane and copied to output, a FIFO the server reads,
+// so secret values the command prints never reach disk unmasked. tee -p keeps
+// writing to the pane if the server stops reading. The exit code is written to
+// exit_status once the command finishes. If the server stopped waiting (the
+// "detached" marker exists), the wrapper cleans up the directory itself.
+const tmuxWrapperScript = `#!/bin/bash
+dir="$(dirname "$0")"
+if [ "$(ps -o pgid= -p $$ | tr -d ' ')" != "$$" ]; then exec setsid bash "$0"; fi
+echo "$$" > "$dir/pgid.tmp" && mv "$dir/pgid.tmp" "$dir/pgid"
+bash "$dir/command.sh" 2>&1 | tee -p "$dir/output"
+echo "${PIPESTATUS[0]}" > "$dir/exit_status.tmp" && mv "$dir/exit_status.tmp" "$dir/exit_status"
+if [ -e "$dir/detached" ]; then rm -rf "$dir"; fi
+`
+
+// executeInTmux runs finalCommand in a tmux session and waits for it to exit,
+// returning its real exit code and only its own output. If opts.TmuxWait is
+// set and the command is still running after that long, it is left running in
+// the session and reported as started.
+func executeInTmux(ctx context.Context, command, finalCommand string, cmdEnv CommandEnvironment, opts ExecutionOptions, start time.Time) CommandResult {
+	failed := func(format string, args ...interface{}) CommandResult {
+		msg := fmt.Sprintf(format, args...)
+		slog.Error(msg)
+		return CommandResult{
+			Command:    command,
+			ExitCode:   -1,
+			Stdout:     "",
+			Stderr:     msg,
+			Duration:   time.Since(start),
+			Success:    false,
+			Status:     CommandStatusFailed,
+			ExecutedAt: start,
+		}
+	}
+
+	sessionName := opts.TmuxSessionName
+	if err := ensureTmuxSession(sessionName, cmdEnv); err != nil {
+		return failed("Failed to create tmux session: %v", err)
+	}
+
+	// Each execution gets its own directory holding the scripts and the files
+	// used to track completion
+	dir, err := os.MkdirTemp("", "devtool_tmux_")
+	if err != nil {
+		return failed("Failed to create temp directory: %v", err)
+	}
+
+	// The pane's shell was started with whatever environment the session was
+	// created with, so the script sets the directory and variables itself. It
+	// holds variable and secret values, so it is passed through a FIFO rather
+	// than written to disk.
+	scriptPath := filepath.Join(dir, "command.sh")
+	removeDir := func() {
+		closeTmuxScript(scriptPath)
+		os.RemoveAll(dir)
+	}
+	if err := syscall.Mkfifo(scriptPath, 0600); err != nil {
+		os.RemoveAll(dir)
+		return failed("Failed to create script FIFO: %v", err)
+	}
+	commandScript := fmt.Sprintf(`set -e
+cd %s
+%s%s
+`, shellQuote(cmdEnv.dir()), exportStatements(cmdEnv), finalCommand)
+
+	wrapperPath := filepath.Join(dir, "wrapper.sh")
+	if err := os.WriteFile(wrapperPath, []byte(tmuxWrapperScript), 0700); err != nil {
+		os.RemoveAll(dir)
+		return failed("Failed to create temp script: %v", err)
+	}
+	output, err := openTmuxOutput(filepath.Join(dir, "output"))
+	if err != nil {
+		os.RemoveAll(dir)
+		return failed("Failed to create output FIFO: %v", err)
+	}
+	go sendTmuxScript(scriptPath, commandScript)
+
+	sendCmd := exec.Command("tmux", "send-keys", "-t", sessionName, fmt.Sprintf("bash %s", wrapperPath), "Enter")
+	setupCommandEnvironment(sendCmd, cmdEnv)
+	if err := sendCmd.Run(); err != nil {
+		output.finish()
+		removeDir()
+		return failed("Failed to send command to tmux session: %v", err)
+	}
+	log.Printf("Command sent to tmux session %s: %s", sessionName, finalCommand)
+
+	var stdout strings.Builder
+	writeOutput := func(chunk []byte) {
+		if len(chunk) == 0 {
+			return
+		}
+		stdout.Write(chunk)
+		if opts.Output != nil {
+			opts.Output(OutputChunk{Stream: StreamStdout, Data: string(chunk)})
+		}
+	}
+
+	var waitExpired <-chan time.Time
+	if opts.TmuxWait > 0 {
+		timer := time.NewTimer(opts.TmuxWait)
+		defer timer.Stop()
+		waitExpired = timer.C
+	}
+
+	ticker := time.NewTicker(tmuxPollInterval)
+	defer ticker.Stop()
+
+	for {
+		if exitCode, ok := readExitStatus(dir); ok {
+			writeOutput(output.finish())
+			removeDir()
+
+			status := CommandStatusSucceeded
+			if exitCode != 0 {
+				status = CommandStatusFailed
+			}
+			log.Printf("Tmux command completed in session %s: exit_code=%d", sessionName, exitCode)
+			return CommandResult{
+				Command:    command,
+				ExitCode:   exitCode,
+				Stdout:     stdout.String(),
+				Stderr:     "",
+				Duration:   time.Since(start),
+				Success:    exitCode == 0,
+				Status:     status,
+				ExecutedAt: start,
+			}
+		}
+
+		select {
+		case <-ticker.C:
+			writeOutput(output.take())
+
+		case <-waitExpired:
+			// Leave the command running; the wrapper removes the directory when it exits
+			if err := os.WriteFile(filepath.Join(dir, "detached"), nil, 0600); err != nil {
+				slog.Error("Failed to mark tmux command as detached", "error", err)
+			}
+			// The command may have finished just before the marker was written
+			if _, ok := readExitStatus(dir); ok {
+				continue
+			}
+			writeOutput(output.take())
+			output.detach()
+			log.Printf("Tmux command still running in session %s after %v, reporting as started", sessionName, opts.TmuxWait)
+			return CommandResult{
+				Command:    command,
+				ExitCode:   0,
+				Stdout:     stdout.String(),
+				Stderr:     "",
+				Duration:   time.Since(start),
+				Success:    true,
+				Status:     CommandStatusStarted,
+				ExecutedAt: start,
+			}
+
+		case <-ctx.Done():
+			// Stop the command before returning, so its execution slot is
+			// only freed once it has exited
+			stopTmuxCommand(dir)
+			writeOutput(output.finish())
+			removeDir()
+
+			exitCode, status := commandStatus(ctx, ctx.Err())
+			stderr := "Command cancelled"
+			if status == CommandStatusTimedOut {
+				stderr = fmt.Sprintf("Command timed out after %v", opts.Timeout)
+			}
+			return CommandResult{
+				Command:    command,
+				ExitCode:   exitCode,
+				Stdout:     stdout.String(),
+				Stderr:     stderr,
+				Duration:   time.Since(start),
+				Success:    false,
+				Status:     status,
+				ExecutedAt: start,
+			}
+		}
+	}
+}
+
+// tmuxOutput collects the output the tmux wrapper copies to its output FIFO.
+// The server holds the FIFO open for writing too, so reading doesn't stop at
+// EOF before the wrapper opens it; the output is read as it comes, so the
+// command never blocks on a full pipe.
+type tmuxOutput struct {
+	reader  *os.File
+	keeper  *os.File
+	done    chan struct{}
+	mu      sync.Mutex
+	pending []byte
+	discard bool
+}
+
+// openTmuxOutput creates the output FIFO at path and starts reading it
+func openTmuxOutput(path string) (*tmuxOutput, error) {
+	if err := syscall.Mkfifo(path, 0600); err != nil {
+		return nil, err
+	}
+	reader, err := os.OpenFile(path, os.O_RDONLY|syscall.O_NONBLOCK, 0)
+	if err != nil {
+		return nil, err
+	}
+	keeper, err := os.OpenFile(path, os.O_WRONLY, 0)
+	if err != nil {
+		reader.Close()
+		return nil, err
+	}
+	output := &tmuxOutput{reader: reader, keeper: keeper, done: make(chan struct{})}
+	go output.read()
+	return output, nil
+}
+
+func (o *tmuxOutput) read() {
+	defer close(o.done)
+	defer o.reader.Close()
+	buf := make([]byte, 32*1024)
+	for {
+		n, err := o.reader.Read(buf)
+		o.mu.Lock()
+		if !o.discard {
+			o.pending = append(o.pending, buf[:n]...)
+		}
+		o.mu.Unlock()
+		if err != nil {
+			return
+		}
+	}
+}
+
+// take returns the output read since the last call
+func (o *tmuxOutput) take() []byte {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	chunk := o.pending
+	o.pending = nil
+	return chunk
+}
+
+// finish returns the rest of the output of a command that has exited. If
+// something still holds the FIFO open after tmuxOutputGracePeriod, what it
+// writes later is discarded.
+func (o *tmuxOutput) finish() []byte {
+	o.keeper.Close()
+	select {
+	case <-o.done:
+	case <-time.After(tmuxOutputGracePeriod):
+		o.mu.Lock()
+		o.discard = true
+		o.mu.Unlock()
+	}
+	return o.take()
+}
+
+// detach stops collecting the output of a command left running. It is still
+// read, and discarded, until the command exits.
+func (o *tmuxOutput) detach() {
+	o.mu.Lock()
+	o.discard = true
+	o.pending = nil
+	o.mu.Unlock()
+	o.keeper.Close()
+}
+
+// sendTmuxScript writes the command script to its FIFO for the wrapper to
+// run. It blocks until the wrapper opens the FIFO or closeTmuxScript gives up
+// on it.
+func sendTmuxScript(path, script string) {
+	file, err := os.OpenFile(path, os.O_WRONLY, 0)
+	if err != nil {
+		slog.Error("Failed to open script FIFO", "error", err)
+		return
+	}
+	defer file.Close()
+	if _, err := file.WriteString(script); err != nil && !errors.Is(err, syscall.EPIPE) {
+		slog.Error("Failed to write script FIFO", "error", err)
+	}
+}
+
+// closeTmuxScript unblocks sendTmuxScript if the wrapper never read the FIFO
+func closeTmuxScript(path string) {
+	if file, err := os.OpenFile(path, os.O_RDONLY|syscall.O_NONBLOCK, 0); err == nil {
+		file.Close()
+	}
+}
+
+// stopTmuxCommand stops a command started by the tmux wrapper the way
+// newShellCommand does: its process group receives SIGTERM, then SIGKILL if it
+// is still running after killGracePeriod. It returns once the group has exited.
+func stopTmuxCommand(dir string) {
+	if _, ok := readExitStatus(dir); ok {
+		return
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "pgid"))
+	if err != nil {
+		// The wrapper hasn't started, and can't once its directory is removed
+		return
+	}
+	pgid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil || pgid <= 1 {
+		slog.Warn("Invalid process group for tmux command", "value", string(data), "path", dir)
+		return
+	}
+
+	if err := syscall.Kill(-pgid, syscall.SIGTERM); err != nil {
+		return
+	}
+	killAt := time.Now().Add(killGracePeriod)
+	giveUpAt := killAt.Add(5 * time.Second)
+	killed := false
+	// Signal 0 only checks whether any process of the group is left
+	for syscall.Kill(-pgid, 0) == nil {
+		now := time.Now()
+		if !killed && now.After(killAt) {
+			slog.Warn("Tmux command still running after SIGTERM, sending SIGKILL", "pgid", pgid, "grace_period", killGracePeriod)
+			syscall.Kill(-pgid, syscall.SIGKILL)
+			killed = true
+		}
+		if now.After(giveUpAt) {
+			slog.Error("Error stopping tmux command: process group still exists after SIGKILL", "pgid", pgid)
+			return
+		}
+		time.Sleep(tmuxPollInterval)
+	}
+}
+
+// readExitStatus returns the exit code written by the tmux wrapper, if the command has finished
+func readExitStatus(dir string) (int, bool) {
+	data, err := os.ReadFile(filepath.Join(dir, "exit_status"))
+	if err != nil {
+		return 0, false
+	}
+	exitCode, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		return -1, true
+	}
+	return exitCode, true
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExecuteInTmux(t *testing.T) {
+	if _, err := exec.LookPath("tmux"); err != nil {
+		t.Skip("tmux is not installed")
+	}
+	withTestDatabase(t)
+	defaultPolicy(t)
+	config.System.Workspace.DefaultDir = t.TempDir()
+	session := fmt.Sprintf("devtool_test_%d", os.Getpid())
+	t.Cleanup(func() { exec.Command("tmux", "kill-session", "-t", session).Run() })
+	opts := ExecutionOptions{TmuxSessionName: session, IsTmuxTerminal: true}
+
+	// More output than a pipe holds arrives in full, along with the exit code
+	var streamed strings.Builder
+	withOutput := opts
+	withOutput.Output = func(chunk OutputChunk) { streamed.WriteString(chunk.Data) }
+	result := executeCommandWithTmux(context.Background(), "seq 1 20000; echo err >&2; exit 3", nil, withOutput)
+	if result.ExitCode != 3 || result.Status != CommandStatusFailed {
+		t.Errorf("exit code %d with status %s, want 3 failed: %s", result.ExitCode, result.Status, result.Stderr)
+	}
+	if !strings.HasPrefix(result.Stdout, "1\n2\n") || !strings.Contains(result.Stdout, "20000") || !strings.Contains(result.Stdout, "err") {
+		t.Errorf("output is incomplete: %d bytes ending in %q", len(result.Stdout), result.Stdout[max(0, len(result.Stdout)-20):])
+	}
+	if streamed.String() != result.Stdout {
+		t.Errorf("streamed %d bytes, want the %d bytes of the result", streamed.Len(), len(result.Stdout))
+	}
+
+	// Output never goes to a file, not even while the command runs
+	var dir string
+	detached := opts
+	detached.TmuxWait = 500 * time.Millisecond
+	result = executeCommandWithTmux(context.Background(), `echo "$(dirname "$0")" > `+filepath.Join(config.System.Workspace.DefaultDir, "dir")+`; echo started; sleep 1`, nil, detached)
+	if result.Status != CommandStatusStarted || !strings.Contains(result.Stdout, "started") {
+		t.Errorf("got status %s with output %q, want started", result.Status, result.Stdout)
+	}
+	if data, err := os.ReadFile(filepath.Join(config.System.Workspace.DefaultDir, "dir")); err == nil {
+		dir = strings.TrimSpace(string(data))
+	}
+	if info, err := os.Stat(filepath.Join(dir, "output")); dir == "" || err != nil || info.Mode()&os.ModeNamedPipe == 0 {
+		t.Errorf("output in %q isn't a FIFO: %v", dir, err)
+	}
+
+	// The detached command cleans up once it exits
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if _, err := os.Stat(dir); os.IsNotExist(err) {
+			return
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	t.Errorf("%s still exists after the detached command exited", dir)
+}
@@ -0,0 +1,346 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/user"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Step conditions
+//
+// A step's `when` expression is evaluated right before the step would run;
+// if it is false the step is skipped. The language is deliberately small:
+//
+//	${VAR} == "value"        variable comparison (also !=); unset variables are ""
+//	user == "bacancy"        OS user the service runs as
+//	succeeded("Step name")   the named step succeeded (or was started) earlier in the run
+//	failed("Step name")      the named step failed or timed out earlier in the run
+//	exists("/some/path")     a file or directory exists
+//	!expr, a && b, a || b    negation, conjunction and disjunction
+//	( expr )                 grouping
+//
+// Strings use double or single quotes and may contain variable references,
+// expanded as described in interpolate.go.
+// Outside of a flow run, succeeded() and failed() are always false.
+
+// whenEnv is the context a condition is evaluated in
+type whenEnv struct {
+	variables map[string]string
+	statuses  map[string]string // Step name to run step status
+}
+
+type whenExpr interface {
+	eval(env *whenEnv) bool
+}
+
+type whenOr struct{ left, right whenExpr }
+type whenAnd struct{ left, right whenExpr }
+type whenNot struct{ expr whenExpr }
+
+type whenCompare struct {
+	left, right whenOperand
+	negate      bool
+}
+
+type whenCall struct {
+	name string
+	arg  whenOperand
+}
+
+// whenOperand is a string literal, a ${VAR} reference or the `user` keyword
+type whenOperand struct {
+	kind  string // "string", "var" or "user"
+	value string
+}
+
+func (e whenOr) eval(env *whenEnv) bool  { return e.left.eval(env) || e.right.eval(env) }
+func (e whenAnd) eval(env *whenEnv) bool { return e.left.eval(env) && e.right.eval(env) }
+func (e whenNot) eval(env *whenEnv) bool { return !e.expr.eval(env) }
+
+func (e whenCompare) eval(env *whenEnv) bool {
+	equal := e.left.resolve(env) == e.right.resolve(env)
+	if e.negate {
+		return !equal
+	}
+	return equal
+}
+
+func (e whenCall) eval(env *whenEnv) bool {
+	arg := e.arg.resolve(env)
+	switch e.name {
+	case "succeeded":
+		return stepSucceeded(env.statuses[arg])
+	case "failed":
+		status := env.statuses[arg]
+		return status == RunStatusFailed || status == RunStatusTimedOut
+	case "exists":
+		_, err := os.Stat(arg)
+		return err == nil
+	}
+	return false
+}
+
+func (o whenOperand) resolve(env *whenEnv) string {
+	switch o.kind {
+	case "var":
+		return env.variables[o.value]
+	case "user":
+		return currentUsername()
+	}
+	value, _ := interpolate(o.value, env.variables)
+	return value
+}
+
+// currentUsername returns the OS user the service runs as
+func currentUsername() string {
+	if u, err := user.Current(); err == nil {
+		return u.Username
+	}
+	return os.Getenv("USER")
+}
+
+// parsedWhen is a parsed condition along with the step names it refers to
+type parsedWhen struct {
+	expr     whenExpr
+	stepRefs []string
+}
+
+// evaluate reports whether the condition holds
+func (w *parsedWhen) evaluate(variables map[string]string, statuses map[string]string) bool {
+	if w == nil {
+		return true
+	}
+	return w.expr.eval(&whenEnv{variables: variables, statuses: statuses})
+}
+
+// checkStepCondition evaluates the condition of a directly executed step and
+// returns a skipped result if it does not hold, or nil if the step should run
+func checkStepCondition(step *StepDB, variables map[string]string) *CommandResult {
+	when, err := parseWhen(step.When)
+	if err == nil && when.evaluate(variables, nil) {
+		return nil
+	}
+
+	reason := fmt.Sprintf("Skipped: condition %q is false", step.When)
+	if err != nil {
+		reason = fmt.Sprintf("Skipped: %v", err)
+	}
+	return &CommandResult{
+		Command:    step.Command,
+		ExitCode:   0,
+		Stdout:     "",
+		Stderr:     reason,
+		Duration:   0,
+		Success:    false,
+		Status:     CommandStatusSkipped,
+		ExecutedAt: time.Now(),
+	}
+}
+
+// parseWhen parses a step condition; an empty condition parses to nil, which always holds
+func parseWhen(input string) (*parsedWhen, error) {
+	if strings.TrimSpace(input) == "" {
+		return nil, nil
+	}
+
+	tokens, err := tokenizeWhen(input)
+	if err != nil {
+		return nil, fmt.Errorf("invalid when condition %q: %v", input, err)
+	}
+
+	p := &whenParser{tokens: tokens}
+	expr, err := p.parseOr()
+	if err == nil && p.pos < len(p.tokens) {
+		err = fmt.Errorf("unexpected %q", p.tokens[p.pos].text)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid when condition %q: %v", input, err)
+	}
+
+	return &parsedWhen{expr: expr, stepRefs: p.stepRefs}, nil
+}
+
+type whenToken struct {
+	kind string // "op", "string", "var" or "ident"
+	text string
+}
+
+func tokenizeWhen(input string) ([]whenToken, error) {
+	var tokens []whenToken
+	for i := 0; i < len(input); {
+		c := input[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n':
+			i++
+		case strings.HasPrefix(input[i:], "&&"), strings.HasPrefix(input[i:], "||"),
+			strings.HasPrefix(input[i:], "=="), strings.HasPrefix(input[i:], "!="):
+			tokens = append(tokens, whenToken{"op", input[i : i+2]})
+			i += 2
+		case c == '!' || c == '(' || c == ')':
+			tokens = append(tokens, whenToken{"op", string(c)})
+			i++
+		case c == '"' || c == '\'':
+			end := strings.IndexByte(input[i+1:], c)
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated string")
+			}
+			tokens = append(tokens, whenToken{"string", input[i+1 : i+1+end]})
+			i += end + 2
+		case strings.HasPrefix(input[i:], "${"):
+			end := strings.IndexByte(input[i:], '}')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated variable reference")
+			}
+			name := input[i+2 : i+end]
+			if name == "" {
+				return nil, fmt.Errorf("empty variable reference")
+			}
+			tokens = append(tokens, whenToken{"var", name})
+			i += end + 1
+		case unicode.IsLetter(rune(c)) || c == '_':
+			start := i
+			for i < len(input) && (unicode.IsLetter(rune(input[i])) || unicode.IsDigit(rune(input[i])) || input[i] == '_') {
+				i++
+			}
+			tokens = append(tokens, whenToken{"ident", input[start:i]})
+		default:
+			return nil, fmt.Errorf("unexpected character %q", c)
+		}
+	}
+	return tokens, nil
+}
+
+type whenParser struct {
+	tokens   []whenToken
+	pos      int
+	stepRefs []string
+}
+
+func (p *whenParser) peek(kind, text string) bool {
+	return p.pos < len(p.tokens) && p.tokens[p.pos].kind == kind && p.tokens[p.pos].text == text
+}
+
+func (p *whenParser) expect(kind, text string) error {
+	if !p.peek(kind, text) {
+		if p.pos < len(p.tokens) {
+			return fmt.Errorf("expected %q, found %q", text, p.tokens[p.pos].text)
+		}
+		return fmt.Errorf("expected %q at end of condition", text)
+	}
+	p.pos++
+	return nil
+}
+
+func (p *whenParser) parseOr() (whenExpr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek("op", "||") {
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = whenOr{left, right}
+	}
+	return left, nil
+}
+
+func (p *whenParser) parseAnd() (whenExpr, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek("op", "&&") {
+		p.pos++
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = whenAnd{left, right}
+	}
+	return left, nil
+}
+
+func (p *whenParser) parseNot() (whenExpr, error) {
+	if p.peek("op", "!") {
+		p.pos++
+		expr, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return whenNot{expr}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *whenParser) parsePrimary() (whenExpr, error) {
+	if p.pos >= len(p.tokens) {
+		return nil, fmt.Errorf("unexpected end of condition")
+	}
+
+	if p.peek("op", "(") {
+		p.pos++
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		return expr, p.expect("op", ")")
+	}
+
+	tok := p.tokens[p.pos]
+	if tok.kind == "ident" && (tok.text == "succeeded" || tok.text == "failed" || tok.text == "exists") {
+		p.pos++
+		if err := p.expect("op", "("); err != nil {
+			return nil, err
+		}
+		if p.pos >= len(p.tokens) || p.tokens[p.pos].kind != "string" {
+			return nil, fmt.Errorf("%s() takes a quoted string argument", tok.text)
+		}
+		arg := p.tokens[p.pos].text
+		p.pos++
+		if err := p.expect("op", ")"); err != nil {
+			return nil, err
+		}
+		if tok.text != "exists" {
+			p.stepRefs = append(p.stepRefs, arg)
+		}
+		return whenCall{name: tok.text, arg: whenOperand{kind: "string", value: arg}}, nil
+	}
+
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos >= len(p.tokens) || (!p.peek("op", "==") && !p.peek("op", "!=")) {
+		return nil, fmt.Errorf("expected == or != after %q", tok.text)
+	}
+	negate := p.tokens[p.pos].text == "!="
+	p.pos++
+	right, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	return whenCompare{left: left, right: right, negate: negate}, nil
+}
+
+func (p *whenParser) parseOperand() (whenOperand, error) {
+	if p.pos >= len(p.tokens) {
+		return whenOperand{}, fmt.Errorf("unexpected end of condition")
+	}
+	tok := p.tokens[p.pos]
+	p.pos++
+	switch {
+	case tok.kind == "string":
+		return whenOperand{kind: "string", value: tok.text}, nil
+	case tok.kind == "var":
+		return whenOperand{kind: "var", value: tok.text}, nil
+	case tok.kind == "ident" && tok.text == "user":
+		return whenOperand{kind: "user"}, nil
+	}
+	return whenOperand{}, fmt.Errorf("unexpected %q", tok.text)
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseWhenEvaluate(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "marker")
+	if err := os.WriteFile(file, nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	variables := map[string]string{"ENV": "prod", "DIR": dir, "EMPTY": ""}
+	statuses := map[string]string{
+		"build":  RunStatusSucceeded,
+		"tmux":   CommandStatusStarted,
+		"test":   RunStatusFailed,
+		"deploy": RunStatusTimedOut,
+		"lint":   RunStatusSkipped,
+	}
+
+	tests := []struct {
+		when string
+		want bool
+	}{
+		{"", true},
+		{`${ENV} == "prod"`, true},
+		{`${ENV} != "prod"`, false},
+		{`'prod' == ${ENV}`, true},
+		{`${MISSING} == ""`, true},
+		{`${EMPTY} == ${MISSING}`, true},
+		{`"${ENV}-eu" == "prod-eu"`, true},
+		{`succeeded("build")`, true},
+		{`succeeded("tmux")`, true},
+		{`succeeded("test")`, false},
+		{`succeeded("lint")`, false},
+		{`failed("test")`, true},
+		{`failed("deploy")`, true},
+		{`failed("build")`, false},
+		{`failed("lint")`, false},
+		{`exists("` + file + `")`, true},
+		{`exists("${DIR}/marker")`, true},
+		{`exists("${DIR}/missing")`, false},
+		{`user == "` + currentUsername() + `"`, true},
+		{`!succeeded("test")`, true},
+		{`!!succeeded("test")`, false},
+		{`succeeded("build") && failed("test")`, true},
+		{`succeeded("build") && succeeded("test")`, false},
+		{`succeeded("test") || ${ENV} == "prod"`, true},
+		// && binds tighter than ||
+		{`succeeded("build") || succeeded("test") && ${ENV} == "x"`, true},
+		{`(succeeded("build") || succeeded("test")) && ${ENV} == "dev"`, false},
+		{`!(${ENV} == "dev" || ${ENV} == "test")`, true},
+	}
+
+	for _, tt := range tests {
+		when, err := parseWhen(tt.when)
+		if err != nil {
+			t.Errorf("parseWhen(%q): %v", tt.when, err)
+			continue
+		}
+		if got := when.evaluate(variables, statuses); got != tt.want {
+			t.Errorf("%q = %v, want %v", tt.when, got, tt.want)
+		}
+	}
+}
+
+func TestParseWhenStepRefs(t *testing.T) {
+	when, err := parseWhen(`succeeded("a") && (failed("b") || exists("/c")) && ${X} == "d"`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"a", "b"}; !reflect.DeepEqual(when.stepRefs, want) {
+		t.Errorf("stepRefs = %q, want %q", when.stepRefs, want)
+	}
+}
+
+func TestParseWhenErrors(t *testing.T) {
+	tests := []struct {
+		when string
+		err  string
+	}{
+		{`${ENV} = "prod"`, "unexpected character"},
+		{`${ENV} == "prod`, "unterminated string"},
+		{`${ENV == "prod"`, "unterminated variable reference"},
+		{`${} == ""`, "empty variable reference"},
+		{`${ENV}`, "expected == or !="},
+		{`${ENV} ==`, "unexpected end of condition"},
+		{`${ENV} == "a" &&`, "unexpected end of condition"},
+		{`(${ENV} == "a"`, `expected ")" at end of condition`},
+		{`${ENV} == "a")`, `unexpected ")"`},
+		{`succeeded(build)`, "takes a quoted string argument"},
+		{`succeeded "build"`, `expected "("`},
+		{`running("build")`, `unexpected "running"`},
+		{`${A} == "a" ${B} == "b"`, `unexpected "B"`},
+	}
+
+	for _, tt := range tests {
+		_, err := parseWhen(tt.when)
+		if err == nil || !strings.Contains(err.Error(), tt.err) {
+			t.Errorf("parseWhen(%q): got error %v, want one containing %q", tt.when, err, tt.err)
+		}
+	}
+}
+
+func TestCheckStepCondition(t *testing.T) {
+	step := &StepDB{Command: "make deploy", When: `${ENV} == "prod"`}
+	if result := checkStepCondition(step, map[string]string{"ENV": "prod"}); result != nil {
+		t.Errorf("condition holds but step was skipped: %+v", result)
+	}
+
+	result := checkStepCondition(step, map[string]string{"ENV": "dev"})
+	if result == nil || result.Status != CommandStatusSkipped || !strings.Contains(result.Stderr, "is false") {
+		t.Errorf("got %+v, want a skipped result", result)
+	}
+
+	step.When = `${ENV} =`
+	result = checkStepCondition(step, nil)
+	if result == nil || result.Status != CommandStatusSkipped || !strings.Contains(result.Stderr, "invalid when condition") {
+		t.Errorf("got %+v, want a skipped result for an invalid condition", result)
+	}
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// Commands may only run in directories allowed by the workspace settings:
+// within one of workspace.allowed_dirs (no restriction if empty), or within
+// the user's home directory if workspace.allow_home_access is set. With
+// allow_home_access unset, the home directory is off limits even if it is
+// inside an allowed directory. Symlinks are resolved before checking, so a
+// link inside an allowed directory can't be used to escape it.
+
+// PolicyError reports an execution rejected by security policy
+type PolicyError struct {
+	Message string
+}
+
+func (e *PolicyError) Error() string {
+	return "policy violation: " + e.Message
+}
+
+// checkWorkingDir returns a *PolicyError if dir is outside the allowed workspace
+func checkWorkingDir(dir string) error {
+	if config == nil {
+		return nil
+	}
+	workspace := config.System.Workspace
+
+	resolved, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return &PolicyError{Message: fmt.Sprintf("cannot resolve working directory %s: %v", dir, err)}
+	}
+	described := dir
+	if resolved != filepath.Clean(dir) {
+		described = fmt.Sprintf("%s (resolved to %s)", dir, resolved)
+	}
+
+	home := resolveAllowedDir(getUserHomeDir())
+	if home == "/" {
+		// Never treat the whole file system as the home directory
+		home = ""
+	}
+	if !workspace.AllowHomeAccess && home != "" && withinDir(resolved, home) {
+		return &PolicyError{Message: fmt.Sprintf("working directory %s is in the home directory %s and workspace.allow_home_access is disabled", described, home)}
+	}
+
+	if len(workspace.AllowedDirs) == 0 {
+		return nil
+	}
+	if workspace.AllowHomeAccess && home != "" && withinDir(resolved, home) {
+		return nil
+	}
+	for _, allowed := range workspace.AllowedDirs {
+		if withinDir(resolved, resolveAllowedDir(allowed)) {
+			return nil
+		}
+	}
+	return &PolicyError{Message: fmt.Sprintf("working directory %s is outside workspace.allowed_dirs (%s)", described, strings.Join(workspace.AllowedDirs, ", "))}
+}
+
+// resolveAllowedDir resolves symlinks in a configured directory, if it exists
+func resolveAllowedDir(dir string) string {
+	if resolved, err := filepath.EvalSymlinks(dir); err == nil {
+		return resolved
+	}
+	return filepath.Clean(dir)
+}
+
+// withinDir reports whether path is dir or inside it
+func withinDir(path, dir string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, "../")
+}
@@ -0,0 +1,19 @@
+// The API is served by the same server as the web interface
+export const API_BASE = '/api'
+
+// apiFetch calls the API with the session cookie set by /login, and sends the
+// browser there if the session is missing or no longer valid
+export const apiFetch = async (url: string, init?: RequestInit): Promise<Response> => {
+  const response = await fetch(url, { credentials: 'same-origin', ...init })
+  if (response.status === 401) {
+    window.location.assign('/login')
+    throw new Error('Authentication required')
+  }
+  return response
+}
+
+// shellSocketUrl returns the URL of the WebSocket shell; the session cookie is sent with it
+export const shellSocketUrl = (query = ''): string => {
+  const protocol = window.location.protocol === 'https:' ? 'wss:' : 'ws:'
+  return `${protocol}//${window.location.host}${API_BASE}/shell${query ? '?' + query : ''}`
+}
@@ -1,3 +1,5 @@
+import { API_BASE, apiFetch } from './client'
+
 export interface CommandResult {
   command: string
   exit_code: number
@@ -28,7 +30,7 @@ export const executeCommand = async (
     variables: variables || {}
   }
 
-  const response = await fetch('http://localhost:8080/api/execute-command', {
+  const response = await apiFetch(`${API_BASE}/execute-command`, {
     method: 'POST',
     headers: {
       'Content-Type': 'application/json'
@@ -54,7 +56,7 @@ export const executeStep = async (stepId: number): Promise<CommandResult> => {
     step_id: stepId
   }
 
-  const response = await fetch('http://localhost:8080/api/execute-step', {
+  const response = await apiFetch(`${API_BASE}/execute-step`, {
     method: 'POST',
     headers: {
       'Content-Type': 'application/json'
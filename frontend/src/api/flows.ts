@@ -1,4 +1,4 @@
-const API_BASE = 'http://localhost:24050/api'
+import { API_BASE, apiFetch } from './client'
 
 export interface ImportFlowRequest {
   name: string
@@ -21,7 +21,7 @@ export interface ImportFlowRequest {
 export const fetchFlows = async () => {
   console.log('fetchFlows API called')
 
-  const response = await fetch(`${API_BASE}/flows`)
+  const response = await apiFetch(`${API_BASE}/flows`)
 
   console.log('Fetch flows API response status:', response.status)
 
@@ -87,7 +87,7 @@ export interface UpdateVariableRequest {
 export const createFlow = async (flowData: CreateFlowRequest) => {
   console.log('createFlow API called with data:', flowData)
 
-  const response = await fetch(`${API_BASE}/flows`, {
+  const response = await apiFetch(`${API_BASE}/flows`, {
     method: 'POST',
     headers: {
       'Content-Type': 'application/json'
@@ -114,7 +114,7 @@ export const updateFlow = async (
 ) => {
   console.log('updateFlow API called with data:', { flowId, flowData })
 
-  const response = await fetch(`${API_BASE}/flows/${flowId}`, {
+  const response = await apiFetch(`${API_BASE}/flows/${flowId}`, {
     method: 'PUT',
     headers: {
       'Content-Type': 'application/json'
@@ -138,7 +138,7 @@ export const updateFlow = async (
 export const deleteFlow = async (flowId: number) => {
   console.log('deleteFlow API called with flowId:', flowId)
 
-  const response = await fetch(`${API_BASE}/flows/${flowId}`, {
+  const response = await apiFetch(`${API_BASE}/flows/${flowId}`, {
     method: 'DELETE'
   })
 
@@ -161,7 +161,7 @@ export const updateStep = async (
 ) => {
   console.log('updateStep API called with data:', { stepId, stepData })
 
-  const response = await fetch(`${API_BASE}/steps/${stepId}`, {
+  const response = await apiFetch(`${API_BASE}/steps/${stepId}`, {
     method: 'PUT',
     headers: {
       'Content-Type': 'application/json'
@@ -185,7 +185,7 @@ export const updateStep = async (
 export const createStep = async (stepData: CreateStepRequest) => {
   console.log('createStep API called with data:', stepData)
 
-  const response = await fetch(`${API_BASE}/steps`, {
+  const response = await apiFetch(`${API_BASE}/steps`, {
     method: 'POST',
     headers: {
       'Content-Type': 'application/json'
@@ -209,7 +209,7 @@ export const createStep = async (stepData: CreateStepRequest) => {
 export const deleteStep = async (stepId: number) => {
   console.log('deleteStep API called with stepId:', stepId)
 
-  const response = await fetch(`${API_BASE}/steps/${stepId}`, {
+  const response = await apiFetch(`${API_BASE}/steps/${stepId}`, {
     method: 'DELETE'
   })
 
@@ -237,7 +237,7 @@ export const updateVariable = async (
     variableData
   })
 
-  const response = await fetch(`${API_BASE}/variables/${flowId}/${key}`, {
+  const response = await apiFetch(`${API_BASE}/variables/${flowId}/${key}`, {
     method: 'PUT',
     headers: {
       'Content-Type': 'application/json'
@@ -261,7 +261,7 @@ export const updateVariable = async (
 export const deleteVariable = async (flowId: number, key: string) => {
   console.log('deleteVariable API called with data:', { flowId, key })
 
-  const response = await fetch(`${API_BASE}/variables/${flowId}/${key}`, {
+  const response = await apiFetch(`${API_BASE}/variables/${flowId}/${key}`, {
     method: 'DELETE'
   })
 
@@ -279,7 +279,7 @@ export const deleteVariable = async (flowId: number, key: string) => {
 export const executeStep = async (stepId: number) => {
   console.log('executeStep API called with stepId:', stepId)
 
-  const response = await fetch(`${API_BASE}/execute-step`, {
+  const response = await apiFetch(`${API_BASE}/execute-step`, {
     method: 'POST',
     headers: {
       'Content-Type': 'application/json'
@@ -302,7 +302,7 @@ export const executeStep = async (stepId: number) => {
 
 // Export/Import functions
 export const exportFlow = async (flowId: number): Promise<Blob> => {
-  const response = await fetch(`${API_BASE}/flows/${flowId}/export`)
+  const response = await apiFetch(`${API_BASE}/flows/${flowId}/export`)
 
   if (!response.ok) {
     const error = await response.json()
@@ -313,7 +313,7 @@ export const exportFlow = async (flowId: number): Promise<Blob> => {
 }
 
 export const importFlow = async (flowData: ImportFlowRequest) => {
-  const response = await fetch(`${API_BASE}/flows/import`, {
+  const response = await apiFetch(`${API_BASE}/flows/import`, {
     method: 'POST',
     headers: {
       'Content-Type': 'application/json'
@@ -6,6 +6,7 @@ import { Badge } from './ui/badge';
 import { Card, CardContent, CardHeader } from './ui/card';
 import { Terminal as TerminalIcon, X, Trash2, Maximize2 } from 'lucide-react';
 import { cn } from '../lib/utils';
+import { shellSocketUrl } from '../api/client';
 import 'xterm/css/xterm.css';
 
 interface TerminalProps {
@@ -18,7 +19,7 @@ interface TerminalProps {
 const Terminal: React.FC<TerminalProps> = ({ command, stepId, onDone, className }) => {
     const xtermRef = useRef<HTMLDivElement>(null);
     const termRef = useRef<XTerm>(new XTerm());
-    const wsRef = useRef<WebSocket>(new WebSocket('ws://localhost:24050/api/shell'));
+    const wsRef = useRef<WebSocket | null>(null);
     const fitAddonRef = useRef<FitAddon>(new FitAddon());
     const [isConnected, setIsConnected] = useState(false);
     const [connectionStatus, setConnectionStatus] = useState('Connecting...');
@@ -75,7 +76,7 @@ const Terminal: React.FC<TerminalProps> = ({ command, stepId, onDone, className
             params.append('step_id', stepId.toString());
         }
 
-        const wsUrl = `ws://localhost:24050/api/shell${params.toString() ? '?' + params.toString() : ''}`;
+        const wsUrl = shellSocketUrl(params.toString());
 
         console.log('[Terminal] Connecting to:', wsUrl);
 
@@ -226,9 +226,14 @@ if systemctl is-active --quiet "$SERVICE_NAME"; then
     echo "📂 Data Directory: $INSTALL_DIR/data"
     echo "⚙️  Configuration: $INSTALL_DIR/config/config.yaml"
     echo ""
+    echo "🔑 API Token:"
+    echo "  The admin token is in $INSTALL_DIR/data/admin.token (accepted from localhost only)"
+    echo "  TOKEN=\$(sudo cat $INSTALL_DIR/data/admin.token)"
+    echo "  Sign in to the web interface at http://localhost:24050/login"
+    echo ""
     echo "🔍 Diagnostic Commands:"
-    echo "  curl http://localhost:24050/api/health          # Check service health"
-    echo "  curl http://localhost:24050/api/diagnostics     # Check file permissions"
+    echo "  curl http://localhost:24050/api/health                                 # Check service health"
+    echo "  curl -H \"Authorization: Bearer \$TOKEN\" http://localhost:24050/api/diagnostics  # Check file permissions"
     echo "  make diagnose                                   # Run full diagnostics"
     echo ""
     echo "📋 Useful Commands:"
@@ -244,7 +249,7 @@ if systemctl is-active --quiet "$SERVICE_NAME"; then
     echo "  Allowed Directories: /home, /opt/dev-tool, /tmp, /var/tmp"
     echo ""
     echo "🚨 If you experience file permission issues:"
-    echo "  1. Check diagnostics: curl http://localhost:24050/api/diagnostics"
+    echo "  1. Check diagnostics: curl -H \"Authorization: Bearer \$TOKEN\" http://localhost:24050/api/diagnostics"
     echo "  2. Verify home directory permissions: ls -la /home/$USER"
     echo "  3. Check service logs: journalctl -u $SERVICE_NAME -f"
     echo "  4. Ensure user $USER exists and has proper permissions"